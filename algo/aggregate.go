@@ -0,0 +1,110 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Collapses each set of parallel edges in the given weighted multigraph into a
+// single edge, weighted as combine says from the weights of the edges it
+// replaces, returning the resulting simple weighted graph. Edges between distinct
+// endpoints are passed to combine alone.
+//
+// For a Digraph, parallel arcs are those with the same source and target, and the
+// result is a weighted digraph; arcs in opposite directions are left separate.
+// Otherwise, edges are parallel whichever way round their endpoints are given.
+// Loops are kept, each set aggregated like any other. Every vertex is carried
+// over, isolated or not. The result is a mutable adjacency list.
+//
+// SumWeights, MinWeight, MaxWeight and MeanWeight are ready-made combiners; combine
+// receives the weights in the order their edges were enumerated.
+func AggregateParallel(g gogl.WeightedGraph, combine func(weights []float64) float64) gogl.WeightedGraph {
+	_, directed := g.(gogl.Digraph)
+
+	// Each set of parallel edges, by the endpoints first seen for it, and where to
+	// find it by the gogl.VertexKeys of its endpoints.
+	var pairs [][2]gogl.Vertex
+	var weights [][]float64
+	index := make(map[[2]interface{}]int)
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		uk, vk := gogl.VertexKey(u), gogl.VertexKey(v)
+		i, seen := index[[2]interface{}{uk, vk}]
+		if !seen && !directed {
+			i, seen = index[[2]interface{}{vk, uk}]
+		}
+
+		if !seen {
+			i = len(pairs)
+			index[[2]interface{}{uk, vk}] = i
+			pairs = append(pairs, [2]gogl.Vertex{u, v})
+			weights = append(weights, nil)
+		}
+		weights[i] = append(weights[i], weightOf(e))
+		return
+	})
+
+	spec := gogl.Spec().Weighted().Loop()
+	if directed {
+		spec = spec.Directed()
+	}
+	ag := spec.Create(al.G)
+
+	vm := ag.(gogl.VertexSetMutator)
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		vm.EnsureVertex(v)
+		return
+	})
+
+	if directed {
+		arcs := make([]gogl.WeightedArc, 0, len(pairs))
+		for i, p := range pairs {
+			arcs = append(arcs, gogl.NewWeightedArc(p[0], p[1], combine(weights[i])))
+		}
+		ag.(gogl.WeightedArcSetMutator).AddArcs(arcs...)
+	} else {
+		edges := make([]gogl.WeightedEdge, 0, len(pairs))
+		for i, p := range pairs {
+			edges = append(edges, gogl.NewWeightedEdge(p[0], p[1], combine(weights[i])))
+		}
+		ag.(gogl.WeightedEdgeSetMutator).AddEdges(edges...)
+	}
+
+	return ag.(gogl.WeightedGraph)
+}
+
+// Combines parallel edge weights by adding them up, as for capacities.
+func SumWeights(weights []float64) float64 {
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	return sum
+}
+
+// Combines parallel edge weights by taking the least, as for shortest paths.
+func MinWeight(weights []float64) float64 {
+	min := weights[0]
+	for _, w := range weights[1:] {
+		if w < min {
+			min = w
+		}
+	}
+	return min
+}
+
+// Combines parallel edge weights by taking the greatest, as for bottleneck paths.
+func MaxWeight(weights []float64) float64 {
+	max := weights[0]
+	for _, w := range weights[1:] {
+		if w > max {
+			max = w
+		}
+	}
+	return max
+}
+
+// Combines parallel edge weights by averaging them.
+func MeanWeight(weights []float64) float64 {
+	return SumWeights(weights) / float64(len(weights))
+}
@@ -0,0 +1,116 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type AggregateSuite struct{}
+
+var _ = Suite(&AggregateSuite{})
+
+// No adjacency list admits parallel edges, so these stand in for weighted
+// multigraphs: a simple graph for the structure, with the given edges, parallels
+// and all, enumerated in place of its own.
+type parallelEdges struct {
+	gogl.WeightedGraph
+	edges []gogl.Edge
+}
+
+func (g parallelEdges) Edges(f gogl.EdgeStep) {
+	for _, e := range g.edges {
+		if f(e) {
+			return
+		}
+	}
+}
+
+type parallelArcs struct {
+	gogl.WeightedDigraph
+	edges []gogl.Edge
+}
+
+func (g parallelArcs) Edges(f gogl.EdgeStep) {
+	for _, e := range g.edges {
+		if f(e) {
+			return
+		}
+	}
+}
+
+func (s *AggregateSuite) TestUndirected(c *C) {
+	edges := []gogl.Edge{
+		gogl.NewWeightedEdge("a", "b", 3),
+		gogl.NewWeightedEdge("b", "a", 1),
+		gogl.NewWeightedEdge("a", "b", 2),
+		gogl.NewWeightedEdge("b", "c", 5),
+		gogl.NewWeightedEdge("c", "c", 1),
+		gogl.NewWeightedEdge("c", "c", 4),
+	}
+	base := gogl.Spec().Weighted().Loop().Create(al.G).(gogl.WeightedGraph)
+	base.(gogl.VertexSetMutator).EnsureVertex("a", "b", "c", "d")
+	g := parallelEdges{base, edges}
+
+	for name, tc := range map[string]struct {
+		combine      func([]float64) float64
+		ab, bc, loop float64
+	}{
+		"sum":  {SumWeights, 6, 5, 5},
+		"min":  {MinWeight, 1, 5, 1},
+		"max":  {MaxWeight, 3, 5, 4},
+		"mean": {MeanWeight, 2, 5, 2.5},
+	} {
+		ag := AggregateParallel(g, tc.combine)
+		_, directed := ag.(gogl.Digraph)
+		c.Assert(directed, Equals, false, Commentf("combiner: %s", name))
+		c.Assert(gogl.Order(ag), Equals, 4, Commentf("combiner: %s", name))
+		c.Assert(gogl.Size(ag), Equals, 3, Commentf("combiner: %s", name))
+		c.Assert(ag.HasWeightedEdge(gogl.NewWeightedEdge("b", "a", tc.ab)), Equals, true, Commentf("combiner: %s", name))
+		c.Assert(ag.HasWeightedEdge(gogl.NewWeightedEdge("b", "c", tc.bc)), Equals, true, Commentf("combiner: %s", name))
+		c.Assert(ag.HasWeightedEdge(gogl.NewWeightedEdge("c", "c", tc.loop)), Equals, true, Commentf("combiner: %s", name))
+	}
+}
+
+func (s *AggregateSuite) TestDirected(c *C) {
+	edges := []gogl.Edge{
+		gogl.NewWeightedArc(1, 2, 3),
+		gogl.NewWeightedArc(1, 2, 1.5),
+		gogl.NewWeightedArc(2, 1, 7),
+		gogl.NewWeightedArc(1, 2, 2),
+	}
+	base := gogl.Spec().Directed().Weighted().Create(al.G).(gogl.WeightedDigraph)
+	g := parallelArcs{base, edges}
+
+	// Arcs in opposite directions are not parallel.
+	ag := AggregateParallel(g, SumWeights).(gogl.WeightedDigraph)
+	c.Assert(gogl.Size(ag), Equals, 2)
+	c.Assert(ag.HasWeightedArc(gogl.NewWeightedArc(1, 2, 6.5)), Equals, true)
+	c.Assert(ag.HasWeightedArc(gogl.NewWeightedArc(2, 1, 7)), Equals, true)
+
+	ag = AggregateParallel(g, MinWeight).(gogl.WeightedDigraph)
+	c.Assert(ag.HasWeightedArc(gogl.NewWeightedArc(1, 2, 1.5)), Equals, true)
+	c.Assert(ag.HasWeightedArc(gogl.NewWeightedArc(2, 1, 7)), Equals, true)
+
+	// The combiner sees the weights in enumeration order.
+	var seen [][]float64
+	AggregateParallel(g, func(ws []float64) float64 {
+		seen = append(seen, ws)
+		return 0
+	})
+	c.Assert(seen, DeepEquals, [][]float64{{3, 1.5, 2}, {7}})
+}
+
+func (s *AggregateSuite) TestSimpleGraphUnchanged(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 4),
+		gogl.NewWeightedEdge(2, 3, -1),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	ag := AggregateParallel(g, MeanWeight)
+	c.Assert(gogl.Size(ag), Equals, 2)
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(ag.HasWeightedEdge(e.(gogl.WeightedEdge)), Equals, true, Commentf("edge %v", e))
+		return
+	})
+}
@@ -0,0 +1,91 @@
+package algo
+
+import (
+	"errors"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// One side of the split of a DAG's vertex into two, for MaximumAntichain. The
+// vertex is given by its position in the list from ReachabilityMatrix.
+type antichainSide struct {
+	i  int
+	in bool
+}
+
+// Finds a maximum antichain of the given DAG: a largest set of vertices, no one
+// of which can reach another. In a precedence graph, this is a largest set of
+// tasks that may all be carried out independently of one another.
+//
+// By Dilworth's theorem, the size of a maximum antichain equals the fewest chains
+// needed to cover the DAG's reachability order, and both are found from a maximum
+// matching in a bipartite graph: each vertex is split into an out-copy and an
+// in-copy, with an edge from u's out-copy to v's in-copy wherever u reaches v (per
+// ReachabilityMatrix). The antichain is then read off a minimum vertex cover of
+// that graph, which König's theorem constructs from the matching; it is made of
+// the vertices neither of whose copies is in the cover.
+//
+// An error is returned if the graph contains a cycle.
+func MaximumAntichain(g gogl.Digraph) ([]gogl.Vertex, error) {
+	if !IsDAG(g) {
+		return nil, errors.New("Graph is not acyclic.")
+	}
+
+	reaches, vertices := ReachabilityMatrix(g)
+
+	var edges gogl.WeightedEdgeList
+	for i, u := range vertices {
+		for j, v := range vertices {
+			if reaches(u, v) {
+				edges = append(edges, gogl.NewWeightedEdge(antichainSide{i, false}, antichainSide{j, true}, 1))
+			}
+		}
+	}
+
+	// With unit weights, a maximum weight matching has maximum cardinality.
+	split := gogl.Spec().Weighted().Using(edges).Create(al.G).(gogl.WeightedGraph)
+	mate, _ := MaximumWeightMatching(split)
+
+	// König: from each unmatched out-copy, follow unmatched edges to in-copies and
+	// matched edges back. The cover is the out-copies not reached, along with the
+	// in-copies that are.
+	reached := make(map[antichainSide]bool)
+	var queue []antichainSide
+	for i := range vertices {
+		out := antichainSide{i, false}
+		if _, matched := mate[out]; !matched {
+			reached[out] = true
+			queue = append(queue, out)
+		}
+	}
+
+	for len(queue) > 0 {
+		out := queue[0]
+		queue = queue[1:]
+
+		for j, v := range vertices {
+			in := antichainSide{j, true}
+			if reached[in] || !reaches(vertices[out.i], v) {
+				continue
+			}
+			reached[in] = true
+
+			if next, matched := mate[in]; matched {
+				if next := next.(antichainSide); !reached[next] {
+					reached[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+
+	antichain := make([]gogl.Vertex, 0)
+	for i, v := range vertices {
+		if reached[antichainSide{i, false}] && !reached[antichainSide{i, true}] {
+			antichain = append(antichain, v)
+		}
+	}
+
+	return antichain, nil
+}
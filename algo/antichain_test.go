@@ -0,0 +1,118 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type AntichainSuite struct{}
+
+var _ = Suite(&AntichainSuite{})
+
+// Checks that no vertex of the antichain reaches another.
+func checkAntichain(c *C, g gogl.Digraph, antichain []gogl.Vertex) {
+	reaches, _ := ReachabilityMatrix(g)
+	for _, u := range antichain {
+		for _, v := range antichain {
+			c.Assert(reaches(u, v), Equals, false, Commentf("%v reaches %v", u, v))
+		}
+	}
+}
+
+// Finds the size of a maximum antichain by trying every subset of vertices.
+func bruteForceAntichain(g gogl.Digraph) int {
+	reaches, vertices := ReachabilityMatrix(g)
+
+	var best int
+	for set := 0; set < 1<<uint(len(vertices)); set++ {
+		var members []gogl.Vertex
+		for i, v := range vertices {
+			if set&(1<<uint(i)) != 0 {
+				members = append(members, v)
+			}
+		}
+
+		ok := true
+		for _, u := range members {
+			for _, v := range members {
+				ok = ok && !reaches(u, v)
+			}
+		}
+		if ok && len(members) > best {
+			best = len(members)
+		}
+	}
+	return best
+}
+
+func (s *AntichainSuite) TestKnownAntichain(c *C) {
+	// Getting dressed: at most four garments can go on in any order relative to
+	// one another, such as the socks, pants, undershirt and watch.
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("undershorts", "pants"),
+		gogl.NewArc("undershorts", "shoes"),
+		gogl.NewArc("pants", "shoes"),
+		gogl.NewArc("pants", "belt"),
+		gogl.NewArc("socks", "shoes"),
+		gogl.NewArc("undershirt", "shirt"),
+		gogl.NewArc("shirt", "belt"),
+		gogl.NewArc("shirt", "tie"),
+		gogl.NewArc("tie", "jacket"),
+		gogl.NewArc("belt", "jacket"),
+	}).Create(al.G).(gogl.Digraph)
+	g.(gogl.VertexSetMutator).EnsureVertex("watch")
+
+	antichain, err := MaximumAntichain(g)
+	c.Assert(err, IsNil)
+	checkAntichain(c, g, antichain)
+	c.Assert(antichain, HasLen, 4)
+	c.Assert(len(antichain), Equals, bruteForceAntichain(g))
+
+	// In a chain, only one vertex at a time.
+	chain := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 2),
+		gogl.NewArc(2, 3),
+		gogl.NewArc(3, 4),
+	}).Create(al.G).(gogl.Digraph)
+	antichain, err = MaximumAntichain(chain)
+	c.Assert(err, IsNil)
+	c.Assert(antichain, HasLen, 1)
+
+	antichain, err = MaximumAntichain(gogl.Spec().Directed().Create(al.G).(gogl.Digraph))
+	c.Assert(err, IsNil)
+	c.Assert(antichain, HasLen, 0)
+}
+
+func (s *AntichainSuite) TestRandomDAGs(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 2 + r.Intn(10)
+		g := gogl.Spec().Directed().Create(al.G).(gogl.Digraph)
+		for v := 0; v < n; v++ {
+			g.(gogl.VertexSetMutator).EnsureVertex(v)
+			for u := 0; u < v; u++ {
+				if r.Intn(4) == 0 {
+					g.(gogl.ArcSetMutator).AddArcs(gogl.NewArc(u, v))
+				}
+			}
+		}
+
+		antichain, err := MaximumAntichain(g)
+		c.Assert(err, IsNil)
+		checkAntichain(c, g, antichain)
+		c.Assert(len(antichain), Equals, bruteForceAntichain(g), Commentf("trial %d", i))
+	}
+}
+
+func (s *AntichainSuite) TestCyclic(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 2),
+		gogl.NewArc(2, 1),
+	}).Create(al.G).(gogl.Digraph)
+
+	_, err := MaximumAntichain(g)
+	c.Assert(err, ErrorMatches, "Graph is not acyclic.")
+}
@@ -0,0 +1,284 @@
+package algo
+
+import (
+	"container/heap"
+	"context"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Calculates the load on each edge: the number of shortest paths, summed over
+// all pairs of vertices, that traverse it. This is edge betweenness centrality
+// without normalization, and identifies the links that would be most congested
+// if traffic were routed along shortest paths between every pair.
+//
+// Where a pair is joined by several shortest paths, each path carries an equal
+// fraction of that pair's single unit of load. For undirected graphs, each
+// unordered pair of vertices is counted once; for Digraphs, arc direction is
+// respected and each ordered pair is counted.
+//
+// Path lengths are hop counts, unless g is a WeightedGraph, in which case they
+// are total edge weights (which must not be negative). The returned map is keyed
+// by the endpoints of each edge, in the order the graph enumerates them - for an
+// arc, its source and then its target - rather than by the edges themselves, so
+// edges whose data cannot be compared are no obstacle. It includes every edge,
+// even those carrying no load. Self-loops never lie on a shortest path.
+func EdgeLoad(g gogl.Graph) map[[2]gogl.Vertex]float64 {
+	load := edgeBetweenness(g)
+
+	if _, directed := g.(gogl.Digraph); !directed {
+		// Each unordered pair was accumulated once from either end.
+		for e := range load {
+			load[e] /= 2
+		}
+	}
+
+	return load
+}
+
+// Calculates the betweenness centrality of each vertex: the number of shortest
+// paths, summed over all pairs of other vertices, that pass through it. Vertices
+// with high betweenness broker much of the graph's traffic, and their removal
+// would lengthen many paths.
+//
+// Brandes' algorithm is used, taking O(VE) time for unweighted graphs and
+// O(VE + V^2 log V) for weighted ones, rather than counting paths pair by pair.
+// As for EdgeLoad, each pair joined by several shortest paths shares a single
+// unit among them; each unordered pair is counted once in an undirected graph,
+// and each ordered pair in a Digraph. Path lengths are hop counts, unless g is a
+// WeightedGraph, in which case they are total edge weights, which must not be
+// negative. Every vertex is in the result, even those lying on no paths.
+func BetweennessCentrality(g gogl.Graph) map[gogl.Vertex]float64 {
+	bc, _ := BetweennessCentralityContext(context.Background(), g)
+	return bc
+}
+
+// Calculates betweenness centrality as BetweennessCentrality does, but abandons
+// the calculation if the provided context is cancelled or its deadline passes.
+// The context is checked before the search from each source vertex; once it is
+// done, the context's error is returned, with no result.
+func BetweennessCentralityContext(ctx context.Context, g gogl.Graph) (map[gogl.Vertex]float64, error) {
+	bc := make(map[gogl.Vertex]float64, sizeHint(g))
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		bc[v] = 0
+		return
+	})
+
+	_, weighted := g.(gogl.WeightedGraph)
+	hint := len(bc)
+	for s := range bc {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var sp *shortestPathDAG
+		if weighted {
+			sp = dijkstraDAG(g, s, hint)
+		} else {
+			sp = bfsDAG(g, s, hint)
+		}
+
+		// Walk back from the farthest vertices, accumulating the dependency of s
+		// on each vertex; the source itself is not between anything.
+		delta := make(map[gogl.Vertex]float64, len(sp.order))
+		for i := len(sp.order) - 1; i > 0; i-- {
+			w := sp.order[i]
+			for _, v := range sp.pred[w] {
+				delta[v] += sp.sigma[v] / sp.sigma[w] * (1 + delta[w])
+			}
+			bc[w] += delta[w]
+		}
+	}
+
+	if _, directed := g.(gogl.Digraph); !directed {
+		// Each unordered pair was accumulated once from either end.
+		for v := range bc {
+			bc[v] /= 2
+		}
+	}
+
+	return bc, nil
+}
+
+// Calculates betweenness centrality as BetweennessCentrality does, then divides
+// each vertex's by the number of pairs of other vertices: (n-1)(n-2)/2 in an
+// undirected graph of order n, or (n-1)(n-2) in a Digraph. The results fall in
+// [0, 1], and can be compared between graphs of different orders; the center of
+// a star scores 1. Graphs of fewer than three vertices have no such pairs, so
+// every score is left at 0.
+func NormalizedBetweennessCentrality(g gogl.Graph) map[gogl.Vertex]float64 {
+	bc := BetweennessCentrality(g)
+
+	n := float64(len(bc))
+	if n < 3 {
+		return bc
+	}
+	pairs := (n - 1) * (n - 2)
+	if _, directed := g.(gogl.Digraph); !directed {
+		pairs /= 2
+	}
+	for v := range bc {
+		bc[v] /= pairs
+	}
+
+	return bc
+}
+
+// Accumulates edge dependencies over single-source shortest paths from every
+// vertex, per Brandes' algorithm. Every ordered pair of vertices contributes, so
+// in an undirected graph each pair is counted twice.
+func edgeBetweenness(g gogl.Graph) map[[2]gogl.Vertex]float64 {
+	// Paths may cross an undirected edge either way, so map both orientations
+	// back to the one the graph enumerates.
+	load := make(map[[2]gogl.Vertex]float64)
+	canon := make(map[[2]gogl.Vertex][2]gogl.Vertex)
+
+	dg, directed := g.(gogl.Digraph)
+	if directed {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			key := [2]gogl.Vertex{a.Source(), a.Target()}
+			load[key] = 0
+			canon[key] = key
+			return
+		})
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			key := [2]gogl.Vertex{u, v}
+			load[key] = 0
+			canon[key] = key
+			canon[[2]gogl.Vertex{v, u}] = key
+			return
+		})
+	}
+
+	_, weighted := g.(gogl.WeightedGraph)
+	hint := sizeHint(g)
+
+	g.Vertices(func(s gogl.Vertex) (terminate bool) {
+		var sp *shortestPathDAG
+		if weighted {
+			sp = dijkstraDAG(g, s, hint)
+		} else {
+			sp = bfsDAG(g, s, hint)
+		}
+
+		// Walk back from the farthest vertices, pushing each vertex's dependency
+		// onto the edges leading into it in proportion to the paths they carry.
+		delta := make(map[gogl.Vertex]float64, len(sp.order))
+		for i := len(sp.order) - 1; i > 0; i-- {
+			w := sp.order[i]
+			for _, v := range sp.pred[w] {
+				c := sp.sigma[v] / sp.sigma[w] * (1 + delta[w])
+				load[canon[[2]gogl.Vertex{v, w}]] += c
+				delta[v] += c
+			}
+		}
+		return
+	})
+
+	return load
+}
+
+// The shortest paths from a single source: vertices in nondecreasing order of
+// distance, the number of shortest paths to each, and each vertex's predecessors
+// on those paths.
+type shortestPathDAG struct {
+	order []gogl.Vertex
+	sigma map[gogl.Vertex]float64
+	pred  map[gogl.Vertex][]gogl.Vertex
+}
+
+func newShortestPathDAG(s gogl.Vertex, hint int) *shortestPathDAG {
+	sp := &shortestPathDAG{
+		order: make([]gogl.Vertex, 0, hint),
+		sigma: make(map[gogl.Vertex]float64, hint),
+		pred:  make(map[gogl.Vertex][]gogl.Vertex, hint),
+	}
+	sp.sigma[s] = 1
+	return sp
+}
+
+// Builds the shortest path DAG from s by breadth-first search, counting hops.
+func bfsDAG(g gogl.Graph, s gogl.Vertex, hint int) *shortestPathDAG {
+	sp := newShortestPathDAG(s, hint)
+	dist := map[gogl.Vertex]int{s: 0}
+
+	sp.order = append(sp.order, s)
+	for i := 0; i < len(sp.order); i++ {
+		v := sp.order[i]
+		eachOutEdge(g, v, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+			dw, seen := dist[w]
+			if !seen {
+				dw = dist[v] + 1
+				dist[w] = dw
+				sp.order = append(sp.order, w)
+			}
+			if dw == dist[v]+1 {
+				sp.sigma[w] += sp.sigma[v]
+				sp.pred[w] = append(sp.pred[w], v)
+			}
+			return
+		})
+	}
+
+	return sp
+}
+
+// Builds the shortest path DAG from s with Dijkstra's algorithm, summing weights.
+func dijkstraDAG(g gogl.Graph, s gogl.Vertex, hint int) *shortestPathDAG {
+	sp := newShortestPathDAG(s, hint)
+	dist := map[gogl.Vertex]float64{s: 0}
+	done := make(map[gogl.Vertex]bool, hint)
+
+	pq := &distQueue{{s, 0}}
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(distItem)
+		v := item.v
+		if done[v] {
+			continue
+		}
+		done[v] = true
+		sp.order = append(sp.order, v)
+
+		eachOutEdge(g, v, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+			if done[w] {
+				return
+			}
+
+			nd := item.d + weightOf(e)
+			switch dw, seen := dist[w]; {
+			case !seen || nd < dw:
+				dist[w] = nd
+				sp.sigma[w] = sp.sigma[v]
+				sp.pred[w] = append(sp.pred[w][:0], v)
+				heap.Push(pq, distItem{w, nd})
+			case nd == dw:
+				sp.sigma[w] += sp.sigma[v]
+				sp.pred[w] = append(sp.pred[w], v)
+			}
+			return
+		})
+	}
+
+	return sp
+}
+
+type distItem struct {
+	v gogl.Vertex
+	d float64
+}
+
+// A min-heap of vertices keyed on tentative distance, for use with container/heap.
+type distQueue []distItem
+
+func (q distQueue) Len() int            { return len(q) }
+func (q distQueue) Less(i, j int) bool  { return q[i].d < q[j].d }
+func (q distQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distQueue) Push(x interface{}) { *q = append(*q, x.(distItem)) }
+func (q *distQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,189 @@
+package algo
+
+import (
+	"context"
+	"math"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type EdgeLoadSuite struct{}
+
+var _ = Suite(&EdgeLoadSuite{})
+
+// Looks up the load on the edge between u and v, in either orientation.
+func loadBetween(load map[[2]gogl.Vertex]float64, u, v gogl.Vertex) float64 {
+	if l, exists := load[[2]gogl.Vertex{u, v}]; exists {
+		return l
+	}
+	if l, exists := load[[2]gogl.Vertex{v, u}]; exists {
+		return l
+	}
+	return -1
+}
+
+func (s *EdgeLoadSuite) TestDumbbellBridge(c *C) {
+	// Two K4s, {0,1,2,3} and {4,5,6,7}, joined by the bridge 3-4.
+	g := relabeledGraph([][2]int{
+		{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3},
+		{4, 5}, {4, 6}, {4, 7}, {5, 6}, {5, 7}, {6, 7},
+		{3, 4},
+	}, identity)
+
+	load := EdgeLoad(g)
+	c.Assert(load, HasLen, 13)
+
+	// Every pair split across the bridge crosses it.
+	bridge := loadBetween(load, 3, 4)
+	c.Assert(bridge, Equals, 16.0)
+	for e, l := range load {
+		if u, v := e[0], e[1]; !(u == 3 && v == 4 || u == 4 && v == 3) {
+			c.Assert(l < bridge, Equals, true, Commentf("edge %v has load %v", e, l))
+		}
+	}
+
+	// An edge to the bridge's endpoint carries its own pair, plus the far side's traffic.
+	c.Assert(loadBetween(load, 0, 3), Equals, 5.0)
+	c.Assert(loadBetween(load, 0, 1), Equals, 1.0)
+}
+
+func (s *EdgeLoadSuite) TestSplitPaths(c *C) {
+	// Opposite corners of a square are joined by two shortest paths, which split the load.
+	load := EdgeLoad(relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}}, identity))
+	for e, l := range load {
+		c.Assert(l, Equals, 2.0, Commentf("edge %v", e))
+	}
+}
+
+func (s *EdgeLoadSuite) TestDirected(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "a"),
+	}).Create(al.G)
+
+	// In a directed 3-cycle, a->b carries (a,b), (a,c) and (c,b); likewise for the others.
+	for e, l := range EdgeLoad(g) {
+		c.Assert(l, Equals, 3.0, Commentf("arc %v", e))
+	}
+}
+
+func (s *EdgeLoadSuite) TestWeighted(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "c", 1),
+		gogl.NewWeightedEdge("a", "c", 5),
+	}).Create(al.G)
+
+	// The direct a-c edge is too heavy to be a shortest path, so b relays.
+	load := EdgeLoad(g)
+	c.Assert(loadBetween(load, "a", "c"), Equals, 0.0)
+	c.Assert(loadBetween(load, "a", "b"), Equals, 2.0)
+	c.Assert(loadBetween(load, "b", "c"), Equals, 2.0)
+}
+
+type BetweennessSuite struct{}
+
+var _ = Suite(&BetweennessSuite{})
+
+func (s *BetweennessSuite) TestStarAndPath(c *C) {
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}}, identity)
+	c.Assert(BetweennessCentrality(star), DeepEquals, map[gogl.Vertex]float64{0: 6, 1: 0, 2: 0, 3: 0, 4: 0})
+	c.Assert(NormalizedBetweennessCentrality(star), DeepEquals, map[gogl.Vertex]float64{0: 1, 1: 0, 2: 0, 3: 0, 4: 0})
+
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}}, identity)
+	c.Assert(BetweennessCentrality(path), DeepEquals, map[gogl.Vertex]float64{1: 0, 2: 2, 3: 2, 4: 0})
+
+	// Opposite corners of a square split their single unit between two paths.
+	square := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}}, identity)
+	for v, b := range BetweennessCentrality(square) {
+		c.Assert(b, Equals, 0.5, Commentf("vertex %v", v))
+	}
+}
+
+func (s *BetweennessSuite) TestDirected(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "d"),
+	}).Create(al.G)
+
+	// b lies between (a, c) and (a, d); c between (a, d) and (b, d).
+	c.Assert(BetweennessCentrality(g), DeepEquals, map[gogl.Vertex]float64{"a": 0, "b": 2, "c": 2, "d": 0})
+	// Ordered pairs are counted, so there are twice as many to divide among.
+	c.Assert(NormalizedBetweennessCentrality(g), DeepEquals, map[gogl.Vertex]float64{"a": 0, "b": 1.0 / 3, "c": 1.0 / 3, "d": 0})
+}
+
+func (s *BetweennessSuite) TestWeighted(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "c", 1),
+		gogl.NewWeightedEdge("a", "c", 5),
+	}).Create(al.G)
+	c.Assert(BetweennessCentrality(g), DeepEquals, map[gogl.Vertex]float64{"a": 0, "b": 1, "c": 0})
+
+	// Unweighted, the direct edge wins.
+	ug := relabeledGraph([][2]int{{1, 2}, {2, 3}, {1, 3}}, identity)
+	c.Assert(BetweennessCentrality(ug), DeepEquals, map[gogl.Vertex]float64{1: 0, 2: 0, 3: 0})
+}
+
+func (s *BetweennessSuite) TestPathLengthSum(c *C) {
+	// Each pair's shortest paths pass through d-1 interior vertices, so the
+	// scores sum to the total over pairs of d-1.
+	g := relabeledGraph(petersen, identity)
+	var want float64
+	g.Vertices(func(u gogl.Vertex) (terminate bool) {
+		dist, _, _ := bfsDistances(g, u, -1)
+		for _, d := range dist {
+			if d > 0 {
+				want += float64(d-1) / 2
+			}
+		}
+		return
+	})
+
+	var sum float64
+	for _, b := range BetweennessCentrality(g) {
+		sum += b
+	}
+	c.Assert(math.Abs(sum-want) < 1e-9, Equals, true, Commentf("sum %v, expected %v", sum, want))
+
+	c.Assert(BetweennessCentrality(gogl.NullGraph), HasLen, 0)
+	c.Assert(NormalizedBetweennessCentrality(relabeledGraph([][2]int{{1, 2}}, identity)), DeepEquals, map[gogl.Vertex]float64{1: 0, 2: 0})
+}
+
+func (s *BetweennessSuite) TestContext(c *C) {
+	g := relabeledGraph(petersen, identity)
+
+	bc, err := BetweennessCentralityContext(context.Background(), g)
+	c.Assert(err, IsNil)
+	c.Assert(bc, DeepEquals, BetweennessCentrality(g))
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	bc, err = BetweennessCentralityContext(cancelled, g)
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(bc, IsNil)
+
+	// Cancelled after a few sources have been searched.
+	ctx := &countdownContext{Context: context.Background(), remaining: 3}
+	bc, err = BetweennessCentralityContext(ctx, g)
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(bc, IsNil)
+	c.Assert(ctx.after, Equals, 1)
+}
+
+func (s *EdgeLoadSuite) TestUncomparableData(c *C) {
+	// Slices cannot be map keys, so the edges themselves could not key the result.
+	g := gogl.Spec().DataEdges().Using(gogl.DataEdgeList{
+		gogl.NewDataEdge("a", "b", []int{1}),
+		gogl.NewDataEdge("b", "c", []int{2}),
+	}).Create(al.G)
+
+	load := EdgeLoad(g)
+	c.Assert(load, HasLen, 2)
+	c.Assert(loadBetween(load, "a", "b"), Equals, 2.0)
+	c.Assert(loadBetween(load, "b", "c"), Equals, 2.0)
+}
@@ -0,0 +1,174 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// A node of a block-cut tree standing for a block of the original graph: a
+// biconnected component, listing the vertices it contains.
+type Block struct {
+	Vertices []gogl.Vertex
+}
+
+// Partitions the graph's edges into its biconnected components, or blocks: the
+// maximal subgraphs that remain connected however any single vertex is removed.
+// Each block is returned as the set of vertices it spans.
+//
+// Unlike 2-edge-connected components, blocks may overlap, but only at cut
+// vertices (articulation points): those whose removal disconnects the graph. A
+// bridge forms a block of two vertices by itself, and an isolated vertex a block
+// of one.
+//
+// Edge direction and self-loops are ignored. Neither the order of the blocks nor
+// that of the vertices within each is meaningful.
+func BiconnectedComponents(g gogl.Graph) [][]gogl.Vertex {
+	return lowLinkSearch(undirectedAdjacency(g)).blocks
+}
+
+// Builds the block-cut tree of the graph, which has a node for each block and for
+// each cut vertex, and an edge between each cut vertex and every block containing
+// it. Block nodes are *Block values, and cut vertex nodes are the vertices
+// themselves; the returned map tags each node as "block" or "cut".
+//
+// As blocks only meet at cut vertices, this is always a tree for a connected
+// graph, and a forest otherwise, with one tree per connected component. Blocks
+// are found as by BiconnectedComponents. The result is a mutable adjacency list.
+func BlockCutTree(g gogl.Graph) (gogl.Graph, map[gogl.Vertex]string) {
+	ll := lowLinkSearch(undirectedAdjacency(g))
+	blocks, cuts := ll.blocks, ll.cuts
+
+	tree := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	kinds := make(map[gogl.Vertex]string, len(blocks)+len(cuts))
+	for _, v := range cuts {
+		tree.EnsureVertex(v)
+		kinds[v] = "cut"
+	}
+
+	for _, vs := range blocks {
+		b := &Block{Vertices: vs}
+		tree.EnsureVertex(b)
+		kinds[b] = "block"
+
+		for _, v := range vs {
+			if _, cut := cuts[gogl.VertexKey(v)]; cut {
+				tree.AddEdges(gogl.NewEdge(b, v))
+			}
+		}
+	}
+
+	return tree, kinds
+}
+
+// Counts the connected components the graph would have if v were removed, without
+// modifying it. The more components a cut vertex would leave, the more severe its
+// failure, making this a ranking for resilience analysis.
+//
+// This is ComponentsAfterRemovalAll, looked up for the one vertex. If v is not
+// present in the graph, the graph's own component count is returned.
+func ComponentsAfterRemoval(g gogl.Graph, v gogl.Vertex) int {
+	adj := undirectedAdjacency(g)
+	counts, components := componentsAfterRemoval(g, adj)
+	if i := adj.IndexOf(v); i >= 0 {
+		return counts[i]
+	}
+	return components
+}
+
+// Counts, for every vertex of the graph, the connected components it would have
+// if that vertex alone were removed.
+//
+// Removing a vertex splits its own component into one piece for each block that
+// contains it, so a single search for the blocks, as by BiconnectedComponents,
+// answers for all vertices at once. A vertex that is not a cut vertex thus leaves
+// the count as it was, unless it is isolated, in which case the count drops by
+// one. Edge direction and self-loops are ignored.
+func ComponentsAfterRemovalAll(g gogl.Graph) map[gogl.Vertex]int {
+	adj := undirectedAdjacency(g)
+	counts, _ := componentsAfterRemoval(g, adj)
+
+	all := make(map[gogl.Vertex]int, len(counts))
+	for i, n := range counts {
+		all[adj.VertexAt(i)] = n
+	}
+	return all
+}
+
+// Computes ComponentsAfterRemovalAll, by vertex id in the given adjacency of g,
+// along with the graph's own component count.
+func componentsAfterRemoval(g gogl.Graph, adj adjacency) ([]int, int) {
+	blocks := lowLinkSearch(adj).blocks
+	components := len(ConnectedComponents(g))
+
+	counts := make([]int, adj.Len())
+	for i := range counts {
+		counts[i] = components - 1
+	}
+	for _, vs := range blocks {
+		// A block of one vertex is an isolated vertex, which leaves nothing behind.
+		if len(vs) > 1 {
+			for _, v := range vs {
+				counts[adj.IndexOf(v)]++
+			}
+		}
+	}
+
+	return counts, components
+}
+
+// Indicates whether there are two paths between u and v that share no vertices
+// but their ends - that is, whether, by Menger's theorem, no single vertex other
+// than u and v can be removed to separate them. Unlike BiconnectedComponents, this
+// examines only the one pair, and so also answers for Digraphs, where the paths
+// must both lead from u to v along the arcs' direction.
+//
+// The question is settled with a maximum flow, stopped as soon as it reaches 2.
+// Each vertex is split into an entry and an exit, joined by an arc of unit
+// capacity so that at most one path passes through it; each edge becomes an arc
+// of unit capacity from the exit of one endpoint to the entry of the other. The
+// flow then runs from u's exit to v's entry.
+//
+// Self-loops are ignored. If either vertex is not present in the graph, or if u
+// and v are the same vertex, false is returned.
+func AreBiconnected(g gogl.Graph, u, v gogl.Vertex) bool {
+	if gogl.VertexKey(u) == gogl.VertexKey(v) || !g.HasVertex(u) || !g.HasVertex(v) {
+		return false
+	}
+
+	vi := gogl.NewVertexIndex(g)
+	fn := newSplitFlowNetwork(g, vi)
+	return fn.maxFlow(splitExit(vi.IndexOf(u)), splitEntry(vi.IndexOf(v)), 2) >= 2
+}
+
+// Builds a flow network in which each vertex of g is split in two, so that at
+// most one unit of flow can pass through it. Vertex i has an entry node and an
+// exit node, joined by an arc of unit capacity, which is arc pair i. Each edge
+// becomes an arc of unit capacity from the exit of one endpoint to the entry of
+// the other, or one each way if g is undirected. Self-loops are ignored.
+func newSplitFlowNetwork(g gogl.Graph, vi *gogl.VertexIndex) *flowNetwork {
+	fn := newFlowNetwork(2 * vi.Len())
+	for i := 0; i < vi.Len(); i++ {
+		fn.addArc(splitEntry(i), splitExit(i), 1)
+	}
+
+	_, directed := g.(gogl.Digraph)
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		a, b := e.Both()
+		ai, bi := vi.IndexOf(a), vi.IndexOf(b)
+		if ai == bi {
+			return
+		}
+
+		fn.addArc(splitExit(ai), splitEntry(bi), 1)
+		if !directed {
+			fn.addArc(splitExit(bi), splitEntry(ai), 1)
+		}
+		return
+	})
+
+	return fn
+}
+
+// The entry and exit nodes of vertex i in a split flow network.
+func splitEntry(i int) int { return 2 * i }
+func splitExit(i int) int  { return 2*i + 1 }
@@ -0,0 +1,379 @@
+package algo
+
+import (
+	"fmt"
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type BiconnectedSuite struct{}
+
+var _ = Suite(&BiconnectedSuite{})
+
+// Finds the cut vertices of g by removing each vertex in turn, and seeing whether
+// that splits a component.
+func bruteForceCuts(g gogl.MutableGraph) map[gogl.Vertex]bool {
+	var vertices []gogl.Vertex
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		vertices = append(vertices, v)
+		return
+	})
+
+	cuts := make(map[gogl.Vertex]bool)
+	for _, v := range vertices {
+		var incident []gogl.Edge
+		g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+			incident = append(incident, e)
+			return
+		})
+
+		// Removing v takes away its component, so splitting it means leaving
+		// at least two behind where there was one.
+		before := len(ConnectedComponents(g))
+		g.RemoveVertex(v)
+		if len(ConnectedComponents(g)) > before {
+			cuts[v] = true
+		}
+		g.EnsureVertex(v)
+		g.AddEdges(incident...)
+	}
+	return cuts
+}
+
+// Checks that tree is a forest joining each cut vertex to the blocks containing
+// it, and returns its blocks in canonical form.
+func checkBlockCutTree(c *C, tree gogl.Graph, kinds map[gogl.Vertex]string, comment string) []string {
+	c.Assert(kinds, HasLen, gogl.Order(tree), Commentf("%s", comment))
+	c.Assert(gogl.Size(tree), Equals, gogl.Order(tree)-len(ConnectedComponents(tree)), Commentf("not a forest; %s", comment))
+
+	var blocks [][]gogl.Vertex
+	tree.Vertices(func(n gogl.Vertex) (terminate bool) {
+		switch kinds[n] {
+		case "block":
+			b := n.(*Block)
+			blocks = append(blocks, b.Vertices)
+			for _, v := range b.Vertices {
+				c.Assert(tree.HasEdge(gogl.NewEdge(b, v)), Equals, kinds[v] == "cut", Commentf("block %v, vertex %v; %s", b.Vertices, v, comment))
+			}
+		case "cut":
+			tree.IncidentTo(n, func(e gogl.Edge) (terminate bool) {
+				u, w := e.Both()
+				if u == n {
+					u = w
+				}
+				c.Assert(kinds[u], Equals, "block", Commentf("%s", comment))
+				return
+			})
+		default:
+			c.Fatalf("node %v has kind %q; %s", n, kinds[n], comment)
+		}
+		return
+	})
+
+	return canonicalPartition(blocks)
+}
+
+func (s *BiconnectedSuite) TestFigureEight(c *C) {
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 3}}, identity)
+
+	tree, kinds := BlockCutTree(g)
+	c.Assert(checkBlockCutTree(c, tree, kinds, ""), DeepEquals, []string{"[1 2 3]", "[3 4 5]"})
+
+	// The cut vertex sits between the two blocks.
+	c.Assert(gogl.Order(tree), Equals, 3)
+	c.Assert(kinds[3], Equals, "cut")
+	var degree int
+	tree.IncidentTo(3, func(e gogl.Edge) (terminate bool) {
+		degree++
+		return
+	})
+	c.Assert(degree, Equals, 2)
+}
+
+func (s *BiconnectedSuite) TestSmallGraphs(c *C) {
+	// Each edge of a path is a block, and the inner vertices are cut vertices.
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}}, identity)
+	c.Assert(canonicalPartition(BiconnectedComponents(path)), DeepEquals, []string{"[1 2]", "[2 3]", "[3 4]"})
+	tree, kinds := BlockCutTree(path)
+	checkBlockCutTree(c, tree, kinds, "path")
+	c.Assert(gogl.Order(tree), Equals, 5)
+
+	// A cycle is a single block; so is a single edge.
+	cycle := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}}, identity)
+	c.Assert(canonicalPartition(BiconnectedComponents(cycle)), DeepEquals, []string{"[1 2 3 4]"})
+	edge := relabeledGraph([][2]int{{1, 2}}, identity)
+	c.Assert(canonicalPartition(BiconnectedComponents(edge)), DeepEquals, []string{"[1 2]"})
+
+	// A star's center joins every leaf's block.
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}}, identity)
+	tree, kinds = BlockCutTree(star)
+	checkBlockCutTree(c, tree, kinds, "star")
+	c.Assert(kinds[0], Equals, "cut")
+	c.Assert(gogl.Order(tree), Equals, 4)
+
+	// Loops are ignored, isolates are blocks of their own, and a disconnected
+	// graph gives a forest.
+	g := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge(1, 1),
+		gogl.NewEdge(1, 2),
+		gogl.NewEdge(4, 5),
+	}).Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex(3)
+	tree, kinds = BlockCutTree(g)
+	c.Assert(checkBlockCutTree(c, tree, kinds, "loops"), DeepEquals, []string{"[1 2]", "[3]", "[4 5]"})
+	c.Assert(gogl.Size(tree), Equals, 0)
+
+	c.Assert(BiconnectedComponents(gogl.NullGraph), HasLen, 0)
+}
+
+func (s *BiconnectedSuite) TestMatchesBruteForce(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	for trial := 0; trial < 40; trial++ {
+		comment := fmt.Sprintf("trial %d", trial)
+
+		const n = 20
+		g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+		for i := 0; i < n; i++ {
+			g.EnsureVertex(i)
+			if i > 0 && r.Intn(6) > 0 {
+				g.AddEdges(gogl.NewEdge(i, r.Intn(i)))
+			}
+		}
+		for i := r.Intn(10); i > 0; i-- {
+			g.AddEdges(gogl.NewEdge(r.Intn(n), r.Intn(n)))
+		}
+
+		tree, kinds := BlockCutTree(g)
+		checkBlockCutTree(c, tree, kinds, comment)
+
+		cuts := bruteForceCuts(g)
+		for v := 0; v < n; v++ {
+			c.Assert(kinds[v] == "cut", Equals, cuts[v], Commentf("vertex %d; %s", v, comment))
+		}
+
+		// Every edge lies in exactly one block, and no block has a cut vertex
+		// of its own.
+		blocks := BiconnectedComponents(g)
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			var in int
+			for _, b := range blocks {
+				var has int
+				for _, w := range b {
+					if w == u || w == v {
+						has++
+					}
+				}
+				if has == 2 {
+					in++
+				}
+			}
+			c.Assert(in, Equals, 1, Commentf("edge %v; %s", e, comment))
+			return
+		})
+
+		for _, b := range blocks {
+			sub := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+			sub.EnsureVertex(b...)
+			g.Edges(func(e gogl.Edge) (terminate bool) {
+				if u, v := e.Both(); sub.HasVertex(u) && sub.HasVertex(v) {
+					sub.AddEdges(e)
+				}
+				return
+			})
+			c.Assert(ConnectedComponents(sub), HasLen, 1, Commentf("block %v; %s", b, comment))
+			c.Assert(bruteForceCuts(sub), HasLen, 0, Commentf("block %v; %s", b, comment))
+		}
+	}
+}
+
+// Indicates whether v can be reached from u in g without passing through the
+// vertex skip, or along the edge between u and v if direct is false.
+func reachesAvoiding(g gogl.Graph, u, v, skip gogl.Vertex, direct bool) bool {
+	seen := map[gogl.Vertex]bool{u: true}
+	stack := []gogl.Vertex{u}
+	for len(stack) > 0 {
+		x := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		var found bool
+		eachOutEdge(g, x, func(e gogl.Edge, y gogl.Vertex) (terminate bool) {
+			if y == skip || seen[y] || (!direct && x == u && y == v) {
+				return
+			}
+			if y == v {
+				found = true
+				return true
+			}
+			seen[y] = true
+			stack = append(stack, y)
+			return
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// Decides AreBiconnected by brute force: adjacent vertices need another way
+// around their edge, and others must stay connected however any vertex between
+// them is removed.
+func bruteForceBiconnected(g gogl.Graph, u, v gogl.Vertex) bool {
+	if !reachesAvoiding(g, u, v, nil, true) {
+		return false
+	}
+
+	var adjacent bool
+	eachOutEdge(g, u, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+		adjacent = adjacent || w == v
+		return
+	})
+	if adjacent {
+		return reachesAvoiding(g, u, v, nil, false)
+	}
+
+	separable := false
+	g.Vertices(func(w gogl.Vertex) (terminate bool) {
+		if w != u && w != v && !reachesAvoiding(g, u, v, w, true) {
+			separable = true
+			return true
+		}
+		return
+	})
+	return !separable
+}
+
+func (s *BiconnectedSuite) TestAreBiconnected(c *C) {
+	cycle := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 1}}, identity)
+	for u := 1; u <= 5; u++ {
+		for v := 1; v <= 5; v++ {
+			c.Assert(AreBiconnected(cycle, u, v), Equals, u != v, Commentf("%d and %d", u, v))
+		}
+	}
+
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}}, identity)
+	c.Assert(AreBiconnected(path, 1, 4), Equals, false)
+	c.Assert(AreBiconnected(path, 1, 2), Equals, false)
+
+	// The vertex shared by a figure-eight's loops separates them.
+	eight := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 3}}, identity)
+	c.Assert(AreBiconnected(eight, 1, 4), Equals, false)
+	c.Assert(AreBiconnected(eight, 1, 3), Equals, true)
+	c.Assert(AreBiconnected(eight, 1, 2), Equals, true)
+
+	c.Assert(AreBiconnected(cycle, 1, 6), Equals, false)
+	c.Assert(AreBiconnected(cycle, 6, 1), Equals, false)
+}
+
+func (s *BiconnectedSuite) TestAreBiconnectedDirected(c *C) {
+	// Two routes from 1 to 4, but none back.
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 2),
+		gogl.NewArc(2, 4),
+		gogl.NewArc(1, 3),
+		gogl.NewArc(3, 4),
+	}).Create(al.G)
+	c.Assert(AreBiconnected(g, 1, 4), Equals, true)
+	c.Assert(AreBiconnected(g, 4, 1), Equals, false)
+
+	// Around a directed cycle, there is only ever one way.
+	cycle := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 2),
+		gogl.NewArc(2, 3),
+		gogl.NewArc(3, 1),
+	}).Create(al.G)
+	c.Assert(AreBiconnected(cycle, 1, 2), Equals, false)
+	c.Assert(AreBiconnected(cycle, 1, 3), Equals, false)
+}
+
+func (s *BiconnectedSuite) TestAreBiconnectedMatchesBruteForce(c *C) {
+	r := stdrand.New(stdrand.NewSource(3))
+	for trial := 0; trial < 30; trial++ {
+		const n = 12
+		directed := trial%2 == 1
+		spec := gogl.Spec()
+		if directed {
+			spec = spec.Directed()
+		}
+		g := spec.Create(al.G)
+		g.(gogl.VertexSetMutator).EnsureVertex(0)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j && r.Float64() < 0.18 {
+					if directed {
+						g.(gogl.ArcSetMutator).AddArcs(gogl.NewArc(i, j))
+					} else {
+						g.(gogl.EdgeSetMutator).AddEdges(gogl.NewEdge(i, j))
+					}
+				}
+			}
+		}
+
+		g.Vertices(func(u gogl.Vertex) (terminate bool) {
+			g.Vertices(func(v gogl.Vertex) (terminate bool) {
+				if u != v {
+					c.Assert(AreBiconnected(g, u, v), Equals, bruteForceBiconnected(g, u, v), Commentf("trial %d, %v and %v", trial, u, v))
+				}
+				return
+			})
+			return
+		})
+	}
+}
+
+func (s *BiconnectedSuite) TestComponentsAfterRemoval(c *C) {
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}, identity)
+	c.Assert(ComponentsAfterRemovalAll(path), DeepEquals, map[gogl.Vertex]int{1: 1, 2: 2, 3: 2, 4: 2, 5: 1})
+
+	// A star of four leaves, alongside an isolated vertex and a triangle.
+	g := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {6, 7}, {7, 8}, {8, 6}}, identity).(gogl.MutableGraph)
+	g.EnsureVertex(5)
+
+	c.Assert(ComponentsAfterRemoval(g, 0), Equals, 6)
+	c.Assert(ComponentsAfterRemoval(g, 1), Equals, 3)
+	c.Assert(ComponentsAfterRemoval(g, 5), Equals, 2)
+	c.Assert(ComponentsAfterRemoval(g, 6), Equals, 3)
+	c.Assert(ComponentsAfterRemoval(g, 9), Equals, 3)
+
+	c.Assert(ComponentsAfterRemovalAll(gogl.NullGraph), HasLen, 0)
+}
+
+func (s *BiconnectedSuite) TestComponentsAfterRemovalMatchesBruteForce(c *C) {
+	r := stdrand.New(stdrand.NewSource(2))
+	for trial := 0; trial < 20; trial++ {
+		const n = 20
+		g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+		for i := 0; i < n; i++ {
+			g.EnsureVertex(i)
+			if i > 0 && r.Intn(5) > 0 {
+				g.AddEdges(gogl.NewEdge(i, r.Intn(i)))
+			}
+		}
+		for i := r.Intn(8); i > 0; i-- {
+			g.AddEdges(gogl.NewEdge(r.Intn(n), r.Intn(n)))
+		}
+
+		counts := ComponentsAfterRemovalAll(g)
+		c.Assert(counts, HasLen, n)
+		for v := 0; v < n; v++ {
+			rest := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+			g.Vertices(func(u gogl.Vertex) (terminate bool) {
+				if u != v {
+					rest.EnsureVertex(u)
+				}
+				return
+			})
+			g.Edges(func(e gogl.Edge) (terminate bool) {
+				if a, b := e.Both(); a != v && b != v {
+					rest.AddEdges(e)
+				}
+				return
+			})
+			c.Assert(counts[v], Equals, len(ConnectedComponents(rest)), Commentf("trial %d, vertex %d", trial, v))
+		}
+	}
+}
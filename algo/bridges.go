@@ -0,0 +1,157 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Finds the bridges of the graph: the edges whose removal would disconnect their
+// endpoints from each other, as they lie on no cycle.
+//
+// Edge direction and self-loops are ignored; a pair of opposing arcs counts as a
+// single edge. The order of the bridges, and of each bridge's endpoints, is not
+// meaningful.
+func Bridges(g gogl.Graph) []gogl.Edge {
+	return lowLinkSearch(undirectedAdjacency(g)).bridges
+}
+
+// Partitions the graph's vertices into its 2-edge-connected components: maximal
+// sets of vertices that remain connected however any single edge is removed.
+//
+// These are exactly what is left of the connected components once the bridges
+// are removed, so every bridge joins two different 2-edge-connected components,
+// and every other edge lies within one. Contracting each component to a single
+// vertex leaves a forest, with the bridges as its edges.
+//
+// Edge direction and self-loops are ignored, as for Bridges. Neither the order of
+// the components nor that of the vertices within each is meaningful.
+func TwoEdgeConnectedComponents(g gogl.Graph) [][]gogl.Vertex {
+	return lowLinkSearch(undirectedAdjacency(g)).components
+}
+
+// The results of lowLinkSearch.
+type lowLinks struct {
+	bridges    []gogl.Edge
+	components [][]gogl.Vertex // 2-edge-connected
+	blocks     [][]gogl.Vertex
+	cuts       map[interface{}]gogl.Vertex // keyed by gogl.VertexKey
+}
+
+// Runs a single depth-first search over the undirected adjacency sets, finding
+// both the bridges, with the 2-edge-connected components they separate (after
+// Tarjan), and the blocks, with the cut vertices at which they meet (after
+// Hopcroft and Tarjan).
+//
+// Each vertex's low-link is the earliest discovery index reachable from its DFS
+// subtree using at most one non-tree edge. For a tree edge from u down to v:
+//
+//   - if low[v] > index[u], nothing below v reaches back above it, so the edge is
+//     a bridge, and closes off v's 2-edge-connected component;
+//   - if low[v] >= index[u], nothing below v reaches around u, so v's subtree,
+//     along with u, closes off a block. That makes u a cut vertex, unless it is
+//     the root of the search, which is a cut vertex only if it has several
+//     children.
+//
+// Vertices are pushed onto a stack for each kind of component as they are
+// discovered; when one closes, the vertices above v on the stack, and v itself,
+// are popped off as its members.
+//
+// The search is iterative, so deep graphs are no risk to the stack.
+func lowLinkSearch(adj adjacency) lowLinks {
+	n := adj.Len()
+	index := make([]int, n) // discovery index plus one; 0 if undiscovered
+	low := make([]int, n)
+	var clock int
+
+	type frame struct {
+		v        int
+		nbrs     []int
+		parent   int // -1 once the tree edge back up has been passed over
+		children int
+	}
+
+	var components, blocks []int // the stacks
+	enter := func(v, parent int) frame {
+		clock++
+		index[v], low[v] = clock, clock
+		components = append(components, v)
+		blocks = append(blocks, v)
+
+		f := frame{v: v, parent: parent}
+		for w := range adj.nbrs[v] {
+			f.nbrs = append(f.nbrs, w)
+		}
+		return f
+	}
+
+	// Pops the stack down to and including v, returning the popped vertices.
+	split := func(stack *[]int, v int) []gogl.Vertex {
+		s := *stack
+		i := len(s) - 1
+		for s[i] != v {
+			i--
+		}
+		popped := adj.vertices(s[i:])
+		*stack = s[:i]
+		return popped
+	}
+
+	ll := lowLinks{cuts: make(map[interface{}]gogl.Vertex)}
+	for root := 0; root < n; root++ {
+		if index[root] != 0 {
+			continue
+		}
+
+		calls := []frame{enter(root, -1)}
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+
+			if len(top.nbrs) > 0 {
+				w := top.nbrs[0]
+				top.nbrs = top.nbrs[1:]
+
+				if w == top.parent {
+					// The tree edge back up is not a way around it.
+					top.parent = -1
+				} else if index[w] == 0 {
+					top.children++
+					calls = append(calls, enter(w, top.v))
+				} else if index[w] < low[top.v] {
+					low[top.v] = index[w]
+				}
+				continue
+			}
+
+			v, children := top.v, top.children
+			calls = calls[:len(calls)-1]
+			if len(calls) == 0 {
+				ll.components = append(ll.components, split(&components, v))
+				// Every child of the root has closed off a block, taking the
+				// rest of the stack with it; only the root itself remains.
+				split(&blocks, v)
+				if children == 0 {
+					ll.blocks = append(ll.blocks, []gogl.Vertex{adj.VertexAt(v)})
+				} else if children > 1 {
+					ll.cuts[gogl.VertexKey(adj.VertexAt(v))] = adj.VertexAt(v)
+				}
+				break
+			}
+
+			u := calls[len(calls)-1].v
+			if low[v] < low[u] {
+				low[u] = low[v]
+			}
+			if low[v] > index[u] {
+				ll.bridges = append(ll.bridges, gogl.NewEdge(adj.VertexAt(u), adj.VertexAt(v)))
+				ll.components = append(ll.components, split(&components, v))
+			}
+			if low[v] >= index[u] {
+				ll.blocks = append(ll.blocks, append(split(&blocks, v), adj.VertexAt(u)))
+				if len(calls) > 1 {
+					ll.cuts[gogl.VertexKey(adj.VertexAt(u))] = adj.VertexAt(u)
+				}
+			}
+		}
+	}
+
+	return ll
+}
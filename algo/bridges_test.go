@@ -0,0 +1,147 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type BridgesSuite struct{}
+
+var _ = Suite(&BridgesSuite{})
+
+// Finds the bridges of g by removing each edge in turn, and seeing whether that
+// splits a component. The bridges are keyed by their ordered endpoints.
+func bruteForceBridges(g gogl.MutableGraph) map[[2]int]bool {
+	before := len(ConnectedComponents(g))
+
+	var edges []gogl.Edge
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		edges = append(edges, e)
+		return
+	})
+
+	bridges := make(map[[2]int]bool)
+	for _, e := range edges {
+		g.RemoveEdges(e)
+		if len(ConnectedComponents(g)) > before {
+			bridges[orderedPair(e)] = true
+		}
+		g.AddEdges(e)
+	}
+	return bridges
+}
+
+func orderedPair(e gogl.Edge) [2]int {
+	u, v := e.Both()
+	if u.(int) > v.(int) {
+		u, v = v, u
+	}
+	return [2]int{u.(int), v.(int)}
+}
+
+func (s *BridgesSuite) TestCliquesJoinedByBridge(c *C) {
+	g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	for _, offset := range []int{0, 4} {
+		for i := 0; i < 4; i++ {
+			for j := i + 1; j < 4; j++ {
+				g.AddEdges(gogl.NewEdge(offset+i, offset+j))
+			}
+		}
+	}
+	g.AddEdges(gogl.NewEdge(3, 4))
+
+	bridges := Bridges(g)
+	c.Assert(bridges, HasLen, 1)
+	c.Assert(orderedPair(bridges[0]), Equals, [2]int{3, 4})
+
+	c.Assert(canonicalPartition(TwoEdgeConnectedComponents(g)), DeepEquals, []string{
+		"[0 1 2 3]",
+		"[4 5 6 7]",
+	})
+}
+
+func (s *BridgesSuite) TestSmallGraphs(c *C) {
+	// Every edge of a path is a bridge.
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}}, identity)
+	c.Assert(Bridges(path), HasLen, 3)
+	c.Assert(TwoEdgeConnectedComponents(path), HasLen, 4)
+
+	// No edge of a cycle is.
+	cycle := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}}, identity)
+	c.Assert(Bridges(cycle), HasLen, 0)
+	c.Assert(canonicalPartition(TwoEdgeConnectedComponents(cycle)), DeepEquals, []string{"[1 2 3 4]"})
+
+	// Two triangles sharing a vertex are 2-edge-connected, though not biconnected.
+	bowtie := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 3}}, identity)
+	c.Assert(Bridges(bowtie), HasLen, 0)
+	c.Assert(TwoEdgeConnectedComponents(bowtie), HasLen, 1)
+
+	// Loops don't make a cycle, and isolates are components of their own.
+	g := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge(1, 1),
+		gogl.NewEdge(1, 2),
+	}).Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex(3)
+	c.Assert(Bridges(g), HasLen, 1)
+	c.Assert(canonicalPartition(TwoEdgeConnectedComponents(g)), DeepEquals, []string{"[1]", "[2]", "[3]"})
+
+	c.Assert(Bridges(gogl.NullGraph), HasLen, 0)
+	c.Assert(TwoEdgeConnectedComponents(gogl.NullGraph), HasLen, 0)
+}
+
+func (s *BridgesSuite) TestDigraph(c *C) {
+	// Direction is ignored, and the opposing arcs 2-3 and 3-2 are one edge.
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 2),
+		gogl.NewArc(2, 3),
+		gogl.NewArc(3, 2),
+		gogl.NewArc(3, 1),
+		gogl.NewArc(3, 4),
+	}).Create(al.G)
+
+	bridges := Bridges(g)
+	c.Assert(bridges, HasLen, 1)
+	c.Assert(orderedPair(bridges[0]), Equals, [2]int{3, 4})
+	c.Assert(canonicalPartition(TwoEdgeConnectedComponents(g)), DeepEquals, []string{"[1 2 3]", "[4]"})
+}
+
+func (s *BridgesSuite) TestMatchesBruteForce(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	for trial := 0; trial < 40; trial++ {
+		const n = 25
+		g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+		for i := 0; i < n; i++ {
+			g.EnsureVertex(i)
+			if i > 0 && r.Intn(5) > 0 {
+				g.AddEdges(gogl.NewEdge(i, r.Intn(i)))
+			}
+		}
+		for i := r.Intn(8); i > 0; i-- {
+			g.AddEdges(gogl.NewEdge(r.Intn(n), r.Intn(n)))
+		}
+
+		want := bruteForceBridges(g)
+		bridges := Bridges(g)
+		c.Assert(bridges, HasLen, len(want), Commentf("trial %d", trial))
+		for _, b := range bridges {
+			c.Assert(want[orderedPair(b)], Equals, true, Commentf("trial %d, edge %v", trial, b))
+		}
+
+		// The components are those left once the bridges are gone.
+		rest := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			rest.EnsureVertex(v)
+			return
+		})
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			if !want[orderedPair(e)] {
+				rest.AddEdges(e)
+			}
+			return
+		})
+		c.Assert(canonicalPartition(TwoEdgeConnectedComponents(g)), DeepEquals, canonicalPartition(ConnectedComponents(rest)), Commentf("trial %d", trial))
+	}
+}
@@ -0,0 +1,116 @@
+package algo
+
+import (
+	"errors"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Builds the centroid decomposition of the given tree, returning the centroid
+// tree along with its root.
+//
+// The centroid of a tree is a vertex whose removal leaves no component with more
+// than half the tree's vertices. The decomposition takes the centroid of the whole
+// tree as the root, then decomposes each component left by its removal in turn,
+// with an arc from each centroid to the centroids of its components. Component
+// sizes at least halve at every level, so the centroid tree has height O(log V).
+// The path between any two vertices passes through their lowest common ancestor
+// in the centroid tree, which is the basis for divide-and-conquer over paths.
+//
+// Edge direction is ignored. An error is returned if the graph is empty, or is
+// not a tree.
+func CentroidDecomposition(g gogl.Graph) (gogl.Digraph, gogl.Vertex, error) {
+	var start gogl.Vertex
+	var found bool
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		start, found = v, true
+		return true
+	})
+	if !found {
+		return nil, nil, errors.New("Cannot decompose an empty graph.")
+	}
+
+	if _, _, _, err := rootTree(g, start); err != nil {
+		return nil, nil, err
+	}
+
+	n := gogl.Order(g)
+	removed := make(map[interface{}]struct{}, n) // keyed by gogl.VertexKey
+	adjacent := func(v gogl.Vertex, f func(w gogl.Vertex)) {
+		g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+			w := otherEnd(e, v)
+			if _, gone := removed[gogl.VertexKey(w)]; !gone {
+				f(w)
+			}
+			return
+		})
+	}
+
+	ct := gogl.Spec().Directed().Create(al.G).(gogl.MutableDigraph)
+
+	// Each task is a component yet to be decomposed, identified by any one of its
+	// vertices, along with the centroid whose removal produced it.
+	type task struct {
+		v, parent gogl.Vertex
+		root      bool
+	}
+
+	var root gogl.Vertex
+	tasks := []task{{v: start, root: true}}
+	for len(tasks) > 0 {
+		t := tasks[len(tasks)-1]
+		tasks = tasks[:len(tasks)-1]
+
+		// Breadth-first over the component, then accumulate subtree sizes in
+		// reverse, tracking the largest part each vertex's removal would leave.
+		// Vertices are referred to by their position in comp.
+		comp := []gogl.Vertex{t.v}
+		parent := []int{-1}
+		seen := map[interface{}]struct{}{gogl.VertexKey(t.v): {}}
+		for i := 0; i < len(comp); i++ {
+			adjacent(comp[i], func(w gogl.Vertex) {
+				if _, ok := seen[gogl.VertexKey(w)]; !ok {
+					seen[gogl.VertexKey(w)] = struct{}{}
+					parent = append(parent, i)
+					comp = append(comp, w)
+				}
+			})
+		}
+
+		total := len(comp)
+		size := make([]int, total)
+		largest := make([]int, total)
+		for i := total - 1; i > 0; i-- {
+			size[i]++
+			p := parent[i]
+			size[p] += size[i]
+			if size[i] > largest[p] {
+				largest[p] = size[i]
+			}
+		}
+		size[0]++
+
+		var centroid gogl.Vertex
+		for i, v := range comp {
+			if largest[i] <= total/2 && total-size[i] <= total/2 {
+				centroid = v
+				break
+			}
+		}
+
+		removed[gogl.VertexKey(centroid)] = struct{}{}
+		if t.root {
+			root = centroid
+			ct.EnsureVertex(centroid)
+		} else {
+			ct.AddArcs(gogl.NewArc(t.parent, centroid))
+		}
+
+		adjacent(centroid, func(w gogl.Vertex) {
+			tasks = append(tasks, task{v: w, parent: centroid})
+		})
+	}
+
+	return ct.(gogl.Digraph), root, nil
+}
@@ -0,0 +1,159 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type CentroidSuite struct{}
+
+var _ = Suite(&CentroidSuite{})
+
+// Returns the height of the given rooted tree, in vertices.
+func treeHeight(g gogl.Digraph, root gogl.Vertex) int {
+	depth := map[gogl.Vertex]int{root: 1}
+	queue := []gogl.Vertex{root}
+	height := 0
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if depth[v] > height {
+			height = depth[v]
+		}
+		g.SuccessorsOf(v, func(w gogl.Vertex) (terminate bool) {
+			depth[w] = depth[v] + 1
+			queue = append(queue, w)
+			return
+		})
+	}
+
+	return height
+}
+
+// Checks the defining property of a centroid tree: each vertex splits the
+// component formed by its centroid-tree descendants into parts no larger than
+// half that component.
+func checkCentroidTree(c *C, g gogl.Graph, ct gogl.Digraph, root gogl.Vertex) {
+	c.Assert(gogl.Order(ct), Equals, gogl.Order(g))
+	c.Assert(gogl.Size(ct), Equals, gogl.Order(g)-1)
+
+	tin, tout := EulerTour(ct, root)
+	c.Assert(len(tin), Equals, gogl.Order(g))
+
+	ct.Vertices(func(cv gogl.Vertex) (terminate bool) {
+		inComp := func(v gogl.Vertex) bool { return IsAncestor(tin, tout, cv, v) }
+
+		var total int
+		ct.Vertices(func(v gogl.Vertex) (terminate bool) {
+			if inComp(v) {
+				total++
+			}
+			return
+		})
+
+		// Each neighbor of cv within the component seeds one part.
+		g.IncidentTo(cv, func(e gogl.Edge) (terminate bool) {
+			u, w := e.Both()
+			if w == cv {
+				w = u
+			}
+			if !inComp(w) {
+				return
+			}
+
+			seen := map[gogl.Vertex]bool{cv: true, w: true}
+			queue := []gogl.Vertex{w}
+			for len(queue) > 0 {
+				v := queue[0]
+				queue = queue[1:]
+				g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+					a, b := e.Both()
+					if b == v {
+						b = a
+					}
+					if !seen[b] && inComp(b) {
+						seen[b] = true
+						queue = append(queue, b)
+					}
+					return
+				})
+			}
+
+			c.Assert(len(seen)-1 <= total/2, Equals, true, Commentf("centroid %v leaves a part of %d in a component of %d", cv, len(seen)-1, total))
+			return
+		})
+		return
+	})
+}
+
+func (s *CentroidSuite) TestPathGraph(c *C) {
+	const n = 1023
+	edges := make(gogl.EdgeList, n-1)
+	for i := range edges {
+		edges[i] = gogl.NewEdge(i, i+1)
+	}
+	g := gogl.Spec().Using(edges).Create(al.G)
+
+	ct, root, err := CentroidDecomposition(g)
+	c.Assert(err, IsNil)
+	c.Assert(root, Equals, 511)
+
+	// A path of 2^10 - 1 vertices decomposes into a perfectly balanced binary tree.
+	c.Assert(treeHeight(ct, root), Equals, 10)
+}
+
+func (s *CentroidSuite) TestLongPathHeight(c *C) {
+	const n = 100000
+	edges := make(gogl.EdgeList, n-1)
+	for i := range edges {
+		edges[i] = gogl.NewEdge(i, i+1)
+	}
+	g := gogl.Spec().Using(edges).Create(al.G)
+
+	ct, root, err := CentroidDecomposition(g)
+	c.Assert(err, IsNil)
+	c.Assert(gogl.Order(ct), Equals, n)
+
+	// ceil(log2(100001)) = 17
+	c.Assert(treeHeight(ct, root) <= 17, Equals, true)
+}
+
+func (s *CentroidSuite) TestRandomTree(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	var edges gogl.EdgeList
+	for i := 1; i < 200; i++ {
+		edges = append(edges, gogl.NewEdge(r.Intn(i), i))
+	}
+	g := gogl.Spec().Using(edges).Create(al.G)
+
+	ct, root, err := CentroidDecomposition(g)
+	c.Assert(err, IsNil)
+	checkCentroidTree(c, g, ct, root)
+	c.Assert(treeHeight(ct, root) <= 8, Equals, true)
+}
+
+func (s *CentroidSuite) TestSingleVertex(c *C) {
+	g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex("solo")
+
+	ct, root, err := CentroidDecomposition(g)
+	c.Assert(err, IsNil)
+	c.Assert(root, Equals, "solo")
+	c.Assert(gogl.Order(ct), Equals, 1)
+}
+
+func (s *CentroidSuite) TestRejectsNonTrees(c *C) {
+	_, _, err := CentroidDecomposition(gogl.NullGraph)
+	c.Assert(err, ErrorMatches, "Cannot decompose an empty graph.")
+
+	cycle := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge(1, 2),
+		gogl.NewEdge(2, 3),
+		gogl.NewEdge(3, 1),
+	}).Create(al.G)
+	_, _, err = CentroidDecomposition(cycle)
+	c.Assert(err, ErrorMatches, "Graph is not a tree: it contains a cycle.")
+}
@@ -0,0 +1,220 @@
+package algo
+
+import (
+	"context"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Enumerates all maximal cliques in the graph using the Bron-Kerbosch algorithm
+// with Tomita pivoting, passing each clique to the provided visit function. If
+// visit returns true, enumeration terminates.
+//
+// Edge direction and self-loops are ignored. Each clique slice is freshly
+// allocated, and may be retained by the caller.
+func MaximalCliques(g gogl.Graph, visit func([]gogl.Vertex) (terminate bool)) {
+	MaximalCliquesContext(context.Background(), g, visit)
+}
+
+// Enumerates maximal cliques as MaximalCliques does, but abandons the search if
+// the provided context is cancelled or its deadline passes. The context is checked
+// at each branch of the search; once it is done, enumeration stops and the
+// context's error is returned.
+func MaximalCliquesContext(ctx context.Context, g gogl.Graph, visit func([]gogl.Vertex) (terminate bool)) (err error) {
+	adj := undirectedAdjacency(g)
+
+	p := make(map[int]struct{}, adj.Len())
+	for v := range adj.nbrs {
+		p[v] = struct{}{}
+	}
+
+	bk := &bronKerbosch{adj: adj, visit: visit, halt: haltOn(ctx, &err)}
+	bk.expand(nil, p, make(map[int]struct{}))
+	return
+}
+
+// Enumerates all maximal cliques in the graph, as MaximalCliques does, but with
+// the outermost level of recursion taken in degeneracy order.
+//
+// Processing vertices in degeneracy order - repeatedly removing a vertex of
+// minimum remaining degree - bounds each top-level candidate set by the graph's
+// degeneracy d, rather than by its maximum degree. This runs in O(d·V·3^(d/3))
+// time, which is near-optimal, and a considerable improvement on plain pivoting
+// for large sparse graphs.
+func MaximalCliquesDegeneracy(g gogl.Graph, visit func([]gogl.Vertex) (terminate bool)) {
+	MaximalCliquesDegeneracyContext(context.Background(), g, visit)
+}
+
+// Enumerates maximal cliques as MaximalCliquesDegeneracy does, but abandons the
+// search if the provided context is cancelled or its deadline passes, as for
+// MaximalCliquesContext.
+func MaximalCliquesDegeneracyContext(ctx context.Context, g gogl.Graph, visit func([]gogl.Vertex) (terminate bool)) (err error) {
+	adj := undirectedAdjacency(g)
+	order, _ := degeneracyOrder(adj)
+
+	rank := make([]int, len(order))
+	for i, v := range order {
+		rank[v] = i
+	}
+
+	bk := &bronKerbosch{adj: adj, visit: visit, halt: haltOn(ctx, &err)}
+	for i, v := range order {
+		p := make(map[int]struct{})
+		x := make(map[int]struct{})
+		for w := range adj.nbrs[v] {
+			if rank[w] > i {
+				p[w] = struct{}{}
+			} else {
+				x[w] = struct{}{}
+			}
+		}
+
+		if bk.expand([]int{v}, p, x) {
+			return
+		}
+	}
+	return
+}
+
+// Returns the degeneracy of the graph - the smallest d such that every subgraph
+// has a vertex of degree at most d - along with a degeneracy ordering, in which
+// each vertex has at most d neighbors that come after it.
+//
+// Degeneracy measures sparsity in a way that maximum degree does not: a star has
+// degeneracy 1, however many leaves it has. Greedily coloring the vertices in the
+// reverse of the ordering uses at most d+1 colors, so d+1 - the coloring number -
+// bounds the chromatic number. It likewise bounds the size of the largest clique.
+//
+// The ordering is found by repeatedly removing a vertex of minimum remaining
+// degree, in O(V+E). Edge direction and self-loops are ignored.
+func Degeneracy(g gogl.Graph) (int, []gogl.Vertex) {
+	adj := undirectedAdjacency(g)
+	order, d := degeneracyOrder(adj)
+	return d, adj.vertices(order)
+}
+
+// Orders vertices by repeatedly removing one of minimum remaining degree, using
+// a bucket queue so that the whole ordering takes O(V+E). The ordering is of
+// vertex ids; the greatest degree a vertex had on removal, which is the
+// degeneracy, is returned as well.
+func degeneracyOrder(adj adjacency) ([]int, int) {
+	n := adj.Len()
+	degree := make([]int, n)
+	var buckets []map[int]struct{}
+	for v, ns := range adj.nbrs {
+		d := len(ns)
+		degree[v] = d
+		for len(buckets) <= d {
+			buckets = append(buckets, make(map[int]struct{}))
+		}
+		buckets[d][v] = struct{}{}
+	}
+
+	order := make([]int, 0, n)
+	removed := make([]bool, n)
+	var degeneracy int
+	for low := 0; len(order) < n; {
+		if len(buckets[low]) == 0 {
+			low++
+			continue
+		}
+
+		var v int
+		for v = range buckets[low] {
+			break
+		}
+		delete(buckets[low], v)
+		removed[v] = true
+		order = append(order, v)
+		if low > degeneracy {
+			degeneracy = low
+		}
+
+		for w := range adj.nbrs[v] {
+			if removed[w] {
+				continue
+			}
+			d := degree[w]
+			delete(buckets[d], w)
+			buckets[d-1][w] = struct{}{}
+			degree[w] = d - 1
+		}
+
+		// Removing v can only lower its neighbors' degrees by one.
+		if low > 0 {
+			low--
+		}
+	}
+
+	return order, degeneracy
+}
+
+type bronKerbosch struct {
+	adj   adjacency
+	visit func([]gogl.Vertex) bool
+	halt  func() bool // early abort check, consulted at each branch
+}
+
+// Reports every maximal clique that extends r with vertices from p, and with none
+// from x, all given by id. Returns true if the visit function called for
+// termination, or the search was halted.
+func (bk *bronKerbosch) expand(r []int, p, x map[int]struct{}) (terminate bool) {
+	if bk.halt() {
+		return true
+	}
+
+	if len(p) == 0 {
+		if len(x) == 0 {
+			return bk.visit(bk.adj.vertices(r))
+		}
+		return false
+	}
+
+	// Pivot on the vertex covering the most of p; only vertices outside its
+	// neighborhood need be tried, as any clique containing only its neighbors
+	// could be extended by it.
+	var pivot int
+	best := -1
+	for _, set := range []map[int]struct{}{p, x} {
+		for u := range set {
+			var n int
+			for w := range p {
+				if _, ok := bk.adj.nbrs[u][w]; ok {
+					n++
+				}
+			}
+			if n > best {
+				pivot, best = u, n
+			}
+		}
+	}
+
+	var candidates []int
+	for v := range p {
+		if _, ok := bk.adj.nbrs[pivot][v]; !ok {
+			candidates = append(candidates, v)
+		}
+	}
+
+	for _, v := range candidates {
+		np := make(map[int]struct{})
+		nx := make(map[int]struct{})
+		for w := range bk.adj.nbrs[v] {
+			if _, ok := p[w]; ok {
+				np[w] = struct{}{}
+			}
+			if _, ok := x[w]; ok {
+				nx[w] = struct{}{}
+			}
+		}
+
+		if bk.expand(append(r, v), np, nx) {
+			return true
+		}
+
+		delete(p, v)
+		x[v] = struct{}{}
+	}
+
+	return false
+}
@@ -0,0 +1,305 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+	stdrand "math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type CliqueSuite struct{}
+
+var _ = Suite(&CliqueSuite{})
+
+var cliqueFinders = map[string]func(gogl.Graph, func([]gogl.Vertex) bool){
+	"pivoting":   MaximalCliques,
+	"degeneracy": MaximalCliquesDegeneracy,
+}
+
+// Collects the cliques found by f as canonical strings, so they can be compared as sets.
+func collectCliques(g gogl.Graph, f func(gogl.Graph, func([]gogl.Vertex) bool)) map[string]bool {
+	found := make(map[string]bool)
+	f(g, func(clique []gogl.Vertex) (terminate bool) {
+		ints := make([]int, len(clique))
+		for i, v := range clique {
+			ints[i] = v.(int)
+		}
+		sort.Ints(ints)
+		found[fmt.Sprint(ints)] = true
+		return
+	})
+	return found
+}
+
+// Finds the maximal cliques of a small integer-labeled graph by checking every vertex subset.
+func bruteForceCliques(g gogl.Graph, n int) map[string]bool {
+	isClique := func(mask int) bool {
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				if mask&(1<<uint(i)) != 0 && mask&(1<<uint(j)) != 0 && !g.HasEdge(gogl.NewEdge(i, j)) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	found := make(map[string]bool)
+	for mask := 1; mask < 1<<uint(n); mask++ {
+		if !isClique(mask) {
+			continue
+		}
+
+		maximal := true
+		for i := 0; i < n && maximal; i++ {
+			if mask&(1<<uint(i)) == 0 && isClique(mask|1<<uint(i)) {
+				maximal = false
+			}
+		}
+
+		if maximal {
+			var ints []int
+			for i := 0; i < n; i++ {
+				if mask&(1<<uint(i)) != 0 {
+					ints = append(ints, i)
+				}
+			}
+			found[fmt.Sprint(ints)] = true
+		}
+	}
+	return found
+}
+
+func (s *CliqueSuite) TestKnownGraphs(c *C) {
+	wheel := relabeledGraph([][2]int{
+		{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 1},
+		{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5},
+	}, identity)
+	k4 := relabeledGraph([][2]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, identity)
+
+	for name, f := range cliqueFinders {
+		// The Petersen graph is triangle-free, so its maximal cliques are its edges.
+		c.Assert(collectCliques(relabeledGraph(petersen, identity), f), HasLen, 15, Commentf("finder: %s", name))
+		c.Assert(collectCliques(wheel, f), HasLen, 5, Commentf("finder: %s", name))
+		c.Assert(collectCliques(k4, f), DeepEquals, map[string]bool{"[1 2 3 4]": true}, Commentf("finder: %s", name))
+	}
+}
+
+func (s *CliqueSuite) TestMatchesBruteForce(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+		for i := 0; i < 12; i++ {
+			g.EnsureVertex(i)
+			for j := 0; j < i; j++ {
+				if r.Float64() < 0.4 {
+					g.AddEdges(gogl.NewEdge(i, j))
+				}
+			}
+		}
+
+		want := bruteForceCliques(g, 12)
+		for name, f := range cliqueFinders {
+			c.Assert(collectCliques(g, f), DeepEquals, want, Commentf("finder: %s, trial %d", name, trial))
+		}
+	}
+}
+
+func (s *CliqueSuite) TestIsolatesAndLoops(c *C) {
+	g := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge(1, 1),
+		gogl.NewEdge(1, 2),
+	}).Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex(3)
+
+	for name, f := range cliqueFinders {
+		c.Assert(collectCliques(g, f), DeepEquals, map[string]bool{"[1 2]": true, "[3]": true}, Commentf("finder: %s", name))
+	}
+}
+
+func (s *CliqueSuite) TestTermination(c *C) {
+	for name, f := range cliqueFinders {
+		var n int
+		f(relabeledGraph(petersen, identity), func(clique []gogl.Vertex) bool {
+			n++
+			return n == 3
+		})
+		c.Assert(n, Equals, 3, Commentf("finder: %s", name))
+	}
+}
+
+func (s *CliqueSuite) TestContext(c *C) {
+	g := relabeledGraph(grotzsch, identity)
+
+	for name, f := range map[string]func(context.Context, gogl.Graph, func([]gogl.Vertex) bool) error{
+		"pivoting":   MaximalCliquesContext,
+		"degeneracy": MaximalCliquesDegeneracyContext,
+	} {
+		var count int
+		err := f(context.Background(), g, func([]gogl.Vertex) (terminate bool) {
+			count++
+			return
+		})
+		c.Assert(err, IsNil, Commentf("finder: %s", name))
+		c.Assert(count, Equals, len(grotzsch), Commentf("finder: %s", name))
+
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		count = 0
+		err = f(cancelled, g, func([]gogl.Vertex) (terminate bool) {
+			count++
+			return
+		})
+		c.Assert(err, Equals, context.Canceled, Commentf("finder: %s", name))
+		c.Assert(count, Equals, 0, Commentf("finder: %s", name))
+
+		ctx := &countdownContext{Context: context.Background(), remaining: 5}
+		err = f(ctx, g, func([]gogl.Vertex) (terminate bool) { return })
+		c.Assert(err, Equals, context.Canceled, Commentf("finder: %s", name))
+		// The search must stop as soon as cancellation is observed.
+		c.Assert(ctx.after, Equals, 1, Commentf("finder: %s", name))
+	}
+}
+
+func (s *CliqueSuite) TestSparseCountsAgree(c *C) {
+	g := gogl.Spec().Using(rand.BernoulliDistribution(300, 0.02, false, true, stdrand.NewSource(1))).Create(al.G)
+	c.Assert(collectCliques(g, MaximalCliquesDegeneracy), DeepEquals, collectCliques(g, MaximalCliques))
+}
+
+// Checks that order is a degeneracy ordering for d: it lists every vertex of g
+// once, no vertex has more than d neighbors after it, and some vertex has exactly
+// d, as the one removed from the densest remaining subgraph must.
+func checkDegeneracy(c *C, g gogl.Graph, d int, order []gogl.Vertex) {
+	c.Assert(order, HasLen, gogl.Order(g))
+	pos := make(map[gogl.Vertex]int, len(order))
+	for i, v := range order {
+		pos[v] = i
+	}
+	c.Assert(pos, HasLen, len(order))
+
+	var most int
+	adj := undirectedAdjacency(g)
+	for i, ns := range adj.nbrs {
+		v := adj.VertexAt(i)
+		var later int
+		for w := range ns {
+			if pos[adj.VertexAt(w)] > pos[v] {
+				later++
+			}
+		}
+		c.Assert(later <= d, Equals, true, Commentf("vertex %v has %d later neighbors, degeneracy %d", v, later, d))
+		if later > most {
+			most = later
+		}
+	}
+	c.Assert(most, Equals, d)
+}
+
+// Finds the degeneracy of a small graph by taking the minimum degree of every
+// induced subgraph.
+func bruteForceDegeneracy(g gogl.Graph, n int) int {
+	adj := undirectedAdjacency(g)
+	var best int
+	for mask := 1; mask < 1<<uint(n); mask++ {
+		min := n
+		for i := 0; i < n; i++ {
+			if mask&(1<<uint(i)) == 0 {
+				continue
+			}
+			var d int
+			for w := range adj.nbrs[adj.IndexOf(i)] {
+				if mask&(1<<uint(adj.VertexAt(w).(int))) != 0 {
+					d++
+				}
+			}
+			if d < min {
+				min = d
+			}
+		}
+		if min > best {
+			best = min
+		}
+	}
+	return best
+}
+
+func (s *CliqueSuite) TestDegeneracy(c *C) {
+	// A random tree.
+	r := stdrand.New(stdrand.NewSource(1))
+	tree := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	tree.EnsureVertex(0)
+	for i := 1; i < 50; i++ {
+		tree.AddEdges(gogl.NewEdge(i, r.Intn(i)))
+	}
+	d, order := Degeneracy(tree)
+	c.Assert(d, Equals, 1)
+	checkDegeneracy(c, tree, d, order)
+
+	for n := 1; n <= 7; n++ {
+		k := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+		k.EnsureVertex(0)
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				k.AddEdges(gogl.NewEdge(i, j))
+			}
+		}
+		d, order := Degeneracy(k)
+		c.Assert(d, Equals, n-1, Commentf("K_%d", n))
+		checkDegeneracy(c, k, d, order)
+	}
+
+	// The Petersen graph is 3-regular, so its degeneracy is 3. With a pendant
+	// path, loops and an isolate added, it still is.
+	g := gogl.Spec().Loop().Using(relabeledGraph(petersen, identity)).Create(al.G).(gogl.MutableGraph)
+	g.AddEdges(gogl.NewEdge(0, 10), gogl.NewEdge(10, 11), gogl.NewEdge(11, 11), gogl.NewEdge(5, 5))
+	g.EnsureVertex(12)
+	d, order = Degeneracy(g)
+	c.Assert(d, Equals, 3)
+	checkDegeneracy(c, g, d, order)
+
+	d, order = Degeneracy(gogl.NullGraph)
+	c.Assert(d, Equals, 0)
+	c.Assert(order, HasLen, 0)
+}
+
+func (s *CliqueSuite) TestDegeneracyRandom(c *C) {
+	r := stdrand.New(stdrand.NewSource(2))
+	for trial := 0; trial < 30; trial++ {
+		const n = 11
+		g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+		for i := 0; i < n; i++ {
+			g.EnsureVertex(i)
+			for j := 0; j < i; j++ {
+				if r.Float64() < 0.45 {
+					g.AddEdges(gogl.NewEdge(i, j))
+				}
+			}
+		}
+
+		d, order := Degeneracy(g)
+		c.Assert(d, Equals, bruteForceDegeneracy(g, n), Commentf("trial %d", trial))
+		checkDegeneracy(c, g, d, order)
+	}
+}
+
+func benchmarkCliques(b *testing.B, f func(gogl.Graph, func([]gogl.Vertex) bool)) {
+	g := gogl.Spec().Using(rand.BernoulliDistribution(2000, 0.005, false, true, stdrand.NewSource(1))).Create(al.G)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f(g, func([]gogl.Vertex) bool { return false })
+	}
+}
+
+func BenchmarkMaximalCliques(b *testing.B) {
+	benchmarkCliques(b, MaximalCliques)
+}
+
+func BenchmarkMaximalCliquesDegeneracy(b *testing.B) {
+	benchmarkCliques(b, MaximalCliquesDegeneracy)
+}
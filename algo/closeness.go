@@ -0,0 +1,75 @@
+package algo
+
+import (
+	"fmt"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Calculates the closeness centrality of each vertex: how near it lies to all the
+// others. For a vertex u in a connected graph of order n, this is the reciprocal
+// of its mean distance to the others, (n-1) / Σ d(u, v), so that the center of a
+// star scores 1 and a vertex with more distant neighbors scores less.
+//
+// Where not every vertex is reachable, the distances to the unreachable ones are
+// undefined, and the sum is taken over the r vertices u can reach, itself
+// included. The Wasserman-Faust correction then scales the result by the
+// fraction of the other vertices that u reaches:
+//
+//	C(u) = (r-1)/(n-1) * (r-1) / Σ d(u, v)
+//
+// Without it, a vertex reaching a single neighbor would outscore a vertex at the
+// heart of a large component. In a connected graph, r = n and the correction has
+// no effect. A vertex that reaches no others scores 0.
+//
+// Distances are hop counts, found by breadth-first search, unless g is a
+// WeightedGraph, in which case they are total edge weights, found by Dijkstra's
+// algorithm. In a Digraph, distances are measured along arcs leading out from
+// each vertex.
+//
+// If g is weighted and any weight is negative, ErrNegativeWeight is returned. If
+// zero-weight edges leave some vertex at distance 0 from every vertex it reaches,
+// its mean distance is 0 and its closeness unbounded, so an error is returned.
+func ClosenessCentrality(g gogl.Graph) (map[gogl.Vertex]float64, error) {
+	_, weighted := g.(gogl.WeightedGraph)
+	if weighted && hasNegativeWeight(g) {
+		return nil, ErrNegativeWeight
+	}
+	n := float64(gogl.Order(g))
+
+	closeness := make(map[gogl.Vertex]float64, sizeHint(g))
+	var err error
+	g.Vertices(func(u gogl.Vertex) (terminate bool) {
+		var sum float64
+		var reached int
+		if weighted {
+			dist := dijkstra(g, u)
+			for _, d := range dist {
+				sum += d
+			}
+			reached = len(dist)
+		} else {
+			dist, _, _ := bfsDistances(g, u, -1)
+			for _, d := range dist {
+				sum += float64(d)
+			}
+			reached = len(dist)
+		}
+
+		var c float64
+		if r := float64(reached - 1); r > 0 {
+			if sum == 0 {
+				err = fmt.Errorf("Vertex %v is at distance 0 from every vertex it reaches, so its closeness is unbounded.", u)
+				return true
+			}
+			c = r / (n - 1) * r / sum
+		}
+		closeness[u] = c
+		return
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return closeness, nil
+}
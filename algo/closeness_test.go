@@ -0,0 +1,84 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type ClosenessSuite struct{}
+
+var _ = Suite(&ClosenessSuite{})
+
+// Calculates closeness centrality, failing the test on error.
+func closeness(c *C, g gogl.Graph) map[gogl.Vertex]float64 {
+	cc, err := ClosenessCentrality(g)
+	c.Assert(err, IsNil)
+	return cc
+}
+
+func (s *ClosenessSuite) TestConnected(c *C) {
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}}, identity)
+	// Each leaf is 1 from the center and 2 from the three other leaves.
+	c.Assert(closeness(c, star), DeepEquals, map[gogl.Vertex]float64{0: 1, 1: 4.0 / 7, 2: 4.0 / 7, 3: 4.0 / 7, 4: 4.0 / 7})
+
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}, identity)
+	c.Assert(closeness(c, path), DeepEquals, map[gogl.Vertex]float64{1: 0.4, 2: 4.0 / 7, 3: 4.0 / 6, 4: 4.0 / 7, 5: 0.4})
+}
+
+func (s *ClosenessSuite) TestWassermanFaust(c *C) {
+	// A triangle and a lone edge, with an isolated vertex: n = 6.
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 1}, {4, 5}}, identity)
+	g.(gogl.VertexSetMutator).EnsureVertex(6)
+
+	cc := closeness(c, g)
+	// Triangle vertices reach two others at distance 1 each: 2/5 * 2/2.
+	c.Assert(cc[1], Equals, 0.4)
+	// Edge ends reach one other at distance 1: 1/5 * 1/1.
+	c.Assert(cc[4], Equals, 0.2)
+	// The isolate reaches nobody, so gets 0 rather than NaN.
+	c.Assert(cc[6], Equals, 0.0)
+	c.Assert(cc, HasLen, 6)
+
+	c.Assert(closeness(c, gogl.NullGraph), HasLen, 0)
+}
+
+func (s *ClosenessSuite) TestDirected(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+	}).Create(al.G)
+
+	// a reaches both others, at 1 and 2; b reaches only c; c reaches nobody.
+	c.Assert(closeness(c, g), DeepEquals, map[gogl.Vertex]float64{"a": 2.0 / 3, "b": 0.5, "c": 0})
+}
+
+func (s *ClosenessSuite) TestWeighted(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "c", 3),
+		gogl.NewWeightedEdge("a", "c", 5),
+	}).Create(al.G)
+
+	// From a: b at 1, c at 4. From b: 1 and 3. From c: 3 and 4.
+	c.Assert(closeness(c, g), DeepEquals, map[gogl.Vertex]float64{"a": 2.0 / 5, "b": 2.0 / 4, "c": 2.0 / 7})
+}
+
+func (s *ClosenessSuite) TestWeightsRejected(c *C) {
+	negative := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "c", -1),
+	}).Create(al.G)
+	cc, err := ClosenessCentrality(negative)
+	c.Assert(err, Equals, ErrNegativeWeight)
+	c.Assert(cc, IsNil)
+
+	// Every vertex is at distance 0 from the others, so none has a finite score.
+	zero := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 0),
+		gogl.NewWeightedEdge("b", "c", 0),
+	}).Create(al.G)
+	cc, err = ClosenessCentrality(zero)
+	c.Assert(err, ErrorMatches, "Vertex .* is at distance 0 from every vertex it reaches, so its closeness is unbounded.")
+	c.Assert(cc, IsNil)
+}
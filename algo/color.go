@@ -0,0 +1,620 @@
+package algo
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Colors the graph's vertices using the DSATUR heuristic, returning the color
+// assigned to each vertex and the number of colors used. Colors are numbered
+// from 0.
+//
+// DSATUR colors vertices one at a time, always choosing next the uncolored vertex
+// whose neighbors already bear the most distinct colors (its saturation), breaking
+// ties by degree, and giving it the lowest color its neighbors don't use. This
+// usually needs fewer colors than plain greedy coloring, and is exact for
+// bipartite graphs, cycles and wheels.
+//
+// Edge direction is ignored. Self-loops are ignored as well, as no coloring could
+// satisfy them.
+func DSaturColoring(g gogl.Graph) (map[gogl.Vertex]int, int) {
+	adj := undirectedAdjacency(g)
+	colors, count := dsatur(adj)
+	return adj.colorMap(colors), count
+}
+
+// Computes DSaturColoring, returning the color of each vertex by id.
+func dsatur(adj adjacency) ([]int, int) {
+	n := adj.Len()
+	colors := make([]int, n)
+	// The set of colors among each vertex's neighbors.
+	saturation := make([]map[int]struct{}, n)
+
+	pq := make(satQueue, 0, n)
+	for v, ns := range adj.nbrs {
+		colors[v] = -1
+		saturation[v] = make(map[int]struct{})
+		pq = append(pq, satItem{v: v, degree: len(ns)})
+	}
+	heap.Init(&pq)
+
+	var count int
+	for pq.Len() > 0 {
+		item := heap.Pop(&pq).(satItem)
+		v := item.v
+		// Entries are not updated in place; stale ones are simply skipped.
+		if colors[v] >= 0 || item.sat != len(saturation[v]) {
+			continue
+		}
+
+		c := lowestFreeColor(saturation[v])
+		colors[v] = c
+		if c >= count {
+			count = c + 1
+		}
+
+		for w := range adj.nbrs[v] {
+			if colors[w] >= 0 {
+				continue
+			}
+			if _, seen := saturation[w][c]; !seen {
+				saturation[w][c] = struct{}{}
+				heap.Push(&pq, satItem{v: w, sat: len(saturation[w]), degree: len(adj.nbrs[w])})
+			}
+		}
+	}
+
+	return colors, count
+}
+
+// Keys the given colors, one for each vertex by id, by the vertices themselves.
+func (adj adjacency) colorMap(colors []int) map[gogl.Vertex]int {
+	m := make(map[gogl.Vertex]int, len(colors))
+	for v, c := range colors {
+		m[adj.VertexAt(v)] = c
+	}
+	return m
+}
+
+// Colors the graph's vertices greedily, visiting them in the given standard
+// ordering and giving each the lowest color not already borne by one of its
+// neighbors. Returned are the color assigned to each vertex and the number of
+// colors used; colors are numbered from 0.
+//
+// The quality of a greedy coloring depends entirely on the order, so comparing
+// the counts the orderings give is a cheap way to find a good coloring. The
+// vertices are ordered as by OrderVertices; to color them in some other order,
+// use GreedyColoringInOrder. Edge direction and self-loops are ignored.
+func GreedyColoring(g gogl.Graph, ordering VertexOrdering) (map[gogl.Vertex]int, int) {
+	return GreedyColoringInOrder(g, OrderVertices(g, ordering))
+}
+
+// Colors the graph's vertices greedily in the given order, as GreedyColoring
+// does for a standard ordering.
+//
+// Some order is always optimal, and for some classes of graph a good one is easy
+// to find that no standard ordering captures: for example, coloring an interval
+// graph in order of interval start is optimal.
+//
+// Vertices absent from order are colored after those present, in an arbitrary
+// sequence; vertices listed more than once keep their first position, and those
+// not in the graph are ignored. Edge direction and self-loops are ignored.
+func GreedyColoringInOrder(g gogl.Graph, order []gogl.Vertex) (map[gogl.Vertex]int, int) {
+	adj := undirectedAdjacency(g)
+	colors := make([]int, adj.Len())
+	for v := range colors {
+		colors[v] = -1
+	}
+
+	var count int
+	color := func(v int) {
+		if colors[v] >= 0 {
+			return
+		}
+
+		used := make(map[int]struct{}, len(adj.nbrs[v]))
+		for w := range adj.nbrs[v] {
+			if c := colors[w]; c >= 0 {
+				used[c] = struct{}{}
+			}
+		}
+
+		c := lowestFreeColor(used)
+		colors[v] = c
+		if c >= count {
+			count = c + 1
+		}
+	}
+
+	for _, v := range order {
+		if i := adj.IndexOf(v); i >= 0 {
+			color(i)
+		}
+	}
+	for v := range colors {
+		color(v)
+	}
+
+	return adj.colorMap(colors), count
+}
+
+// VertexOrdering selects a standard order in which to visit a graph's vertices,
+// chiefly for coloring by GreedyColoring.
+type VertexOrdering int
+
+const (
+	// The order in which the graph enumerates its vertices.
+	NaturalOrder VertexOrdering = iota
+	// Vertices in decreasing order of degree, so that the most constrained are
+	// colored while the most colors remain free.
+	LargestFirst
+	// Vertices in the reverse of a degeneracy ordering, in which each vertex was
+	// removed with the fewest remaining neighbors. Colored greedily, each vertex
+	// then has at most d earlier neighbors, where d is the degeneracy, so at most
+	// d+1 colors are used; this is optimal for trees and other forests.
+	SmallestLast
+)
+
+// The Welsh-Powell algorithm fills one color at a time, sweeping the vertices in
+// decreasing order of degree and giving the color to each that can take it. The
+// result is the same as greedy coloring in that order, so it is a synonym for
+// LargestFirst.
+const WelshPowell = LargestFirst
+
+// Returns the graph's vertices in the given order. Degrees are those reported by
+// DegreeOf, so in a Digraph both in- and out-arcs count. Ties are broken by the
+// order in which the graph enumerates its vertices.
+func OrderVertices(g gogl.Graph, ordering VertexOrdering) []gogl.Vertex {
+	switch ordering {
+	case LargestFirst:
+		order := gogl.CollectVertices(g)
+		degree := make(map[interface{}]int, len(order)) // keyed by gogl.VertexKey
+		for _, v := range order {
+			degree[gogl.VertexKey(v)], _ = g.DegreeOf(v)
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return degree[gogl.VertexKey(order[i])] > degree[gogl.VertexKey(order[j])]
+		})
+		return order
+	case SmallestLast:
+		adj := undirectedAdjacency(g)
+		order, _ := degeneracyOrder(adj)
+		for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+			order[i], order[j] = order[j], order[i]
+		}
+		return adj.vertices(order)
+	default:
+		return gogl.CollectVertices(g)
+	}
+}
+
+// Indicates whether the graph is bipartite, attempting to 2-color it by
+// breadth-first search. If it is, every vertex is returned with its color, 0 or
+// 1, such that no edge joins two vertices of the same color; each component is
+// colored separately, its first vertex taking color 0.
+//
+// If it is not, the partial coloring made up to the first conflict is returned
+// instead. The two ends of the offending edge are both in it, bearing the same
+// color, so that the conflict may be inspected; they lie on an odd cycle. Edge
+// direction is ignored. A self-loop is an odd cycle, so no graph with one is
+// bipartite.
+func IsBipartite(g gogl.Graph) (bool, map[gogl.Vertex]int) {
+	vi := gogl.NewVertexIndex(g)
+	side, bipartite := twoColor(g, vi)
+
+	colors := make(map[gogl.Vertex]int, len(side))
+	for i, c := range side {
+		if c != -1 {
+			colors[vi.VertexAt(i)] = c
+		}
+	}
+	return bipartite, colors
+}
+
+// Attempts to 2-color the graph as IsBipartite does, giving the color of each
+// vertex by its id in vi. Vertices not reached before a conflict have color -1.
+func twoColor(g gogl.Graph, vi *gogl.VertexIndex) ([]int, bool) {
+	colors := make([]int, vi.Len())
+	for i := range colors {
+		colors[i] = -1
+	}
+
+	for root := range colors {
+		if colors[root] != -1 {
+			continue
+		}
+
+		colors[root] = 0
+		queue := []int{root}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+
+			conflict := false
+			g.AdjacentTo(vi.VertexAt(v), func(w gogl.Vertex) (terminate bool) {
+				wi := vi.IndexOf(w)
+				if colors[wi] == -1 {
+					colors[wi] = 1 - colors[v]
+					queue = append(queue, wi)
+				}
+				conflict = colors[wi] == colors[v]
+				return conflict
+			})
+			if conflict {
+				return colors, false
+			}
+		}
+	}
+
+	return colors, true
+}
+
+// Returns the lowest color not in the used set.
+func lowestFreeColor(used map[int]struct{}) int {
+	c := 0
+	for {
+		if _, taken := used[c]; !taken {
+			return c
+		}
+		c++
+	}
+}
+
+type satItem struct {
+	v           int
+	sat, degree int
+}
+
+// A max-heap of vertices keyed on saturation, then degree, for use with container/heap.
+type satQueue []satItem
+
+func (q satQueue) Len() int { return len(q) }
+func (q satQueue) Less(i, j int) bool {
+	if q[i].sat != q[j].sat {
+		return q[i].sat > q[j].sat
+	}
+	return q[i].degree > q[j].degree
+}
+func (q satQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *satQueue) Push(x interface{}) { *q = append(*q, x.(satItem)) }
+func (q *satQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// Computes the chromatic number of the graph: the fewest colors with which its
+// vertices can be colored so that no edge joins two of the same color.
+//
+// The problem is NP-hard, and this performs an exact backtracking search, so it is
+// practical only for graphs of moderate size. The search is bounded on both sides
+// before it begins: from above by a DSATUR coloring, and from below by the largest
+// clique, whose vertices must all differ. Each candidate number of colors between
+// the two is then tried in turn, in a DSATUR-ordered search that prunes heavily by
+// breaking symmetry - colors are interchangeable, so the largest clique's colors
+// are fixed in advance, and a vertex may only take a color not yet used by any
+// vertex if it is the lowest such color.
+//
+// Edge direction and self-loops are ignored.
+func ChromaticNumber(g gogl.Graph) int {
+	k, _ := ChromaticNumberContext(context.Background(), g)
+	return k
+}
+
+// Calculates the chromatic number as ChromaticNumber does, but abandons the
+// search if the provided context is cancelled or its deadline passes. The context
+// is checked at each branch of both the clique and the coloring searches; once it
+// is done, the context's error is returned.
+func ChromaticNumberContext(ctx context.Context, g gogl.Graph) (int, error) {
+	adj := undirectedAdjacency(g)
+	if adj.Len() == 0 {
+		return 0, nil
+	}
+
+	_, upper := dsatur(adj)
+	clique, err := largestClique(ctx, g)
+	if err != nil {
+		return 0, err
+	}
+
+	for k := len(clique); k < upper; k++ {
+		s := newColorSearch(adj, clique, k, false)
+		s.halt = haltOn(ctx, &err)
+		if s.solve() {
+			return k, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return upper, nil
+}
+
+// Colors the graph's vertices with the given number of colors such that the
+// color classes differ in size by at most one, as in load-balanced scheduling.
+// The returned map gives the color of each vertex, numbered from 0.
+//
+// Like ChromaticNumber, this is an exact backtracking search, and so is practical
+// only for graphs of moderate size; the same pruning applies, along with a limit
+// on the size of each color class. By the Hajnal-Szemerédi theorem, an equitable
+// coloring always exists with more colors than the graph's maximum degree; with
+// fewer, one may not. An error is returned if none exists, or if colors is not
+// positive.
+//
+// Edge direction and self-loops are ignored.
+func EquitableColoring(g gogl.Graph, colors int) (map[gogl.Vertex]int, error) {
+	return EquitableColoringContext(context.Background(), g, colors)
+}
+
+// Finds an equitable coloring as EquitableColoring does, but abandons the search
+// if the provided context is cancelled or its deadline passes, as for
+// ChromaticNumberContext.
+func EquitableColoringContext(ctx context.Context, g gogl.Graph, colors int) (map[gogl.Vertex]int, error) {
+	if colors <= 0 {
+		return nil, errors.New("Number of colors must be positive.")
+	}
+
+	clique, err := largestClique(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+	if len(clique) <= colors {
+		adj := undirectedAdjacency(g)
+		s := newColorSearch(adj, clique, colors, true)
+		s.halt = haltOn(ctx, &err)
+		if s.solve() {
+			return adj.colorMap(s.color), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("No equitable coloring with %d colors exists.", colors)
+}
+
+// Extends the given partial coloring to a proper coloring of the whole graph,
+// using colors numbered from 0 to maxColors-1, and returns it. The colors in the
+// partial assignment are kept fixed; this is the precoloring extension problem,
+// as arises when some resources are assigned in advance. Vertices of the partial
+// assignment not present in the graph are ignored.
+//
+// Like ChromaticNumber, this is an exact backtracking search, and so is practical
+// only for graphs of moderate size. As colors left unused by the partial
+// assignment are interchangeable, the search breaks symmetry among those alone.
+//
+// An error is returned if maxColors is not positive, if the partial assignment
+// uses a color out of range or gives two adjacent vertices the same color, or if
+// no extension exists. Edge direction and self-loops are ignored.
+func CompleteColoring(g gogl.Graph, partial map[gogl.Vertex]int, maxColors int) (map[gogl.Vertex]int, error) {
+	return CompleteColoringContext(context.Background(), g, partial, maxColors)
+}
+
+// Extends a partial coloring as CompleteColoring does, but abandons the search if
+// the provided context is cancelled or its deadline passes. The context is checked
+// at each branch of the search; once it is done, the context's error is returned.
+func CompleteColoringContext(ctx context.Context, g gogl.Graph, partial map[gogl.Vertex]int, maxColors int) (map[gogl.Vertex]int, error) {
+	if maxColors <= 0 {
+		return nil, errors.New("Number of colors must be positive.")
+	}
+
+	adj := undirectedAdjacency(g)
+
+	// The search requires the fixed colors to be 0..m-1, so relabel them, in
+	// increasing order; the free colors follow.
+	precolored := make(map[int]int) // by vertex id
+	fixed := make(map[int]struct{})
+	for v, c := range partial {
+		i := adj.IndexOf(v)
+		if i < 0 {
+			continue
+		}
+		if c < 0 || c >= maxColors {
+			return nil, fmt.Errorf("Vertex %v has color %d, outside the %d available.", v, c, maxColors)
+		}
+		precolored[i] = c
+		fixed[c] = struct{}{}
+	}
+	for v, c := range precolored {
+		for w := range adj.nbrs[v] {
+			if d, colored := precolored[w]; colored && c == d {
+				return nil, fmt.Errorf("Partial coloring is improper: %v and %v both have color %d.", adj.VertexAt(v), adj.VertexAt(w), c)
+			}
+		}
+	}
+
+	var original []int
+	for c := 0; c < maxColors; c++ {
+		if _, ok := fixed[c]; ok {
+			original = append(original, c)
+		}
+	}
+	internal := make(map[int]int, len(original))
+	for i, c := range original {
+		internal[c] = i
+	}
+	for c := 0; c < maxColors; c++ {
+		if _, ok := fixed[c]; !ok {
+			original = append(original, c)
+		}
+	}
+
+	var err error
+	s := newColorSearch(adj, nil, maxColors, false)
+	s.halt = haltOn(ctx, &err)
+	for v, c := range precolored {
+		s.assign(v, internal[c])
+	}
+	s.used = len(fixed)
+
+	if !s.solve() {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Partial coloring cannot be extended with %d colors.", maxColors)
+	}
+
+	for v, c := range s.color {
+		s.color[v] = original[c]
+	}
+	return adj.colorMap(s.color), nil
+}
+
+// Returns a maximum clique of the graph, or the context's error if it is done
+// before the search finishes.
+func largestClique(ctx context.Context, g gogl.Graph) ([]gogl.Vertex, error) {
+	var clique []gogl.Vertex
+	err := MaximalCliquesDegeneracyContext(ctx, g, func(c []gogl.Vertex) (terminate bool) {
+		if len(c) > len(clique) {
+			clique = c
+		}
+		return
+	})
+	return clique, err
+}
+
+// The state of a search for a coloring using at most k colors.
+type colorSearch struct {
+	k         int
+	adj       [][]int     // by vertex id, as are the other per-vertex slices
+	color     []int       // color of each vertex, or -1
+	conflicts [][]int     // number of each vertex's neighbors having each color
+	sat       []int       // number of distinct colors among each vertex's neighbors
+	used      int         // colors 0..used-1 are in use
+	left      int         // number of uncolored vertices
+	halt      func() bool // early abort check, consulted at each branch
+	halted    bool        // set once halt has reported true
+
+	// For an equitable coloring, no color class may exceed hi vertices, and at
+	// most big of them may reach it; full is the number that have.
+	equitable     bool
+	size          []int // number of vertices of each color
+	hi, big, full int
+}
+
+// Sets up a search for a k-coloring, optionally equitable, with the given clique
+// precolored.
+func newColorSearch(adj adjacency, clique []gogl.Vertex, k int, equitable bool) *colorSearch {
+	n := adj.Len()
+	s := &colorSearch{
+		k:         k,
+		adj:       make([][]int, n),
+		color:     make([]int, n),
+		conflicts: make([][]int, n),
+		sat:       make([]int, n),
+		left:      n,
+		equitable: equitable,
+		size:      make([]int, k),
+		hi:        (n + k - 1) / k,
+		big:       n % k,
+	}
+	if s.big == 0 {
+		s.big = k
+	}
+
+	for v, ns := range adj.nbrs {
+		for w := range ns {
+			s.adj[v] = append(s.adj[v], w)
+		}
+		s.color[v] = -1
+		s.conflicts[v] = make([]int, k)
+	}
+
+	for c, v := range clique {
+		s.assign(adj.IndexOf(v), c)
+	}
+	s.used = len(clique)
+
+	return s
+}
+
+// Indicates whether color c has room for another vertex.
+func (s *colorSearch) fits(c int) bool {
+	if !s.equitable {
+		return true
+	}
+	return s.size[c] < s.hi-1 || (s.size[c] == s.hi-1 && s.full < s.big)
+}
+
+func (s *colorSearch) assign(v, c int) {
+	s.color[v] = c
+	s.left--
+	if s.size[c]++; s.size[c] == s.hi {
+		s.full++
+	}
+	for _, w := range s.adj[v] {
+		if s.conflicts[w][c]++; s.conflicts[w][c] == 1 {
+			s.sat[w]++
+		}
+	}
+}
+
+func (s *colorSearch) unassign(v int) {
+	c := s.color[v]
+	s.color[v] = -1
+	s.left++
+	if s.size[c] == s.hi {
+		s.full--
+	}
+	s.size[c]--
+	for _, w := range s.adj[v] {
+		if s.conflicts[w][c]--; s.conflicts[w][c] == 0 {
+			s.sat[w]--
+		}
+	}
+}
+
+// Attempts to extend the current partial coloring to all vertices. Once the
+// search is halted, every branch fails.
+func (s *colorSearch) solve() bool {
+	if s.left == 0 {
+		return true
+	}
+	if s.halt() {
+		s.halted = true
+		return false
+	}
+
+	// Branch on the most saturated uncolored vertex, which has the fewest options.
+	v := -1
+	for i, c := range s.color {
+		if c == -1 && (v == -1 || s.sat[i] > s.sat[v] || (s.sat[i] == s.sat[v] && len(s.adj[i]) > len(s.adj[v]))) {
+			v = i
+		}
+	}
+
+	limit := s.used + 1
+	if limit > s.k {
+		limit = s.k
+	}
+
+	for c := 0; c < limit; c++ {
+		if s.conflicts[v][c] > 0 || !s.fits(c) {
+			continue
+		}
+
+		used := s.used
+		if c == used {
+			s.used++
+		}
+		s.assign(v, c)
+
+		if s.solve() {
+			return true
+		}
+
+		s.unassign(v)
+		s.used = used
+		if s.halted {
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,526 @@
+package algo
+
+import (
+	"context"
+	stdrand "math/rand"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type ColorSuite struct{}
+
+var _ = Suite(&ColorSuite{})
+
+// Checks that every vertex is colored within [0, count), and that no edge joins two
+// vertices of the same color.
+func checkColoring(c *C, g gogl.Graph, colors map[gogl.Vertex]int, count int) {
+	c.Assert(colors, HasLen, gogl.Order(g))
+	for v, col := range colors {
+		c.Assert(col >= 0 && col < count, Equals, true, Commentf("vertex %v has color %d of %d", v, col, count))
+	}
+
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		if u, v := e.Both(); u != v {
+			c.Assert(colors[u] != colors[v], Equals, true, Commentf("edge %v joins two vertices of color %d", e, colors[u]))
+		}
+		return
+	})
+}
+
+func (s *ColorSuite) TestBipartite(c *C) {
+	// A 6x6 grid, with vertices numbered row-major.
+	var grid [][2]int
+	for i := 0; i < 36; i++ {
+		if i%6 < 5 {
+			grid = append(grid, [2]int{i, i + 1})
+		}
+		if i < 30 {
+			grid = append(grid, [2]int{i, i + 6})
+		}
+	}
+
+	// A random bipartite graph, between evens and odds.
+	r := stdrand.New(stdrand.NewSource(1))
+	var random [][2]int
+	for i := 0; i < 200; i++ {
+		random = append(random, [2]int{2 * r.Intn(50), 2*r.Intn(50) + 1})
+	}
+
+	for name, pairs := range map[string][][2]int{
+		"grid":       grid,
+		"even cycle": {{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 1}},
+		"random":     random,
+		"two stars":  {{0, 1}, {0, 2}, {0, 3}, {10, 11}, {10, 12}},
+	} {
+		g := relabeledGraph(pairs, identity)
+		colors, count := DSaturColoring(g)
+		checkColoring(c, g, colors, count)
+		c.Assert(count, Equals, 2, Commentf("graph: %s", name))
+	}
+}
+
+func (s *ColorSuite) TestOrderVertices(c *C) {
+	g := gogl.Spec().Using(rand.BernoulliDistribution(80, 0.1, false, true, stdrand.NewSource(3))).Create(al.G)
+
+	for _, ordering := range []VertexOrdering{NaturalOrder, LargestFirst, WelshPowell, SmallestLast} {
+		order := OrderVertices(g, ordering)
+		c.Assert(order, HasLen, gogl.Order(g), Commentf("ordering %d", ordering))
+		seen := make(map[gogl.Vertex]bool)
+		for _, v := range order {
+			c.Assert(seen[v], Equals, false, Commentf("ordering %d repeats %v", ordering, v))
+			seen[v] = true
+		}
+
+		colors, count := GreedyColoring(g, ordering)
+		checkColoring(c, g, colors, count)
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			g.AdjacentTo(v, func(w gogl.Vertex) (terminate bool) {
+				c.Assert(colors[v] != colors[w], Equals, true, Commentf("ordering %d colors %v and %v alike", ordering, v, w))
+				return
+			})
+			return
+		})
+	}
+
+	order := OrderVertices(g, LargestFirst)
+	for i := 1; i < len(order); i++ {
+		prev, _ := g.DegreeOf(order[i-1])
+		cur, _ := g.DegreeOf(order[i])
+		c.Assert(prev >= cur, Equals, true, Commentf("position %d", i))
+	}
+	c.Assert(OrderVertices(gogl.NullGraph, LargestFirst), HasLen, 0)
+}
+
+func (s *ColorSuite) TestSmallestLastTree(c *C) {
+	// A ternary tree. Being 1-degenerate, any tree takes two colors when
+	// colored greedily in smallest-last order.
+	var pairs [][2]int
+	for i := 1; i < 40; i++ {
+		pairs = append(pairs, [2]int{(i - 1) / 3, i})
+	}
+	g := relabeledGraph(pairs, identity)
+
+	colors, count := GreedyColoring(g, SmallestLast)
+	checkColoring(c, g, colors, count)
+	c.Assert(count, Equals, 2)
+}
+
+func (s *ColorSuite) TestIsBipartite(c *C) {
+	even := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}, {10, 11}}, identity)
+	ok, colors := IsBipartite(even)
+	c.Assert(ok, Equals, true)
+	checkColoring(c, even, colors, 2)
+
+	// Direction is ignored.
+	dg := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("c", "b"),
+		gogl.NewArc("c", "d"),
+	}).Create(al.G)
+	ok, colors = IsBipartite(dg)
+	c.Assert(ok, Equals, true)
+	checkColoring(c, dg, colors, 2)
+
+	ok, colors = IsBipartite(gogl.NullGraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(colors, HasLen, 0)
+}
+
+func (s *ColorSuite) TestIsBipartiteConflict(c *C) {
+	// A 5-cycle, with a bipartite component that may or may not be colored
+	// before the conflict is found.
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 1}, {10, 11}}, identity)
+	ok, colors := IsBipartite(g)
+	c.Assert(ok, Equals, false)
+
+	// All of the cycle is colored, as the conflict is only found at its far
+	// side; exactly one of its edges joins two vertices of the same color.
+	var clashes int
+	for i := 1; i <= 5; i++ {
+		u, v := i, i%5+1
+		cu, okU := colors[u]
+		cv, okV := colors[v]
+		c.Assert(okU && okV, Equals, true, Commentf("edge %d-%d", u, v))
+		if cu == cv {
+			clashes++
+		}
+	}
+	c.Assert(clashes, Equals, 1)
+
+	loop := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("b", "b"),
+	}).Create(al.G)
+	ok, _ = IsBipartite(loop)
+	c.Assert(ok, Equals, false)
+
+	ok, _ = IsBipartite(relabeledGraph(petersen, identity))
+	c.Assert(ok, Equals, false)
+}
+
+func (s *ColorSuite) TestKnownChromaticNumbers(c *C) {
+	k5 := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < i; j++ {
+			k5.AddEdges(gogl.NewEdge(i, j))
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		g     gogl.Graph
+		count int
+	}{
+		"odd cycle": {relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 1}}, identity), 3},
+		"petersen":  {relabeledGraph(petersen, identity), 3},
+		"K5":        {k5, 5},
+	} {
+		colors, count := DSaturColoring(tc.g)
+		checkColoring(c, tc.g, colors, count)
+		c.Assert(count, Equals, tc.count, Commentf("graph: %s", name))
+	}
+}
+
+func (s *ColorSuite) TestRandomGraphs(c *C) {
+	for seed := int64(1); seed <= 5; seed++ {
+		g := gogl.Spec().Loop().Using(rand.BernoulliDistribution(100, 0.1, false, true, stdrand.NewSource(seed))).Create(al.G).(gogl.MutableGraph)
+		// A loop must not prevent coloring.
+		g.AddEdges(gogl.NewEdge(0, 0))
+
+		colors, count := DSaturColoring(g)
+		checkColoring(c, g, colors, count)
+	}
+}
+
+func (s *ColorSuite) TestTrivialGraphs(c *C) {
+	colors, count := DSaturColoring(gogl.NullGraph)
+	c.Assert(colors, HasLen, 0)
+	c.Assert(count, Equals, 0)
+
+	g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex("a", "b")
+	colors, count = DSaturColoring(g)
+	c.Assert(count, Equals, 1)
+	c.Assert(colors, DeepEquals, map[gogl.Vertex]int{"a": 0, "b": 0})
+}
+
+func (s *ColorSuite) TestGreedyOrder(c *C) {
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}}, identity)
+
+	colors, count := GreedyColoringInOrder(path, []gogl.Vertex{1, 2, 3, 4})
+	checkColoring(c, path, colors, count)
+	c.Assert(count, Equals, 2)
+
+	// Coloring both ends first forces a third color in the middle.
+	colors, count = GreedyColoringInOrder(path, []gogl.Vertex{1, 4, 2, 3})
+	checkColoring(c, path, colors, count)
+	c.Assert(count, Equals, 3)
+
+	// Unlisted vertices are still colored; unknown ones are ignored.
+	colors, count = GreedyColoringInOrder(path, []gogl.Vertex{3, "foo", 3})
+	checkColoring(c, path, colors, count)
+	c.Assert(colors[3], Equals, 0)
+
+	for seed := int64(1); seed <= 5; seed++ {
+		g := gogl.Spec().Using(rand.BernoulliDistribution(100, 0.1, false, true, stdrand.NewSource(seed))).Create(al.G)
+		colors, count := GreedyColoringInOrder(g, nil)
+		checkColoring(c, g, colors, count)
+	}
+}
+
+// Finds the chromatic number by plain backtracking: trying each number of colors
+// in turn, coloring vertices in a fixed order with every color. Gives up, reporting
+// false, after trying budget assignments.
+func naiveChromaticNumber(g gogl.Graph, budget int) (int, bool) {
+	vs := gogl.CollectVertices(g)
+	colors := make(map[gogl.Vertex]int, len(vs))
+
+	var tries int
+	var try func(i, k int) bool
+	try = func(i, k int) bool {
+		if i == len(vs) {
+			return true
+		}
+		for col := 0; col < k && tries < budget; col++ {
+			tries++
+			ok := true
+			g.AdjacentTo(vs[i], func(w gogl.Vertex) (terminate bool) {
+				if c, colored := colors[w]; colored && c == col && w != vs[i] {
+					ok = false
+					return true
+				}
+				return
+			})
+
+			if ok {
+				colors[vs[i]] = col
+				if try(i+1, k) {
+					return true
+				}
+				delete(colors, vs[i])
+			}
+		}
+		return false
+	}
+
+	for k := 0; k <= len(vs); k++ {
+		if try(0, k) {
+			return k, true
+		}
+		if tries >= budget {
+			return 0, false
+		}
+	}
+	return len(vs), true
+}
+
+// The Grötzsch graph: triangle-free, yet with chromatic number 4.
+var grotzsch = [][2]int{
+	{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+	{5, 1}, {5, 4}, {6, 0}, {6, 2}, {7, 1}, {7, 3}, {8, 2}, {8, 4}, {9, 3}, {9, 0},
+	{10, 5}, {10, 6}, {10, 7}, {10, 8}, {10, 9},
+}
+
+func (s *ColorSuite) TestChromaticNumber(c *C) {
+	for name, tc := range map[string]struct {
+		g     gogl.Graph
+		count int
+	}{
+		"petersen": {relabeledGraph(petersen, identity), 3},
+		"grotzsch": {relabeledGraph(grotzsch, identity), 4},
+		"C7":       {relabeledGraph([][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 0}}, identity), 3},
+		"K5":       {relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, identity), 5},
+		"null":     {gogl.NullGraph, 0},
+	} {
+		c.Assert(ChromaticNumber(tc.g), Equals, tc.count, Commentf("graph: %s", name))
+	}
+
+	g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex("a", "b")
+	c.Assert(ChromaticNumber(g), Equals, 1)
+}
+
+func (s *ColorSuite) TestColoringContext(c *C) {
+	g := relabeledGraph(grotzsch, identity)
+
+	k, err := ChromaticNumberContext(context.Background(), g)
+	c.Assert(err, IsNil)
+	c.Assert(k, Equals, 4)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ChromaticNumberContext(cancelled, g)
+	c.Assert(err, Equals, context.Canceled)
+	colors, err := EquitableColoringContext(cancelled, g, 4)
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(colors, IsNil)
+	colors, err = CompleteColoringContext(cancelled, g, map[gogl.Vertex]int{0: 0}, 4)
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(colors, IsNil)
+
+	// Cancelled partway through, once the clique search is done and the coloring
+	// search is under way.
+	for remaining := 1; remaining < 60; remaining += 7 {
+		ctx := &countdownContext{Context: context.Background(), remaining: remaining}
+		_, err = ChromaticNumberContext(ctx, g)
+		c.Assert(err, Equals, context.Canceled, Commentf("after %d checks", remaining))
+		// The search must stop as soon as cancellation is observed.
+		c.Assert(ctx.after, Equals, 1, Commentf("after %d checks", remaining))
+	}
+}
+
+func (s *ColorSuite) TestChromaticNumberMatchesNaive(c *C) {
+	r := stdrand.NewSource(1)
+	for i := 0; i < 200; i++ {
+		n := uint(2 + i%9)
+		g := gogl.Spec().Using(rand.BernoulliDistribution(n, float64(i%10)/10, false, true, r)).Create(al.G)
+
+		want, ok := naiveChromaticNumber(g, 1<<30)
+		c.Assert(ok, Equals, true)
+		c.Assert(ChromaticNumber(g), Equals, want, Commentf("graph %d: %v", i, gogl.CollectVertices(g)))
+	}
+}
+
+// A random graph on 60 vertices, which the naive search cannot finish.
+func chromaticBenchGraph() gogl.Graph {
+	return gogl.Spec().Using(rand.BernoulliDistribution(60, 0.3, false, true, stdrand.NewSource(1))).Create(al.G)
+}
+
+func BenchmarkChromaticNumber(b *testing.B) {
+	g := chromaticBenchGraph()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ChromaticNumber(g)
+	}
+}
+
+func BenchmarkChromaticNumberNaive(b *testing.B) {
+	g := chromaticBenchGraph()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := naiveChromaticNumber(g, 1e7); !ok {
+			b.Skip("naive search gave up after 1e7 assignments")
+		}
+	}
+}
+
+// Checks that the coloring is proper, uses colors within [0, k), and has color
+// classes that differ in size by at most one.
+func checkEquitable(c *C, g gogl.Graph, colors map[gogl.Vertex]int, k int) {
+	checkColoring(c, g, colors, k)
+
+	sizes := make([]int, k)
+	for _, col := range colors {
+		sizes[col]++
+	}
+	min, max := sizes[0], sizes[0]
+	for _, size := range sizes {
+		if size < min {
+			min = size
+		}
+		if size > max {
+			max = size
+		}
+	}
+	c.Assert(max-min <= 1, Equals, true, Commentf("color class sizes %v", sizes))
+}
+
+func (s *ColorSuite) TestEquitableComplete(c *C) {
+	k5 := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, identity)
+
+	colors, err := EquitableColoring(k5, 5)
+	c.Assert(err, IsNil)
+	checkEquitable(c, k5, colors, 5)
+
+	// Extra colors simply go unused.
+	colors, err = EquitableColoring(k5, 7)
+	c.Assert(err, IsNil)
+	checkEquitable(c, k5, colors, 7)
+
+	_, err = EquitableColoring(k5, 4)
+	c.Assert(err, ErrorMatches, "No equitable coloring with 4 colors exists.")
+}
+
+func (s *ColorSuite) TestEquitableForcedDistribution(c *C) {
+	// The center of a star must be alone in its class, as it neighbors every leaf.
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}}, identity)
+
+	colors, err := EquitableColoring(star, 3)
+	c.Assert(err, IsNil)
+	checkEquitable(c, star, colors, 3)
+	for v := 1; v <= 4; v++ {
+		c.Assert(colors[v] != colors[0], Equals, true)
+	}
+
+	// Though the star is bipartite, two classes would have to hold three vertices
+	// and two, and the center can share with no one.
+	_, err = EquitableColoring(star, 2)
+	c.Assert(err, ErrorMatches, "No equitable coloring with 2 colors exists.")
+}
+
+func (s *ColorSuite) TestEquitableRandom(c *C) {
+	for seed := int64(1); seed <= 10; seed++ {
+		g := gogl.Spec().Using(rand.BernoulliDistribution(30, 0.15, false, true, stdrand.NewSource(seed))).Create(al.G)
+
+		// Hajnal-Szemerédi guarantees one with more colors than the maximum degree.
+		var maxDegree int
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			if d, _ := g.DegreeOf(v); d > maxDegree {
+				maxDegree = d
+			}
+			return
+		})
+
+		colors, err := EquitableColoring(g, maxDegree+1)
+		c.Assert(err, IsNil)
+		checkEquitable(c, g, colors, maxDegree+1)
+	}
+}
+
+func (s *ColorSuite) TestEquitableTrivial(c *C) {
+	colors, err := EquitableColoring(gogl.NullGraph, 3)
+	c.Assert(err, IsNil)
+	c.Assert(colors, HasLen, 0)
+
+	_, err = EquitableColoring(gogl.NullGraph, 0)
+	c.Assert(err, ErrorMatches, "Number of colors must be positive.")
+}
+
+// Checks that the coloring is proper, uses colors within [0, k), and keeps every
+// color of the partial assignment.
+func checkCompletion(c *C, g gogl.Graph, colors, partial map[gogl.Vertex]int, k int) {
+	checkColoring(c, g, colors, k)
+	for v, col := range partial {
+		if g.HasVertex(v) {
+			c.Assert(colors[v], Equals, col, Commentf("vertex %v", v))
+		}
+	}
+}
+
+func (s *ColorSuite) TestCompleteColoringForced(c *C) {
+	// In two colors, a path's coloring is fixed by any one of its vertices.
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}}, identity)
+	partial := map[gogl.Vertex]int{1: 1}
+	colors, err := CompleteColoring(path, partial, 2)
+	c.Assert(err, IsNil)
+	checkCompletion(c, path, colors, partial, 2)
+	c.Assert(colors, DeepEquals, map[gogl.Vertex]int{1: 1, 2: 0, 3: 1, 4: 0})
+
+	// The fixed colors need not be the lowest.
+	triangle := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 1}}, identity)
+	partial = map[gogl.Vertex]int{1: 2, 2: 0, "absent": 1}
+	colors, err = CompleteColoring(triangle, partial, 3)
+	c.Assert(err, IsNil)
+	checkCompletion(c, triangle, colors, partial, 3)
+	c.Assert(colors[3], Equals, 1)
+	c.Assert(colors, HasLen, 3)
+
+	// With nothing fixed, this is plain k-coloring.
+	colors, err = CompleteColoring(relabeledGraph(petersen, identity), nil, 3)
+	c.Assert(err, IsNil)
+	checkColoring(c, relabeledGraph(petersen, identity), colors, 3)
+}
+
+func (s *ColorSuite) TestCompleteColoringInfeasible(c *C) {
+	// The middle of the path neighbors both colors.
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}}, identity)
+	_, err := CompleteColoring(path, map[gogl.Vertex]int{1: 0, 3: 1}, 2)
+	c.Assert(err, ErrorMatches, "Partial coloring cannot be extended with 2 colors.")
+
+	// A third color makes room.
+	colors, err := CompleteColoring(path, map[gogl.Vertex]int{1: 0, 3: 1}, 3)
+	c.Assert(err, IsNil)
+	c.Assert(colors[2], Equals, 2)
+
+	_, err = CompleteColoring(path, map[gogl.Vertex]int{1: 0, 2: 0}, 2)
+	c.Assert(err, ErrorMatches, "Partial coloring is improper: .* both have color 0.")
+
+	_, err = CompleteColoring(path, map[gogl.Vertex]int{1: 2}, 2)
+	c.Assert(err, ErrorMatches, "Vertex 1 has color 2, outside the 2 available.")
+
+	_, err = CompleteColoring(path, nil, 0)
+	c.Assert(err, ErrorMatches, "Number of colors must be positive.")
+}
+
+func (s *ColorSuite) TestCompleteColoringRandom(c *C) {
+	r := stdrand.New(stdrand.NewSource(5))
+	for seed := int64(1); seed <= 10; seed++ {
+		g := gogl.Spec().Using(rand.BernoulliDistribution(25, 0.2, false, true, stdrand.NewSource(seed))).Create(al.G)
+
+		// Any part of an existing coloring can be extended to a whole one.
+		full, count := DSaturColoring(g)
+		partial := make(map[gogl.Vertex]int)
+		for v, col := range full {
+			if r.Intn(3) == 0 {
+				partial[v] = col
+			}
+		}
+
+		colors, err := CompleteColoring(g, partial, count)
+		c.Assert(err, IsNil, Commentf("seed %d", seed))
+		checkCompletion(c, g, colors, partial, count)
+	}
+}
@@ -0,0 +1,45 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Returns the complement of the simple graph g: a graph on the same vertices in
+// which two distinct vertices are adjacent iff they are not adjacent in g. The
+// complement turns questions about independent sets into questions about
+// cliques, and colorings of g into clique covers of its complement.
+//
+// Self-loops are never included, as in a simple graph. The complement of a
+// Digraph is a Digraph, with an arc from u to v iff g has no such arc, as told
+// by HasArc; so where g has an arc one way only, its complement has the other.
+// Otherwise HasEdge decides. Weights, labels and data cannot carry over to
+// edges g does not have, so the result is a basic, mutable adjacency list. As
+// every pair of vertices is tested, this takes O(V^2) time.
+func Complement(g gogl.SimpleGraph) gogl.Graph {
+	vertices := gogl.CollectVertices(g)
+	dg, directed := g.(gogl.Digraph)
+
+	var edges []gogl.Edge
+	for i, u := range vertices {
+		for j, v := range vertices {
+			switch {
+			case i == j:
+			case directed:
+				if !dg.HasArc(gogl.NewArc(u, v)) {
+					edges = append(edges, gogl.NewArc(u, v))
+				}
+			case i < j:
+				if !g.HasEdge(gogl.NewEdge(u, v)) {
+					edges = append(edges, gogl.NewEdge(u, v))
+				}
+			}
+		}
+	}
+
+	spec := gogl.Spec().Using(gogl.VertexEdgeList{V: vertices, E: edges})
+	if directed {
+		spec = spec.Directed().Using(gogl.VertexArcList{V: vertices, E: edges})
+	}
+	return spec.Create(al.G)
+}
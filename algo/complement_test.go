@@ -0,0 +1,68 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type ComplementSuite struct{}
+
+var _ = Suite(&ComplementSuite{})
+
+func (s *ComplementSuite) TestUndirected(c *C) {
+	// The complement of the path a-b-c-d is the path b-d-a-c.
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("b", "c"),
+		gogl.NewEdge("c", "d"),
+	}).Create(al.G).(gogl.SimpleGraph)
+
+	cg := Complement(g)
+	_, directed := cg.(gogl.Digraph)
+	c.Assert(directed, Equals, false)
+	c.Assert(gogl.Order(cg), Equals, 4)
+	c.Assert(gogl.Size(cg), Equals, 3)
+	c.Assert(cg.HasEdge(gogl.NewEdge("b", "d")), Equals, true)
+	c.Assert(cg.HasEdge(gogl.NewEdge("d", "a")), Equals, true)
+	c.Assert(cg.HasEdge(gogl.NewEdge("a", "c")), Equals, true)
+
+	// The Petersen graph is 3-regular on 10 vertices, so its complement is 6-regular.
+	pg := Complement(relabeledGraph(petersen, identity).(gogl.SimpleGraph))
+	c.Assert(gogl.Size(pg), Equals, 30)
+	pg.Vertices(func(v gogl.Vertex) (terminate bool) {
+		d, _ := pg.DegreeOf(v)
+		c.Assert(d, Equals, 6)
+		return
+	})
+
+	// Complementing twice gives back the original.
+	twice := Complement(Complement(g).(gogl.SimpleGraph))
+	c.Assert(gogl.Size(twice), Equals, 3)
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(twice.HasEdge(e), Equals, true)
+		return
+	})
+
+	// Isolated vertices gain every edge.
+	mg := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	mg.EnsureVertex(1, 2, 3)
+	c.Assert(gogl.Size(Complement(mg.(gogl.SimpleGraph))), Equals, 3)
+}
+
+func (s *ComplementSuite) TestDirected(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "a"),
+		gogl.NewArc("b", "c"),
+	}).Create(al.G).(gogl.SimpleGraph)
+
+	cg := Complement(g)
+	dg, ok := cg.(gogl.Digraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(gogl.Size(cg), Equals, 3)
+	c.Assert(dg.HasArc(gogl.NewArc("c", "b")), Equals, true)
+	c.Assert(dg.HasArc(gogl.NewArc("a", "c")), Equals, true)
+	c.Assert(dg.HasArc(gogl.NewArc("c", "a")), Equals, true)
+	c.Assert(dg.HasArc(gogl.NewArc("b", "c")), Equals, false)
+}
@@ -0,0 +1,219 @@
+package algo
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Partitions the graph's vertices into its connected components. Edge direction
+// is ignored, so for a Digraph these are its weakly connected components.
+//
+// Neither the order of the components nor that of the vertices within each is
+// meaningful.
+func ConnectedComponents(g gogl.Graph) [][]gogl.Vertex {
+	vi := gogl.NewVertexIndex(g)
+
+	ds := newDisjointSet(vi.Len())
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		ds.union(vi.IndexOf(u), vi.IndexOf(v))
+		return
+	})
+
+	return ds.groups(vi)
+}
+
+// Partitions the digraph's vertices into its strongly connected components: the
+// maximal sets in which every vertex can reach every other along arcs.
+//
+// Tarjan's algorithm finds them in a single linear-time pass, run iteratively so
+// that long paths cannot overflow the stack. The components are returned in the
+// order Tarjan's algorithm completes them, which is a reverse topological order
+// of the condensation: no arc leads from a component to a later one. The order
+// of vertices within each component is not meaningful.
+func StronglyConnectedComponents(g gogl.Digraph) [][]gogl.Vertex {
+	component := stronglyConnected(g)
+
+	var count int
+	for _, c := range component {
+		if c >= count {
+			count = c + 1
+		}
+	}
+
+	sccs := make([][]gogl.Vertex, count)
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		c := component[gogl.VertexKey(v)]
+		sccs[c] = append(sccs[c], v)
+		return
+	})
+	return sccs
+}
+
+// Collapses each strongly connected component of the digraph into a single
+// vertex, returning the condensation: a DAG with an arc between two components
+// iff some arc of g leads from a vertex of the first to one of the second.
+//
+// The vertices of the condensation are ints, each the position of its component
+// in the result of StronglyConnectedComponents, so arcs always lead from higher
+// numbers to lower. Arcs within a component are dropped, and parallel arcs
+// between components merge. The result is a mutable adjacency list.
+func CondensationGraph(g gogl.Digraph) gogl.Digraph {
+	component := stronglyConnected(g)
+
+	cg := gogl.Spec().Directed().Create(al.G)
+	for _, c := range component {
+		cg.(gogl.VertexSetMutator).EnsureVertex(c)
+	}
+
+	var arcs []gogl.Arc
+	g.Arcs(func(a gogl.Arc) (terminate bool) {
+		if cu, cv := component[gogl.VertexKey(a.Source())], component[gogl.VertexKey(a.Target())]; cu != cv {
+			arcs = append(arcs, gogl.NewArc(cu, cv))
+		}
+		return
+	})
+	cg.(gogl.MutableDigraph).AddArcs(arcs...)
+
+	return cg.(gogl.Digraph)
+}
+
+// Partitions the graph's vertices into its connected components, as
+// ConnectedComponents, but spreads the work across the given number of goroutines.
+//
+// The edge set is split into one shard per worker, and each worker builds its own
+// disjoint-set forest from its shard. The forests are then merged pairwise, also
+// in parallel: merging a forest into another means uniting each vertex with its
+// root in the first, which preserves every connection it records. If workers is
+// not positive, GOMAXPROCS workers are used.
+//
+// Edges must still be enumerated sequentially, so the speedup depends on how
+// cheaply g does so relative to the cost of the union operations.
+func ConnectedComponentsParallel(g gogl.Graph, workers int) [][]gogl.Vertex {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	vi := gogl.NewVertexIndex(g)
+	n := vi.Len()
+
+	var edges [][2]int
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		if u, v := e.Both(); gogl.VertexKey(u) != gogl.VertexKey(v) {
+			edges = append(edges, [2]int{vi.IndexOf(u), vi.IndexOf(v)})
+		}
+		return
+	})
+
+	if workers > len(edges) {
+		workers = len(edges)
+	}
+	if workers <= 1 {
+		ds := newDisjointSet(n)
+		for _, e := range edges {
+			ds.union(e[0], e[1])
+		}
+		return ds.groups(vi)
+	}
+
+	forests := make([]*disjointSet, workers)
+	var wg sync.WaitGroup
+	for w := range forests {
+		shard := edges[w*len(edges)/workers : (w+1)*len(edges)/workers]
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			ds := newDisjointSet(n)
+			for _, e := range shard {
+				ds.union(e[0], e[1])
+			}
+			forests[w] = ds
+		}(w)
+	}
+	wg.Wait()
+
+	// Merge in a binary tree, so that each round halves the number of forests.
+	for step := 1; step < workers; step *= 2 {
+		for i := 0; i+step < workers; i += 2 * step {
+			wg.Add(1)
+			go func(into, from *disjointSet) {
+				defer wg.Done()
+				into.merge(from)
+			}(forests[i], forests[i+step])
+		}
+		wg.Wait()
+	}
+
+	return forests[0].groups(vi)
+}
+
+// A disjoint-set forest over the integers [0, n), with union by rank and path
+// halving.
+type disjointSet struct {
+	parent []int
+	rank   []uint8
+}
+
+func newDisjointSet(n int) *disjointSet {
+	ds := &disjointSet{parent: make([]int, n), rank: make([]uint8, n)}
+	for i := range ds.parent {
+		ds.parent[i] = i
+	}
+	return ds
+}
+
+// Returns the representative of the set containing x.
+func (ds *disjointSet) find(x int) int {
+	for ds.parent[x] != x {
+		ds.parent[x] = ds.parent[ds.parent[x]]
+		x = ds.parent[x]
+	}
+	return x
+}
+
+// Unites the sets containing x and y, reporting whether they were distinct.
+func (ds *disjointSet) union(x, y int) bool {
+	x, y = ds.find(x), ds.find(y)
+	if x == y {
+		return false
+	}
+
+	if ds.rank[x] < ds.rank[y] {
+		x, y = y, x
+	}
+	ds.parent[y] = x
+	if ds.rank[x] == ds.rank[y] {
+		ds.rank[x]++
+	}
+	return true
+}
+
+// Unites every pair of elements that are in the same set in other. Both forests
+// must be over the same elements.
+func (ds *disjointSet) merge(other *disjointSet) {
+	for x := range other.parent {
+		if r := other.find(x); r != x {
+			ds.union(x, r)
+		}
+	}
+}
+
+// Groups the indexed vertices, whose ids are the forest's elements, by set.
+func (ds *disjointSet) groups(vi *gogl.VertexIndex) [][]gogl.Vertex {
+	byRoot := make(map[int]int)
+	var groups [][]gogl.Vertex
+	for i, v := range vi.List() {
+		r := ds.find(i)
+		g, exists := byRoot[r]
+		if !exists {
+			g = len(groups)
+			byRoot[r] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], v)
+	}
+	return groups
+}
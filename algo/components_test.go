@@ -0,0 +1,174 @@
+package algo
+
+import (
+	"fmt"
+	stdrand "math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type ComponentsSuite struct{}
+
+var _ = Suite(&ComponentsSuite{})
+
+// Renders a partition of vertices in a canonical form, for comparison.
+func canonicalPartition(components [][]gogl.Vertex) []string {
+	var out []string
+	for _, comp := range components {
+		var vs []string
+		for _, v := range comp {
+			vs = append(vs, fmt.Sprint(v))
+		}
+		sort.Strings(vs)
+		out = append(out, fmt.Sprint(vs))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// A forest of many small random components, along with some isolates.
+func sparseComponents(n int, seed int64) gogl.Graph {
+	r := stdrand.New(stdrand.NewSource(seed))
+	g := gogl.Spec().Loop().Create(al.G).(gogl.MutableGraph)
+	for i := 0; i < n; i++ {
+		g.EnsureVertex(i)
+		if i%50 != 0 && r.Intn(10) > 0 {
+			// Join to an earlier vertex in the same block of 50.
+			g.AddEdges(gogl.NewEdge(i, i-1-r.Intn(i%50)))
+		}
+	}
+	g.AddEdges(gogl.NewEdge(3, 3))
+	return g
+}
+
+func (s *ComponentsSuite) TestSequential(c *C) {
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {4, 5}}, identity).(gogl.MutableGraph)
+	g.EnsureVertex(6)
+
+	c.Assert(canonicalPartition(ConnectedComponents(g)), DeepEquals, []string{"[1 2 3]", "[4 5]", "[6]"})
+	c.Assert(ConnectedComponents(gogl.NullGraph), HasLen, 0)
+
+	// Arc direction is ignored.
+	dg := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("c", "b"),
+		gogl.NewArc("d", "e"),
+	}).Create(al.G)
+	c.Assert(canonicalPartition(ConnectedComponents(dg)), DeepEquals, []string{"[a b c]", "[d e]"})
+}
+
+func (s *ComponentsSuite) TestParallelMatchesSequential(c *C) {
+	graphs := []gogl.Graph{
+		sparseComponents(2000, 1),
+		gogl.Spec().Using(rand.BernoulliDistribution(300, 0.005, false, true, stdrand.NewSource(2))).Create(al.G),
+		gogl.Spec().Directed().Using(rand.BernoulliDistribution(300, 0.003, true, true, stdrand.NewSource(3))).Create(al.G),
+		relabeledGraph(petersen, identity),
+		gogl.NullGraph,
+	}
+
+	for i, g := range graphs {
+		want := canonicalPartition(ConnectedComponents(g))
+		for _, workers := range []int{0, 1, 2, 3, 8, 1000} {
+			got := canonicalPartition(ConnectedComponentsParallel(g, workers))
+			c.Assert(got, DeepEquals, want, Commentf("graph %d, %d workers", i, workers))
+		}
+	}
+}
+
+func (s *ComponentsSuite) TestStronglyConnected(c *C) {
+	// Two cycles, a-b-c and d-e, joined by c->d, with f hanging off e.
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "a"),
+		gogl.NewArc("c", "d"),
+		gogl.NewArc("d", "e"),
+		gogl.NewArc("e", "d"),
+		gogl.NewArc("e", "f"),
+	}).Create(al.G).(gogl.Digraph)
+
+	sccs := StronglyConnectedComponents(g)
+	c.Assert(canonicalPartition(sccs), DeepEquals, []string{"[a b c]", "[d e]", "[f]"})
+	// Sinks come first.
+	c.Assert(canonicalPartition(sccs[:1]), DeepEquals, []string{"[f]"})
+	c.Assert(canonicalPartition(sccs[2:]), DeepEquals, []string{"[a b c]"})
+
+	cg := CondensationGraph(g)
+	c.Assert(gogl.Order(cg), Equals, 3)
+	c.Assert(gogl.Size(cg), Equals, 2)
+	c.Assert(cg.HasArc(gogl.NewArc(2, 1)), Equals, true)
+	c.Assert(cg.HasArc(gogl.NewArc(1, 0)), Equals, true)
+}
+
+func (s *ComponentsSuite) TestStronglyConnectedRandom(c *C) {
+	for seed := int64(1); seed <= 5; seed++ {
+		g := randomDigraph(40, 0.04, seed)
+		sccs := StronglyConnectedComponents(g)
+
+		position := make(map[gogl.Vertex]int)
+		var count int
+		for i, scc := range sccs {
+			count += len(scc)
+			for _, v := range scc {
+				position[v] = i
+			}
+		}
+		c.Assert(count, Equals, gogl.Order(g))
+
+		// Vertices share a component iff each reaches the other.
+		reach := make(map[gogl.Vertex]map[interface{}]int)
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			reach[v], _, _ = bfsDistances(g, v, -1)
+			return
+		})
+		for u := range reach {
+			for v := range reach {
+				_, uv := reach[u][v]
+				_, vu := reach[v][u]
+				c.Assert(position[u] == position[v], Equals, uv && vu, Commentf("seed %d, %v and %v", seed, u, v))
+			}
+		}
+
+		cg := CondensationGraph(g)
+		c.Assert(IsDAG(cg), Equals, true)
+		cg.Arcs(func(a gogl.Arc) (terminate bool) {
+			c.Assert(a.Source().(int) > a.Target().(int), Equals, true, Commentf("seed %d", seed))
+			return
+		})
+	}
+}
+
+func (s *ComponentsSuite) TestStronglyConnectedDeep(c *C) {
+	// A single long cycle, which a recursive search would follow to its full depth.
+	const n = 100000
+	var arcs gogl.ArcList
+	for i := 0; i < n; i++ {
+		arcs = append(arcs, gogl.NewArc(i, (i+1)%n))
+	}
+	g := gogl.Spec().Directed().Using(arcs).Create(al.G).(gogl.Digraph)
+
+	sccs := StronglyConnectedComponents(g)
+	c.Assert(sccs, HasLen, 1)
+	c.Assert(sccs[0], HasLen, n)
+}
+
+func BenchmarkConnectedComponents(b *testing.B) {
+	g := sparseComponents(200000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ConnectedComponents(g)
+	}
+}
+
+func BenchmarkConnectedComponentsParallel(b *testing.B) {
+	g := sparseComponents(200000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ConnectedComponentsParallel(g, 0)
+	}
+}
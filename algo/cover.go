@@ -0,0 +1,190 @@
+package algo
+
+import (
+	"math"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Finds a vertex cover of the graph - a set of vertices touching every edge - of
+// low total weight, with each vertex weighing as vertexWeight says. Returned are
+// the cover and its weight. Weights must be non-negative. The vertices not in a
+// cover form an independent set, and the heavier the one, the lighter the other.
+//
+// For bipartite graphs, the cover is exact: by König's theorem, a minimum weight
+// cover corresponds to a minimum cut in a flow network with an arc from the source
+// to each vertex on one side, weighted as the vertex, an arc of infinite capacity
+// along each edge, and an arc from each vertex on the other side to the sink.
+//
+// Otherwise, the problem is NP-hard, and a 2-approximation is found with the local
+// ratio method of Bar-Yehuda and Even: each edge in turn lowers the residual weight
+// of both its endpoints by the lesser of the two, and the cover is the vertices
+// whose residual weight has been exhausted. This matches the guarantee of rounding
+// the LP relaxation, without the need to solve it.
+//
+// Edge direction is ignored. A self-loop can only be covered by its own vertex, so
+// such vertices are always in the cover.
+func MinWeightVertexCover(g gogl.Graph, vertexWeight func(gogl.Vertex) float64) ([]gogl.Vertex, float64) {
+	vi := gogl.NewVertexIndex(g)
+	if colors, bipartite := twoColor(g, vi); bipartite {
+		return bipartiteCover(g, vi, colors, vertexWeight)
+	}
+
+	residual := make([]float64, vi.Len())
+	for i, v := range vi.List() {
+		residual[i] = vertexWeight(v)
+	}
+
+	// Loops go first: their vertices are in the cover regardless, and exhausting
+	// them early keeps other edges from needlessly paying down their neighbors.
+	touched := make([]bool, vi.Len())
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		if u, v := e.Both(); gogl.VertexKey(u) == gogl.VertexKey(v) {
+			a := vi.IndexOf(u)
+			touched[a] = true
+			residual[a] = 0
+		}
+		return
+	})
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		a, b := vi.IndexOf(u), vi.IndexOf(v)
+		touched[a], touched[b] = true, true
+
+		eps := math.Min(residual[a], residual[b])
+		residual[a] -= eps
+		residual[b] -= eps
+		return
+	})
+
+	var cover []gogl.Vertex
+	var weight float64
+	for i, v := range vi.List() {
+		if touched[i] && residual[i] == 0 {
+			cover = append(cover, v)
+			weight += vertexWeight(v)
+		}
+	}
+
+	return cover, weight
+}
+
+// Finds an exact minimum weight vertex cover of a bipartite graph by minimum cut,
+// given a 2-coloring of it by vertex id.
+func bipartiteCover(g gogl.Graph, vi *gogl.VertexIndex, colors []int, vertexWeight func(gogl.Vertex) float64) ([]gogl.Vertex, float64) {
+	n := vi.Len()
+	source, sink := n, n+1
+
+	fn := newFlowNetwork(n + 2)
+	for i, v := range vi.List() {
+		if colors[i] == 1 {
+			fn.addArc(i, sink, vertexWeight(v))
+		} else {
+			fn.addArc(source, i, vertexWeight(v))
+		}
+	}
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		a, b := vi.IndexOf(u), vi.IndexOf(v)
+		if colors[a] == 1 {
+			a, b = b, a
+		}
+		fn.addArc(a, b, math.Inf(1))
+		return
+	})
+
+	fn.maxFlow(source, sink, math.Inf(1))
+	reachable := fn.residualReach(source)
+
+	// The cut separates the source's side from the sink's. A source-side vertex is
+	// in the cover if its arc from the source was cut, and a sink-side vertex if
+	// its arc to the sink was.
+	var cover []gogl.Vertex
+	var weight float64
+	for i, v := range vi.List() {
+		if reachable[i] == (colors[i] == 1) {
+			cover = append(cover, v)
+			weight += vertexWeight(v)
+		}
+	}
+
+	return cover, weight
+}
+
+// A flow network over vertices numbered [0, n), stored as paired residual arcs.
+type flowNetwork struct {
+	arcs [][]int // indices into to and cap of each vertex's arcs
+	to   []int
+	cap  []float64 // residual capacity; arc i^1 is the reverse of arc i
+}
+
+func newFlowNetwork(n int) *flowNetwork {
+	return &flowNetwork{arcs: make([][]int, n)}
+}
+
+func (fn *flowNetwork) addArc(u, v int, capacity float64) {
+	fn.arcs[u] = append(fn.arcs[u], len(fn.to))
+	fn.to, fn.cap = append(fn.to, v), append(fn.cap, capacity)
+	fn.arcs[v] = append(fn.arcs[v], len(fn.to))
+	fn.to, fn.cap = append(fn.to, u), append(fn.cap, 0)
+}
+
+// Pushes a maximum flow from s to t with the Edmonds-Karp algorithm, returning its
+// value. Augmentation stops early once the flow reaches limit, for callers that
+// only need to know whether it can; the flow may then exceed limit by up to the
+// capacity of the last augmenting path.
+func (fn *flowNetwork) maxFlow(s, t int, limit float64) float64 {
+	var total float64
+	for total < limit {
+		// Breadth-first search for a shortest augmenting path, recording the arc
+		// by which each vertex was reached.
+		via := make([]int, len(fn.arcs))
+		for i := range via {
+			via[i] = -1
+		}
+		queue := []int{s}
+		for len(queue) > 0 && via[t] == -1 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, a := range fn.arcs[u] {
+				if v := fn.to[a]; fn.cap[a] > 0 && v != s && via[v] == -1 {
+					via[v] = a
+					queue = append(queue, v)
+				}
+			}
+		}
+
+		if via[t] == -1 {
+			return total
+		}
+
+		push := math.Inf(1)
+		for v := t; v != s; v = fn.to[via[v]^1] {
+			push = math.Min(push, fn.cap[via[v]])
+		}
+		for v := t; v != s; v = fn.to[via[v]^1] {
+			fn.cap[via[v]] -= push
+			fn.cap[via[v]^1] += push
+		}
+		total += push
+	}
+	return total
+}
+
+// Marks the vertices reachable from s through arcs with residual capacity.
+func (fn *flowNetwork) residualReach(s int) []bool {
+	reached := make([]bool, len(fn.arcs))
+	reached[s] = true
+	stack := []int{s}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, a := range fn.arcs[u] {
+			if v := fn.to[a]; fn.cap[a] > 0 && !reached[v] {
+				reached[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+	return reached
+}
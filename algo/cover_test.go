@@ -0,0 +1,141 @@
+package algo
+
+import (
+	"math"
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type CoverSuite struct{}
+
+var _ = Suite(&CoverSuite{})
+
+// Checks that the cover touches every edge, and that its weight is as reported.
+func checkCover(c *C, g gogl.Graph, cover []gogl.Vertex, weight float64, vertexWeight func(gogl.Vertex) float64) {
+	in := make(map[gogl.Vertex]bool)
+	var sum float64
+	for _, v := range cover {
+		in[v] = true
+		sum += vertexWeight(v)
+	}
+	c.Assert(math.Abs(sum-weight) < 1e-9, Equals, true, Commentf("reported weight %v, actual %v", weight, sum))
+
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		c.Assert(in[u] || in[v], Equals, true, Commentf("edge %v is not covered", e))
+		return
+	})
+}
+
+// Finds the weight of a minimum weight vertex cover by trying every subset.
+func bruteForceCover(g gogl.Graph, vertexWeight func(gogl.Vertex) float64) float64 {
+	vs := gogl.CollectVertices(g)
+	best := math.Inf(1)
+	for set := 0; set < 1<<uint(len(vs)); set++ {
+		in := make(map[gogl.Vertex]bool)
+		var weight float64
+		for i, v := range vs {
+			if set&(1<<uint(i)) != 0 {
+				in[v] = true
+				weight += vertexWeight(v)
+			}
+		}
+
+		covers := true
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			covers = in[u] || in[v]
+			return !covers
+		})
+		if covers && weight < best {
+			best = weight
+		}
+	}
+	return best
+}
+
+func (s *CoverSuite) TestBipartiteKnown(c *C) {
+	// Workers on the left, tasks on the right. The expensive worker a is best
+	// avoided by covering its three tasks, while b and c are cheaper to take
+	// themselves than their tasks.
+	g := relabeledGraph([][2]int{{1, 10}, {1, 11}, {1, 12}, {2, 12}, {2, 13}, {3, 13}, {3, 14}}, identity)
+	weights := map[gogl.Vertex]float64{1: 10, 2: 2, 3: 1, 10: 1, 11: 2, 12: 3, 13: 4, 14: 5}
+	weightOf := func(v gogl.Vertex) float64 { return weights[v] }
+
+	cover, weight := MinWeightVertexCover(g, weightOf)
+	checkCover(c, g, cover, weight, weightOf)
+	c.Assert(weight, Equals, 9.0)
+	c.Assert(weight, Equals, bruteForceCover(g, weightOf))
+}
+
+func (s *CoverSuite) TestBipartiteRandom(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		var pairs [][2]int
+		for u := 0; u < 5; u++ {
+			for v := 5; v < 10; v++ {
+				if r.Intn(3) == 0 {
+					pairs = append(pairs, [2]int{u, v})
+				}
+			}
+		}
+		if len(pairs) == 0 {
+			continue
+		}
+		g := relabeledGraph(pairs, identity)
+
+		weights := make(map[gogl.Vertex]float64)
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			weights[v] = float64(r.Intn(10))
+			return
+		})
+		weightOf := func(v gogl.Vertex) float64 { return weights[v] }
+
+		cover, weight := MinWeightVertexCover(g, weightOf)
+		checkCover(c, g, cover, weight, weightOf)
+		c.Assert(weight, Equals, bruteForceCover(g, weightOf), Commentf("trial %d", i))
+	}
+}
+
+func (s *CoverSuite) TestApproximation(c *C) {
+	r := stdrand.New(stdrand.NewSource(2))
+	for i := 0; i < 50; i++ {
+		var pairs [][2]int
+		for u := 0; u < 10; u++ {
+			for v := u + 1; v < 10; v++ {
+				if r.Intn(3) == 0 {
+					pairs = append(pairs, [2]int{u, v})
+				}
+			}
+		}
+		// A triangle ensures the graph is not bipartite.
+		pairs = append(pairs, [2]int{0, 1}, [2]int{1, 2}, [2]int{2, 0})
+		g := relabeledGraph(pairs, identity)
+
+		weights := make(map[gogl.Vertex]float64)
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			weights[v] = 1 + float64(r.Intn(10))
+			return
+		})
+		weightOf := func(v gogl.Vertex) float64 { return weights[v] }
+
+		cover, weight := MinWeightVertexCover(g, weightOf)
+		checkCover(c, g, cover, weight, weightOf)
+		c.Assert(weight <= 2*bruteForceCover(g, weightOf), Equals, true, Commentf("trial %d", i))
+	}
+}
+
+func (s *CoverSuite) TestLoops(c *C) {
+	g := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "a"),
+		gogl.NewEdge("a", "b"),
+	}).Create(al.G)
+	weightOf := func(v gogl.Vertex) float64 { return map[gogl.Vertex]float64{"a": 5, "b": 1}[v] }
+
+	cover, weight := MinWeightVertexCover(g, weightOf)
+	c.Assert(cover, DeepEquals, []gogl.Vertex{"a"})
+	c.Assert(weight, Equals, 5.0)
+}
@@ -0,0 +1,102 @@
+package algo
+
+import (
+	"fmt"
+	"math"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type CurrentFlowSuite struct{}
+
+var _ = Suite(&CurrentFlowSuite{})
+
+func unitWeighted(pairs [][2]int) gogl.WeightedGraph {
+	el := make(gogl.WeightedEdgeList, 0, len(pairs))
+	for _, p := range pairs {
+		el = append(el, gogl.NewWeightedEdge(p[0], p[1], 1))
+	}
+	return gogl.Spec().Weighted().Using(el).Create(al.G).(gogl.WeightedGraph)
+}
+
+// Derives each vertex's shortest path betweenness from the load on its edges:
+// every pair routed through the vertex loads two of its edges, while every pair
+// it ends loads one.
+func shortestPathBetweenness(g gogl.Graph) map[gogl.Vertex]float64 {
+	b := make(map[gogl.Vertex]float64)
+	for e, load := range EdgeLoad(g) {
+		u, v := e[0], e[1]
+		b[u] += load / 2
+		b[v] += load / 2
+	}
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		reach, _, _ := bfsDistances(g, v, -1)
+		b[v] -= float64(len(reach)-1) / 2
+		return
+	})
+	return b
+}
+
+func (s *CurrentFlowSuite) TestSingleRoutes(c *C) {
+	// With only one route between each pair, the current follows the shortest
+	// path, and the two betweennesses agree.
+	for _, pairs := range [][][2]int{
+		{{1, 2}, {2, 3}, {3, 4}, {4, 5}},
+		{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {4, 5}},
+		{{1, 2}, {3, 4}, {4, 5}},
+	} {
+		g := unitWeighted(pairs)
+		cf, sp := CurrentFlowBetweenness(g), shortestPathBetweenness(g)
+		c.Assert(cf, HasLen, len(sp))
+		for v, want := range sp {
+			c.Assert(math.Abs(cf[v]-want) < 1e-9, Equals, true, Commentf("%v: got %v, want %v", v, cf[v], want))
+		}
+	}
+
+	// On a cycle, the current between each pair splits across the two arcs
+	// joining them in inverse proportion to their lengths. On one of five, the
+	// shares passing any vertex from the six pairs of others are 1/5, 2/5, 3/5,
+	// 1/5, 2/5 and 1/5, which sum to 2.
+	cycle := unitWeighted([][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}})
+	cf := CurrentFlowBetweenness(cycle)
+	for v := 0; v < 5; v++ {
+		c.Assert(math.Abs(cf[v]-2) < 1e-9, Equals, true, Commentf("%v: %v", v, cf[v]))
+	}
+}
+
+func (s *CurrentFlowSuite) TestDetour(c *C) {
+	// Two cliques of five, joined by an edge between l0 and r0, and by a detour
+	// through c. Being one step longer, the detour lies on no shortest path, but
+	// it still carries a third of the current between the cliques.
+	var edges gogl.WeightedEdgeList
+	for i := 0; i < 5; i++ {
+		for j := i + 1; j < 5; j++ {
+			edges = append(edges, gogl.NewWeightedEdge(fmt.Sprint("l", i), fmt.Sprint("l", j), 1))
+			edges = append(edges, gogl.NewWeightedEdge(fmt.Sprint("r", i), fmt.Sprint("r", j), 1))
+		}
+	}
+	edges = append(edges,
+		gogl.NewWeightedEdge("l0", "r0", 1),
+		gogl.NewWeightedEdge("l0", "c", 1),
+		gogl.NewWeightedEdge("c", "r0", 1),
+	)
+	g := gogl.Spec().Weighted().Using(edges).Create(al.G).(gogl.WeightedGraph)
+
+	cf, sp := CurrentFlowBetweenness(g), shortestPathBetweenness(g)
+	c.Assert(sp["c"], Equals, float64(0))
+	c.Assert(cf["c"] > 8, Equals, true, Commentf("%v", cf["c"]))
+
+	// Shortest paths rank c no higher than the cliques' other members, which lie
+	// on no shortest paths either; current flow ranks it well above them, and
+	// below only the ends of the bridge.
+	for _, side := range []string{"l", "r"} {
+		for i := 1; i < 5; i++ {
+			v := fmt.Sprint(side, i)
+			c.Assert(sp[v], Equals, sp["c"])
+			c.Assert(cf["c"] > cf[v], Equals, true, Commentf("%v: %v", v, cf[v]))
+		}
+		c.Assert(cf[side+"0"] > cf["c"], Equals, true)
+	}
+}
@@ -0,0 +1,98 @@
+package algo
+
+import "github.com/sdboyer/gogl"
+
+// Indicates whether the graph contains a cycle. A self-loop is a cycle, as is a
+// pair of parallel edges in an undirected multigraph.
+//
+// For a Digraph, this is whether FindCycle finds one. Otherwise, edges are
+// merged into a disjoint-set forest one at a time, and a cycle exists iff some
+// edge joins two vertices that are already connected. Either way, this takes
+// near-linear time.
+func HasCycle(g gogl.Graph) bool {
+	if dg, directed := g.(gogl.Digraph); directed {
+		_, found := FindCycle(dg)
+		return found
+	}
+
+	vi := gogl.NewVertexIndex(g)
+	ds := newDisjointSet(vi.Len())
+	var cyclic bool
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		cyclic = !ds.union(vi.IndexOf(u), vi.IndexOf(v))
+		return cyclic
+	})
+	return cyclic
+}
+
+// Finds a directed cycle in the digraph, if it has one, returning its vertices in
+// the order the cycle visits them. As for IsCycle, the sequence closes the cycle
+// explicitly, ending with the vertex it starts with; a self-loop on v is [v, v].
+// Where a dependency graph is expected to be acyclic, the cycle makes for a
+// readable error.
+//
+// A depth-first search, run iteratively, marks the vertices on its current path;
+// an arc back to one of them closes a cycle, which is read off the path. This
+// takes O(V+E) time. Which cycle is found, if there are several, depends on the
+// order of enumeration.
+func FindCycle(g gogl.Digraph) ([]gogl.Vertex, bool) {
+	const (
+		onPath = iota + 1
+		done
+	)
+	state := make(map[interface{}]int, sizeHint(g)) // keyed by gogl.VertexKey
+
+	type frame struct {
+		v    gogl.Vertex
+		succ []gogl.Vertex
+	}
+
+	var cycle []gogl.Vertex
+	g.Vertices(func(root gogl.Vertex) (terminate bool) {
+		if state[gogl.VertexKey(root)] != 0 {
+			return
+		}
+
+		enter := func(v gogl.Vertex) frame {
+			state[gogl.VertexKey(v)] = onPath
+			f := frame{v: v}
+			g.ArcsFrom(v, func(a gogl.Arc) (terminate bool) {
+				f.succ = append(f.succ, a.Target())
+				return
+			})
+			return f
+		}
+
+		path := []frame{enter(root)}
+		for len(path) > 0 {
+			top := &path[len(path)-1]
+			if len(top.succ) == 0 {
+				state[gogl.VertexKey(top.v)] = done
+				path = path[:len(path)-1]
+				continue
+			}
+
+			w := top.succ[0]
+			top.succ = top.succ[1:]
+			switch state[gogl.VertexKey(w)] {
+			case 0:
+				path = append(path, enter(w))
+			case onPath:
+				// w is on the path; the cycle runs from it to the top, and back.
+				i := len(path) - 1
+				for gogl.VertexKey(path[i].v) != gogl.VertexKey(w) {
+					i--
+				}
+				for _, f := range path[i:] {
+					cycle = append(cycle, f.v)
+				}
+				cycle = append(cycle, w)
+				return true
+			}
+		}
+		return
+	})
+
+	return cycle, cycle != nil
+}
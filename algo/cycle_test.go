@@ -0,0 +1,78 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type CycleSuite struct{}
+
+var _ = Suite(&CycleSuite{})
+
+func (s *CycleSuite) TestUndirected(c *C) {
+	tree := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("a", "c"),
+		gogl.NewEdge("c", "d"),
+		gogl.NewEdge("x", "y"),
+	}).Create(al.G).(gogl.MutableGraph)
+	c.Assert(HasCycle(tree), Equals, false)
+
+	tree.AddEdges(gogl.NewEdge("d", "b"))
+	c.Assert(HasCycle(tree), Equals, true)
+
+	loop := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "a"),
+	}).Create(al.G)
+	c.Assert(HasCycle(loop), Equals, true)
+
+	c.Assert(HasCycle(gogl.NullGraph), Equals, false)
+}
+
+func (s *CycleSuite) TestDirected(c *C) {
+	// A diamond has no directed cycle, though its underlying graph does.
+	g := gogl.Spec().Directed().Loop().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("a", "c"),
+		gogl.NewArc("b", "d"),
+		gogl.NewArc("c", "d"),
+	}).Create(al.G)
+	dg := g.(gogl.Digraph)
+
+	cycle, found := FindCycle(dg)
+	c.Assert(found, Equals, false)
+	c.Assert(cycle, IsNil)
+	c.Assert(HasCycle(g), Equals, false)
+
+	g.(gogl.MutableDigraph).AddArcs(gogl.NewArc("d", "a"))
+	cycle, found = FindCycle(dg)
+	c.Assert(found, Equals, true)
+	c.Assert(cycle, HasLen, 4)
+	c.Assert(IsCycle(g, cycle), Equals, true, Commentf("%v", cycle))
+	c.Assert(HasCycle(g), Equals, true)
+
+	g.(gogl.MutableDigraph).RemoveArcs(gogl.NewArc("d", "a"))
+	g.(gogl.MutableDigraph).AddArcs(gogl.NewArc("c", "c"))
+	cycle, found = FindCycle(dg)
+	c.Assert(found, Equals, true)
+	c.Assert(cycle, DeepEquals, []gogl.Vertex{"c", "c"})
+}
+
+func (s *CycleSuite) TestRandom(c *C) {
+	for seed := int64(1); seed <= 10; seed++ {
+		g := randomDigraph(30, 0.05, seed)
+		cycle, found := FindCycle(g)
+		c.Assert(found, Equals, !IsDAG(g), Commentf("seed %d", seed))
+		if found {
+			c.Assert(IsCycle(g, cycle), Equals, true, Commentf("seed %d: %v", seed, cycle))
+		}
+
+		ug := gogl.Spec().Using(rand.BernoulliDistribution(30, 0.06, false, true, stdrand.NewSource(seed))).Create(al.G)
+		components := len(ConnectedComponents(ug))
+		c.Assert(HasCycle(ug), Equals, gogl.Size(ug) > gogl.Order(ug)-components, Commentf("seed %d", seed))
+	}
+}
@@ -0,0 +1,189 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Indicates whether the given digraph is acyclic.
+//
+// Kahn's algorithm is used: vertices with no remaining in-arcs are repeatedly
+// removed, and the graph is acyclic iff this removes every vertex. A loop is a
+// cycle.
+func IsDAG(g gogl.Digraph) bool {
+	order, _, indegree := kahnOrder(g)
+	return len(order) == len(indegree)
+}
+
+// Runs Kahn's algorithm on g, driven by InDegreeOf and ArcsFrom, returning the
+// vertices in the order they were removed, and the in-degree, keyed by
+// gogl.VertexKey, of each vertex that remained when it stopped.
+//
+// Vertices are removed in rounds: first the sources, in the order g enumerates
+// them, then the vertices whose last in-arcs came from the first round, and so
+// on. The removal order is thus topologically sorted, and rounds holds the index
+// in it at which each round ends. If g has a cycle, the vertices on or
+// downstream of it are never removed, so are missing from the order, and keep a
+// positive in-degree.
+func kahnOrder(g gogl.Digraph) (order []gogl.Vertex, rounds []int, indegree map[interface{}]int) {
+	indegree = make(map[interface{}]int, sizeHint(g))
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		d, _ := g.InDegreeOf(v)
+		indegree[gogl.VertexKey(v)] = d
+		if d == 0 {
+			order = append(order, v)
+		}
+		return
+	})
+
+	for start := 0; start < len(order); {
+		end := len(order)
+		for _, v := range order[start:end] {
+			g.ArcsFrom(v, func(a gogl.Arc) (terminate bool) {
+				w := a.Target()
+				k := gogl.VertexKey(w)
+				if indegree[k]--; indegree[k] == 0 {
+					order = append(order, w)
+				}
+				return
+			})
+		}
+		rounds = append(rounds, end)
+		start = end
+	}
+
+	return order, rounds, indegree
+}
+
+// Partitions the vertices of the given DAG into generations, which form a
+// schedule of maximum parallelism. The first generation holds the vertices with
+// no predecessors. Each later generation holds the vertices whose predecessors
+// all fall in earlier generations. Each generation holds every such vertex not
+// already placed. If the arcs are dependencies between tasks, all the tasks of a
+// generation may run at once, as soon as the previous generation has finished.
+//
+// This is Kahn's algorithm run in rounds. Each vertex is placed as early as its
+// predecessors allow, so the number of generations is one more than the length
+// of the longest path. The order of vertices within a generation is not
+// meaningful. An error is returned if the graph has a cycle.
+func Generations(g gogl.Digraph) ([][]gogl.Vertex, error) {
+	order, rounds, indegree := kahnOrder(g)
+	if len(order) < len(indegree) {
+		return nil, errors.New("Graph is not acyclic.")
+	}
+
+	generations := make([][]gogl.Vertex, len(rounds))
+	var start int
+	for i, end := range rounds {
+		generations[i] = order[start:end:end]
+		start = end
+	}
+	return generations, nil
+}
+
+// Returned by TopologicalSort when the digraph has a cycle. Vertices holds the
+// vertices that lie on some cycle, in the order g enumerates them; vertices
+// merely downstream of a cycle, which also could not be sorted, are left out.
+type ErrCyclic struct {
+	Vertices []gogl.Vertex
+}
+
+func (e ErrCyclic) Error() string {
+	return fmt.Sprintf("Graph is not acyclic; %d vertices lie on cycles.", len(e.Vertices))
+}
+
+// Sorts the vertices of the given digraph topologically, such that every arc
+// leads from an earlier vertex to a later one.
+//
+// Kahn's algorithm is used, driven by InDegreeOf and ArcsFrom: vertices with no
+// remaining in-arcs are emitted first-in, first-out, beginning with the sources
+// in the order g enumerates its vertices, and each emitted vertex's arcs are
+// discounted from its successors. The result is therefore deterministic if g's
+// own enumeration is. If some vertices are never emitted, g has a cycle, and an
+// ErrCyclic naming the vertices on cycles is returned; a loop is a cycle.
+func TopologicalSort(g gogl.Digraph) ([]gogl.Vertex, error) {
+	order, _, indegree := kahnOrder(g)
+	if len(order) == len(indegree) {
+		return order, nil
+	}
+
+	// A vertex is on a cycle iff its strongly connected component has another
+	// vertex in it, or it has a loop.
+	scc := stronglyConnected(g)
+	size := make(map[int]int)
+	for _, c := range scc {
+		size[c]++
+	}
+	var cyclic []gogl.Vertex
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		k := gogl.VertexKey(v)
+		if indegree[k] > 0 && (size[scc[k]] > 1 || hasLoop(g, v)) {
+			cyclic = append(cyclic, v)
+		}
+		return
+	})
+	return nil, ErrCyclic{cyclic}
+}
+
+// Indicates whether v has an arc to itself.
+func hasLoop(g gogl.Digraph, v gogl.Vertex) (loop bool) {
+	g.ArcsFrom(v, func(a gogl.Arc) (terminate bool) {
+		loop = gogl.VertexKey(a.Target()) == gogl.VertexKey(v)
+		return loop
+	})
+	return
+}
+
+// Orients every edge of the given graph from the endpoint earlier in order to the
+// one later in it, returning the resulting digraph. As all arcs point forward
+// along a single ordering, the result is always acyclic.
+//
+// Vertices absent from order are placed after those present, in an arbitrary
+// sequence; vertices listed more than once keep their first position. If order is
+// nil, a degeneracy ordering is used, in which each vertex has few neighbors
+// later than itself; this keeps the out-degrees of the result small.
+//
+// Should g be a Digraph, its arcs are reoriented, with the arcs of any 2-cycle
+// collapsing into one. Self-loops are dropped, as they cannot be oriented
+// acyclically. The result is a mutable adjacency list.
+func AcyclicOrientation(g gogl.Graph, order []gogl.Vertex) gogl.Digraph {
+	if order == nil {
+		adj := undirectedAdjacency(g)
+		ids, _ := degeneracyOrder(adj)
+		order = adj.vertices(ids)
+	}
+
+	rank := make(map[interface{}]int, len(order)) // keyed by gogl.VertexKey
+	for _, v := range order {
+		if _, ranked := rank[gogl.VertexKey(v)]; !ranked && g.HasVertex(v) {
+			rank[gogl.VertexKey(v)] = len(rank)
+		}
+	}
+
+	dg := gogl.Spec().Directed().Create(al.G).(gogl.MutableDigraph)
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		if _, ranked := rank[gogl.VertexKey(v)]; !ranked {
+			rank[gogl.VertexKey(v)] = len(rank)
+		}
+		dg.EnsureVertex(v)
+		return
+	})
+
+	arcs := make([]gogl.Arc, 0, gogl.Size(g))
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		switch ru, rv := rank[gogl.VertexKey(u)], rank[gogl.VertexKey(v)]; {
+		case ru < rv:
+			arcs = append(arcs, gogl.NewArc(u, v))
+		case rv < ru:
+			arcs = append(arcs, gogl.NewArc(v, u))
+		}
+		return
+	})
+	dg.AddArcs(arcs...)
+
+	return dg.(gogl.Digraph)
+}
@@ -0,0 +1,235 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type DAGSuite struct{}
+
+var _ = Suite(&DAGSuite{})
+
+func (s *DAGSuite) TestIsDAG(c *C) {
+	dag := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("a", "c"),
+		gogl.NewArc("b", "d"),
+		gogl.NewArc("c", "d"),
+	}).Create(al.G).(gogl.Digraph)
+	c.Assert(IsDAG(dag), Equals, true)
+
+	cyclic := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "a"),
+		gogl.NewArc("c", "d"),
+	}).Create(al.G).(gogl.Digraph)
+	c.Assert(IsDAG(cyclic), Equals, false)
+
+	loop := gogl.Spec().Directed().Loop().Using(gogl.ArcList{
+		gogl.NewArc("a", "a"),
+	}).Create(al.G).(gogl.Digraph)
+	c.Assert(IsDAG(loop), Equals, false)
+}
+
+func (s *DAGSuite) TestOrientation(c *C) {
+	g := relabeledGraph(petersen, identity)
+	order := gogl.CollectVertices(g)
+
+	dg := AcyclicOrientation(g, order)
+	c.Assert(IsDAG(dg), Equals, true)
+	c.Assert(gogl.Order(dg), Equals, 10)
+	c.Assert(gogl.Size(dg), Equals, 15)
+
+	rank := make(map[gogl.Vertex]int)
+	for i, v := range order {
+		rank[v] = i
+	}
+	dg.Arcs(func(a gogl.Arc) (terminate bool) {
+		c.Assert(rank[a.Source()] < rank[a.Target()], Equals, true, Commentf("arc %v", a))
+		c.Assert(g.HasEdge(a), Equals, true, Commentf("arc %v", a))
+		return
+	})
+
+	// Reversing the order reverses every arc.
+	reversed := make([]gogl.Vertex, len(order))
+	for i, v := range order {
+		reversed[len(order)-1-i] = v
+	}
+	rdg := AcyclicOrientation(g, reversed)
+	c.Assert(gogl.Size(rdg), Equals, 15)
+	dg.Arcs(func(a gogl.Arc) (terminate bool) {
+		c.Assert(rdg.HasArc(gogl.NewArc(a.Target(), a.Source())), Equals, true, Commentf("arc %v", a))
+		return
+	})
+}
+
+func (s *DAGSuite) TestDefaultOrder(c *C) {
+	g := gogl.Spec().Loop().Using(rand.BernoulliDistribution(100, 0.1, false, true, stdrand.NewSource(1))).Create(al.G).(gogl.MutableGraph)
+	g.AddEdges(gogl.NewEdge(0, 0))
+
+	dg := AcyclicOrientation(g, nil)
+	c.Assert(IsDAG(dg), Equals, true)
+	c.Assert(gogl.Order(dg), Equals, 100)
+	// Everything but the loops is oriented.
+	c.Assert(gogl.Size(dg), Equals, gogl.Size(g)-len(SelfLoops(g)))
+}
+
+func (s *DAGSuite) TestPartialOrder(c *C) {
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}}, identity)
+
+	// 4 is unlisted, so comes last; the repeated 3 keeps its first position; 42 is ignored.
+	dg := AcyclicOrientation(g, []gogl.Vertex{3, 42, 1, 3, 2})
+	c.Assert(IsDAG(dg), Equals, true)
+	for _, a := range []gogl.Arc{
+		gogl.NewArc(3, 2),
+		gogl.NewArc(1, 2),
+		gogl.NewArc(3, 4),
+		gogl.NewArc(1, 4),
+	} {
+		c.Assert(dg.HasArc(a), Equals, true, Commentf("arc %v", a))
+	}
+	c.Assert(dg.HasVertex(42), Equals, false)
+}
+
+func (s *DAGSuite) TestDigraphInput(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "a"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "a"),
+	}).Create(al.G)
+
+	dg := AcyclicOrientation(g, []gogl.Vertex{"c", "b", "a"})
+	c.Assert(IsDAG(dg), Equals, true)
+	// The a-b 2-cycle collapses into a single arc.
+	c.Assert(gogl.Size(dg), Equals, 3)
+	c.Assert(dg.HasArc(gogl.NewArc("b", "a")), Equals, true)
+	c.Assert(dg.HasArc(gogl.NewArc("c", "b")), Equals, true)
+}
+
+// Checks that the generations cover each vertex once, that each vertex's
+// predecessors all lie in earlier generations, and that each vertex past the
+// first generation has a predecessor in the one just before.
+func checkGenerations(c *C, g gogl.Digraph, generations [][]gogl.Vertex) {
+	gen := make(map[gogl.Vertex]int)
+	for i, vs := range generations {
+		c.Assert(vs, Not(HasLen), 0)
+		for _, v := range vs {
+			_, dup := gen[v]
+			c.Assert(dup, Equals, false, Commentf("vertex %v", v))
+			gen[v] = i
+		}
+	}
+	c.Assert(gen, HasLen, gogl.Order(g))
+
+	for v, i := range gen {
+		latest := -1
+		g.PredecessorsOf(v, func(u gogl.Vertex) (terminate bool) {
+			c.Assert(gen[u] < i, Equals, true, Commentf("%v precedes %v", u, v))
+			if gen[u] > latest {
+				latest = gen[u]
+			}
+			return
+		})
+		c.Assert(latest, Equals, i-1, Commentf("vertex %v", v))
+	}
+}
+
+func (s *DAGSuite) TestGenerations(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("fetch", "build"),
+		gogl.NewArc("configure", "build"),
+		gogl.NewArc("build", "test"),
+		gogl.NewArc("build", "docs"),
+		gogl.NewArc("test", "release"),
+		gogl.NewArc("docs", "release"),
+		gogl.NewArc("fetch", "lint"),
+	}).Create(al.G).(gogl.Digraph)
+
+	generations, err := Generations(g)
+	c.Assert(err, IsNil)
+	c.Assert(generations, HasLen, 4)
+	for i, want := range []string{"[configure fetch]", "[build lint]", "[docs test]", "[release]"} {
+		c.Assert(canonicalPartition(generations[i:i+1]), DeepEquals, []string{want})
+	}
+	checkGenerations(c, g, generations)
+}
+
+func (s *DAGSuite) TestGenerationsRandom(c *C) {
+	for seed := int64(1); seed <= 10; seed++ {
+		g := gogl.Spec().Using(rand.BernoulliDistribution(60, 0.08, false, true, stdrand.NewSource(seed))).Create(al.G)
+		dg := AcyclicOrientation(g, nil)
+
+		generations, err := Generations(dg)
+		c.Assert(err, IsNil)
+		checkGenerations(c, dg, generations)
+	}
+}
+
+func (s *DAGSuite) TestGenerationsCycle(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "b"),
+	}).Create(al.G).(gogl.Digraph)
+
+	_, err := Generations(g)
+	c.Assert(err, ErrorMatches, "Graph is not acyclic.")
+
+	generations, err := Generations(gogl.NullGraph)
+	c.Assert(err, IsNil)
+	c.Assert(generations, HasLen, 0)
+}
+
+func (s *DAGSuite) TestTopologicalSort(c *C) {
+	for seed := int64(1); seed <= 10; seed++ {
+		g := gogl.Spec().Using(rand.BernoulliDistribution(60, 0.08, false, true, stdrand.NewSource(seed))).Create(al.G)
+		dg := AcyclicOrientation(g, nil)
+
+		order, err := TopologicalSort(dg)
+		c.Assert(err, IsNil)
+		c.Assert(order, HasLen, gogl.Order(dg))
+
+		pos := make(map[gogl.Vertex]int)
+		for i, v := range order {
+			pos[v] = i
+		}
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			c.Assert(pos[a.Source()] < pos[a.Target()], Equals, true, Commentf("seed %d, arc %v", seed, a))
+			return
+		})
+	}
+}
+
+func (s *DAGSuite) TestTopologicalSortCycle(c *C) {
+	// b and c form a cycle; d lies downstream of it, and e of a loop.
+	g := gogl.Spec().Directed().Loop().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "b"),
+		gogl.NewArc("c", "d"),
+		gogl.NewArc("a", "e"),
+		gogl.NewArc("e", "e"),
+	}).Create(al.G).(gogl.Digraph)
+
+	order, err := TopologicalSort(g)
+	c.Assert(order, IsNil)
+	c.Assert(err, ErrorMatches, "Graph is not acyclic; 3 vertices lie on cycles.")
+
+	cyclic, ok := err.(ErrCyclic)
+	c.Assert(ok, Equals, true)
+	set := make(map[gogl.Vertex]bool)
+	for _, v := range cyclic.Vertices {
+		set[v] = true
+	}
+	c.Assert(set, DeepEquals, map[gogl.Vertex]bool{"b": true, "c": true, "e": true})
+
+	order, err = TopologicalSort(gogl.NullGraph)
+	c.Assert(err, IsNil)
+	c.Assert(order, HasLen, 0)
+}
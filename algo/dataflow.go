@@ -0,0 +1,64 @@
+package algo
+
+import (
+	"container/list"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Solves a forward dataflow problem over the given digraph by the classic
+// worklist algorithm, returning the value computed at each vertex. This is the
+// engine behind analyses like reaching definitions and constant propagation over
+// a control flow graph; for a backward analysis like liveness, pass the
+// transpose of the graph.
+//
+// Each vertex starts with the value given by init. The worklist is seeded with
+// every vertex; as each is taken from it, transfer computes a new value for it
+// from the current values of its predecessors, in no particular order. If equal
+// reports that the new value differs from the old, it is stored, and the vertex's
+// successors are put back on the worklist. This continues until the worklist is empty, at
+// which point transfer applied to any vertex yields its value again.
+//
+// Termination is the caller's responsibility: it is guaranteed if transfer is
+// monotone over values that can only change finitely many times, as in any
+// lattice of finite height. A transfer function that oscillates will loop
+// forever.
+func Fixpoint(g gogl.Digraph, init func(v gogl.Vertex) interface{}, transfer func(v gogl.Vertex, preds []interface{}) interface{}, equal func(a, b interface{}) bool) map[gogl.Vertex]interface{} {
+	values := make(map[gogl.Vertex]interface{}, sizeHint(g))
+	queued := make(map[gogl.Vertex]bool, sizeHint(g))
+	worklist := list.New()
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		values[v] = init(v)
+		queued[v] = true
+		worklist.PushBack(v)
+		return
+	})
+
+	for worklist.Len() > 0 {
+		v := worklist.Remove(worklist.Front())
+		queued[v] = false
+
+		var preds []interface{}
+		g.PredecessorsOf(v, func(u gogl.Vertex) (terminate bool) {
+			preds = append(preds, values[u])
+			return
+		})
+
+		next := transfer(v, preds)
+		if equal(next, values[v]) {
+			continue
+		}
+		values[v] = next
+
+		g.SuccessorsOf(v, func(w gogl.Vertex) (terminate bool) {
+			if !queued[w] {
+				queued[w] = true
+				worklist.PushBack(w)
+			}
+			return
+		})
+	}
+
+	return values
+}
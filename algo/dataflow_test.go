@@ -0,0 +1,107 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type FixpointSuite struct{}
+
+var _ = Suite(&FixpointSuite{})
+
+func randomDigraph(n uint, p float64, seed int64) gogl.Digraph {
+	return gogl.Spec().Directed().Using(rand.BernoulliDistribution(n, p, true, true, stdrand.NewSource(seed))).Create(al.G).(gogl.Digraph)
+}
+
+// Checks that the values are a fixpoint: transfer changes none of them.
+func checkFixpoint(c *C, g gogl.Digraph, values map[gogl.Vertex]interface{}, transfer func(gogl.Vertex, []interface{}) interface{}, equal func(a, b interface{}) bool) {
+	c.Assert(values, HasLen, gogl.Order(g))
+	for v, val := range values {
+		var preds []interface{}
+		g.PredecessorsOf(v, func(u gogl.Vertex) (terminate bool) {
+			preds = append(preds, values[u])
+			return
+		})
+		c.Assert(equal(transfer(v, preds), val), Equals, true, Commentf("vertex %v", v))
+	}
+}
+
+func (s *FixpointSuite) TestReachability(c *C) {
+	for seed := int64(1); seed <= 10; seed++ {
+		g := randomDigraph(40, 0.04, seed)
+		entry := gogl.CollectVertices(g)[0]
+		reaches, _ := ReachabilityMatrix(g)
+
+		var calls int
+		transfer := func(v gogl.Vertex, preds []interface{}) interface{} {
+			calls++
+			if v == entry {
+				return true
+			}
+			for _, p := range preds {
+				if p.(bool) {
+					return true
+				}
+			}
+			return false
+		}
+		equal := func(a, b interface{}) bool { return a == b }
+
+		values := Fixpoint(g, func(v gogl.Vertex) interface{} { return false }, transfer, equal)
+
+		for v, reached := range values {
+			c.Assert(reached, Equals, v == entry || reaches(entry, v), Commentf("seed %d, vertex %v", seed, v))
+		}
+		// Each vertex can change at most once, re-enqueueing each successor once.
+		c.Assert(calls <= gogl.Order(g)+gogl.Size(g), Equals, true, Commentf("seed %d: %d calls", seed, calls))
+		checkFixpoint(c, g, values, transfer, equal)
+	}
+}
+
+// A reaching-definitions style analysis, where each vertex defines itself: the
+// value at v is the set of vertices with a path to v.
+func (s *FixpointSuite) TestReachingSets(c *C) {
+	for seed := int64(1); seed <= 10; seed++ {
+		g := randomDigraph(30, 0.06, seed)
+		reaches, _ := ReachabilityMatrix(g)
+
+		init := func(v gogl.Vertex) interface{} {
+			return map[gogl.Vertex]bool{v: true}
+		}
+		transfer := func(v gogl.Vertex, preds []interface{}) interface{} {
+			out := map[gogl.Vertex]bool{v: true}
+			for _, p := range preds {
+				for u := range p.(map[gogl.Vertex]bool) {
+					out[u] = true
+				}
+			}
+			return out
+		}
+		// The sets only grow, so comparing sizes suffices.
+		equal := func(a, b interface{}) bool {
+			return len(a.(map[gogl.Vertex]bool)) == len(b.(map[gogl.Vertex]bool))
+		}
+
+		values := Fixpoint(g, init, transfer, equal)
+
+		for v, val := range values {
+			g.Vertices(func(u gogl.Vertex) (terminate bool) {
+				c.Assert(val.(map[gogl.Vertex]bool)[u], Equals, u == v || reaches(u, v), Commentf("seed %d, %v to %v", seed, u, v))
+				return
+			})
+		}
+		checkFixpoint(c, g, values, transfer, equal)
+	}
+}
+
+func (s *FixpointSuite) TestEmpty(c *C) {
+	values := Fixpoint(gogl.NullGraph,
+		func(v gogl.Vertex) interface{} { return 0 },
+		func(v gogl.Vertex, preds []interface{}) interface{} { return 0 },
+		func(a, b interface{}) bool { return a == b })
+	c.Assert(values, HasLen, 0)
+}
@@ -0,0 +1,74 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/multi"
+)
+
+// Removes duplicate edges from a labeled graph: edges that are identical in both
+// endpoints and label, as happen after unioning labeled graphs that share edges.
+// One of each set of duplicates is kept. Edges that share their endpoints but
+// differ in label are distinct parallel edges, and are all kept.
+//
+// Edges are compared as HasLabeledEdge compares them. In an undirected graph,
+// orientation is ignored, so (u, v, "x") duplicates (v, u, "x"). If g is a
+// Digraph, arcs are compared as HasLabeledArc does, so that opposing arcs with
+// the same label are both kept.
+//
+// The adjacency lists cannot hold parallel edges, so the result is a read-only
+// labeled multigraph from graph/multi, holding all of g's vertices. It is a
+// LabeledDigraph if g is a Digraph.
+func DedupLabeledEdges(g gogl.LabeledGraph) gogl.LabeledGraph {
+	dg, directed := g.(gogl.Digraph)
+
+	seen := make(map[labeledKey]bool)
+	var edges []gogl.Edge
+	keep := func(e gogl.Edge) {
+		u, v := e.Both()
+		k := newLabeledKey(u, v, labelOf(e))
+		if seen[k] || (!directed && seen[k.reverse()]) {
+			return
+		}
+		seen[k] = true
+		edges = append(edges, e)
+	}
+
+	spec := gogl.Spec().Immutable().Labeled().PseudoGraph()
+	if directed {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			keep(a)
+			return
+		})
+		spec = spec.Directed().Using(gogl.VertexArcList{V: gogl.CollectVertices(g), E: edges})
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			keep(e)
+			return
+		})
+		spec = spec.Using(gogl.VertexEdgeList{V: gogl.CollectVertices(g), E: edges})
+	}
+
+	return spec.Create(multi.G).(gogl.LabeledGraph)
+}
+
+// Returns the label of the given edge, or the empty string if it is unlabeled.
+func labelOf(e gogl.Edge) string {
+	if le, ok := e.(gogl.LabeledEdge); ok {
+		return le.Label()
+	}
+	return ""
+}
+
+// Identifies an edge by its endpoints, as oriented, and its label.
+type labeledKey struct {
+	u, v  interface{}
+	label string
+}
+
+func newLabeledKey(u, v gogl.Vertex, label string) labeledKey {
+	return labeledKey{gogl.VertexKey(u), gogl.VertexKey(v), label}
+}
+
+func (k labeledKey) reverse() labeledKey {
+	return labeledKey{k.v, k.u, k.label}
+}
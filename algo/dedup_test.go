@@ -0,0 +1,97 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/graph/multi"
+)
+
+type DedupSuite struct{}
+
+var _ = Suite(&DedupSuite{})
+
+func (s *DedupSuite) TestUndirected(c *C) {
+	edges := gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge("a", "b", "x"),
+		gogl.NewLabeledEdge("b", "a", "x"), // the same edge, seen from the other end
+		gogl.NewLabeledEdge("a", "b", "x"),
+		gogl.NewLabeledEdge("a", "b", "y"), // a label-distinct parallel
+		gogl.NewLabeledEdge("b", "c", "x"),
+		gogl.NewLabeledEdge("c", "c", "z"),
+		gogl.NewLabeledEdge("c", "c", "z"),
+	}
+	mg := gogl.Spec().Immutable().Labeled().PseudoGraph().Using(edges).Create(multi.G).(gogl.LabeledGraph)
+	c.Assert(gogl.Size(mg), Equals, 7)
+
+	g := DedupLabeledEdges(mg)
+	_, directed := g.(gogl.Digraph)
+	c.Assert(directed, Equals, false)
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(gogl.Size(g), Equals, 4)
+
+	for _, e := range []gogl.LabeledEdge{
+		gogl.NewLabeledEdge("b", "a", "x"),
+		gogl.NewLabeledEdge("a", "b", "y"),
+		gogl.NewLabeledEdge("c", "b", "x"),
+		gogl.NewLabeledEdge("c", "c", "z"),
+	} {
+		c.Assert(g.HasLabeledEdge(e), Equals, true, Commentf("edge %v", e))
+	}
+	c.Assert(g.HasLabeledEdge(gogl.NewLabeledEdge("b", "c", "y")), Equals, false)
+	c.Assert(g.HasEdge(gogl.NewEdge("c", "a")), Equals, false)
+
+	deg, _ := g.DegreeOf("b")
+	c.Assert(deg, Equals, 3)
+	c.Assert(collectAdjacent(g, "b"), DeepEquals, map[gogl.Vertex]int{"a": 1, "c": 1})
+}
+
+func (s *DedupSuite) TestDirected(c *C) {
+	arcs := gogl.LabeledArcList{
+		gogl.NewLabeledArc("a", "b", "x"),
+		gogl.NewLabeledArc("a", "b", "x"),
+		gogl.NewLabeledArc("b", "a", "x"), // opposing arcs are distinct
+		gogl.NewLabeledArc("a", "b", "y"),
+	}
+	g, ok := DedupLabeledEdges(gogl.Spec().Directed().Immutable().Labeled().PseudoGraph().Using(arcs).Create(multi.G).(gogl.LabeledGraph)).(gogl.LabeledDigraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(gogl.Size(g), Equals, 3)
+
+	c.Assert(g.HasLabeledArc(gogl.NewLabeledArc("a", "b", "x")), Equals, true)
+	c.Assert(g.HasLabeledArc(gogl.NewLabeledArc("b", "a", "x")), Equals, true)
+	c.Assert(g.HasLabeledArc(gogl.NewLabeledArc("a", "b", "y")), Equals, true)
+	c.Assert(g.HasLabeledArc(gogl.NewLabeledArc("b", "a", "y")), Equals, false)
+
+	out, _ := g.OutDegreeOf("a")
+	in, _ := g.InDegreeOf("a")
+	c.Assert([2]int{out, in}, Equals, [2]int{2, 1})
+
+	t := g.Transpose()
+	c.Assert(t.HasArc(gogl.NewArc("b", "a")), Equals, true)
+	c.Assert(t.(gogl.LabeledDigraph).HasLabeledArc(gogl.NewLabeledArc("b", "a", "y")), Equals, true)
+	c.Assert(t.(gogl.LabeledDigraph).HasLabeledArc(gogl.NewLabeledArc("a", "b", "y")), Equals, false)
+}
+
+func (s *DedupSuite) TestSimpleGraphUnchanged(c *C) {
+	src := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge(1, 2, "foo"),
+		gogl.NewLabeledEdge(2, 3, "bar"),
+	}).Create(al.G).(gogl.MutableLabeledGraph)
+	src.EnsureVertex(4)
+
+	g := DedupLabeledEdges(src)
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(gogl.Size(g), Equals, 2)
+	c.Assert(g.HasVertex(4), Equals, true)
+	c.Assert(g.HasLabeledEdge(gogl.NewLabeledEdge(3, 2, "bar")), Equals, true)
+}
+
+// Counts the times each vertex is passed as adjacent to v.
+func collectAdjacent(g gogl.Graph, v gogl.Vertex) map[gogl.Vertex]int {
+	counts := make(map[gogl.Vertex]int)
+	g.AdjacentTo(v, func(w gogl.Vertex) (terminate bool) {
+		counts[w]++
+		return
+	})
+	return counts
+}
@@ -0,0 +1,163 @@
+package algo
+
+import (
+	"math"
+	"sort"
+
+	"github.com/sdboyer/gogl"
+)
+
+// LoopMode selects how much a self-loop contributes to a vertex's weighted degree.
+type LoopMode int
+
+const (
+	// A loop contributes its weight twice, as it meets the vertex at both ends.
+	// This is the usual convention, and the one under which the degree sum
+	// formula holds: the degrees of all vertices sum to twice the total edge
+	// weight. In a Digraph, it amounts to counting the loop once as an out-arc
+	// and once as an in-arc.
+	LoopsTwice LoopMode = iota
+	// A loop contributes its weight once, as a single edge at the vertex.
+	LoopsOnce
+	// Loops contribute nothing.
+	LoopsIgnored
+)
+
+// Calculates the weighted degree, or strength, of the given vertex: the total
+// weight of its incident edges, with any self-loop counted per the given mode.
+// In a Digraph, both in- and out-arcs are counted. Edges that are not weighted
+// count as having weight 1. The second return value indicates whether the vertex
+// is present in the graph.
+func WeightedDegree(g gogl.Graph, v gogl.Vertex, mode LoopMode) (strength float64, exists bool) {
+	if !g.HasVertex(v) {
+		return 0, false
+	}
+
+	g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+		// Loops may be reported more than once (as both an out- and in-arc), so
+		// they are found separately.
+		if u, w := e.Both(); gogl.VertexKey(u) != gogl.VertexKey(w) {
+			strength += weightOf(e)
+		}
+		return
+	})
+
+	var loop float64
+	eachOutEdge(g, v, func(e gogl.Edge, adj gogl.Vertex) (terminate bool) {
+		if gogl.VertexKey(adj) == gogl.VertexKey(v) {
+			loop += weightOf(e)
+		}
+		return
+	})
+
+	switch mode {
+	case LoopsTwice:
+		strength += 2 * loop
+	case LoopsOnce:
+		strength += loop
+	}
+
+	return strength, true
+}
+
+// Returns the degree of every vertex, as reported by DegreeOf, sorted from largest
+// to smallest. Isomorphic graphs have equal degree sequences, so comparing them
+// is a cheap way to rule isomorphism out.
+func DegreeSequence(g gogl.Graph) []int {
+	seq := make([]int, 0, sizeHint(g))
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		d, _ := g.DegreeOf(v)
+		seq = append(seq, d)
+		return
+	})
+
+	sort.Sort(sort.Reverse(sort.IntSlice(seq)))
+	return seq
+}
+
+// Calculates the degree assortativity of the graph: the Pearson correlation
+// between the degrees of the vertices at either end of an edge. It lies in
+// [-1, 1]. Positive values mean that vertices tend to be joined to others of
+// similar degree, as in many social networks; negative ones, that high-degree
+// vertices tend to be joined to low-degree ones, as in a star.
+//
+// In an undirected graph, degrees are as reported by DegreeOf, and each edge is
+// counted in both orientations, so that the correlation is symmetric. In a
+// Digraph, the out-degree of each arc's source is correlated with the in-degree
+// of its target.
+//
+// The correlation is undefined, and NaN is returned, if the graph has no edges
+// or if the degrees at the ends of its edges do not vary, as in a regular graph.
+func DegreeAssortativity(g gogl.Graph) float64 {
+	var n, sx, sy, sxx, syy, sxy float64
+	add := func(x, y int) {
+		fx, fy := float64(x), float64(y)
+		n++
+		sx += fx
+		sy += fy
+		sxx += fx * fx
+		syy += fy * fy
+		sxy += fx * fy
+	}
+
+	if dg, ok := g.(gogl.Digraph); ok {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			out, _ := dg.OutDegreeOf(a.Source())
+			in, _ := dg.InDegreeOf(a.Target())
+			add(out, in)
+			return
+		})
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			du, _ := g.DegreeOf(u)
+			dv, _ := g.DegreeOf(v)
+			add(du, dv)
+			add(dv, du)
+			return
+		})
+	}
+
+	if n == 0 {
+		return math.NaN()
+	}
+	cov := sxy/n - sx/n*sy/n
+	vx := sxx/n - sx/n*sx/n
+	vy := syy/n - sy/n*sy/n
+	if vx <= 0 || vy <= 0 {
+		return math.NaN()
+	}
+	return cov / math.Sqrt(vx*vy)
+}
+
+// Builds the Laplacian matrix L = D - A of the graph, where A is its weighted
+// adjacency matrix and D is the diagonal matrix of its weighted degrees. Returned
+// along with the matrix are the vertices in the order of its rows and columns.
+// Edges that are not weighted count as having weight 1.
+//
+// For a Digraph, this is the out-degree Laplacian, with D holding out-degrees
+// and A having an entry in row u and column v for each arc u->v. Either way, each
+// row sums to zero.
+//
+// Self-loops do not affect the Laplacian, whatever LoopMode might be chosen: a loop
+// adds the same amount to its vertex's degree as to its own adjacency entry, and
+// the two cancel on the diagonal. There is, therefore, no mode to choose.
+func Laplacian(g gogl.Graph) ([][]float64, []gogl.Vertex) {
+	vi := gogl.NewVertexIndex(g)
+	vertices := vi.List()
+
+	l := make([][]float64, len(vertices))
+	for i, v := range vertices {
+		l[i] = make([]float64, len(vertices))
+		eachOutEdge(g, v, func(e gogl.Edge, adj gogl.Vertex) (terminate bool) {
+			if gogl.VertexKey(adj) != gogl.VertexKey(v) {
+				w := weightOf(e)
+				l[i][i] += w
+				l[i][vi.IndexOf(adj)] -= w
+			}
+			return
+		})
+	}
+
+	return l, vertices
+}
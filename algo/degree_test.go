@@ -0,0 +1,134 @@
+package algo
+
+import (
+	"math"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type DegreeSuite struct{}
+
+var _ = Suite(&DegreeSuite{})
+
+func (s *DegreeSuite) TestWeightedDegreeLoops(c *C) {
+	g := gogl.Spec().Weighted().Loop().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "a", 3),
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("a", "c", 2),
+	}).Create(al.G)
+
+	for mode, want := range map[LoopMode]float64{LoopsTwice: 9, LoopsOnce: 6, LoopsIgnored: 3} {
+		d, exists := WeightedDegree(g, "a", mode)
+		c.Assert(exists, Equals, true)
+		c.Assert(d, Equals, want, Commentf("mode %d", mode))
+	}
+
+	// Under the default convention, degrees sum to twice the total weight.
+	var sum float64
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		d, _ := WeightedDegree(g, v, LoopsTwice)
+		sum += d
+		return
+	})
+	c.Assert(sum, Equals, 12.0)
+
+	_, exists := WeightedDegree(g, "z", LoopsTwice)
+	c.Assert(exists, Equals, false)
+}
+
+func (s *DegreeSuite) TestWeightedDegreeDirectedLoops(c *C) {
+	g := gogl.Spec().Directed().Weighted().Loop().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "a", 3),
+		gogl.NewWeightedArc("a", "b", 1),
+		gogl.NewWeightedArc("c", "a", 2),
+	}).Create(al.G)
+
+	for mode, want := range map[LoopMode]float64{LoopsTwice: 9, LoopsOnce: 6, LoopsIgnored: 3} {
+		d, _ := WeightedDegree(g, "a", mode)
+		c.Assert(d, Equals, want, Commentf("mode %d", mode))
+	}
+
+	// Unweighted edges count as 1.
+	d, _ := WeightedDegree(gogl.Spec().Loop().Using(gogl.EdgeList{gogl.NewEdge(1, 1), gogl.NewEdge(1, 2)}).Create(al.G), 1, LoopsTwice)
+	c.Assert(d, Equals, 3.0)
+}
+
+func (s *DegreeSuite) TestLaplacianLoops(c *C) {
+	edges := gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("a", "c", 2),
+		gogl.NewWeightedEdge("b", "c", 0.5),
+	}
+	plain := gogl.Spec().Weighted().Using(edges).Create(al.G)
+	looped := gogl.Spec().Weighted().Loop().Using(append(edges, gogl.NewWeightedEdge("a", "a", 3))).Create(al.G)
+
+	lp, vp := Laplacian(plain)
+	ll, vl := Laplacian(looped)
+
+	at := func(l [][]float64, vs []gogl.Vertex, u, v gogl.Vertex) float64 {
+		var i, j int
+		for k, x := range vs {
+			if x == u {
+				i = k
+			}
+			if x == v {
+				j = k
+			}
+		}
+		return l[i][j]
+	}
+
+	c.Assert(at(lp, vp, "a", "a"), Equals, 3.0)
+	c.Assert(at(lp, vp, "a", "c"), Equals, -2.0)
+	c.Assert(at(lp, vp, "c", "b"), Equals, -0.5)
+
+	// The loop leaves the Laplacian unchanged.
+	for _, u := range vp {
+		for _, v := range vp {
+			c.Assert(at(ll, vl, u, v), Equals, at(lp, vp, u, v), Commentf("entry %v, %v", u, v))
+		}
+	}
+
+	for i, row := range ll {
+		var sum float64
+		for _, x := range row {
+			sum += x
+		}
+		c.Assert(sum, Equals, 0.0, Commentf("row %v", vl[i]))
+	}
+}
+
+func (s *DegreeSuite) TestDegreeSequence(c *C) {
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}}, identity)
+	c.Assert(DegreeSequence(star), DeepEquals, []int{4, 1, 1, 1, 1})
+
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}}, identity)
+	c.Assert(DegreeSequence(path), DeepEquals, []int{2, 2, 1, 1})
+
+	c.Assert(DegreeSequence(gogl.NullGraph), HasLen, 0)
+}
+
+func (s *DegreeSuite) TestDegreeAssortativity(c *C) {
+	near := func(got, want float64) bool { return math.Abs(got-want) < 1e-9 }
+
+	// Every edge joins the hub to a leaf.
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}}, identity)
+	c.Assert(near(DegreeAssortativity(star), -1), Equals, true)
+
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}}, identity)
+	c.Assert(near(DegreeAssortativity(path), -0.5), Equals, true, Commentf("got %v", DegreeAssortativity(path)))
+
+	// Sources of out-degree 2 and 1 lead to targets of in-degree 1 and 2.
+	dg := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("a", "c"),
+		gogl.NewArc("b", "c"),
+	}).Create(al.G)
+	c.Assert(near(DegreeAssortativity(dg), -0.5), Equals, true, Commentf("got %v", DegreeAssortativity(dg)))
+
+	// Undefined where degrees do not vary.
+	c.Assert(math.IsNaN(DegreeAssortativity(relabeledGraph(petersen, identity))), Equals, true)
+	c.Assert(math.IsNaN(DegreeAssortativity(gogl.NullGraph)), Equals, true)
+}
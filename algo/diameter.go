@@ -0,0 +1,321 @@
+package algo
+
+import (
+	"errors"
+	"math"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Calculates the diameter of the graph: the greatest number of edges on the
+// shortest path between any two vertices. Arc direction is respected for
+// Digraphs, and weights are ignored.
+//
+// This performs a breadth-first search from every vertex, taking O(VE) time. If
+// any vertex cannot reach another, the diameter is infinite, and ErrUnreachable
+// is returned; ReachableDiameter ignores such pairs instead. Graphs with fewer
+// than two vertices have a diameter of 0.
+func Diameter(g gogl.Graph) (int, error) {
+	n := gogl.Order(g)
+
+	var diameter int
+	var err error
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		dist, _, ecc := bfsDistances(g, v, -1)
+		if len(dist) < n {
+			err = ErrUnreachable
+			return true
+		}
+		if ecc > diameter {
+			diameter = ecc
+		}
+		return
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	return diameter, nil
+}
+
+// ErrUnreachable is returned by distance-based measures when some vertex
+// cannot reach another, leaving the distance between them infinite.
+var ErrUnreachable = errors.New("Graph is not connected, so some distances are infinite.")
+
+// Calculates the eccentricity of the given vertex: the greatest number of edges
+// on the shortest path from it to any other vertex. Arc direction is respected
+// for Digraphs, and weights are ignored.
+//
+// If some vertex cannot be reached from v, its eccentricity is infinite, and
+// ErrUnreachable is returned.
+func Eccentricity(g gogl.Graph, v gogl.Vertex) (int, error) {
+	if !g.HasVertex(v) {
+		return 0, errors.New("Vertex is not present in graph.")
+	}
+
+	dist, _, ecc := bfsDistances(g, v, -1)
+	if len(dist) < gogl.Order(g) {
+		return 0, ErrUnreachable
+	}
+	return ecc, nil
+}
+
+// Calculates the radius of the graph: the least eccentricity of any vertex, such
+// that some vertex is within that many edges of every other. Arc direction is
+// respected for Digraphs, and weights are ignored.
+//
+// As with Diameter, a breadth-first search is run from every vertex. If any
+// vertex cannot reach another, ErrUnreachable is returned. Graphs with fewer than
+// two vertices have a radius of 0.
+func Radius(g gogl.Graph) (int, error) {
+	n := gogl.Order(g)
+
+	radius := -1
+	var err error
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		dist, _, ecc := bfsDistances(g, v, -1)
+		if len(dist) < n {
+			err = ErrUnreachable
+			return true
+		}
+		if radius == -1 || ecc < radius {
+			radius = ecc
+		}
+		return
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	if radius == -1 {
+		radius = 0
+	}
+	return radius, nil
+}
+
+// Calculates the diameter as Diameter does, but over only those pairs of vertices
+// where one can reach the other: the greatest finite shortest path length. For a
+// graph that is not connected, this is the largest diameter of any of its
+// components, or in a Digraph, the longest of its shortest paths.
+func ReachableDiameter(g gogl.Graph) int {
+	var diameter int
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		if _, _, ecc := bfsDistances(g, v, -1); ecc > diameter {
+			diameter = ecc
+		}
+		return
+	})
+
+	return diameter
+}
+
+// Returns the number of edges on the shortest path from s to each vertex it can
+// reach, keyed by gogl.VertexKey, along with those vertices in the order they
+// were reached and the greatest such number. If limit is non-negative, the search
+// goes no further than limit edges from s.
+func bfsDistances(g gogl.Graph, s gogl.Vertex, limit int) (map[interface{}]int, []gogl.Vertex, int) {
+	dist := map[interface{}]int{gogl.VertexKey(s): 0}
+	queue := []gogl.Vertex{s}
+
+	var max int
+	for i := 0; i < len(queue); i++ {
+		v := queue[i]
+		dv := dist[gogl.VertexKey(v)]
+		if dv == limit {
+			continue
+		}
+
+		eachOutEdge(g, v, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+			k := gogl.VertexKey(w)
+			if _, seen := dist[k]; !seen {
+				dist[k] = dv + 1
+				max = dv + 1
+				queue = append(queue, w)
+			}
+			return
+		})
+	}
+
+	return dist, queue, max
+}
+
+// Stands in for an eccentricity upper bound that is not yet known.
+const unboundedEcc = math.MaxInt32
+
+// DynamicDiameter wraps an undirected MutableGraph, keeping track of its diameter
+// as edges and vertices are added and removed. The graph must only be modified
+// through the wrapper.
+//
+// Rather than searching from every vertex, as Diameter does, the diameter is found
+// by narrowing bounds on each vertex's eccentricity (its greatest distance to any
+// other vertex), after Takes and Kosters' BoundingDiameters algorithm. Each
+// breadth-first search fixes one eccentricity exactly and tightens the bounds on
+// all the others, and the search ends once the bounds pin down the diameter; this
+// typically takes only a handful of searches.
+//
+// The bounds are cached between updates, as each kind of update leaves one side
+// of them intact: adding edges can only shorten distances, so upper bounds remain
+// valid, while removing edges can only lengthen them, so lower bounds remain valid.
+// The diameter is recomputed lazily, when next requested.
+type DynamicDiameter struct {
+	gogl.MutableGraph
+	// All keyed by gogl.VertexKey.
+	vertex       map[interface{}]gogl.Vertex
+	lower, upper map[interface{}]int
+	diameter     int
+	err          error
+	stale        bool
+}
+
+// Creates a DynamicDiameter tracking the diameter of the provided graph.
+func NewDynamicDiameter(g gogl.MutableGraph) *DynamicDiameter {
+	dd := &DynamicDiameter{
+		MutableGraph: g,
+		vertex:       make(map[interface{}]gogl.Vertex, sizeHint(g)),
+		lower:        make(map[interface{}]int, sizeHint(g)),
+		upper:        make(map[interface{}]int, sizeHint(g)),
+		stale:        true,
+	}
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		dd.track(v)
+		return
+	})
+
+	return dd
+}
+
+// Adds edges to the graph. Eccentricities may shrink, so their lower bounds are
+// discarded.
+//
+// Only those endpoints the graph actually took in are tracked; a simple graph
+// drops self-loops, and with them any vertex they alone would have added.
+func (dd *DynamicDiameter) AddEdges(edges ...gogl.Edge) {
+	dd.MutableGraph.AddEdges(edges...)
+	for k := range dd.lower {
+		dd.lower[k] = 0
+	}
+	for _, e := range edges {
+		u, v := e.Both()
+		for _, w := range [2]gogl.Vertex{u, v} {
+			if dd.MutableGraph.HasVertex(w) {
+				dd.track(w)
+			}
+		}
+	}
+	dd.stale = true
+}
+
+// Removes edges from the graph. Eccentricities may grow, so their upper bounds are
+// discarded.
+func (dd *DynamicDiameter) RemoveEdges(edges ...gogl.Edge) {
+	dd.MutableGraph.RemoveEdges(edges...)
+	for k := range dd.upper {
+		dd.upper[k] = unboundedEcc
+	}
+	dd.stale = true
+}
+
+// Ensures the provided vertices are present in the graph.
+func (dd *DynamicDiameter) EnsureVertex(vertices ...gogl.Vertex) {
+	dd.MutableGraph.EnsureVertex(vertices...)
+	dd.track(vertices...)
+	dd.stale = true
+}
+
+// Removes the provided vertices from the graph, if present. As this may both
+// lengthen and shorten the remaining distances, all bounds are discarded.
+func (dd *DynamicDiameter) RemoveVertex(vertices ...gogl.Vertex) {
+	dd.MutableGraph.RemoveVertex(vertices...)
+	for _, v := range vertices {
+		k := gogl.VertexKey(v)
+		delete(dd.vertex, k)
+		delete(dd.lower, k)
+		delete(dd.upper, k)
+	}
+	for k := range dd.lower {
+		dd.lower[k], dd.upper[k] = 0, unboundedEcc
+	}
+	dd.stale = true
+}
+
+// Begins tracking bounds for any of the given vertices not already tracked.
+func (dd *DynamicDiameter) track(vertices ...gogl.Vertex) {
+	for _, v := range vertices {
+		k := gogl.VertexKey(v)
+		if _, exists := dd.lower[k]; !exists {
+			dd.vertex[k] = v
+			dd.lower[k], dd.upper[k] = 0, unboundedEcc
+		}
+	}
+}
+
+// Returns the current diameter of the graph, as defined by Diameter:
+// ErrUnreachable is returned if the graph is not connected.
+func (dd *DynamicDiameter) Diameter() (int, error) {
+	if dd.stale {
+		dd.diameter, dd.err = dd.compute()
+		dd.stale = false
+	}
+	return dd.diameter, dd.err
+}
+
+func (dd *DynamicDiameter) compute() (int, error) {
+	n := len(dd.lower)
+	if n < 2 {
+		return 0, nil
+	}
+
+	// The greatest eccentricity lower bound is a lower bound on the diameter.
+	var dl int
+	for _, l := range dd.lower {
+		if l > dl {
+			dl = l
+		}
+	}
+
+	for high := true; ; high = !high {
+		// Only vertices whose eccentricity might exceed the known lower bound on
+		// the diameter are worth searching from. Alternate between the one with
+		// the greatest upper bound, which may raise the lower bound on the
+		// diameter, and the one with the least lower bound, which is likely
+		// central, and so tightens upper bounds well.
+		var v interface{}
+		var found bool
+		for w, u := range dd.upper {
+			if u <= dl {
+				continue
+			}
+			if !found || (high && u > dd.upper[v]) || (!high && dd.lower[w] < dd.lower[v]) {
+				v, found = w, true
+			}
+		}
+		if !found {
+			return dl, nil
+		}
+
+		dist, _, ecc := bfsDistances(dd.MutableGraph, dd.vertex[v], -1)
+		if len(dist) < n {
+			return 0, ErrUnreachable
+		}
+		if ecc > dl {
+			dl = ecc
+		}
+
+		for w, d := range dist {
+			l := d
+			if ecc-d > l {
+				l = ecc - d
+			}
+			if l > dd.lower[w] {
+				dd.lower[w] = l
+				if l > dl {
+					dl = l
+				}
+			}
+			if u := ecc + d; u < dd.upper[w] {
+				dd.upper[w] = u
+			}
+		}
+	}
+}
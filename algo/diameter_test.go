@@ -0,0 +1,166 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type DiameterSuite struct{}
+
+var _ = Suite(&DiameterSuite{})
+
+// A diameter along with its error, so the pair can be compared at once.
+type diameterResult struct {
+	d   int
+	err error
+}
+
+func diam(d int, err error) diameterResult {
+	return diameterResult{d, err}
+}
+
+func (s *DiameterSuite) TestDiameter(c *C) {
+	c.Assert(diam(Diameter(relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}, identity))), Equals, diam(4, nil))
+	c.Assert(diam(Diameter(relabeledGraph(petersen, identity))), Equals, diam(2, nil))
+	c.Assert(diam(Diameter(relabeledGraph([][2]int{{1, 2}, {3, 4}}, identity))), Equals, diam(0, ErrUnreachable))
+	c.Assert(diam(Diameter(gogl.NullGraph)), Equals, diam(0, nil))
+
+	// Around a directed cycle, the way back is long.
+	dg := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 2),
+		gogl.NewArc(2, 3),
+		gogl.NewArc(3, 4),
+		gogl.NewArc(4, 1),
+	}).Create(al.G)
+	c.Assert(diam(Diameter(dg)), Equals, diam(3, nil))
+}
+
+func (s *DiameterSuite) TestEccentricity(c *C) {
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}, identity)
+	for v, want := range map[int]int{1: 4, 2: 3, 3: 2, 4: 3, 5: 4} {
+		ecc, err := Eccentricity(path, v)
+		c.Assert(err, IsNil)
+		c.Assert(ecc, Equals, want, Commentf("vertex %d", v))
+	}
+	r, err := Radius(path)
+	c.Assert(err, IsNil)
+	c.Assert(r, Equals, 2)
+
+	r, err = Radius(relabeledGraph(petersen, identity))
+	c.Assert(err, IsNil)
+	c.Assert(r, Equals, 2)
+
+	_, err = Eccentricity(path, 42)
+	c.Assert(err, ErrorMatches, "Vertex is not present in graph.")
+
+	r, err = Radius(gogl.NullGraph)
+	c.Assert(err, IsNil)
+	c.Assert(r, Equals, 0)
+}
+
+func (s *DiameterSuite) TestUnreachable(c *C) {
+	// A path of three and a lone edge.
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {4, 5}}, identity)
+	_, err := Eccentricity(g, 2)
+	c.Assert(err, Equals, ErrUnreachable)
+	_, err = Radius(g)
+	c.Assert(err, Equals, ErrUnreachable)
+	_, err = Diameter(g)
+	c.Assert(err, Equals, ErrUnreachable)
+	c.Assert(ReachableDiameter(g), Equals, 2)
+
+	// Only a can reach everything, but the longest path from it is still finite.
+	dg := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "d"),
+	}).Create(al.G)
+	ecc, err := Eccentricity(dg, "a")
+	c.Assert(err, IsNil)
+	c.Assert(ecc, Equals, 3)
+	_, err = Eccentricity(dg, "b")
+	c.Assert(err, Equals, ErrUnreachable)
+	c.Assert(ReachableDiameter(dg), Equals, 3)
+
+	c.Assert(ReachableDiameter(relabeledGraph(petersen, identity)), Equals, 2)
+	c.Assert(ReachableDiameter(gogl.NullGraph), Equals, 0)
+}
+
+func (s *DiameterSuite) TestDynamicInsertion(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	dd := NewDynamicDiameter(g)
+	c.Assert(diam(dd.Diameter()), Equals, diam(0, nil))
+
+	// Grow a random tree, then keep adding shortcuts.
+	const n = 80
+	dd.EnsureVertex(0)
+	for i := 1; i < n; i++ {
+		dd.AddEdges(gogl.NewEdge(i, r.Intn(i)))
+		c.Assert(diam(dd.Diameter()), Equals, diam(Diameter(g)), Commentf("after adding vertex %d", i))
+	}
+	for i := 0; i < 100; i++ {
+		dd.AddEdges(gogl.NewEdge(r.Intn(n), r.Intn(n)))
+		c.Assert(diam(dd.Diameter()), Equals, diam(Diameter(g)), Commentf("after shortcut %d", i))
+	}
+}
+
+func (s *DiameterSuite) TestDynamicMixed(c *C) {
+	r := stdrand.New(stdrand.NewSource(2))
+	g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	dd := NewDynamicDiameter(g)
+
+	const n = 40
+	for i := 0; i < n; i++ {
+		dd.EnsureVertex(i)
+	}
+	c.Assert(diam(dd.Diameter()), Equals, diam(0, ErrUnreachable))
+
+	for i := 0; i < 400; i++ {
+		u, v := r.Intn(n), r.Intn(n)
+		switch r.Intn(10) {
+		case 0, 1, 2:
+			dd.RemoveEdges(gogl.NewEdge(u, v))
+		case 3:
+			dd.RemoveVertex(u)
+			dd.EnsureVertex(u)
+		default:
+			dd.AddEdges(gogl.NewEdge(u, v))
+		}
+
+		// Query only some of the time, so updates also accumulate.
+		if r.Intn(3) == 0 {
+			c.Assert(diam(dd.Diameter()), Equals, diam(Diameter(g)), Commentf("step %d", i))
+		}
+	}
+	c.Assert(diam(dd.Diameter()), Equals, diam(Diameter(g)))
+}
+
+func (s *DiameterSuite) TestDynamicWrapsExisting(c *C) {
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}}, identity).(gogl.MutableGraph)
+	dd := NewDynamicDiameter(g)
+	c.Assert(diam(dd.Diameter()), Equals, diam(5, nil))
+
+	dd.AddEdges(gogl.NewEdge(1, 6))
+	c.Assert(diam(dd.Diameter()), Equals, diam(3, nil))
+
+	dd.RemoveEdges(gogl.NewEdge(3, 4))
+	c.Assert(diam(dd.Diameter()), Equals, diam(5, nil))
+
+	dd.RemoveVertex(1)
+	c.Assert(diam(dd.Diameter()), Equals, diam(0, ErrUnreachable))
+}
+
+func (s *DiameterSuite) TestDynamicDroppedLoop(c *C) {
+	g := relabeledGraph([][2]int{{1, 2}}, identity).(gogl.MutableGraph)
+	dd := NewDynamicDiameter(g)
+
+	// A simple graph drops the loop, so vertex 3 never joins it.
+	dd.AddEdges(gogl.NewEdge(3, 3))
+	c.Assert(g.HasVertex(3), Equals, false)
+	c.Assert(diam(dd.Diameter()), Equals, diam(Diameter(g)))
+	c.Assert(diam(dd.Diameter()), Equals, diam(1, nil))
+}
@@ -0,0 +1,77 @@
+package algo
+
+import "github.com/sdboyer/gogl"
+
+// Returns the difference of g1 and g2: a graph with every vertex of g1, and those
+// of g1's edges that do not also appear in g2. Comparing two snapshots of an
+// evolving graph this way shows what was removed from, or, swapping them, added
+// to it.
+//
+// The result has g1's directedness and edge type, and its edges keep their
+// weights, labels or data. An edge appears in g2 if g2's most specific Has*Edge
+// method reports it: for a weighted, labeled or data edge, that of the same
+// kind, so that an edge whose weight, label or data has changed counts as
+// removed and re-added. Where g1 and g2 are both Digraphs, the matching Has*Arc
+// method is used, so arcs must also agree in direction; otherwise direction is
+// ignored. The result is a mutable adjacency list.
+func Difference(g1, g2 gogl.Graph) gogl.Graph {
+	vertices := gogl.CollectVertices(g1)
+
+	_, directed := g1.(gogl.Digraph)
+	_, bothDirected := g2.(gogl.Digraph)
+	bothDirected = bothDirected && directed
+
+	var edges []gogl.Edge
+	keep := func(e gogl.Edge) (terminate bool) {
+		if !hasEquivalent(g2, e, bothDirected) {
+			edges = append(edges, e)
+		}
+		return
+	}
+	if directed {
+		g1.(gogl.Digraph).Arcs(func(a gogl.Arc) bool { return keep(a) })
+	} else {
+		g1.Edges(keep)
+	}
+
+	return rebuildLike(g1, vertices, edges)
+}
+
+// Reports whether g has the given edge, as decided by the most specific Has*
+// method the two share. If arcs is true, g must be a Digraph and e an Arc, and
+// direction is respected.
+func hasEquivalent(g gogl.Graph, e gogl.Edge, arcs bool) bool {
+	if arcs {
+		switch e := e.(type) {
+		case gogl.WeightedArc:
+			if wg, ok := g.(gogl.WeightedDigraph); ok {
+				return wg.HasWeightedArc(e)
+			}
+		case gogl.LabeledArc:
+			if lg, ok := g.(gogl.LabeledDigraph); ok {
+				return lg.HasLabeledArc(e)
+			}
+		case gogl.DataArc:
+			if dg, ok := g.(gogl.DataDigraph); ok {
+				return dg.HasDataArc(e)
+			}
+		}
+		return g.(gogl.Digraph).HasArc(e.(gogl.Arc))
+	}
+
+	switch e := e.(type) {
+	case gogl.WeightedEdge:
+		if wg, ok := g.(gogl.WeightedGraph); ok {
+			return wg.HasWeightedEdge(e)
+		}
+	case gogl.LabeledEdge:
+		if lg, ok := g.(gogl.LabeledGraph); ok {
+			return lg.HasLabeledEdge(e)
+		}
+	case gogl.DataEdge:
+		if dg, ok := g.(gogl.DataGraph); ok {
+			return dg.HasDataEdge(e)
+		}
+	}
+	return g.HasEdge(e)
+}
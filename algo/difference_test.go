@@ -0,0 +1,86 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type DifferenceSuite struct{}
+
+var _ = Suite(&DifferenceSuite{})
+
+func (s *DifferenceSuite) TestBasic(c *C) {
+	before := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("b", "c"),
+		gogl.NewEdge("c", "d"),
+	}).Create(al.G)
+	after := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("c", "b"),
+		gogl.NewEdge("d", "e"),
+	}).Create(al.G)
+
+	removed := Difference(before, after)
+	c.Assert(gogl.Order(removed), Equals, 4)
+	c.Assert(gogl.Size(removed), Equals, 2)
+	c.Assert(removed.HasEdge(gogl.NewEdge("a", "b")), Equals, true)
+	c.Assert(removed.HasEdge(gogl.NewEdge("c", "d")), Equals, true)
+
+	added := Difference(after, before)
+	c.Assert(gogl.Order(added), Equals, 4)
+	c.Assert(gogl.Size(added), Equals, 1)
+	c.Assert(added.HasEdge(gogl.NewEdge("d", "e")), Equals, true)
+
+	c.Assert(gogl.Size(Difference(before, before)), Equals, 0)
+}
+
+func (s *DifferenceSuite) TestWeighted(c *C) {
+	before := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "c", 2),
+	}).Create(al.G)
+	after := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "c", 5),
+	}).Create(al.G)
+
+	// The reweighted edge counts as changed, and keeps its old weight.
+	d := Difference(before, after)
+	wd, ok := d.(gogl.WeightedGraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(gogl.Size(d), Equals, 1)
+	c.Assert(wd.HasWeightedEdge(gogl.NewWeightedEdge("b", "c", 2)), Equals, true)
+
+	// Against a basic graph, only endpoints matter.
+	d = Difference(before, gogl.Spec().Using(gogl.EdgeList{gogl.NewEdge("c", "b")}).Create(al.G))
+	c.Assert(gogl.Size(d), Equals, 1)
+	c.Assert(d.(gogl.WeightedGraph).HasWeightedEdge(gogl.NewWeightedEdge("a", "b", 1)), Equals, true)
+}
+
+func (s *DifferenceSuite) TestDirected(c *C) {
+	g1 := gogl.Spec().Directed().Labeled().Using(gogl.LabeledArcList{
+		gogl.NewLabeledArc("a", "b", "x"),
+		gogl.NewLabeledArc("b", "c", "y"),
+		gogl.NewLabeledArc("c", "a", "z"),
+	}).Create(al.G)
+	g2 := gogl.Spec().Directed().Labeled().Using(gogl.LabeledArcList{
+		gogl.NewLabeledArc("a", "b", "x"),
+		gogl.NewLabeledArc("c", "b", "y"),
+		gogl.NewLabeledArc("c", "a", "w"),
+	}).Create(al.G)
+
+	// b->c is reversed in g2, and c->a relabeled, so only a->b is removed.
+	d := Difference(g1, g2)
+	ld, ok := d.(gogl.LabeledDigraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(gogl.Size(d), Equals, 2)
+	c.Assert(ld.HasLabeledArc(gogl.NewLabeledArc("b", "c", "y")), Equals, true)
+	c.Assert(ld.HasLabeledArc(gogl.NewLabeledArc("c", "a", "z")), Equals, true)
+
+	// An undirected graph matches arcs either way round.
+	ug := gogl.Spec().Using(gogl.EdgeList{gogl.NewEdge("c", "b")}).Create(al.G)
+	d = Difference(g1, ug)
+	c.Assert(gogl.Size(d), Equals, 2)
+	c.Assert(d.(gogl.Digraph).HasArc(gogl.NewArc("b", "c")), Equals, false)
+}
@@ -0,0 +1,157 @@
+package algo
+
+import "github.com/sdboyer/gogl"
+
+// Finds a largest set of paths from source to target that share no edge. By
+// Menger's theorem, their number is the local edge connectivity of the pair: the
+// fewest edges whose removal separates target from source. The paths themselves
+// are the alternative routes a network designer needs, which the count alone
+// does not give.
+//
+// Each edge becomes an arc of unit capacity, in both directions if g is
+// undirected, and a maximum flow is pushed from source to target. The flow is then
+// decomposed into paths by following flow-carrying arcs out of the source. Any
+// cycles met along the way carry no flow between the two ends, and are dropped,
+// so every path is simple.
+//
+// Each path is a sequence of the graph's own edges, leading from source to
+// target. In a Digraph the arcs are followed in their direction; in an
+// undirected graph an edge may be passed in either orientation. Self-loops are
+// ignored, and each parallel edge counts separately. If either vertex is not
+// present in the graph, or they are the same vertex, no paths are returned.
+func EdgeDisjointPaths(g gogl.Graph, source, target gogl.Vertex) ([][]gogl.Edge, int) {
+	if gogl.VertexKey(source) == gogl.VertexKey(target) || !g.HasVertex(source) || !g.HasVertex(target) {
+		return nil, 0
+	}
+
+	vi := gogl.NewVertexIndex(g)
+	fn := newFlowNetwork(vi.Len())
+
+	// The edge behind each arc pair; pair i is arcs 2*i and 2*i+1.
+	var edges []gogl.Edge
+	dg, directed := g.(gogl.Digraph)
+	add := func(e gogl.Edge) {
+		u, v := e.Both()
+		if gogl.VertexKey(u) == gogl.VertexKey(v) {
+			return
+		}
+
+		edges = append(edges, e)
+		fn.addArc(vi.IndexOf(u), vi.IndexOf(v), 1)
+		if !directed {
+			// The reverse arc doubles as the arc for travel the other way; the
+			// edge's net flow is then 1 less the forward residual, either way.
+			fn.cap[len(fn.cap)-1] = 1
+		}
+	}
+
+	if directed {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			add(a)
+			return
+		})
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			add(e)
+			return
+		})
+	}
+
+	s, t := vi.IndexOf(source), vi.IndexOf(target)
+	count := int(fn.maxFlow(s, t, float64(len(edges))))
+
+	var paths [][]gogl.Edge
+	for _, arcs := range decomposeUnitFlow(fn, s, t, count) {
+		path := make([]gogl.Edge, len(arcs))
+		for i, a := range arcs {
+			path[i] = edges[a/2]
+		}
+		paths = append(paths, path)
+	}
+	return paths, count
+}
+
+// Finds a largest set of paths from source to target that share no vertex but
+// their ends. By Menger's theorem, their number is the local vertex connectivity
+// of the pair: the fewest other vertices whose failure separates target from
+// source. If the two are adjacent, the edge between them forms one of the paths
+// by itself, as no vertex failure can break it.
+//
+// Each vertex is split into an entry and an exit joined by an arc of unit
+// capacity, as in AreBiconnected, and a maximum flow is pushed from the source's
+// exit to the target's entry. The flow is decomposed into paths as in
+// EdgeDisjointPaths.
+//
+// Each path lists its vertices in order, from source to target inclusive. In a
+// Digraph the arcs are followed in their direction. Self-loops are ignored. If
+// either vertex is not present in the graph, or they are the same vertex, no
+// paths are returned.
+func VertexDisjointPaths(g gogl.Graph, source, target gogl.Vertex) ([][]gogl.Vertex, int) {
+	if gogl.VertexKey(source) == gogl.VertexKey(target) || !g.HasVertex(source) || !g.HasVertex(target) {
+		return nil, 0
+	}
+
+	vi := gogl.NewVertexIndex(g)
+	fn := newSplitFlowNetwork(g, vi)
+
+	s, t := splitExit(vi.IndexOf(source)), splitEntry(vi.IndexOf(target))
+	count := int(fn.maxFlow(s, t, float64(vi.Len())))
+
+	var paths [][]gogl.Vertex
+	for _, arcs := range decomposeUnitFlow(fn, s, t, count) {
+		path := []gogl.Vertex{source}
+		for _, a := range arcs {
+			// Arcs between vertices lead to an entry; those within one, to an exit.
+			if n := fn.to[a]; n == splitEntry(n/2) {
+				path = append(path, vi.VertexAt(n/2))
+			}
+		}
+		paths = append(paths, path)
+	}
+	return paths, count
+}
+
+// Decomposes a unit-capacity flow of the given value from s to t into paths,
+// returning the arcs each path takes. Arcs are paired as addArc leaves them, with
+// a capacity of 1 on the forward arc, and on the reverse arc only if it is to
+// carry travel the other way; the net flow across a pair is then 1 less the
+// residual capacity of its forward arc.
+func decomposeUnitFlow(fn *flowNetwork, s, t, count int) [][]int {
+	out := make([][]int, len(fn.arcs))
+	for a := 0; a < len(fn.to); a += 2 {
+		switch flow := 1 - fn.cap[a]; {
+		case flow > 0:
+			out[fn.to[a^1]] = append(out[fn.to[a^1]], a)
+		case flow < 0:
+			out[fn.to[a]] = append(out[fn.to[a]], a^1)
+		}
+	}
+
+	paths := make([][]int, 0, count)
+	for len(paths) < count {
+		// Walk flow out of s until reaching t, consuming each arc taken. Should
+		// the walk come back around to a node it has visited, the cycle in
+		// between is cut out of the path.
+		at := map[int]int{s: 0}
+		var walk []int
+		for u := s; u != t; {
+			a := out[u][len(out[u])-1]
+			out[u] = out[u][:len(out[u])-1]
+
+			v := fn.to[a]
+			if k, seen := at[v]; seen {
+				for _, b := range walk[k:] {
+					delete(at, fn.to[b])
+				}
+				walk = walk[:k]
+			} else {
+				walk = append(walk, a)
+				at[v] = len(walk)
+			}
+			u = v
+		}
+		paths = append(paths, walk)
+	}
+
+	return paths
+}
@@ -0,0 +1,263 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type DisjointPathsSuite struct{}
+
+var _ = Suite(&DisjointPathsSuite{})
+
+// Checks that each path leads from source to target without repeating a vertex,
+// and that no edge is used twice across all the paths.
+func checkEdgeDisjoint(c *C, paths [][]gogl.Edge, source, target gogl.Vertex, directed bool) {
+	used := make(map[gogl.Edge]bool)
+	for _, path := range paths {
+		c.Assert(path, Not(HasLen), 0)
+
+		at := source
+		visited := map[gogl.Vertex]bool{source: true}
+		for _, e := range path {
+			c.Assert(used[e], Equals, false, Commentf("edge %v reused", e))
+			used[e] = true
+
+			u, v := e.Both()
+			if u != at {
+				c.Assert(directed, Equals, false, Commentf("arc %v followed backwards", e))
+				u, v = v, u
+			}
+			c.Assert(u, Equals, at, Commentf("path %v is broken at %v", path, e))
+			c.Assert(visited[v], Equals, false, Commentf("path %v revisits %v", path, v))
+			visited[v] = true
+			at = v
+		}
+		c.Assert(at, Equals, target)
+	}
+}
+
+// Finds the fewest edges whose removal separates target from source, by trying
+// every subset of edges.
+func bruteForceEdgeCut(g gogl.Graph, source, target gogl.Vertex) int {
+	var edges []gogl.Edge
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		edges = append(edges, e)
+		return
+	})
+	_, directed := g.(gogl.Digraph)
+
+	best := len(edges)
+	for mask := 0; mask < 1<<uint(len(edges)); mask++ {
+		var removed int
+		adj := make(map[gogl.Vertex][]gogl.Vertex)
+		for i, e := range edges {
+			if mask&(1<<uint(i)) != 0 {
+				removed++
+				continue
+			}
+			u, v := e.Both()
+			adj[u] = append(adj[u], v)
+			if !directed {
+				adj[v] = append(adj[v], u)
+			}
+		}
+		if removed >= best {
+			continue
+		}
+
+		seen := map[gogl.Vertex]bool{source: true}
+		stack := []gogl.Vertex{source}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, w := range adj[u] {
+				if !seen[w] {
+					seen[w] = true
+					stack = append(stack, w)
+				}
+			}
+		}
+		if !seen[target] {
+			best = removed
+		}
+	}
+	return best
+}
+
+func (s *DisjointPathsSuite) TestTwoPaths(c *C) {
+	// s has three ways out, but t only two ways in.
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("s", "a"),
+		gogl.NewEdge("s", "b"),
+		gogl.NewEdge("s", "c"),
+		gogl.NewEdge("c", "a"),
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("a", "t"),
+		gogl.NewEdge("b", "t"),
+	}).Create(al.G)
+
+	paths, count := EdgeDisjointPaths(g, "s", "t")
+	c.Assert(count, Equals, 2)
+	c.Assert(paths, HasLen, 2)
+	checkEdgeDisjoint(c, paths, "s", "t", false)
+
+	// Direction is followed in a digraph.
+	dg := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("s", "a"),
+		gogl.NewArc("s", "b"),
+		gogl.NewArc("a", "t"),
+		gogl.NewArc("t", "b"),
+	}).Create(al.G)
+
+	paths, count = EdgeDisjointPaths(dg, "s", "t")
+	c.Assert(count, Equals, 1)
+	checkEdgeDisjoint(c, paths, "s", "t", true)
+
+	paths, count = EdgeDisjointPaths(dg, "t", "s")
+	c.Assert(count, Equals, 0)
+	c.Assert(paths, HasLen, 0)
+}
+
+func (s *DisjointPathsSuite) TestDegenerate(c *C) {
+	g := relabeledGraph([][2]int{{1, 2}}, identity)
+
+	for _, pair := range [][2]gogl.Vertex{{1, 1}, {1, 3}, {3, 1}} {
+		paths, count := EdgeDisjointPaths(g, pair[0], pair[1])
+		c.Assert(count, Equals, 0)
+		c.Assert(paths, IsNil)
+	}
+}
+
+func (s *DisjointPathsSuite) TestMatchesBruteForce(c *C) {
+	var tried int
+	for seed := int64(1); seed <= 15; seed++ {
+		directed, p := seed%2 == 0, 0.35
+		if directed {
+			p = 0.2
+		}
+		src := rand.BernoulliDistribution(8, p, directed, true, stdrand.NewSource(seed))
+
+		var g gogl.Graph
+		if directed {
+			g = gogl.Spec().Directed().Using(src).Create(al.G)
+		} else {
+			g = gogl.Spec().Using(src).Create(al.G)
+		}
+		if gogl.Size(g) > 16 {
+			continue
+		}
+		tried++
+
+		for _, pair := range [][2]gogl.Vertex{{0, 7}, {1, 4}, {6, 2}} {
+			paths, count := EdgeDisjointPaths(g, pair[0], pair[1])
+			c.Assert(count, Equals, bruteForceEdgeCut(g, pair[0], pair[1]), Commentf("seed %d, pair %v", seed, pair))
+			c.Assert(paths, HasLen, count)
+			checkEdgeDisjoint(c, paths, pair[0], pair[1], directed)
+		}
+	}
+	c.Assert(tried >= 10, Equals, true, Commentf("only %d graphs were small enough", tried))
+}
+
+// Checks that each path leads from source to target along the graph's edges, and
+// that no vertex but the ends appears twice across all the paths.
+func checkVertexDisjoint(c *C, g gogl.Graph, paths [][]gogl.Vertex, source, target gogl.Vertex) {
+	dg, directed := g.(gogl.Digraph)
+	used := make(map[gogl.Vertex]bool)
+	for _, path := range paths {
+		c.Assert(path[0], Equals, source)
+		c.Assert(path[len(path)-1], Equals, target)
+		for i := 1; i < len(path); i++ {
+			if directed {
+				c.Assert(dg.HasArc(gogl.NewArc(path[i-1], path[i])), Equals, true, Commentf("path %v", path))
+			} else {
+				c.Assert(g.HasEdge(gogl.NewEdge(path[i-1], path[i])), Equals, true, Commentf("path %v", path))
+			}
+		}
+		for _, v := range path[1 : len(path)-1] {
+			c.Assert(used[v], Equals, false, Commentf("vertex %v reused", v))
+			used[v] = true
+		}
+	}
+}
+
+func (s *DisjointPathsSuite) TestVertexDisjoint(c *C) {
+	// Two routes from s to t, through a and through b.
+	edges := gogl.EdgeList{
+		gogl.NewEdge("s", "a"),
+		gogl.NewEdge("a", "t"),
+		gogl.NewEdge("s", "b"),
+		gogl.NewEdge("b", "t"),
+	}
+	g := gogl.Spec().Using(edges).Create(al.G)
+
+	paths, count := VertexDisjointPaths(g, "s", "t")
+	c.Assert(count, Equals, 2)
+	c.Assert(paths, HasLen, 2)
+	checkVertexDisjoint(c, g, paths, "s", "t")
+
+	// Both routes from s to u pass through the bottleneck m, which takes them
+	// down to one, though they need share no edge.
+	g = gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("s", "a"),
+		gogl.NewEdge("s", "b"),
+		gogl.NewEdge("a", "m"),
+		gogl.NewEdge("b", "m"),
+		gogl.NewEdge("m", "x"),
+		gogl.NewEdge("m", "y"),
+		gogl.NewEdge("x", "u"),
+		gogl.NewEdge("y", "u"),
+	}).Create(al.G)
+
+	paths, count = VertexDisjointPaths(g, "s", "u")
+	c.Assert(count, Equals, 1)
+	c.Assert(paths, HasLen, 1)
+	checkVertexDisjoint(c, g, paths, "s", "u")
+	c.Assert(paths[0][2], Equals, "m")
+
+	_, count = EdgeDisjointPaths(g, "s", "u")
+	c.Assert(count, Equals, 2)
+}
+
+func (s *DisjointPathsSuite) TestVertexDisjointAdjacent(c *C) {
+	// The direct edge is a path of its own, alongside the one through a.
+	g := relabeledGraph([][2]int{{1, 2}, {1, 3}, {3, 2}}, identity)
+
+	paths, count := VertexDisjointPaths(g, 1, 2)
+	c.Assert(count, Equals, 2)
+	checkVertexDisjoint(c, g, paths, 1, 2)
+
+	paths, count = VertexDisjointPaths(g, 1, 4)
+	c.Assert(count, Equals, 0)
+	c.Assert(paths, IsNil)
+}
+
+func (s *DisjointPathsSuite) TestVertexDisjointRandom(c *C) {
+	for seed := int64(1); seed <= 10; seed++ {
+		directed := seed%2 == 0
+		src := rand.BernoulliDistribution(20, 0.2, directed, true, stdrand.NewSource(seed))
+
+		var g gogl.Graph
+		if directed {
+			g = gogl.Spec().Directed().Using(src).Create(al.G)
+		} else {
+			g = gogl.Spec().Using(src).Create(al.G)
+		}
+
+		for _, pair := range [][2]gogl.Vertex{{0, 19}, {3, 11}} {
+			paths, count := VertexDisjointPaths(g, pair[0], pair[1])
+			c.Assert(paths, HasLen, count)
+			checkVertexDisjoint(c, g, paths, pair[0], pair[1])
+
+			// Vertex-disjoint paths are edge-disjoint, so there can't be more.
+			_, edgeCount := EdgeDisjointPaths(g, pair[0], pair[1])
+			c.Assert(count <= edgeCount, Equals, true, Commentf("seed %d, pair %v", seed, pair))
+
+			// With two or more paths, no single vertex separates the pair.
+			c.Assert(AreBiconnected(g, pair[0], pair[1]), Equals, count >= 2, Commentf("seed %d, pair %v", seed, pair))
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package algo
+
+import "github.com/sdboyer/gogl"
+
+// Finds a dominating set of the graph - a set of vertices such that every vertex
+// is either in the set or adjacent to one that is - by the classic greedy method:
+// repeatedly take the vertex that would dominate the most vertices not yet
+// dominated, until none remain. Dominating sets model problems like facility
+// placement and sensor coverage, where each chosen site serves its neighbors.
+//
+// The result is within a factor of ln(Δ+1)+1 of the minimum, where Δ is the
+// maximum degree, which is essentially the best guarantee any polynomial time
+// algorithm can offer. Ties are broken arbitrarily.
+//
+// Edge direction and self-loops are ignored. Isolated vertices can only dominate
+// themselves, and so are always in the set.
+func GreedyDominatingSet(g gogl.Graph) []gogl.Vertex {
+	vertices, closed := closedNeighborhoods(g)
+
+	dominated := make([]bool, len(vertices))
+	left := len(vertices)
+
+	var set []gogl.Vertex
+	for left > 0 {
+		best, gain := -1, 0
+		for i, ns := range closed {
+			var count int
+			for _, j := range ns {
+				if !dominated[j] {
+					count++
+				}
+			}
+			if count > gain {
+				best, gain = i, count
+			}
+		}
+
+		set = append(set, vertices[best])
+		for _, j := range closed[best] {
+			if !dominated[j] {
+				dominated[j] = true
+				left--
+			}
+		}
+	}
+
+	return set
+}
+
+// Finds a minimum dominating set of the graph: the smallest set of vertices such
+// that every vertex is either in the set or adjacent to one that is. Its size is
+// the graph's domination number.
+//
+// The problem is NP-hard, and this performs an exact branch and bound search, so
+// it is practical only for small graphs. The greedy set bounds the search from
+// the start. Each branch takes a vertex not yet dominated with the fewest ways to
+// become so, and tries each vertex of its closed neighborhood in turn, as one of
+// them must be in the set; a branch is abandoned once even the most productive
+// choices could not beat the best set found.
+//
+// Edge direction and self-loops are ignored. Neither the order of the vertices nor
+// the choice among minimum sets is meaningful.
+func MinDominatingSet(g gogl.Graph) []gogl.Vertex {
+	vertices, closed := closedNeighborhoods(g)
+
+	best := GreedyDominatingSet(g)
+	if len(best) <= 1 {
+		return best
+	}
+
+	maxReach := 0
+	for _, ns := range closed {
+		if len(ns) > maxReach {
+			maxReach = len(ns)
+		}
+	}
+
+	// The number of chosen vertices dominating each vertex.
+	cover := make([]int, len(vertices))
+	left := len(vertices)
+	var chosen []int
+
+	var search func()
+	search = func() {
+		if left == 0 {
+			best = best[:0]
+			for _, i := range chosen {
+				best = append(best, vertices[i])
+			}
+			return
+		}
+
+		// Each further vertex dominates at most maxReach more.
+		if len(chosen)+(left+maxReach-1)/maxReach >= len(best) {
+			return
+		}
+
+		v := -1
+		for i, c := range cover {
+			if c == 0 && (v == -1 || len(closed[i]) < len(closed[v])) {
+				v = i
+			}
+		}
+
+		for _, u := range closed[v] {
+			chosen = append(chosen, u)
+			for _, w := range closed[u] {
+				if cover[w]++; cover[w] == 1 {
+					left--
+				}
+			}
+
+			search()
+
+			for _, w := range closed[u] {
+				if cover[w]--; cover[w] == 0 {
+					left++
+				}
+			}
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+
+	best = append([]gogl.Vertex(nil), best...)
+	search()
+	return best
+}
+
+// Numbers the graph's vertices, returning them along with each one's closed
+// neighborhood: its own index and those of its neighbors, ignoring direction.
+func closedNeighborhoods(g gogl.Graph) ([]gogl.Vertex, [][]int) {
+	adj := undirectedAdjacency(g)
+
+	closed := make([][]int, adj.Len())
+	for v, ns := range adj.nbrs {
+		closed[v] = append(closed[v], v)
+		for w := range ns {
+			closed[v] = append(closed[v], w)
+		}
+	}
+
+	return adj.List(), closed
+}
@@ -0,0 +1,120 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type DominatingSuite struct{}
+
+var _ = Suite(&DominatingSuite{})
+
+// Indicates whether every vertex of g is in the set or adjacent to a member.
+func dominates(g gogl.Graph, set []gogl.Vertex) bool {
+	dominated := make(map[gogl.Vertex]bool)
+	for _, v := range set {
+		dominated[v] = true
+		g.AdjacentTo(v, func(w gogl.Vertex) (terminate bool) {
+			dominated[w] = true
+			return
+		})
+	}
+
+	all := true
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		all = dominated[v]
+		return !all
+	})
+	return all
+}
+
+// Finds the domination number by trying every subset of vertices.
+func bruteForceDomination(g gogl.Graph) int {
+	var vertices []gogl.Vertex
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		vertices = append(vertices, v)
+		return
+	})
+
+	best := len(vertices)
+	for mask := 0; mask < 1<<uint(len(vertices)); mask++ {
+		var set []gogl.Vertex
+		for i, v := range vertices {
+			if mask&(1<<uint(i)) != 0 {
+				set = append(set, v)
+			}
+		}
+		if len(set) < best && dominates(g, set) {
+			best = len(set)
+		}
+	}
+	return best
+}
+
+func (s *DominatingSuite) TestStar(c *C) {
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}}, identity)
+
+	c.Assert(GreedyDominatingSet(star), DeepEquals, seq(0))
+	c.Assert(MinDominatingSet(star), DeepEquals, seq(0))
+}
+
+func (s *DominatingSuite) TestKnownDominationNumbers(c *C) {
+	cycle := func(n int) [][2]int {
+		var pairs [][2]int
+		for i := 0; i < n; i++ {
+			pairs = append(pairs, [2]int{i, (i + 1) % n})
+		}
+		return pairs
+	}
+
+	for name, tc := range map[string]struct {
+		pairs [][2]int
+		size  int
+	}{
+		"path":     {[][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}, 2},
+		"C9":       {cycle(9), 3},
+		"C10":      {cycle(10), 4},
+		"petersen": {petersen, 3},
+	} {
+		g := relabeledGraph(tc.pairs, identity)
+		set := MinDominatingSet(g)
+		c.Assert(set, HasLen, tc.size, Commentf("graph: %s", name))
+		c.Assert(dominates(g, set), Equals, true, Commentf("graph: %s", name))
+	}
+}
+
+func (s *DominatingSuite) TestIsolatesAndDirection(c *C) {
+	// Each isolate must dominate itself; direction doesn't matter.
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 0),
+		gogl.NewArc(2, 0),
+		gogl.NewArc(3, 0),
+	}).Create(al.G).(gogl.MutableDigraph)
+	g.EnsureVertex("a", "b")
+
+	for _, set := range [][]gogl.Vertex{GreedyDominatingSet(g), MinDominatingSet(g)} {
+		c.Assert(set, HasLen, 3)
+		c.Assert(dominates(g, set), Equals, true)
+	}
+
+	c.Assert(GreedyDominatingSet(gogl.NullGraph), HasLen, 0)
+	c.Assert(MinDominatingSet(gogl.NullGraph), HasLen, 0)
+}
+
+func (s *DominatingSuite) TestRandomGraphs(c *C) {
+	for seed := int64(1); seed <= 20; seed++ {
+		g := gogl.Spec().Using(rand.BernoulliDistribution(12, 0.2, false, true, stdrand.NewSource(seed))).Create(al.G)
+
+		greedy := GreedyDominatingSet(g)
+		c.Assert(dominates(g, greedy), Equals, true, Commentf("seed %d", seed))
+
+		min := MinDominatingSet(g)
+		c.Assert(dominates(g, min), Equals, true, Commentf("seed %d", seed))
+		c.Assert(min, HasLen, bruteForceDomination(g), Commentf("seed %d", seed))
+		c.Assert(len(min) <= len(greedy), Equals, true, Commentf("seed %d", seed))
+	}
+}
@@ -0,0 +1,77 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Performs a depth-first traversal of g from root, recording the time at which
+// each vertex is entered (tin) and exited (tout).
+//
+// Each vertex's [tin, tout] interval contains the intervals of exactly those
+// vertices visited beneath it, so on a tree, ancestry can be answered in O(1) by
+// interval containment; see IsAncestor. On a DAG, a vertex reachable along
+// several paths is only visited beneath the first, so the intervals describe the
+// traversal's spanning tree rather than full reachability.
+//
+// Only vertices reachable from root receive timestamps. If root is not present in
+// the graph, both maps are empty. The traversal is iterative, so arbitrarily deep
+// trees pose no risk to the stack.
+func EulerTour(g gogl.Digraph, root gogl.Vertex) (tin, tout map[gogl.Vertex]int) {
+	tin = make(map[gogl.Vertex]int, sizeHint(g))
+	tout = make(map[gogl.Vertex]int, sizeHint(g))
+	if !g.HasVertex(root) {
+		return
+	}
+
+	type frame struct {
+		v    gogl.Vertex
+		succ []gogl.Vertex
+	}
+
+	var clock int
+	enter := func(v gogl.Vertex) frame {
+		tin[v] = clock
+		clock++
+
+		f := frame{v: v}
+		g.SuccessorsOf(v, func(w gogl.Vertex) (terminate bool) {
+			f.succ = append(f.succ, w)
+			return
+		})
+		return f
+	}
+
+	stack := []frame{enter(root)}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+
+		if len(top.succ) == 0 {
+			tout[top.v] = clock
+			clock++
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := top.succ[0]
+		top.succ = top.succ[1:]
+		if _, seen := tin[next]; !seen {
+			stack = append(stack, enter(next))
+		}
+	}
+
+	return
+}
+
+// Indicates whether u is an ancestor of v, according to the timestamps produced
+// by EulerTour. Every visited vertex is considered an ancestor of itself.
+//
+// If either vertex was not visited by the tour, false is returned.
+func IsAncestor(tin, tout map[gogl.Vertex]int, u, v gogl.Vertex) bool {
+	uin, uok := tin[u]
+	vin, vok := tin[v]
+	if !uok || !vok {
+		return false
+	}
+
+	return uin <= vin && tout[v] <= tout[u]
+}
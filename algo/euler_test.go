@@ -0,0 +1,97 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type EulerSuite struct{}
+
+var _ = Suite(&EulerSuite{})
+
+// Child -> parent relation for a small rooted tree:
+//
+//	     0
+//	   / | \
+//	  1  2  3
+//	 / \     \
+//	4   5     6
+//	    |
+//	    7
+var treeParents = map[gogl.Vertex]gogl.Vertex{
+	1: 0, 2: 0, 3: 0,
+	4: 1, 5: 1,
+	6: 3,
+	7: 5,
+}
+
+func treeFromParents(parents map[gogl.Vertex]gogl.Vertex) gogl.Digraph {
+	var arcs gogl.ArcList
+	for child, parent := range parents {
+		arcs = append(arcs, gogl.NewArc(parent, child))
+	}
+	return gogl.Spec().Directed().Using(arcs).Create(al.G).(gogl.Digraph)
+}
+
+// Determines ancestry directly, by walking up the parent relation.
+func walkAncestry(parents map[gogl.Vertex]gogl.Vertex, u, v gogl.Vertex) bool {
+	for {
+		if u == v {
+			return true
+		}
+		p, ok := parents[v]
+		if !ok {
+			return false
+		}
+		v = p
+	}
+}
+
+func (s *EulerSuite) TestAncestryMatchesTree(c *C) {
+	g := treeFromParents(treeParents)
+	tin, tout := EulerTour(g, 0)
+
+	c.Assert(len(tin), Equals, 8)
+	c.Assert(len(tout), Equals, 8)
+
+	for u := 0; u < 8; u++ {
+		c.Assert(tin[u] < tout[u], Equals, true)
+		for v := 0; v < 8; v++ {
+			c.Assert(IsAncestor(tin, tout, u, v), Equals, walkAncestry(treeParents, u, v), Commentf("u=%v, v=%v", u, v))
+		}
+	}
+}
+
+func (s *EulerSuite) TestSubtreeRoot(c *C) {
+	g := treeFromParents(treeParents)
+	tin, tout := EulerTour(g, 1)
+
+	// Only the subtree rooted at 1 is visited.
+	c.Assert(len(tin), Equals, 4)
+	c.Assert(IsAncestor(tin, tout, 1, 7), Equals, true)
+	c.Assert(IsAncestor(tin, tout, 0, 1), Equals, false)
+	c.Assert(IsAncestor(tin, tout, 1, 6), Equals, false)
+}
+
+func (s *EulerSuite) TestMissingRoot(c *C) {
+	tin, tout := EulerTour(treeFromParents(treeParents), 42)
+	c.Assert(tin, HasLen, 0)
+	c.Assert(tout, HasLen, 0)
+}
+
+func (s *EulerSuite) TestDeepPath(c *C) {
+	// Deep enough that a recursive traversal would be a real liability.
+	const depth = 200000
+	arcs := make(gogl.ArcList, depth-1)
+	for i := range arcs {
+		arcs[i] = gogl.NewArc(i, i+1)
+	}
+	g := gogl.Spec().Directed().Using(arcs).Create(al.G).(gogl.Digraph)
+
+	tin, tout := EulerTour(g, 0)
+	c.Assert(len(tin), Equals, depth)
+	c.Assert(IsAncestor(tin, tout, 0, depth-1), Equals, true)
+	c.Assert(IsAncestor(tin, tout, depth-1, 0), Equals, false)
+	c.Assert(IsAncestor(tin, tout, 1000, 1001), Equals, true)
+}
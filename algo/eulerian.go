@@ -0,0 +1,193 @@
+package algo
+
+import "github.com/sdboyer/gogl"
+
+// Indicates whether the graph has an Eulerian circuit: a closed walk that
+// traverses every edge exactly once. A graph without edges trivially has one.
+//
+// In an undirected graph, every vertex must have even degree; in a Digraph,
+// every vertex's in-degree must equal its out-degree. Either way, all the
+// vertices with edges must also be connected, weakly for a Digraph. Degrees are
+// tallied from the edges themselves, a self-loop counting twice in an undirected
+// graph, and once each way in a Digraph, rather than taken from DegreeOf, as
+// implementations differ in how they count loops there. Isolated vertices do
+// not matter.
+func HasEulerianCircuit(g gogl.Graph) bool {
+	eg := newEulerGraph(g)
+	for v := range eg.adj {
+		if eg.balance(v) != 0 {
+			return false
+		}
+	}
+	return eg.connected()
+}
+
+// Finds an Eulerian trail in the graph, a walk that traverses every edge exactly
+// once, returning its edges in the order they are traversed. The second return
+// value is false if the graph has no such trail. If the graph has an Eulerian
+// circuit, the trail returned is one; a graph without edges gives an empty one.
+//
+// An undirected graph has a trail iff it has at most two vertices of odd degree,
+// from one of which the trail must start; a Digraph, iff every vertex has equal
+// in- and out-degree, except perhaps for a start with one more out-arc than
+// in-arcs and an end with one more in-arc than out-arcs. As for
+// HasEulerianCircuit, the vertices with edges must also be connected.
+//
+// Hierholzer's algorithm, run iteratively, finds the trail in O(V+E) time. The
+// edges returned are those g enumerates, so parallel edges each appear once.
+// For an undirected graph, an edge's endpoints may be given either way round;
+// each edge shares the vertex it is entered from with the edge before it.
+func EulerianPath(g gogl.Graph) ([]gogl.Edge, bool) {
+	eg := newEulerGraph(g)
+
+	// A directed trail must start at the one vertex with a surplus of out-arcs,
+	// if there is one; an undirected trail, at either odd vertex, if any.
+	start := -1
+	var surplus, deficit int
+	for v := range eg.adj {
+		switch b := eg.balance(v); {
+		case b == 1:
+			if surplus++; surplus == 1 {
+				start = v
+			}
+		case b == -1:
+			deficit++
+		case b != 0:
+			return nil, false
+		case start == -1 && len(eg.adj[v]) > 0:
+			start = v
+		}
+	}
+	if eg.directed && (surplus > 1 || surplus != deficit) || !eg.directed && surplus != 0 && surplus != 2 {
+		return nil, false
+	}
+	if len(eg.edges) == 0 {
+		return []gogl.Edge{}, true
+	}
+
+	// Hierholzer's algorithm: walk unused edges until stuck, then back up,
+	// emitting edges in reverse as each vertex is exhausted.
+	used := make([]bool, len(eg.edges))
+	next := make([]int, len(eg.adj))
+	type step struct{ v, via int }
+	stack := []step{{start, -1}}
+	trail := make([]gogl.Edge, 0, len(eg.edges))
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		adj := eg.adj[top.v]
+		for next[top.v] < len(adj) && used[adj[next[top.v]].edge] {
+			next[top.v]++
+		}
+
+		if next[top.v] < len(adj) {
+			h := adj[next[top.v]]
+			used[h.edge] = true
+			stack = append(stack, step{h.to, h.edge})
+			continue
+		}
+
+		stack = stack[:len(stack)-1]
+		if top.via != -1 {
+			trail = append(trail, eg.edges[top.via])
+		}
+	}
+
+	// Edges left unused lie in another component.
+	if len(trail) < len(eg.edges) {
+		return nil, false
+	}
+	for i, j := 0, len(trail)-1; i < j; i, j = i+1, j-1 {
+		trail[i], trail[j] = trail[j], trail[i]
+	}
+	return trail, true
+}
+
+// A graph's edges, indexed so that each can be marked as traversed.
+type eulerGraph struct {
+	directed bool
+	edges    []gogl.Edge
+	// For each vertex index, the edges that may be traversed from it; in an
+	// undirected graph, every edge appears at both of its ends.
+	adj [][]halfEdge
+	// Each vertex's in-degree in a Digraph, counting arcs as they are added.
+	in []int
+}
+
+type halfEdge struct {
+	edge, to int
+}
+
+func newEulerGraph(g gogl.Graph) *eulerGraph {
+	index := make(map[interface{}]int, sizeHint(g)) // keyed by gogl.VertexKey
+	indexOf := func(v gogl.Vertex) int {
+		k := gogl.VertexKey(v)
+		i, seen := index[k]
+		if !seen {
+			i = len(index)
+			index[k] = i
+		}
+		return i
+	}
+
+	eg := &eulerGraph{}
+	add := func(e gogl.Edge, u, v gogl.Vertex) {
+		ui, vi := indexOf(u), indexOf(v)
+		for len(eg.adj) < len(index) {
+			eg.adj = append(eg.adj, nil)
+			eg.in = append(eg.in, 0)
+		}
+
+		ei := len(eg.edges)
+		eg.edges = append(eg.edges, e)
+		eg.adj[ui] = append(eg.adj[ui], halfEdge{ei, vi})
+		if eg.directed {
+			eg.in[vi]++
+		} else {
+			// A loop appears twice at its vertex, giving it degree 2.
+			eg.adj[vi] = append(eg.adj[vi], halfEdge{ei, ui})
+		}
+	}
+
+	if dg, ok := g.(gogl.Digraph); ok {
+		eg.directed = true
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			add(a, a.Source(), a.Target())
+			return
+		})
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			add(e, u, v)
+			return
+		})
+	}
+
+	return eg
+}
+
+// Returns how far the vertex is from having an Eulerian circuit through it: in a
+// Digraph, its out-degree less its in-degree; otherwise, its degree modulo 2.
+func (eg *eulerGraph) balance(v int) int {
+	if eg.directed {
+		return len(eg.adj[v]) - eg.in[v]
+	}
+	return len(eg.adj[v]) % 2
+}
+
+// Indicates whether every edge is in the same weakly connected component.
+func (eg *eulerGraph) connected() bool {
+	if len(eg.edges) == 0 {
+		return true
+	}
+
+	ds := newDisjointSet(len(eg.adj))
+	components := len(eg.adj)
+	for u, adj := range eg.adj {
+		for _, h := range adj {
+			if ds.union(u, h.to) {
+				components--
+			}
+		}
+	}
+	return components == 1
+}
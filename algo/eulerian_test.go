@@ -0,0 +1,153 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type EulerianSuite struct{}
+
+var _ = Suite(&EulerianSuite{})
+
+// Checks that the trail uses each of g's edges once, and that each edge leaves
+// from the vertex the one before it entered, returning the vertices visited.
+func checkTrail(c *C, g gogl.Graph, trail []gogl.Edge) []gogl.Vertex {
+	c.Assert(trail, HasLen, gogl.Size(g))
+
+	_, directed := g.(gogl.Digraph)
+	var walk []gogl.Vertex
+	for i, e := range trail {
+		c.Assert(g.HasEdge(e), Equals, true, Commentf("edge %v", e))
+		for _, f := range trail[:i] {
+			u, v := f.Both()
+			c.Assert(gogl.NewEdge(u, v) == e || gogl.NewEdge(v, u) == e, Equals, false, Commentf("edge %v repeated", e))
+		}
+
+		u, v := e.Both()
+		if i == 0 {
+			walk = append(walk, u)
+			if !directed {
+				// Which end the trail starts from depends on the next edge.
+				if len(trail) > 1 {
+					if x, y := trail[1].Both(); u == x || u == y {
+						walk[0] = v
+					}
+				}
+			}
+		}
+		at := walk[len(walk)-1]
+		switch {
+		case u == at:
+			walk = append(walk, v)
+		case !directed && v == at:
+			walk = append(walk, u)
+		default:
+			c.Fatalf("Edge %v does not leave from %v.", e, at)
+		}
+	}
+	return walk
+}
+
+func (s *EulerianSuite) TestCircuit(c *C) {
+	// Two triangles sharing the vertex a.
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 1}, {1, 4}, {4, 5}, {5, 1}}, identity)
+	c.Assert(HasEulerianCircuit(g), Equals, true)
+
+	trail, ok := EulerianPath(g)
+	c.Assert(ok, Equals, true)
+	walk := checkTrail(c, g, trail)
+	c.Assert(walk[0], Equals, walk[len(walk)-1])
+
+	c.Assert(HasEulerianCircuit(relabeledGraph(petersen, identity)), Equals, false)
+}
+
+func (s *EulerianSuite) TestPath(c *C) {
+	// A square with one diagonal: 1 and 3 have odd degree.
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}, {1, 3}}, identity)
+	c.Assert(HasEulerianCircuit(g), Equals, false)
+
+	trail, ok := EulerianPath(g)
+	c.Assert(ok, Equals, true)
+	walk := checkTrail(c, g, trail)
+	ends := map[gogl.Vertex]bool{walk[0]: true, walk[len(walk)-1]: true}
+	c.Assert(ends, DeepEquals, map[gogl.Vertex]bool{1: true, 3: true})
+
+	// A star has four odd vertices.
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}}, identity)
+	trail, ok = EulerianPath(star)
+	c.Assert(ok, Equals, false)
+	c.Assert(trail, IsNil)
+}
+
+func (s *EulerianSuite) TestDigraph(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "a"),
+		gogl.NewArc("a", "d"),
+		gogl.NewArc("d", "a"),
+	}).Create(al.G).(gogl.MutableDigraph)
+	c.Assert(HasEulerianCircuit(g), Equals, true)
+	trail, ok := EulerianPath(g)
+	c.Assert(ok, Equals, true)
+	walk := checkTrail(c, g, trail)
+	c.Assert(walk[0], Equals, walk[len(walk)-1])
+
+	// An extra arc leaves b with a surplus and d with a deficit, so the trail
+	// must run from b to d.
+	g.AddArcs(gogl.NewArc("b", "d"))
+	c.Assert(HasEulerianCircuit(g), Equals, false)
+	trail, ok = EulerianPath(g)
+	c.Assert(ok, Equals, true)
+	walk = checkTrail(c, g, trail)
+	c.Assert(walk[0], Equals, "b")
+	c.Assert(walk[len(walk)-1], Equals, "d")
+
+	// Balanced in total, but two vertices have a surplus.
+	g.AddArcs(gogl.NewArc("c", "d"), gogl.NewArc("a", "c"))
+	_, ok = EulerianPath(g)
+	c.Assert(ok, Equals, false)
+}
+
+func (s *EulerianSuite) TestDisconnected(c *C) {
+	// Two triangles: every degree is even, but no single walk covers both.
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 1}, {4, 5}, {5, 6}, {6, 4}}, identity)
+	c.Assert(HasEulerianCircuit(g), Equals, false)
+	_, ok := EulerianPath(g)
+	c.Assert(ok, Equals, false)
+
+	// Isolated vertices do not count.
+	tri := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 1}}, identity)
+	tri.(gogl.VertexSetMutator).EnsureVertex(4)
+	c.Assert(HasEulerianCircuit(tri), Equals, true)
+
+	c.Assert(HasEulerianCircuit(gogl.NullGraph), Equals, true)
+	trail, ok := EulerianPath(gogl.NullGraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(trail, HasLen, 0)
+}
+
+func (s *EulerianSuite) TestLoops(c *C) {
+	// A loop adds two to its vertex's degree, keeping parity.
+	g := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("b", "c"),
+		gogl.NewEdge("c", "a"),
+		gogl.NewEdge("b", "b"),
+	}).Create(al.G)
+	c.Assert(HasEulerianCircuit(g), Equals, true)
+	trail, ok := EulerianPath(g)
+	c.Assert(ok, Equals, true)
+	checkTrail(c, g, trail)
+
+	dg := gogl.Spec().Directed().Loop().Using(gogl.ArcList{
+		gogl.NewArc("a", "a"),
+		gogl.NewArc("a", "b"),
+	}).Create(al.G)
+	c.Assert(HasEulerianCircuit(dg), Equals, false)
+	trail, ok = EulerianPath(dg)
+	c.Assert(ok, Equals, true)
+	walk := checkTrail(c, dg, trail)
+	c.Assert(walk, DeepEquals, []gogl.Vertex{"a", "a", "b"})
+}
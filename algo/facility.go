@@ -0,0 +1,195 @@
+package algo
+
+import (
+	"errors"
+	"math"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Finds the weighted center of the graph: the vertices of least eccentricity,
+// where a vertex's eccentricity is its greatest shortest path distance to any
+// other vertex. Returned are the center and that least eccentricity, the graph's
+// radius. Placing a single facility at a center vertex minimizes the worst-case
+// distance from it to any vertex it serves - the 1-center problem.
+//
+// Distances are found by running Dijkstra's algorithm from every vertex, as
+// MultiSourceShortestPaths does, so edge weights must not be negative. Arc direction
+// is respected for Digraphs, measuring distance out from each vertex. The order
+// of the center vertices is not meaningful.
+//
+// An error is returned if the graph is empty, or if no vertex can reach all the
+// others, leaving every eccentricity infinite; for a disconnected graph, find the
+// center of each connected component separately.
+func WeightedCenter(g gogl.WeightedGraph) ([]gogl.Vertex, float64, error) {
+	vertices := gogl.CollectVertices(g)
+	if len(vertices) == 0 {
+		return nil, 0, errors.New("Cannot find the center of an empty graph.")
+	}
+
+	var center []gogl.Vertex
+	var radius float64
+	for i, dist := range dijkstraEach(g, vertices, 0) {
+		source := vertices[i]
+		if len(dist) < len(vertices) {
+			continue
+		}
+
+		var ecc float64
+		for _, d := range dist {
+			if d > ecc {
+				ecc = d
+			}
+		}
+
+		switch {
+		case center == nil || ecc < radius:
+			center, radius = []gogl.Vertex{source}, ecc
+		case ecc == radius:
+			center = append(center, source)
+		}
+	}
+
+	if center == nil {
+		return nil, 0, errors.New("No vertex can reach all the others, so the graph has no center.")
+	}
+	return center, radius, nil
+}
+
+// Chooses p facility vertices so as to minimize the greatest distance from any
+// vertex to its nearest facility - the p-center problem, as for siting emergency
+// services that must reach everyone quickly. Returned are the facilities and
+// that greatest distance.
+//
+// The problem is NP-hard, so facilities are chosen heuristically, as described
+// for PMedian. Among choices with the same greatest distance, the one with the
+// least total distance is preferred.
+func PCenter(g gogl.WeightedGraph, p int) ([]gogl.Vertex, float64) {
+	return locateFacilities(g, p, math.Max)
+}
+
+// Chooses p facility vertices so as to minimize the total distance from every
+// vertex to its nearest facility - the p-median problem, as for siting
+// warehouses to minimize the cost of serving all customers. Returned are the
+// facilities and that total distance.
+//
+// The problem is NP-hard, so a heuristic is used. Distances are found as for
+// WeightedCenter, so edge weights must not be negative. Facilities are first
+// added greedily, each time taking the vertex that most improves the objective.
+// The Teitz-Bart interchange heuristic then swaps facilities for other vertices
+// while any swap improves it, leaving a solution that no single swap can better.
+//
+// Arc direction is respected for Digraphs: each vertex is served from the
+// nearest facility that can reach it. If some vertex is unreachable from every
+// facility chosen, the objective is infinite; fewer vertices left unserved are
+// preferred over shorter distances. If p is at least the number of vertices,
+// every vertex is a facility. p must be positive, else panic.
+func PMedian(g gogl.WeightedGraph, p int) ([]gogl.Vertex, float64) {
+	return locateFacilities(g, p, func(total, d float64) float64 { return total + d })
+}
+
+// Runs the greedy and interchange heuristics of PMedian, with the objective
+// folding the distances from each vertex to its nearest facility by combine.
+func locateFacilities(g gogl.WeightedGraph, p int, combine func(acc, d float64) float64) ([]gogl.Vertex, float64) {
+	if p < 1 {
+		panic("p must be positive.")
+	}
+
+	vertices := gogl.CollectVertices(g)
+	n := len(vertices)
+	if p >= n {
+		return vertices, 0
+	}
+
+	dist := make([][]float64, n)
+	for i, d := range dijkstraEach(g, vertices, 0) {
+		row := make([]float64, n)
+		for j, v := range vertices {
+			row[j] = math.Inf(1)
+			if dv, reached := d[gogl.VertexKey(v)]; reached {
+				row[j] = dv
+			}
+		}
+		dist[i] = row
+	}
+
+	// Scores a set of facilities by the number of vertices none of them reaches,
+	// then the combined distances of the rest to their nearest facility, then
+	// the total of those distances. The total breaks the many ties a greatest
+	// distance leaves, steering the search toward serving everyone closer.
+	type score struct {
+		unserved    int
+		cost, total float64
+	}
+	better := func(a, b score) bool {
+		switch {
+		case a.unserved != b.unserved:
+			return a.unserved < b.unserved
+		case a.cost != b.cost:
+			return a.cost < b.cost
+		}
+		return a.total < b.total
+	}
+	evaluate := func(facilities []int) (sc score) {
+		for v := 0; v < n; v++ {
+			nearest := math.Inf(1)
+			for _, f := range facilities {
+				nearest = math.Min(nearest, dist[f][v])
+			}
+			if math.IsInf(nearest, 1) {
+				sc.unserved++
+			} else {
+				sc.cost = combine(sc.cost, nearest)
+				sc.total += nearest
+			}
+		}
+		return
+	}
+
+	chosen := make([]bool, n)
+	facilities := make([]int, 0, p)
+	var current score
+	for len(facilities) < p {
+		best := -1
+		var bestScore score
+		for v := 0; v < n; v++ {
+			if chosen[v] {
+				continue
+			}
+			if sc := evaluate(append(facilities, v)); best == -1 || better(sc, bestScore) {
+				best, bestScore = v, sc
+			}
+		}
+		chosen[best] = true
+		facilities = append(facilities, best)
+		current = bestScore
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for i, f := range facilities {
+			for v := 0; v < n; v++ {
+				if chosen[v] {
+					continue
+				}
+
+				facilities[i] = v
+				if sc := evaluate(facilities); better(sc, current) {
+					chosen[f], chosen[v] = false, true
+					f, current, improved = v, sc, true
+				} else {
+					facilities[i] = f
+				}
+			}
+		}
+	}
+
+	result := make([]gogl.Vertex, p)
+	for i, f := range facilities {
+		result[i] = vertices[f]
+	}
+	if current.unserved > 0 {
+		return result, math.Inf(1)
+	}
+	return result, current.cost
+}
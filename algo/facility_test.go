@@ -0,0 +1,221 @@
+package algo
+
+import (
+	"math"
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type FacilitySuite struct{}
+
+var _ = Suite(&FacilitySuite{})
+
+func (s *FacilitySuite) TestWeightedCenterPath(c *C) {
+	// The path is 7 long, so its weighted midpoint lies on the long last edge,
+	// 1.5 short of c; by hops alone, b would do as well.
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "c", 1),
+		gogl.NewWeightedEdge("c", "d", 5),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	center, radius, err := WeightedCenter(g)
+	c.Assert(err, IsNil)
+	c.Assert(center, DeepEquals, []gogl.Vertex{"c"})
+	c.Assert(radius, Equals, float64(5))
+
+	// Balanced weights give two centers.
+	g = gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 2),
+		gogl.NewWeightedEdge("b", "c", 3),
+		gogl.NewWeightedEdge("c", "d", 2),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	center, radius, err = WeightedCenter(g)
+	c.Assert(err, IsNil)
+	c.Assert(center, HasLen, 2)
+	c.Assert(map[gogl.Vertex]bool{center[0]: true, center[1]: true}, DeepEquals, map[gogl.Vertex]bool{"b": true, "c": true})
+	c.Assert(radius, Equals, float64(5))
+}
+
+func (s *FacilitySuite) TestWeightedCenterDirected(c *C) {
+	// Only a can reach everything.
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "b", 1),
+		gogl.NewWeightedArc("b", "c", 1),
+		gogl.NewWeightedArc("c", "b", 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	center, radius, err := WeightedCenter(g)
+	c.Assert(err, IsNil)
+	c.Assert(center, DeepEquals, []gogl.Vertex{"a"})
+	c.Assert(radius, Equals, float64(2))
+}
+
+func (s *FacilitySuite) TestWeightedCenterErrors(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("c", "d", 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	_, _, err := WeightedCenter(g)
+	c.Assert(err, ErrorMatches, ".*has no center.")
+
+	_, _, err = WeightedCenter(gogl.Spec().Weighted().Create(al.G).(gogl.WeightedGraph))
+	c.Assert(err, ErrorMatches, "Cannot find the center of an empty graph.")
+}
+
+func (s *FacilitySuite) TestWeightedCenterRandom(c *C) {
+	for seed := int64(1); seed <= 6; seed++ {
+		g := weightedBernoulli(25, 0.3, seed%2 == 0, seed)
+		n := gogl.Order(g)
+
+		ecc := make(map[gogl.Vertex]float64)
+		least := math.Inf(1)
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			dist := dijkstra(g, v)
+			ecc[v] = math.Inf(1)
+			if len(dist) == n {
+				ecc[v] = 0
+				for _, d := range dist {
+					ecc[v] = math.Max(ecc[v], d)
+				}
+			}
+			least = math.Min(least, ecc[v])
+			return
+		})
+
+		center, radius, err := WeightedCenter(g)
+		if math.IsInf(least, 1) {
+			c.Assert(err, NotNil, Commentf("seed %d", seed))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("seed %d", seed))
+		c.Assert(radius, Equals, least, Commentf("seed %d", seed))
+
+		var count int
+		for _, e := range ecc {
+			if e == least {
+				count++
+			}
+		}
+		c.Assert(center, HasLen, count, Commentf("seed %d", seed))
+		for _, v := range center {
+			c.Assert(ecc[v], Equals, least, Commentf("seed %d, vertex %v", seed, v))
+		}
+	}
+}
+
+// Two stars, each of a hub and three leaves, with hubs far apart.
+func twoStars() gogl.WeightedGraph {
+	return gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "a1", 1),
+		gogl.NewWeightedEdge("a", "a2", 1),
+		gogl.NewWeightedEdge("a", "a3", 1),
+		gogl.NewWeightedEdge("b", "b1", 1),
+		gogl.NewWeightedEdge("b", "b2", 1),
+		gogl.NewWeightedEdge("b", "b3", 1),
+		gogl.NewWeightedEdge("a", "b", 10),
+	}).Create(al.G).(gogl.WeightedGraph)
+}
+
+// Computes the distance from each vertex to its nearest facility.
+func nearestFacility(g gogl.WeightedGraph, facilities []gogl.Vertex) map[gogl.Vertex]float64 {
+	nearest := make(map[gogl.Vertex]float64)
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		nearest[v] = math.Inf(1)
+		return
+	})
+	for _, f := range facilities {
+		for v, d := range dijkstra(g, f) {
+			nearest[v] = math.Min(nearest[v], d)
+		}
+	}
+	return nearest
+}
+
+func medianCost(g gogl.WeightedGraph, facilities []gogl.Vertex) (total float64) {
+	for _, d := range nearestFacility(g, facilities) {
+		total += d
+	}
+	return
+}
+
+func centerCost(g gogl.WeightedGraph, facilities []gogl.Vertex) (most float64) {
+	for _, d := range nearestFacility(g, facilities) {
+		most = math.Max(most, d)
+	}
+	return
+}
+
+func (s *FacilitySuite) TestTwoStars(c *C) {
+	g := twoStars()
+	hubs := map[gogl.Vertex]bool{"a": true, "b": true}
+
+	facilities, cost := PMedian(g, 2)
+	c.Assert(cost, Equals, float64(6))
+	c.Assert(map[gogl.Vertex]bool{facilities[0]: true, facilities[1]: true}, DeepEquals, hubs)
+
+	facilities, cost = PCenter(g, 2)
+	c.Assert(cost, Equals, float64(1))
+	c.Assert(map[gogl.Vertex]bool{facilities[0]: true, facilities[1]: true}, DeepEquals, hubs)
+
+	// One facility serves both stars from a hub.
+	_, cost = PCenter(g, 1)
+	c.Assert(cost, Equals, float64(11))
+
+	facilities, cost = PMedian(g, 20)
+	c.Assert(facilities, HasLen, 8)
+	c.Assert(cost, Equals, float64(0))
+
+	c.Assert(func() { PMedian(g, 0) }, PanicMatches, "p must be positive.")
+}
+
+func (s *FacilitySuite) TestUnreachable(c *C) {
+	// Nothing reaches a, so it must be a facility for all to be served.
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "b", 1),
+		gogl.NewWeightedArc("c", "b", 1),
+		gogl.NewWeightedArc("b", "d", 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	facilities, cost := PMedian(g, 2)
+	c.Assert(map[gogl.Vertex]bool{facilities[0]: true, facilities[1]: true}, DeepEquals, map[gogl.Vertex]bool{"a": true, "c": true})
+	c.Assert(cost, Equals, float64(3))
+
+	// With one facility, a or c goes unserved.
+	_, cost = PCenter(g, 1)
+	c.Assert(math.IsInf(cost, 1), Equals, true)
+}
+
+func (s *FacilitySuite) TestBeatsRandom(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	for seed := int64(1); seed <= 4; seed++ {
+		g := weightedBernoulli(30, 0.15, false, seed)
+		vertices := gogl.CollectVertices(g)
+		const p = 4
+
+		median, medianObj := PMedian(g, p)
+		center, centerObj := PCenter(g, p)
+		c.Assert(median, HasLen, p)
+		c.Assert(center, HasLen, p)
+		c.Assert(medianObj, Equals, medianCost(g, median), Commentf("seed %d", seed))
+		c.Assert(centerObj, Equals, centerCost(g, center), Commentf("seed %d", seed))
+
+		var medianTotal, centerTotal float64
+		const trials = 50
+		for i := 0; i < trials; i++ {
+			var random []gogl.Vertex
+			for _, j := range r.Perm(len(vertices))[:p] {
+				random = append(random, vertices[j])
+			}
+			medianTotal += medianCost(g, random)
+			centerTotal += centerCost(g, random)
+		}
+		c.Assert(medianObj < medianTotal/trials, Equals, true, Commentf("seed %d: %v against %v", seed, medianObj, medianTotal/trials))
+		c.Assert(centerObj < centerTotal/trials, Equals, true, Commentf("seed %d: %v against %v", seed, centerObj, centerTotal/trials))
+	}
+}
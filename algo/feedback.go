@@ -0,0 +1,112 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Finds a small feedback vertex set of the graph: a set of vertices whose removal
+// leaves it acyclic. A Digraph is left a DAG; any other graph, a forest. Breaking
+// every cycle at a vertex is how a deadlock among processes is cleared, and how
+// loopy belief networks are conditioned into tractable ones.
+//
+// Finding a minimum such set is NP-hard, so a greedy heuristic is used. Any vertex
+// with a self-loop must be in the set, and is taken first. Then, repeatedly, the
+// vertices still on some cycle are found - those in a strongly connected
+// component of more than one vertex, for a Digraph, or in a 2-edge-connected
+// component of more than one, for an undirected graph - and the one with the most
+// edges to others in its component is removed, until no cycle remains. In an
+// undirected graph, parallel edges form a cycle of their own, so their endpoints
+// count as on one whatever their components. Ties are broken arbitrarily.
+//
+// The order of the vertices in the set is the order in which they were taken.
+func FeedbackVertexSet(g gogl.Graph) []gogl.Vertex {
+	dg, directed := g.(gogl.Digraph)
+
+	var set []gogl.Vertex
+	looped := make(map[interface{}]bool) // keyed by gogl.VertexKey
+	// The number of edges between each pair of distinct vertices, and the pair
+	// itself, keyed by the pair's gogl.VertexKeys; for an undirected graph, a pair
+	// is counted under whichever order was seen first.
+	multiplicity := make(map[[2]interface{}]int)
+	pairs := make(map[[2]interface{}][2]gogl.Vertex)
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		uk, vk := gogl.VertexKey(u), gogl.VertexKey(v)
+		if uk == vk {
+			if !looped[uk] {
+				looped[uk] = true
+				set = append(set, u)
+			}
+			return
+		}
+
+		key := [2]interface{}{uk, vk}
+		if _, seen := multiplicity[[2]interface{}{vk, uk}]; seen && !directed {
+			key = [2]interface{}{vk, uk}
+		}
+		if _, seen := pairs[key]; !seen {
+			pairs[key] = [2]gogl.Vertex{u, v}
+		}
+		multiplicity[key]++
+		return
+	})
+
+	// The remaining graph, whose cycles are left to break. It is simple, so
+	// neither loops nor parallel edges carry over.
+	var work gogl.Graph
+	if directed {
+		work = gogl.Spec().Directed().Using(dg).Create(al.G)
+	} else {
+		work = gogl.Spec().Using(g).Create(al.G)
+	}
+	vm := work.(gogl.VertexSetMutator)
+	for _, v := range set {
+		vm.RemoveVertex(v)
+	}
+
+	for {
+		var component map[interface{}]int // keyed by gogl.VertexKey
+		if directed {
+			component = stronglyConnected(work.(gogl.Digraph))
+		} else {
+			component = make(map[interface{}]int, sizeHint(work))
+			components := lowLinkSearch(undirectedAdjacency(work)).components
+			for i, c := range components {
+				for _, v := range c {
+					component[gogl.VertexKey(v)] = i
+				}
+			}
+		}
+
+		degree := make(map[interface{}]int) // keyed by gogl.VertexKey
+		for key, count := range multiplicity {
+			uk, vk := key[0], key[1]
+			cu, uok := component[uk]
+			cv, vok := component[vk]
+			if !uok || !vok {
+				continue
+			}
+			if cu == cv || (!directed && count > 1) {
+				degree[uk] += count
+				degree[vk] += count
+			}
+		}
+
+		var best gogl.Vertex
+		var most int
+		for key, pair := range pairs {
+			for i, k := range key {
+				if d := degree[k]; d > most {
+					best, most = pair[i], d
+				}
+			}
+		}
+		if most == 0 {
+			return set
+		}
+
+		set = append(set, best)
+		vm.RemoveVertex(best)
+	}
+}
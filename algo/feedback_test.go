@@ -0,0 +1,154 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/graph/multi"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type FeedbackVertexSetSuite struct{}
+
+var _ = Suite(&FeedbackVertexSetSuite{})
+
+// Checks that removing the given vertices leaves g without cycles: a DAG if g is
+// a Digraph, or a forest otherwise.
+func checkFeedbackVertexSet(c *C, g gogl.Graph, set []gogl.Vertex) {
+	removed := make(map[gogl.Vertex]bool)
+	for _, v := range set {
+		c.Assert(g.HasVertex(v), Equals, true)
+		c.Assert(removed[v], Equals, false, Commentf("%v taken twice", v))
+		removed[v] = true
+	}
+
+	var kept []gogl.Edge
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		if u, v := e.Both(); !removed[u] && !removed[v] {
+			c.Assert(u, Not(Equals), v, Commentf("loop on %v survives", u))
+			kept = append(kept, e)
+		}
+		return
+	})
+
+	if _, directed := g.(gogl.Digraph); directed {
+		rest := gogl.Spec().Directed().Create(al.G).(gogl.MutableDigraph)
+		for _, e := range kept {
+			u, v := e.Both()
+			rest.AddArcs(gogl.NewArc(u, v))
+		}
+		c.Assert(IsDAG(rest.(gogl.Digraph)), Equals, true, Commentf("set %v", set))
+		return
+	}
+
+	// A forest has one edge fewer than vertices for each of its components.
+	rest := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		if !removed[v] {
+			rest.EnsureVertex(v)
+		}
+		return
+	})
+	rest.AddEdges(kept...)
+	c.Assert(len(kept), Equals, gogl.Order(rest)-len(ConnectedComponents(rest)), Commentf("set %v", set))
+}
+
+func (s *FeedbackVertexSetSuite) TestIntersectingCycles(c *C) {
+	// Three triangles sharing vertex 0, plus a square sharing an edge with one of
+	// them; 0 and any vertex of the square break them all.
+	g := relabeledGraph([][2]int{
+		{0, 1}, {1, 2}, {2, 0},
+		{0, 3}, {3, 4}, {4, 0},
+		{0, 5}, {5, 6}, {6, 0},
+		{1, 7}, {7, 8}, {8, 2},
+		{6, 9},
+	}, identity)
+
+	set := FeedbackVertexSet(g)
+	checkFeedbackVertexSet(c, g, set)
+	c.Assert(set, HasLen, 2)
+	c.Assert(set[0], Equals, 0)
+
+	// The same, as cycles of arcs, with 1 and 2 also forming a cycle of their own;
+	// now it takes one of them to break what 0 does not.
+	dg := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(0, 1), gogl.NewArc(1, 2), gogl.NewArc(2, 0),
+		gogl.NewArc(0, 3), gogl.NewArc(3, 4), gogl.NewArc(4, 0),
+		gogl.NewArc(0, 5), gogl.NewArc(5, 6), gogl.NewArc(6, 0),
+		gogl.NewArc(1, 7), gogl.NewArc(7, 8), gogl.NewArc(8, 2),
+		gogl.NewArc(2, 1),
+		gogl.NewArc(6, 9),
+	}).Create(al.G)
+
+	set = FeedbackVertexSet(dg)
+	checkFeedbackVertexSet(c, dg, set)
+	c.Assert(set, HasLen, 2)
+	c.Assert(set[0], Equals, 0)
+}
+
+func (s *FeedbackVertexSetSuite) TestAcyclic(c *C) {
+	tree := relabeledGraph([][2]int{{1, 2}, {1, 3}, {3, 4}, {3, 5}}, identity)
+	c.Assert(FeedbackVertexSet(tree), HasLen, 0)
+
+	// Arcs that would form a cycle if undirected do not in a DAG.
+	dag := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("a", "c"),
+		gogl.NewArc("b", "d"),
+		gogl.NewArc("c", "d"),
+	}).Create(al.G)
+	c.Assert(FeedbackVertexSet(dag), HasLen, 0)
+
+	c.Assert(FeedbackVertexSet(gogl.NullGraph), HasLen, 0)
+}
+
+func (s *FeedbackVertexSetSuite) TestLoopsAndParallelEdges(c *C) {
+	// A loop on a, and a pair of parallel edges between c and d; the path
+	// through b is no cycle.
+	edges := gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge("a", "a", "loop"),
+		gogl.NewLabeledEdge("a", "b", "x"),
+		gogl.NewLabeledEdge("b", "c", "x"),
+		gogl.NewLabeledEdge("c", "d", "x"),
+		gogl.NewLabeledEdge("d", "c", "y"),
+	}
+	g := gogl.Spec().Immutable().Labeled().PseudoGraph().Using(edges).Create(multi.G)
+
+	set := FeedbackVertexSet(g)
+	checkFeedbackVertexSet(c, g, set)
+	c.Assert(set, HasLen, 2)
+	c.Assert(set[0], Equals, "a")
+
+	// In a digraph, a pair of opposing arcs is a cycle, but parallel ones are not.
+	arcs := gogl.LabeledArcList{
+		gogl.NewLabeledArc("a", "b", "x"),
+		gogl.NewLabeledArc("a", "b", "y"),
+		gogl.NewLabeledArc("c", "d", "x"),
+		gogl.NewLabeledArc("d", "c", "x"),
+	}
+	dg := gogl.Spec().Directed().Immutable().Labeled().PseudoGraph().Using(arcs).Create(multi.G)
+
+	set = FeedbackVertexSet(dg)
+	checkFeedbackVertexSet(c, dg, set)
+	c.Assert(set, HasLen, 1)
+}
+
+func (s *FeedbackVertexSetSuite) TestRandom(c *C) {
+	for seed := int64(1); seed <= 10; seed++ {
+		directed := seed%2 == 0
+		src := rand.BernoulliDistribution(40, 0.08, directed, true, stdrand.NewSource(seed))
+
+		var g gogl.Graph
+		if directed {
+			g = gogl.Spec().Directed().Using(src).Create(al.G)
+		} else {
+			g = gogl.Spec().Using(src).Create(al.G)
+		}
+
+		set := FeedbackVertexSet(g)
+		checkFeedbackVertexSet(c, g, set)
+		c.Assert(len(set) < gogl.Order(g)/2, Equals, true, Commentf("seed %d: %d vertices taken", seed, len(set)))
+	}
+}
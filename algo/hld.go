@@ -0,0 +1,142 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// A heavy-light decomposition of a tree, as produced by HeavyLightDecomposition.
+//
+// Every vertex is assigned a position in [0, V). The tree is split into chains,
+// each running down from a chain head through successive heavy children (the
+// child with the largest subtree); the vertices of a chain occupy contiguous
+// positions, increasing with depth. Any path in the tree crosses O(log V) chains,
+// so a path query against a segment tree laid out by position takes O(log² V).
+type HLD struct {
+	// Vertices are numbered by their place in breadth-first order from the root.
+	// parent, depth, head and pos are indexed by that number; vertex, by position.
+	index  map[interface{}]int // keyed by gogl.VertexKey
+	parent []int
+	depth  []int
+	head   []int
+	pos    []int
+	vertex []gogl.Vertex // by position
+}
+
+// Decomposes the given tree, rooted at root, into heavy and light chains.
+//
+// Edge direction is ignored. An error is returned if the root is not present in
+// the graph, or if the graph is not a tree - that is, if it is not connected, or
+// contains a cycle.
+func HeavyLightDecomposition(g gogl.Graph, root gogl.Vertex) (*HLD, error) {
+	order, parent, children, err := rootTree(g, root)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(order)
+	h := &HLD{
+		index:  make(map[interface{}]int, n),
+		parent: parent,
+		depth:  make([]int, n),
+		head:   make([]int, n),
+		pos:    make([]int, n),
+		vertex: make([]gogl.Vertex, 0, n),
+	}
+
+	for i, v := range order {
+		h.index[gogl.VertexKey(v)] = i
+		if i > 0 {
+			h.depth[i] = h.depth[parent[i]] + 1
+		}
+	}
+
+	size := make([]int, n)
+	heavy := make([]int, n)
+	for v := n - 1; v >= 0; v-- {
+		size[v]++
+		heavy[v] = -1
+		best := 0
+		for _, c := range children[v] {
+			size[v] += size[c]
+			if size[c] > best {
+				best, heavy[v] = size[c], c
+			}
+		}
+	}
+
+	// Lay out each chain contiguously, starting a new chain at every light child.
+	heads := []int{0}
+	for len(heads) > 0 {
+		top := heads[len(heads)-1]
+		heads = heads[:len(heads)-1]
+
+		for v := top; v != -1; v = heavy[v] {
+			h.head[v] = top
+			h.pos[v] = len(h.vertex)
+			h.vertex = append(h.vertex, order[v])
+
+			for _, c := range children[v] {
+				if c != heavy[v] {
+					heads = append(heads, c)
+				}
+			}
+		}
+	}
+
+	return h, nil
+}
+
+// Returns the position assigned to the given vertex, and whether the vertex is in
+// the decomposed tree.
+func (h *HLD) Pos(v gogl.Vertex) (pos int, exists bool) {
+	if i, exists := h.index[gogl.VertexKey(v)]; exists {
+		return h.pos[i], true
+	}
+	return 0, false
+}
+
+// Returns the vertex at the given position, or nil if the position is out of range.
+func (h *HLD) Vertex(pos int) gogl.Vertex {
+	if pos < 0 || pos >= len(h.vertex) {
+		return nil
+	}
+	return h.vertex[pos]
+}
+
+// Returns the ranges of positions covering the path from u to v, in order.
+//
+// Each range [a, b] is inclusive and lies within a single chain. Ranges are
+// oriented in the direction of travel: where a > b, the path runs from position a
+// down to b. Walking each range in turn, from a to b, visits exactly the vertices
+// of the path from u to v. If either vertex is not in the tree, nil is returned.
+func (h *HLD) Path(u, v gogl.Vertex) [][2]int {
+	a, exists := h.index[gogl.VertexKey(u)]
+	if !exists {
+		return nil
+	}
+	b, exists := h.index[gogl.VertexKey(v)]
+	if !exists {
+		return nil
+	}
+
+	// Climb from whichever end sits on the deeper chain until both are on the
+	// same chain. Ranges from u's side run upward; those from v's side run
+	// downward, and are collected in reverse.
+	var up, down [][2]int
+	for h.head[a] != h.head[b] {
+		if ha, hb := h.head[a], h.head[b]; h.depth[ha] >= h.depth[hb] {
+			up = append(up, [2]int{h.pos[a], h.pos[ha]})
+			a = h.parent[ha]
+		} else {
+			down = append(down, [2]int{h.pos[hb], h.pos[b]})
+			b = h.parent[hb]
+		}
+	}
+	up = append(up, [2]int{h.pos[a], h.pos[b]})
+
+	for i := len(down) - 1; i >= 0; i-- {
+		up = append(up, down[i])
+	}
+
+	return up
+}
@@ -0,0 +1,149 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type HLDSuite struct{}
+
+var _ = Suite(&HLDSuite{})
+
+// Finds the path from u to v directly, by walking both up to their lowest common ancestor.
+func parentPath(parents map[gogl.Vertex]gogl.Vertex, u, v gogl.Vertex) []gogl.Vertex {
+	ancestors := map[gogl.Vertex]int{}
+	var up []gogl.Vertex
+	for x, ok := u, true; ok; x, ok = parents[x] {
+		ancestors[x] = len(up)
+		up = append(up, x)
+	}
+
+	var down []gogl.Vertex
+	x := v
+	for {
+		if i, common := ancestors[x]; common {
+			up = up[:i+1]
+			break
+		}
+		down = append(down, x)
+		x = parents[x]
+	}
+
+	for i := len(down) - 1; i >= 0; i-- {
+		up = append(up, down[i])
+	}
+	return up
+}
+
+// Expands the ranges returned by HLD.Path into the vertices they cover.
+func expandRanges(h *HLD, ranges [][2]int) []gogl.Vertex {
+	var path []gogl.Vertex
+	for _, r := range ranges {
+		step := 1
+		if r[0] > r[1] {
+			step = -1
+		}
+		for i := r[0]; ; i += step {
+			path = append(path, h.Vertex(i))
+			if i == r[1] {
+				break
+			}
+		}
+	}
+	return path
+}
+
+func undirectedTree(parents map[gogl.Vertex]gogl.Vertex) gogl.Graph {
+	var edges gogl.EdgeList
+	for child, parent := range parents {
+		edges = append(edges, gogl.NewEdge(parent, child))
+	}
+	return gogl.Spec().Using(edges).Create(al.G)
+}
+
+func (s *HLDSuite) TestPathsReconstruct(c *C) {
+	h, err := HeavyLightDecomposition(undirectedTree(treeParents), 0)
+	c.Assert(err, IsNil)
+
+	for u := 0; u < 8; u++ {
+		for v := 0; v < 8; v++ {
+			c.Assert(expandRanges(h, h.Path(u, v)), DeepEquals, parentPath(treeParents, u, v), Commentf("u=%v, v=%v", u, v))
+		}
+	}
+}
+
+func (s *HLDSuite) TestPositions(c *C) {
+	h, err := HeavyLightDecomposition(undirectedTree(treeParents), 0)
+	c.Assert(err, IsNil)
+
+	seen := make(map[int]bool)
+	for v := 0; v < 8; v++ {
+		pos, exists := h.Pos(v)
+		c.Assert(exists, Equals, true)
+		c.Assert(h.Vertex(pos), Equals, v)
+		seen[pos] = true
+	}
+	c.Assert(len(seen), Equals, 8)
+
+	// 0-1-5-7 is the heaviest chain, so it is laid out first, and contiguously.
+	c.Assert(h.Path(0, 7), DeepEquals, [][2]int{{0, 3}})
+
+	_, exists := h.Pos(42)
+	c.Assert(exists, Equals, false)
+	c.Assert(h.Vertex(8), IsNil)
+	c.Assert(h.Path(0, 42), IsNil)
+}
+
+func (s *HLDSuite) TestRandomTree(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	parents := make(map[gogl.Vertex]gogl.Vertex)
+	for i := 1; i < 500; i++ {
+		parents[i] = r.Intn(i)
+	}
+
+	// Edge direction is ignored, so a digraph with arcs pointing either way works too.
+	var arcs gogl.ArcList
+	for child, parent := range parents {
+		if r.Intn(2) == 0 {
+			arcs = append(arcs, gogl.NewArc(parent, child))
+		} else {
+			arcs = append(arcs, gogl.NewArc(child, parent))
+		}
+	}
+	g := gogl.Spec().Directed().Using(arcs).Create(al.G)
+
+	h, err := HeavyLightDecomposition(g, 0)
+	c.Assert(err, IsNil)
+
+	for i := 0; i < 1000; i++ {
+		u, v := r.Intn(500), r.Intn(500)
+		ranges := h.Path(u, v)
+		c.Assert(expandRanges(h, ranges), DeepEquals, parentPath(parents, u, v), Commentf("u=%v, v=%v", u, v))
+
+		// Each side of the path crosses at most log2(V) light edges.
+		c.Assert(len(ranges) <= 2*9+1, Equals, true, Commentf("%d ranges for u=%v, v=%v", len(ranges), u, v))
+	}
+}
+
+func (s *HLDSuite) TestRejectsNonTrees(c *C) {
+	_, err := HeavyLightDecomposition(undirectedTree(treeParents), 42)
+	c.Assert(err, ErrorMatches, "Root vertex is not present in graph.")
+
+	cycle := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge(1, 2),
+		gogl.NewEdge(2, 3),
+		gogl.NewEdge(3, 1),
+	}).Create(al.G)
+	_, err = HeavyLightDecomposition(cycle, 1)
+	c.Assert(err, ErrorMatches, "Graph is not a tree: it contains a cycle.")
+
+	forest := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge(1, 2),
+		gogl.NewEdge(3, 4),
+	}).Create(al.G)
+	_, err = HeavyLightDecomposition(forest, 1)
+	c.Assert(err, ErrorMatches, "Graph is not a tree: it is not connected.")
+}
@@ -0,0 +1,147 @@
+package algo
+
+import (
+	"fmt"
+	"sort"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type IdentifiableSuite struct{}
+
+var _ = Suite(&IdentifiableSuite{})
+
+// A vertex carrying a map, which is not comparable, and so would panic if used as
+// a map key.
+type mapVertex struct {
+	id    string
+	attrs map[string]int
+}
+
+func (v mapVertex) ID() string { return v.id }
+
+func mapLabel(i int) gogl.Vertex {
+	return mapVertex{fmt.Sprint(i), map[string]int{"n": i}}
+}
+
+func vertexIDs(vertices []gogl.Vertex) []string {
+	ids := make([]string, len(vertices))
+	for i, v := range vertices {
+		ids[i] = v.(mapVertex).id
+	}
+	return ids
+}
+
+// Orients each pair of the Petersen graph through the given function.
+func orientedPetersen(orient func(p [2]int) (int, int)) gogl.Digraph {
+	arcs := make(gogl.ArcList, 0, len(petersen))
+	for _, p := range petersen {
+		u, v := orient(p)
+		arcs = append(arcs, gogl.NewArc(mapLabel(u), mapLabel(v)))
+	}
+	return gogl.Spec().Directed().Using(arcs).Create(al.G).(gogl.Digraph)
+}
+
+func (s *IdentifiableSuite) TestUndirected(c *C) {
+	g := relabeledGraph(petersen, mapLabel)
+
+	c.Assert(ConnectedComponents(g), HasLen, 1)
+	c.Assert(Bridges(g), HasLen, 0)
+	c.Assert(BiconnectedComponents(g), HasLen, 1)
+	c.Assert(HasCycle(g), Equals, true)
+
+	d, err := Diameter(g)
+	c.Assert(err, IsNil)
+	c.Assert(d, Equals, 2)
+	r, err := Radius(g)
+	c.Assert(err, IsNil)
+	c.Assert(r, Equals, 2)
+
+	c.Assert(ChromaticNumber(g), Equals, 3)
+	k, _ := Degeneracy(g)
+	c.Assert(k, Equals, 3)
+
+	var cliques int
+	MaximalCliques(g, func(clique []gogl.Vertex) (terminate bool) {
+		c.Assert(clique, HasLen, 2)
+		cliques++
+		return
+	})
+	c.Assert(cliques, Equals, len(petersen))
+
+	outer := []gogl.Vertex{mapLabel(0), mapLabel(1), mapLabel(2), mapLabel(3), mapLabel(4)}
+	c.Assert(IsPath(g, outer), Equals, true)
+	c.Assert(IsCycle(g, append(outer, outer[0])), Equals, true)
+	c.Assert(IsCycle(g, append(outer[:3:3], outer[0])), Equals, false)
+
+	sub := InducedSubgraph(g, outer)
+	c.Assert(gogl.Order(sub), Equals, 5)
+	c.Assert(gogl.Size(sub), Equals, 5)
+
+	c.Assert(EqualUpToIsomorphism(g, relabeledGraph(petersen, identity)), Equals, true)
+}
+
+func (s *IdentifiableSuite) TestDirected(c *C) {
+	dag := orientedPetersen(func(p [2]int) (int, int) {
+		if p[0] > p[1] {
+			return p[1], p[0]
+		}
+		return p[0], p[1]
+	})
+	c.Assert(IsDAG(dag), Equals, true)
+	_, found := FindCycle(dag)
+	c.Assert(found, Equals, false)
+
+	order, err := TopologicalSort(dag)
+	c.Assert(err, IsNil)
+	c.Assert(order, HasLen, 10)
+	pos := make(map[string]int)
+	for i, id := range vertexIDs(order) {
+		pos[id] = i
+	}
+	for _, p := range petersen {
+		u, v := fmt.Sprint(p[0]), fmt.Sprint(p[1])
+		if p[0] > p[1] {
+			u, v = v, u
+		}
+		c.Assert(pos[u] < pos[v], Equals, true, Commentf("%s->%s", u, v))
+	}
+
+	dg := orientedPetersen(func(p [2]int) (int, int) { return p[0], p[1] })
+	c.Assert(IsDAG(dg), Equals, false)
+	cycle, found := FindCycle(dg)
+	c.Assert(found, Equals, true)
+	c.Assert(IsCycle(dg, cycle), Equals, true)
+
+	var sizes []int
+	for _, comp := range StronglyConnectedComponents(dg) {
+		sizes = append(sizes, len(comp))
+	}
+	sort.Ints(sizes)
+	var total int
+	for _, n := range sizes {
+		total += n
+	}
+	c.Assert(total, Equals, 10)
+	c.Assert(sizes[len(sizes)-1] >= 5, Equals, true)
+}
+
+func (s *IdentifiableSuite) TestShortestPath(c *C) {
+	edges := make(gogl.WeightedEdgeList, 0, len(petersen))
+	for _, p := range petersen {
+		edges = append(edges, gogl.NewWeightedEdge(mapLabel(p[0]), mapLabel(p[1]), float64(p[0]+p[1])))
+	}
+	g := gogl.Spec().Weighted().Using(edges).Create(al.G).(gogl.WeightedGraph)
+
+	path, cost, err := ShortestPath(g, mapLabel(0), mapLabel(5))
+	c.Assert(err, IsNil)
+	c.Assert(vertexIDs(path), DeepEquals, []string{"0", "5"})
+	c.Assert(cost, Equals, 5.0)
+
+	path, cost, err = ShortestPath(g, mapLabel(3), mapLabel(9))
+	c.Assert(err, IsNil)
+	c.Assert(vertexIDs(path), DeepEquals, []string{"3", "4", "9"})
+	c.Assert(cost, Equals, 20.0)
+}
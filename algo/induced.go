@@ -0,0 +1,37 @@
+package algo
+
+import "github.com/sdboyer/gogl"
+
+// Returns the subgraph of g induced by the given vertices: those vertices, and
+// every edge of g whose endpoints are both among them. Extracting each cluster
+// of a partition this way lets it be examined as a graph in its own right.
+//
+// The result has g's directedness and edge type, and its edges keep their
+// weights, labels or data. Given vertices that are not present in g are
+// ignored, rather than added as isolates, and duplicates are ignored too. The
+// result is a mutable adjacency list.
+func InducedSubgraph(g gogl.Graph, vertices []gogl.Vertex) gogl.Graph {
+	members := make(map[interface{}]bool, len(vertices)) // keyed by gogl.VertexKey
+	var present []gogl.Vertex
+	for _, v := range vertices {
+		if k := gogl.VertexKey(v); !members[k] && g.HasVertex(v) {
+			members[k] = true
+			present = append(present, v)
+		}
+	}
+
+	var edges []gogl.Edge
+	keep := func(e gogl.Edge) (terminate bool) {
+		if u, v := e.Both(); members[gogl.VertexKey(u)] && members[gogl.VertexKey(v)] {
+			edges = append(edges, e)
+		}
+		return
+	}
+	if dg, directed := g.(gogl.Digraph); directed {
+		dg.Arcs(func(a gogl.Arc) bool { return keep(a) })
+	} else {
+		g.Edges(keep)
+	}
+
+	return rebuildLike(g, present, edges)
+}
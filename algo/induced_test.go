@@ -0,0 +1,53 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type InducedSuite struct{}
+
+var _ = Suite(&InducedSuite{})
+
+func (s *InducedSuite) TestWeighted(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "c", 2),
+		gogl.NewWeightedEdge("c", "a", 3),
+		gogl.NewWeightedEdge("c", "d", 4),
+	}).Create(al.G)
+
+	sub := InducedSubgraph(g, []gogl.Vertex{"a", "c", "d", "a", "nope"})
+	wg, ok := sub.(gogl.WeightedGraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(gogl.Order(sub), Equals, 3)
+	c.Assert(sub.HasVertex("nope"), Equals, false)
+	c.Assert(gogl.Size(sub), Equals, 2)
+	c.Assert(wg.HasWeightedEdge(gogl.NewWeightedEdge("a", "c", 3)), Equals, true)
+	c.Assert(wg.HasWeightedEdge(gogl.NewWeightedEdge("c", "d", 4)), Equals, true)
+
+	// An isolated member stays, without edges.
+	sub = InducedSubgraph(g, []gogl.Vertex{"a", "d"})
+	c.Assert(gogl.Order(sub), Equals, 2)
+	c.Assert(gogl.Size(sub), Equals, 0)
+
+	c.Assert(gogl.Order(InducedSubgraph(g, nil)), Equals, 0)
+}
+
+func (s *InducedSuite) TestDirected(c *C) {
+	g := gogl.Spec().Directed().Loop().DataEdges().Using(gogl.DataArcList{
+		gogl.NewDataArc(1, 2, "x"),
+		gogl.NewDataArc(2, 1, "y"),
+		gogl.NewDataArc(2, 2, "loop"),
+		gogl.NewDataArc(2, 3, "z"),
+	}).Create(al.G)
+
+	sub := InducedSubgraph(g, []gogl.Vertex{1, 2})
+	dg, ok := sub.(gogl.DataDigraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(gogl.Size(sub), Equals, 3)
+	c.Assert(dg.HasDataArc(gogl.NewDataArc(1, 2, "x")), Equals, true)
+	c.Assert(dg.HasDataArc(gogl.NewDataArc(2, 1, "y")), Equals, true)
+	c.Assert(dg.HasDataArc(gogl.NewDataArc(2, 2, "loop")), Equals, true)
+}
@@ -0,0 +1,377 @@
+package algo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Searches for an isomorphism between two graphs using a VF2-style state space
+// search, returning a mapping from the vertices of a to the vertices of b.
+//
+// Two graphs are isomorphic if there exists a bijection between their vertex sets
+// such that u and v are adjacent in a iff their images are adjacent in b. For
+// digraphs, arc direction must be preserved as well; a Digraph is never isomorphic
+// to an undirected graph. Edge metadata (labels, weights, data) is disregarded.
+//
+// If no isomorphism exists, the returned map is nil and the second return value is false.
+func VF2(a, b gogl.Graph) (mapping map[gogl.Vertex]gogl.Vertex, found bool) {
+	mapping, found, _ = VF2Context(context.Background(), a, b)
+	return
+}
+
+// Performs the same search as VF2, but abandons it if the provided context is
+// cancelled or its deadline passes. The context is checked at every step of the
+// search; once it is done, the context's error is returned promptly.
+func VF2Context(ctx context.Context, a, b gogl.Graph) (mapping map[gogl.Vertex]gogl.Vertex, found bool, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	ma := newMatchGraph(a)
+	mb := newMatchGraph(b)
+	if !ma.couldBeIsomorphic(mb) {
+		return nil, false, nil
+	}
+
+	st := newMatchState(ma, mb, true, nil)
+	st.halt = haltOn(ctx, &err)
+
+	st.match(func(core []int) bool {
+		mapping = make(map[gogl.Vertex]gogl.Vertex, len(core))
+		for p, t := range core {
+			mapping[ma.vertices[p]] = mb.vertices[t]
+		}
+		found = true
+		return true
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+	return
+}
+
+// Indicates whether the two graphs have the same structure, regardless of the
+// identity of their vertices. That is, whether there exists an isomorphism
+// between them.
+//
+// This is what is typically wanted when comparing two independently constructed
+// graphs. Edge metadata (labels, weights, data) is disregarded; see
+// EqualUpToLabeledIsomorphism and EqualUpToWeightedIsomorphism for stricter variants.
+func EqualUpToIsomorphism(a, b gogl.Graph) bool {
+	return equalUpToIsomorphismWith(a, b, nil)
+}
+
+// Indicates whether there exists an isomorphism between the two labeled graphs
+// under which every edge maps to an edge carrying the same label.
+func EqualUpToLabeledIsomorphism(a, b gogl.LabeledGraph) bool {
+	return equalUpToIsomorphismWith(a, b, func(x, y gogl.Edge) bool {
+		lx, okx := x.(gogl.LabeledEdge)
+		ly, oky := y.(gogl.LabeledEdge)
+		return okx && oky && lx.Label() == ly.Label()
+	})
+}
+
+// Indicates whether there exists an isomorphism between the two weighted graphs
+// under which every edge maps to an edge carrying the same weight.
+func EqualUpToWeightedIsomorphism(a, b gogl.WeightedGraph) bool {
+	return equalUpToIsomorphismWith(a, b, func(x, y gogl.Edge) bool {
+		wx, okx := x.(gogl.WeightedEdge)
+		wy, oky := y.(gogl.WeightedEdge)
+		return okx && oky && wx.Weight() == wy.Weight()
+	})
+}
+
+func equalUpToIsomorphismWith(a, b gogl.Graph, edgeEq func(x, y gogl.Edge) bool) (found bool) {
+	ma := newMatchGraph(a)
+	mb := newMatchGraph(b)
+	if !ma.couldBeIsomorphic(mb) {
+		return false
+	}
+
+	newMatchState(ma, mb, true, edgeEq).match(func([]int) bool {
+		found = true
+		return true
+	})
+	return
+}
+
+// An integer-indexed snapshot of a graph's structure, used as one side of a
+// matching search.
+type matchGraph struct {
+	directed bool
+	vertices []gogl.Vertex
+	index    map[interface{}]int // keyed by gogl.VertexKey
+	out      []map[int]gogl.Edge // for undirected graphs, out and in are the same
+	in       []map[int]gogl.Edge
+}
+
+func newMatchGraph(g gogl.Graph) *matchGraph {
+	n := sizeHint(g)
+	mg := &matchGraph{
+		vertices: make([]gogl.Vertex, 0, n),
+		index:    make(map[interface{}]int, n),
+	}
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		mg.index[gogl.VertexKey(v)] = len(mg.vertices)
+		mg.vertices = append(mg.vertices, v)
+		return
+	})
+
+	mg.out = make([]map[int]gogl.Edge, len(mg.vertices))
+	for i := range mg.out {
+		mg.out[i] = make(map[int]gogl.Edge)
+	}
+
+	if dg, ok := g.(gogl.Digraph); ok {
+		mg.directed = true
+		mg.in = make([]map[int]gogl.Edge, len(mg.vertices))
+		for i := range mg.in {
+			mg.in[i] = make(map[int]gogl.Edge)
+		}
+
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			s, t := mg.index[gogl.VertexKey(a.Source())], mg.index[gogl.VertexKey(a.Target())]
+			mg.out[s][t] = a
+			mg.in[t][s] = a
+			return
+		})
+	} else {
+		mg.in = mg.out
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			ui, vi := mg.index[gogl.VertexKey(u)], mg.index[gogl.VertexKey(v)]
+			mg.out[ui][vi] = e
+			mg.out[vi][ui] = e
+			return
+		})
+	}
+
+	return mg
+}
+
+// Cheap invariant checks that rule out an isomorphism before any search is done.
+func (mg *matchGraph) couldBeIsomorphic(other *matchGraph) bool {
+	if mg.directed != other.directed || len(mg.vertices) != len(other.vertices) {
+		return false
+	}
+
+	degrees := func(g *matchGraph) [][2]int {
+		d := make([][2]int, len(g.vertices))
+		for i := range g.vertices {
+			d[i] = [2]int{len(g.out[i]), len(g.in[i])}
+		}
+		sort.Slice(d, func(i, j int) bool {
+			return d[i][0] < d[j][0] || (d[i][0] == d[j][0] && d[i][1] < d[j][1])
+		})
+		return d
+	}
+
+	da, db := degrees(mg), degrees(other)
+	for i := range da {
+		if da[i] != db[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Holds the state of a depth-first search for mappings from the pattern graph
+// (a) into the target graph (b).
+//
+// In induced mode, a non-edge in the pattern must map to a non-edge in the target;
+// combined with equal orders, this yields isomorphisms. Otherwise, the search
+// yields (not necessarily induced) subgraph monomorphisms.
+type matchState struct {
+	a, b    *matchGraph
+	induced bool
+	edgeEq  func(x, y gogl.Edge) bool
+
+	order  []int // order in which pattern vertices are matched
+	parent []int // for each position in order, an already-ordered neighbor (or -1)
+	core   []int // pattern index -> target index, or -1
+	rcore  []int // target index -> pattern index, or -1
+
+	terminated bool
+	halt       func() bool // optional early abort check, consulted at each search step
+}
+
+func newMatchState(a, b *matchGraph, induced bool, edgeEq func(x, y gogl.Edge) bool) *matchState {
+	st := &matchState{
+		a:       a,
+		b:       b,
+		induced: induced,
+		edgeEq:  edgeEq,
+		core:    make([]int, len(a.vertices)),
+		rcore:   make([]int, len(b.vertices)),
+	}
+
+	for i := range st.core {
+		st.core[i] = -1
+	}
+	for i := range st.rcore {
+		st.rcore[i] = -1
+	}
+
+	st.computeOrder()
+	return st
+}
+
+// Orders the pattern's vertices breadth-first, starting each component from its
+// highest-degree vertex, so that nearly every vertex is matched while adjacent to
+// an already-matched one. This sharply limits the candidates at each step.
+func (st *matchState) computeOrder() {
+	n := len(st.a.vertices)
+	seen := make([]bool, n)
+	deg := func(i int) int { return len(st.a.out[i]) + len(st.a.in[i]) }
+
+	byDegree := make([]int, n)
+	for i := range byDegree {
+		byDegree[i] = i
+	}
+	sort.SliceStable(byDegree, func(i, j int) bool { return deg(byDegree[i]) > deg(byDegree[j]) })
+
+	for _, root := range byDegree {
+		if seen[root] {
+			continue
+		}
+
+		seen[root] = true
+		st.order = append(st.order, root)
+		st.parent = append(st.parent, -1)
+
+		for qi := len(st.order) - 1; qi < len(st.order); qi++ {
+			v := st.order[qi]
+			for _, adj := range []map[int]gogl.Edge{st.a.out[v], st.a.in[v]} {
+				for w := range adj {
+					if !seen[w] {
+						seen[w] = true
+						st.order = append(st.order, w)
+						st.parent = append(st.parent, v)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Runs the search, calling visit with each complete mapping found, as a slice from
+// pattern index to target index. The slice is reused as the search goes on, so
+// must not be retained. If visit returns true, the search terminates.
+func (st *matchState) match(visit func(core []int) (terminate bool)) {
+	if len(st.a.vertices) > len(st.b.vertices) {
+		return
+	}
+	st.extend(0, visit)
+}
+
+func (st *matchState) extend(depth int, visit func([]int) bool) {
+	if st.halt != nil && st.halt() {
+		st.terminated = true
+		return
+	}
+
+	if depth == len(st.order) {
+		st.terminated = visit(st.core)
+		return
+	}
+
+	u := st.order[depth]
+	try := func(v int) {
+		if st.rcore[v] != -1 || !st.feasible(u, v) {
+			return
+		}
+
+		st.core[u], st.rcore[v] = v, u
+		st.extend(depth+1, visit)
+		st.core[u], st.rcore[v] = -1, -1
+	}
+
+	if p := st.parent[depth]; p != -1 {
+		// u is adjacent to an already-matched vertex, so its image must be adjacent
+		// to that vertex's image, in the same direction.
+		pv := st.core[p]
+		var cands map[int]gogl.Edge
+		if _, isOut := st.a.out[p][u]; isOut {
+			cands = st.b.out[pv]
+		} else {
+			cands = st.b.in[pv]
+		}
+		for v := range cands {
+			if try(v); st.terminated {
+				return
+			}
+		}
+	} else {
+		for v := range st.b.vertices {
+			if try(v); st.terminated {
+				return
+			}
+		}
+	}
+}
+
+// Checks whether mapping pattern vertex u to target vertex v is consistent with the
+// pairs mapped so far.
+func (st *matchState) feasible(u, v int) bool {
+	a, b := st.a, st.b
+
+	if st.induced {
+		if len(a.out[u]) != len(b.out[v]) || len(a.in[u]) != len(b.in[v]) {
+			return false
+		}
+	} else if len(a.out[u]) > len(b.out[v]) || len(a.in[u]) > len(b.in[v]) {
+		return false
+	}
+
+	// Loops have to be checked explicitly, as u is not yet in the core.
+	ea, aloop := a.out[u][u]
+	eb, bloop := b.out[v][v]
+	if aloop && (!bloop || (st.edgeEq != nil && !st.edgeEq(ea, eb))) {
+		return false
+	}
+	if st.induced && bloop && !aloop {
+		return false
+	}
+
+	// Every pattern edge to a matched vertex must have a counterpart in the target.
+	check := func(aadj, badj map[int]gogl.Edge) bool {
+		for w, e := range aadj {
+			if tw := st.core[w]; tw != -1 {
+				te, exists := badj[tw]
+				if !exists || (st.edgeEq != nil && !st.edgeEq(e, te)) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	if !check(a.out[u], b.out[v]) || (a.directed && !check(a.in[u], b.in[v])) {
+		return false
+	}
+
+	if st.induced {
+		// ...and, when induced, every target edge to a matched vertex must have a
+		// counterpart in the pattern.
+		rcheck := func(badj, aadj map[int]gogl.Edge) bool {
+			for tw := range badj {
+				if w := st.rcore[tw]; w != -1 {
+					if _, exists := aadj[w]; !exists {
+						return false
+					}
+				}
+			}
+			return true
+		}
+
+		if !rcheck(b.out[v], a.out[u]) || (b.directed && !rcheck(b.in[v], a.in[u])) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,187 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type IsomorphismSuite struct{}
+
+var _ = Suite(&IsomorphismSuite{})
+
+// Builds an undirected graph from pairs of ints, relabeling each vertex through the given function.
+func relabeledGraph(pairs [][2]int, label func(int) gogl.Vertex) gogl.Graph {
+	el := make(gogl.EdgeList, 0, len(pairs))
+	for _, p := range pairs {
+		el = append(el, gogl.NewEdge(label(p[0]), label(p[1])))
+	}
+	return gogl.Spec().Using(el).Create(al.G)
+}
+
+func identity(i int) gogl.Vertex { return i }
+
+var petersen = [][2]int{
+	{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}, // outer cycle
+	{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5}, // inner pentagram
+	{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9}, // spokes
+}
+
+func (s *IsomorphismSuite) TestRelabeledCopies(c *C) {
+	a := relabeledGraph(petersen, identity)
+	b := relabeledGraph(petersen, func(i int) gogl.Vertex {
+		return fmt.Sprintf("v%d", (i*7+3)%10)
+	})
+
+	c.Assert(EqualUpToIsomorphism(a, b), Equals, true)
+
+	mapping, found := VF2(a, b)
+	c.Assert(found, Equals, true)
+	c.Assert(len(mapping), Equals, 10)
+
+	// The mapping must actually carry every edge onto an edge.
+	a.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		c.Assert(b.HasEdge(gogl.NewEdge(mapping[u], mapping[v])), Equals, true)
+		return
+	})
+}
+
+func (s *IsomorphismSuite) TestStructurallyDifferent(c *C) {
+	// A hexagon and two disjoint triangles have identical degree sequences.
+	hexagon := relabeledGraph([][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 0}}, identity)
+	triangles := relabeledGraph([][2]int{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}}, identity)
+
+	c.Assert(EqualUpToIsomorphism(hexagon, triangles), Equals, false)
+	c.Assert(EqualUpToIsomorphism(hexagon, hexagon), Equals, true)
+
+	// Differing size short-circuits.
+	path := relabeledGraph([][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}}, identity)
+	c.Assert(EqualUpToIsomorphism(hexagon, path), Equals, false)
+
+	c.Assert(EqualUpToIsomorphism(gogl.NullGraph, gogl.NullGraph), Equals, true)
+}
+
+func (s *IsomorphismSuite) TestDirected(c *C) {
+	a := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 2),
+		gogl.NewArc(2, 3),
+		gogl.NewArc(1, 3),
+	}).Create(al.G)
+
+	// Same shape, relabeled
+	b := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("x", "z"),
+		gogl.NewArc("z", "y"),
+		gogl.NewArc("x", "y"),
+	}).Create(al.G)
+
+	// A directed 3-cycle has the same underlying undirected graph, but is not isomorphic.
+	cyc := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 2),
+		gogl.NewArc(2, 3),
+		gogl.NewArc(3, 1),
+	}).Create(al.G)
+
+	undirected := relabeledGraph([][2]int{{1, 2}, {2, 3}, {1, 3}}, identity)
+
+	c.Assert(EqualUpToIsomorphism(a, b), Equals, true)
+	c.Assert(EqualUpToIsomorphism(a, cyc), Equals, false)
+	c.Assert(EqualUpToIsomorphism(a, undirected), Equals, false)
+}
+
+func (s *IsomorphismSuite) TestLabeled(c *C) {
+	a := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge(1, 2, "red"),
+		gogl.NewLabeledEdge(2, 3, "blue"),
+		gogl.NewLabeledEdge(3, 4, "red"),
+	}).Create(al.G).(gogl.LabeledGraph)
+
+	b := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge("d", "c", "red"),
+		gogl.NewLabeledEdge("c", "b", "blue"),
+		gogl.NewLabeledEdge("b", "a", "red"),
+	}).Create(al.G).(gogl.LabeledGraph)
+
+	// Same path shape, but the odd label is at the end rather than the middle.
+	d := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge("a", "b", "blue"),
+		gogl.NewLabeledEdge("b", "c", "red"),
+		gogl.NewLabeledEdge("c", "d", "red"),
+	}).Create(al.G).(gogl.LabeledGraph)
+
+	c.Assert(EqualUpToLabeledIsomorphism(a, b), Equals, true)
+	c.Assert(EqualUpToIsomorphism(a, d), Equals, true)
+	c.Assert(EqualUpToLabeledIsomorphism(a, d), Equals, false)
+}
+
+func (s *IsomorphismSuite) TestWeighted(c *C) {
+	a := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 1),
+		gogl.NewWeightedEdge(2, 3, 2),
+		gogl.NewWeightedEdge(3, 1, 3),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	b := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 3),
+		gogl.NewWeightedEdge("b", "c", 1),
+		gogl.NewWeightedEdge("c", "a", 2),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	d := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 3),
+		gogl.NewWeightedEdge("b", "c", 3),
+		gogl.NewWeightedEdge("c", "a", 2),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	c.Assert(EqualUpToWeightedIsomorphism(a, b), Equals, true)
+	c.Assert(EqualUpToIsomorphism(a, d), Equals, true)
+	c.Assert(EqualUpToWeightedIsomorphism(a, d), Equals, false)
+}
+
+// A context that reports cancellation only after its Err() method has been
+// called a set number of times, for deterministically cancelling mid-computation.
+type countdownContext struct {
+	context.Context
+	remaining int
+	after     int // calls to Err() made after cancellation was first reported
+}
+
+func (ctx *countdownContext) Err() error {
+	if ctx.remaining > 0 {
+		ctx.remaining--
+		return nil
+	}
+	ctx.after++
+	return context.Canceled
+}
+
+func (s *IsomorphismSuite) TestVF2Context(c *C) {
+	a := relabeledGraph(petersen, identity)
+	b := relabeledGraph(petersen, func(i int) gogl.Vertex {
+		return (i*3 + 1) % 10
+	})
+
+	mapping, found, err := VF2Context(context.Background(), a, b)
+	c.Assert(err, IsNil)
+	c.Assert(found, Equals, true)
+	c.Assert(len(mapping), Equals, 10)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	mapping, found, err = VF2Context(cancelled, a, b)
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(found, Equals, false)
+	c.Assert(mapping, IsNil)
+
+	ctx := &countdownContext{Context: context.Background(), remaining: 5}
+	mapping, found, err = VF2Context(ctx, a, b)
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(found, Equals, false)
+	c.Assert(mapping, IsNil)
+	// The search must stop as soon as cancellation is observed.
+	c.Assert(ctx.after, Equals, 1)
+}
@@ -0,0 +1,21 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Returns all the self-loops in the graph - edges whose two endpoints are the
+// same vertex - in the order the graph enumerates them.
+//
+// Simple graphs cannot contain loops, so for them the result is always empty.
+func SelfLoops(g gogl.Graph) []gogl.Edge {
+	var loops []gogl.Edge
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		if u, v := e.Both(); gogl.VertexKey(u) == gogl.VertexKey(v) {
+			loops = append(loops, e)
+		}
+		return
+	})
+
+	return loops
+}
@@ -0,0 +1,54 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type LoopsSuite struct{}
+
+var _ = Suite(&LoopsSuite{})
+
+var loopEdges = gogl.EdgeList{
+	gogl.NewEdge(1, 1),
+	gogl.NewEdge(1, 2),
+	gogl.NewEdge(2, 3),
+	gogl.NewEdge(3, 3),
+}
+
+func (s *LoopsSuite) TestSelfLoops(c *C) {
+	g := gogl.Spec().Loop().Using(loopEdges).Create(al.G)
+
+	loops := SelfLoops(g)
+	c.Assert(len(loops), Equals, 2)
+
+	seen := make(map[gogl.Vertex]bool)
+	for _, e := range loops {
+		u, v := e.Both()
+		c.Assert(u, Equals, v)
+		seen[u] = true
+	}
+	c.Assert(seen, DeepEquals, map[gogl.Vertex]bool{1: true, 3: true})
+}
+
+func (s *LoopsSuite) TestSelfLoopsDirected(c *C) {
+	g := gogl.Spec().Directed().Loop().Using(gogl.ArcList{
+		gogl.NewArc("a", "a"),
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "a"),
+	}).Create(al.G)
+
+	loops := SelfLoops(g)
+	c.Assert(len(loops), Equals, 1)
+	u, v := loops[0].Both()
+	c.Assert(u, Equals, "a")
+	c.Assert(v, Equals, "a")
+}
+
+func (s *LoopsSuite) TestSelfLoopsSimple(c *C) {
+	g := gogl.Spec().Using(loopEdges).Create(al.G)
+
+	c.Assert(SelfLoops(g), HasLen, 0)
+	c.Assert(gogl.Size(g), Equals, 2)
+}
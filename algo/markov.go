@@ -0,0 +1,195 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/sdboyer/gogl"
+)
+
+// The most rounds of power iteration StationaryDistribution will perform.
+const maxPowerIterations = 1000000
+
+// Calculates the stationary distribution of the Markov chain whose transition
+// probabilities are given by the graph's edge weights, normalized per vertex as
+// by RowNormalize; dangling vertices are treated as absorbing.
+//
+// The distribution is found by power iteration, which stops once successive
+// estimates differ by less than tol in total (L1) variation. To guarantee
+// convergence for periodic chains, the iteration runs on the lazy chain, which
+// stays put with probability 1/2 at each step; this has the same stationary
+// distribution. Transient vertices receive probability 0. The result sums to 1.
+//
+// A stationary distribution is only unique if the chain has a single recurrent
+// class - a set of vertices that, once entered, is never left. An error is
+// returned if the chain has several, as well as if tol is not positive, if the
+// graph is empty, or if the iteration fails to converge.
+func StationaryDistribution(g gogl.WeightedGraph, tol float64) (map[gogl.Vertex]float64, error) {
+	if tol <= 0 {
+		return nil, errors.New("Tolerance must be positive.")
+	}
+
+	p := RowNormalize(g, true).(gogl.WeightedDigraph)
+	vi := gogl.NewVertexIndex(p)
+	n := vi.Len()
+	if n == 0 {
+		return nil, errors.New("Cannot find the stationary distribution of an empty graph.")
+	}
+
+	if classes := recurrentClasses(p); classes > 1 {
+		return nil, fmt.Errorf("Markov chain has %d recurrent classes, so no unique stationary distribution.", classes)
+	}
+
+	type transition struct {
+		from, to int
+		p        float64
+	}
+	var transitions []transition
+	p.Arcs(func(a gogl.Arc) (terminate bool) {
+		transitions = append(transitions, transition{vi.IndexOf(a.Source()), vi.IndexOf(a.Target()), a.(gogl.WeightedArc).Weight()})
+		return
+	})
+
+	pi, next := make([]float64, n), make([]float64, n)
+	for i := range pi {
+		pi[i] = 1 / float64(n)
+	}
+
+	for iter := 0; ; iter++ {
+		if iter == maxPowerIterations {
+			return nil, fmt.Errorf("Power iteration did not converge within %d rounds.", maxPowerIterations)
+		}
+
+		for i := range next {
+			next[i] = pi[i] / 2
+		}
+		for _, t := range transitions {
+			next[t.to] += pi[t.from] * t.p / 2
+		}
+
+		var diff float64
+		for i := range pi {
+			diff += math.Abs(next[i] - pi[i])
+		}
+		pi, next = next, pi
+
+		if diff < tol {
+			break
+		}
+	}
+
+	dist := make(map[gogl.Vertex]float64, n)
+	for i, v := range vi.List() {
+		dist[v] = pi[i]
+	}
+	return dist, nil
+}
+
+// Counts the strongly connected components of g that have no arcs leaving them;
+// in a Markov chain, these are the recurrent classes.
+func recurrentClasses(g gogl.Digraph) int {
+	component := stronglyConnected(g)
+
+	closed := make(map[int]bool)
+	for _, c := range component {
+		closed[c] = true
+	}
+	g.Arcs(func(a gogl.Arc) (terminate bool) {
+		if cs, ct := component[gogl.VertexKey(a.Source())], component[gogl.VertexKey(a.Target())]; cs != ct {
+			closed[cs] = false
+		}
+		return
+	})
+
+	var count int
+	for _, isClosed := range closed {
+		if isClosed {
+			count++
+		}
+	}
+	return count
+}
+
+// Assigns each vertex of g, by gogl.VertexKey, the number of its strongly
+// connected component, using
+// an iterative form of Tarjan's algorithm. Components are numbered from 0 in the
+// order they complete, a reverse topological order of the condensation.
+func stronglyConnected(g gogl.Digraph) map[interface{}]int {
+	hint := sizeHint(g)
+	// All keyed by gogl.VertexKey.
+	index := make(map[interface{}]int, hint)
+	lowlink := make(map[interface{}]int, hint)
+	onStack := make(map[interface{}]bool, hint)
+	component := make(map[interface{}]int, hint)
+
+	type frame struct {
+		v    interface{} // the vertex's key
+		succ []gogl.Vertex
+	}
+
+	var stack []interface{}
+	var components int
+	g.Vertices(func(root gogl.Vertex) (terminate bool) {
+		if _, visited := index[gogl.VertexKey(root)]; visited {
+			return
+		}
+
+		enter := func(v gogl.Vertex) frame {
+			k := gogl.VertexKey(v)
+			index[k], lowlink[k] = len(index), len(index)
+			stack = append(stack, k)
+			onStack[k] = true
+
+			f := frame{v: k}
+			g.SuccessorsOf(v, func(w gogl.Vertex) (terminate bool) {
+				f.succ = append(f.succ, w)
+				return
+			})
+			return f
+		}
+
+		calls := []frame{enter(root)}
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+
+			if len(top.succ) > 0 {
+				w := top.succ[0]
+				top.succ = top.succ[1:]
+
+				if k := gogl.VertexKey(w); !onStack[k] {
+					if _, visited := index[k]; !visited {
+						calls = append(calls, enter(w))
+					}
+				} else if index[k] < lowlink[top.v] {
+					lowlink[top.v] = index[k]
+				}
+				continue
+			}
+
+			v := top.v
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				if u := calls[len(calls)-1].v; lowlink[v] < lowlink[u] {
+					lowlink[u] = lowlink[v]
+				}
+			}
+
+			if lowlink[v] == index[v] {
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					component[w] = components
+					if w == v {
+						break
+					}
+				}
+				components++
+			}
+		}
+		return
+	})
+
+	return component
+}
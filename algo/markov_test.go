@@ -0,0 +1,120 @@
+package algo
+
+import (
+	"math"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type MarkovSuite struct{}
+
+var _ = Suite(&MarkovSuite{})
+
+func assertDistribution(c *C, got, want map[gogl.Vertex]float64, tol float64) {
+	c.Assert(len(got), Equals, len(want))
+
+	var total float64
+	for v, p := range want {
+		total += got[v]
+		c.Assert(math.Abs(got[v]-p) < tol, Equals, true, Commentf("vertex %v: got %v, want %v", v, got[v], p))
+	}
+	c.Assert(math.Abs(total-1) < 1e-9, Equals, true, Commentf("distribution sums to %v", total))
+}
+
+func (s *MarkovSuite) TestReversibleRandomWalk(c *C) {
+	// A random walk on an undirected weighted graph is reversible, with each
+	// vertex's stationary probability proportional to its weighted degree.
+	edges := gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "c", 2),
+		gogl.NewWeightedEdge("c", "a", 3),
+		gogl.NewWeightedEdge("c", "d", 4),
+		gogl.NewWeightedEdge("d", "e", 0.5),
+		gogl.NewWeightedEdge("e", "a", 1.5),
+	}
+	g := gogl.Spec().Weighted().Using(edges).Create(al.G).(gogl.WeightedGraph)
+
+	want := make(map[gogl.Vertex]float64)
+	var total float64
+	for _, e := range edges {
+		u, v := e.Both()
+		want[u] += e.Weight()
+		want[v] += e.Weight()
+		total += 2 * e.Weight()
+	}
+	for v := range want {
+		want[v] /= total
+	}
+
+	dist, err := StationaryDistribution(g, 1e-12)
+	c.Assert(err, IsNil)
+	assertDistribution(c, dist, want, 1e-9)
+}
+
+func (s *MarkovSuite) TestPeriodic(c *C) {
+	// A directed 3-cycle has period 3; plain power iteration from anything but
+	// the uniform distribution would rotate forever.
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc(1, 2, 1),
+		gogl.NewWeightedArc(2, 3, 1),
+		gogl.NewWeightedArc(3, 1, 1),
+		gogl.NewWeightedArc(3, 4, 1),
+		gogl.NewWeightedArc(4, 1, 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	// Solving pi = pi P by hand: pi1 = pi3/2 + pi4, pi2 = pi1, pi3 = pi2, pi4 = pi3/2.
+	dist, err := StationaryDistribution(g, 1e-12)
+	c.Assert(err, IsNil)
+	assertDistribution(c, dist, map[gogl.Vertex]float64{1: 2.0 / 7, 2: 2.0 / 7, 3: 2.0 / 7, 4: 1.0 / 7}, 1e-9)
+}
+
+func (s *MarkovSuite) TestTransientAndAbsorbing(c *C) {
+	// Everything drains into the absorbing vertex d, which is the only recurrent
+	// class; the rest are transient.
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "b", 1),
+		gogl.NewWeightedArc("b", "a", 1),
+		gogl.NewWeightedArc("b", "c", 1),
+		gogl.NewWeightedArc("c", "d", 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	dist, err := StationaryDistribution(g, 1e-12)
+	c.Assert(err, IsNil)
+	assertDistribution(c, dist, map[gogl.Vertex]float64{"a": 0, "b": 0, "c": 0, "d": 1}, 1e-9)
+}
+
+func (s *MarkovSuite) TestNotErgodic(c *C) {
+	// Two separate cycles.
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc(1, 2, 1),
+		gogl.NewWeightedArc(2, 1, 1),
+		gogl.NewWeightedArc(3, 4, 1),
+		gogl.NewWeightedArc(4, 3, 1),
+		gogl.NewWeightedArc(5, 1, 1),
+		gogl.NewWeightedArc(5, 3, 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	_, err := StationaryDistribution(g, 1e-9)
+	c.Assert(err, ErrorMatches, "Markov chain has 2 recurrent classes, so no unique stationary distribution.")
+
+	// Two dangling vertices are two absorbing states.
+	g = gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc(1, 2, 1),
+		gogl.NewWeightedArc(1, 3, 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	_, err = StationaryDistribution(g, 1e-9)
+	c.Assert(err, ErrorMatches, "Markov chain has 2 recurrent classes, so no unique stationary distribution.")
+}
+
+func (s *MarkovSuite) TestInvalidInput(c *C) {
+	g := gogl.Spec().Directed().Weighted().Create(al.G).(gogl.WeightedGraph)
+
+	_, err := StationaryDistribution(g, 1e-9)
+	c.Assert(err, ErrorMatches, "Cannot find the stationary distribution of an empty graph.")
+
+	_, err = StationaryDistribution(g, 0)
+	c.Assert(err, ErrorMatches, "Tolerance must be positive.")
+}
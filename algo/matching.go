@@ -0,0 +1,708 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Finds a maximum-weight matching in a general undirected graph, using Edmonds'
+// blossom algorithm with the primal-dual method, in O(V³) time.
+//
+// A matching is a set of edges, no two of which share a vertex. Unlike bipartite
+// matching algorithms, this handles odd cycles correctly, by shrinking them into
+// "blossoms" as they are discovered. The matching maximizes total weight; it is
+// not necessarily of maximum cardinality, and edges with non-positive weight are
+// never matched.
+//
+// The returned map pairs each matched vertex with its mate, in both directions,
+// so it contains two entries per matched edge. Also returned is the matching's
+// total weight. Edge direction is ignored; where vertices are joined in both
+// directions, the heavier weight is used. Self-loops are ignored.
+func MaximumWeightMatching(g gogl.WeightedGraph) (map[gogl.Vertex]gogl.Vertex, float64) {
+	vi := gogl.NewVertexIndex(g)
+
+	type pair struct{ i, j int }
+	seen := make(map[pair]int)
+	var edges []blossomEdge
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		i, j := vi.IndexOf(u), vi.IndexOf(v)
+		if i == j {
+			return
+		}
+		if i > j {
+			i, j = j, i
+		}
+
+		// Edges that could only detract from the matching are left out entirely.
+		w := weightOf(e)
+		if w <= 0 {
+			return
+		}
+
+		if k, exists := seen[pair{i, j}]; !exists {
+			seen[pair{i, j}] = len(edges)
+			edges = append(edges, blossomEdge{i, j, w})
+		} else if w > edges[k].w {
+			edges[k].w = w
+		}
+		return
+	})
+
+	mate := newBlossomMatcher(vi.Len(), edges).solve()
+
+	matching := make(map[gogl.Vertex]gogl.Vertex)
+	var total float64
+	for i, j := range mate {
+		if j >= 0 {
+			matching[vi.VertexAt(i)] = vi.VertexAt(j)
+		}
+	}
+	for _, e := range edges {
+		if mate[e.i] == e.j {
+			total += e.w
+		}
+	}
+
+	return matching, total
+}
+
+type blossomEdge struct {
+	i, j int
+	w    float64
+}
+
+// State for the blossom algorithm. Vertices are numbered [0, n); blossoms, which
+// are nested sets of vertices, share the numbering of their sole vertex if
+// trivial, or take a number in [n, 2n) otherwise.
+//
+// Edges are referred to by index k, and their endpoints by 2k (the edge's first
+// vertex) and 2k+1 (its second); p^1 is thus the other end of the edge from p.
+type blossomMatcher struct {
+	n     int
+	edges []blossomEdge
+
+	// The vertex at each endpoint, and the remote endpoints of each vertex's edges.
+	endpoint  []int
+	neighbend [][]int
+
+	// The remote endpoint of each vertex's matched edge, or -1 if unmatched.
+	mate []int
+
+	// Per top-level blossom: 0 if unlabeled, 1 for S (outer), 2 for T (inner). The
+	// labelend is the endpoint through which the label was assigned, or -1.
+	label    []int
+	labelend []int
+
+	// Each vertex's top-level blossom.
+	inblossom []int
+
+	// Blossom structure: parent, children in cyclic order, base vertex, and the
+	// endpoints of the edges connecting consecutive children.
+	blossomparent []int
+	blossomchilds [][]int
+	blossombase   []int
+	blossomendps  [][]int
+
+	// The least-slack edge to a different S-blossom (for non-trivial S-blossoms, a
+	// list of such edges; for vertices, the edge to any S-blossom), or -1.
+	bestedge         []int
+	blossombestedges [][]int
+
+	unusedblossoms []int
+
+	// Dual variables: for vertices, u(v); for blossoms, z(b).
+	dualvar []float64
+
+	// Whether each edge has been found to have zero slack.
+	allowedge []bool
+
+	// S-vertices yet to be scanned.
+	queue []int
+}
+
+func newBlossomMatcher(n int, edges []blossomEdge) *blossomMatcher {
+	m := &blossomMatcher{
+		n:                n,
+		edges:            edges,
+		endpoint:         make([]int, 2*len(edges)),
+		neighbend:        make([][]int, n),
+		mate:             make([]int, n),
+		label:            make([]int, 2*n),
+		labelend:         make([]int, 2*n),
+		inblossom:        make([]int, n),
+		blossomparent:    make([]int, 2*n),
+		blossomchilds:    make([][]int, 2*n),
+		blossombase:      make([]int, 2*n),
+		blossomendps:     make([][]int, 2*n),
+		bestedge:         make([]int, 2*n),
+		blossombestedges: make([][]int, 2*n),
+		dualvar:          make([]float64, 2*n),
+		allowedge:        make([]bool, len(edges)),
+	}
+
+	var maxweight float64
+	for k, e := range edges {
+		m.endpoint[2*k], m.endpoint[2*k+1] = e.i, e.j
+		m.neighbend[e.i] = append(m.neighbend[e.i], 2*k+1)
+		m.neighbend[e.j] = append(m.neighbend[e.j], 2*k)
+		if e.w > maxweight {
+			maxweight = e.w
+		}
+	}
+
+	for v := 0; v < n; v++ {
+		m.mate[v] = -1
+		m.inblossom[v] = v
+		m.blossombase[v] = v
+		m.dualvar[v] = maxweight
+	}
+	for b := 0; b < 2*n; b++ {
+		m.labelend[b] = -1
+		m.blossomparent[b] = -1
+		m.bestedge[b] = -1
+		if b >= n {
+			m.blossombase[b] = -1
+			m.unusedblossoms = append(m.unusedblossoms, b)
+		}
+	}
+
+	return m
+}
+
+// Returns the slack of edge k: the amount by which its dual constraint is loose.
+func (m *blossomMatcher) slack(k int) float64 {
+	e := m.edges[k]
+	return m.dualvar[e.i] + m.dualvar[e.j] - 2*e.w
+}
+
+// Returns the vertices contained, at any depth, in blossom b.
+func (m *blossomMatcher) leaves(b int) []int {
+	if b < m.n {
+		return []int{b}
+	}
+
+	var vs []int
+	for _, c := range m.blossomchilds[b] {
+		vs = append(vs, m.leaves(c)...)
+	}
+	return vs
+}
+
+// Indexes into a blossom's child or endpoint list, wrapping negative indices
+// around from the end.
+func wrap(j, length int) int {
+	return ((j % length) + length) % length
+}
+
+// Assigns label t to the top-level blossom containing vertex w, having reached it
+// through endpoint p. T-blossoms propagate an S label to their mates.
+func (m *blossomMatcher) assignLabel(w, t, p int) {
+	for {
+		b := m.inblossom[w]
+		m.label[w], m.label[b] = t, t
+		m.labelend[w], m.labelend[b] = p, p
+		m.bestedge[w], m.bestedge[b] = -1, -1
+
+		if t == 1 {
+			m.queue = append(m.queue, m.leaves(b)...)
+			return
+		}
+
+		base := m.blossombase[b]
+		w, t, p = m.endpoint[m.mate[base]], 1, m.mate[base]^1
+	}
+}
+
+// Traces back from vertices v and w, both in S-blossoms, to discover either a new
+// blossom (returning its base vertex) or an augmenting path (returning -1).
+func (m *blossomMatcher) scanBlossom(v, w int) int {
+	var path []int
+	base := -1
+
+	for v != -1 || w != -1 {
+		b := m.inblossom[v]
+		if m.label[b]&4 != 0 {
+			base = m.blossombase[b]
+			break
+		}
+
+		path = append(path, b)
+		m.label[b] = 5
+
+		if m.labelend[b] == -1 {
+			v = -1
+		} else {
+			v = m.endpoint[m.labelend[b]]
+			b = m.inblossom[v]
+			v = m.endpoint[m.labelend[b]]
+		}
+
+		if w != -1 {
+			v, w = w, v
+		}
+	}
+
+	for _, b := range path {
+		m.label[b] = 1
+	}
+	return base
+}
+
+// Constructs a new blossom with the given base, through S-vertices joined by edge
+// k, and relabels and requeues its contents accordingly.
+func (m *blossomMatcher) addBlossom(base, k int) {
+	v, w := m.edges[k].i, m.edges[k].j
+	bb, bv, bw := m.inblossom[base], m.inblossom[v], m.inblossom[w]
+
+	b := m.unusedblossoms[len(m.unusedblossoms)-1]
+	m.unusedblossoms = m.unusedblossoms[:len(m.unusedblossoms)-1]
+
+	m.blossombase[b] = base
+	m.blossomparent[b] = -1
+	m.blossomparent[bb] = b
+
+	// Trace from v back to the base, then from w back to the base.
+	var path, endps []int
+	for bv != bb {
+		m.blossomparent[bv] = b
+		path = append(path, bv)
+		endps = append(endps, m.labelend[bv])
+		v = m.endpoint[m.labelend[bv]]
+		bv = m.inblossom[v]
+	}
+	path = append(path, bb)
+	reverseInts(path)
+	reverseInts(endps)
+	endps = append(endps, 2*k)
+
+	for bw != bb {
+		m.blossomparent[bw] = b
+		path = append(path, bw)
+		endps = append(endps, m.labelend[bw]^1)
+		w = m.endpoint[m.labelend[bw]]
+		bw = m.inblossom[w]
+	}
+
+	m.blossomchilds[b] = path
+	m.blossomendps[b] = endps
+
+	m.label[b] = 1
+	m.labelend[b] = m.labelend[bb]
+	m.dualvar[b] = 0
+
+	for _, v := range m.leaves(b) {
+		if m.label[m.inblossom[v]] == 2 {
+			// Former T-vertices are now S-vertices, and must be scanned.
+			m.queue = append(m.queue, v)
+		}
+		m.inblossom[v] = b
+	}
+
+	// Compute the new blossom's least-slack edges to each neighboring S-blossom.
+	bestedgeto := make([]int, 2*m.n)
+	for i := range bestedgeto {
+		bestedgeto[i] = -1
+	}
+
+	for _, bv := range path {
+		var nblists [][]int
+		if m.blossombestedges[bv] == nil {
+			for _, v := range m.leaves(bv) {
+				nblist := make([]int, len(m.neighbend[v]))
+				for i, p := range m.neighbend[v] {
+					nblist[i] = p / 2
+				}
+				nblists = append(nblists, nblist)
+			}
+		} else {
+			nblists = [][]int{m.blossombestedges[bv]}
+		}
+
+		for _, nblist := range nblists {
+			for _, k := range nblist {
+				j := m.edges[k].j
+				if m.inblossom[j] == b {
+					j = m.edges[k].i
+				}
+
+				bj := m.inblossom[j]
+				if bj != b && m.label[bj] == 1 && (bestedgeto[bj] == -1 || m.slack(k) < m.slack(bestedgeto[bj])) {
+					bestedgeto[bj] = k
+				}
+			}
+		}
+
+		m.blossombestedges[bv] = nil
+		m.bestedge[bv] = -1
+	}
+
+	var best []int
+	for _, k := range bestedgeto {
+		if k != -1 {
+			best = append(best, k)
+		}
+	}
+	m.blossombestedges[b] = best
+
+	m.bestedge[b] = -1
+	for _, k := range best {
+		if m.bestedge[b] == -1 || m.slack(k) < m.slack(m.bestedge[b]) {
+			m.bestedge[b] = k
+		}
+	}
+}
+
+// Expands blossom b, restoring its children as top-level blossoms. If endstage is
+// false, labels are reassigned so that the alternating tree remains consistent.
+func (m *blossomMatcher) expandBlossom(b int, endstage bool) {
+	for _, s := range m.blossomchilds[b] {
+		m.blossomparent[s] = -1
+		if s < m.n {
+			m.inblossom[s] = s
+		} else if endstage && m.dualvar[s] == 0 {
+			m.expandBlossom(s, endstage)
+		} else {
+			for _, v := range m.leaves(s) {
+				m.inblossom[v] = s
+			}
+		}
+	}
+
+	if !endstage && m.label[b] == 2 {
+		// b was a T-blossom; relabel its children along the even-length path from
+		// the entry child to the base, which become alternately T and S.
+		childs, endps := m.blossomchilds[b], m.blossomendps[b]
+		l := len(childs)
+
+		entrychild := m.inblossom[m.endpoint[m.labelend[b]^1]]
+		j := indexOf(childs, entrychild)
+
+		var jstep, endptrick int
+		if j&1 != 0 {
+			j -= l
+			jstep, endptrick = 1, 0
+		} else {
+			jstep, endptrick = -1, 1
+		}
+
+		p := m.labelend[b]
+		for j != 0 {
+			m.label[m.endpoint[p^1]] = 0
+			m.label[m.endpoint[endps[wrap(j-endptrick, l)]^endptrick^1]] = 0
+			m.assignLabel(m.endpoint[p^1], 2, p)
+
+			m.allowedge[endps[wrap(j-endptrick, l)]/2] = true
+			j += jstep
+			p = endps[wrap(j-endptrick, l)] ^ endptrick
+			m.allowedge[p/2] = true
+			j += jstep
+		}
+
+		bv := childs[wrap(j, l)]
+		m.label[m.endpoint[p^1]], m.label[bv] = 2, 2
+		m.labelend[m.endpoint[p^1]], m.labelend[bv] = p, p
+		m.bestedge[bv] = -1
+
+		// The remaining children, off the path, are relabeled only if they were
+		// reached from outside.
+		j += jstep
+		for childs[wrap(j, l)] != entrychild {
+			bv := childs[wrap(j, l)]
+			if m.label[bv] == 1 {
+				j += jstep
+				continue
+			}
+
+			for _, v := range m.leaves(bv) {
+				if m.label[v] != 0 {
+					m.label[v] = 0
+					m.label[m.endpoint[m.mate[m.blossombase[bv]]]] = 0
+					m.assignLabel(v, 2, m.labelend[v])
+					break
+				}
+			}
+			j += jstep
+		}
+	}
+
+	m.label[b], m.labelend[b] = -1, -1
+	m.blossomchilds[b], m.blossomendps[b] = nil, nil
+	m.blossombase[b] = -1
+	m.blossombestedges[b] = nil
+	m.bestedge[b] = -1
+	m.unusedblossoms = append(m.unusedblossoms, b)
+}
+
+// Swaps matched and unmatched edges along the path within blossom b from vertex v
+// to the base, making v the new base.
+func (m *blossomMatcher) augmentBlossom(b, v int) {
+	t := v
+	for m.blossomparent[t] != b {
+		t = m.blossomparent[t]
+	}
+	if t >= m.n {
+		m.augmentBlossom(t, v)
+	}
+
+	childs, endps := m.blossomchilds[b], m.blossomendps[b]
+	l := len(childs)
+	i := indexOf(childs, t)
+	j := i
+
+	var jstep, endptrick int
+	if i&1 != 0 {
+		j -= l
+		jstep, endptrick = 1, 0
+	} else {
+		jstep, endptrick = -1, 1
+	}
+
+	for j != 0 {
+		j += jstep
+		t = childs[wrap(j, l)]
+		p := endps[wrap(j-endptrick, l)] ^ endptrick
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p])
+		}
+
+		j += jstep
+		t = childs[wrap(j, l)]
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p^1])
+		}
+
+		m.mate[m.endpoint[p]] = p ^ 1
+		m.mate[m.endpoint[p^1]] = p
+	}
+
+	// Rotate the child list so that the new base comes first.
+	m.blossomchilds[b] = append(append([]int(nil), childs[i:]...), childs[:i]...)
+	m.blossomendps[b] = append(append([]int(nil), endps[i:]...), endps[:i]...)
+	m.blossombase[b] = m.blossombase[m.blossomchilds[b][0]]
+}
+
+// Swaps matched and unmatched edges along the augmenting path through edge k.
+func (m *blossomMatcher) augmentMatching(k int) {
+	v, w := m.edges[k].i, m.edges[k].j
+
+	for _, sp := range [2][2]int{{v, 2*k + 1}, {w, 2 * k}} {
+		s, p := sp[0], sp[1]
+		for {
+			bs := m.inblossom[s]
+			if bs >= m.n {
+				m.augmentBlossom(bs, s)
+			}
+			m.mate[s] = p
+
+			if m.labelend[bs] == -1 {
+				// Reached an exposed vertex; this end of the path is done.
+				break
+			}
+
+			t := m.endpoint[m.labelend[bs]]
+			bt := m.inblossom[t]
+			s = m.endpoint[m.labelend[bt]]
+			j := m.endpoint[m.labelend[bt]^1]
+			if bt >= m.n {
+				m.augmentBlossom(bt, j)
+			}
+			m.mate[j] = m.labelend[bt]
+			p = m.labelend[bt] ^ 1
+		}
+	}
+}
+
+// Runs the algorithm, returning the mate of each vertex, or -1 for those left
+// unmatched.
+func (m *blossomMatcher) solve() []int {
+	n := m.n
+	if n == 0 {
+		return nil
+	}
+
+	// Each stage either augments the matching or proves it maximal.
+	for stage := 0; stage < n; stage++ {
+		for i := range m.label {
+			m.label[i] = 0
+			m.bestedge[i] = -1
+			if i >= n {
+				m.blossombestedges[i] = nil
+			}
+		}
+		for k := range m.allowedge {
+			m.allowedge[k] = false
+		}
+		m.queue = m.queue[:0]
+
+		for v := 0; v < n; v++ {
+			if m.mate[v] == -1 && m.label[m.inblossom[v]] == 0 {
+				m.assignLabel(v, 1, -1)
+			}
+		}
+
+		augmented := false
+		for {
+			// Grow the alternating forest along tight edges.
+			for len(m.queue) > 0 && !augmented {
+				v := m.queue[len(m.queue)-1]
+				m.queue = m.queue[:len(m.queue)-1]
+
+				for _, p := range m.neighbend[v] {
+					k := p / 2
+					w := m.endpoint[p]
+					if m.inblossom[v] == m.inblossom[w] {
+						continue
+					}
+
+					var kslack float64
+					if !m.allowedge[k] {
+						if kslack = m.slack(k); kslack <= 0 {
+							m.allowedge[k] = true
+						}
+					}
+
+					switch {
+					case m.allowedge[k] && m.label[m.inblossom[w]] == 0:
+						m.assignLabel(w, 2, p^1)
+					case m.allowedge[k] && m.label[m.inblossom[w]] == 1:
+						if base := m.scanBlossom(v, w); base >= 0 {
+							m.addBlossom(base, k)
+						} else {
+							m.augmentMatching(k)
+							augmented = true
+						}
+					case m.allowedge[k] && m.label[w] == 0:
+						m.label[w] = 2
+						m.labelend[w] = p ^ 1
+					case !m.allowedge[k] && m.label[m.inblossom[w]] == 1:
+						if b := m.inblossom[v]; m.bestedge[b] == -1 || kslack < m.slack(m.bestedge[b]) {
+							m.bestedge[b] = k
+						}
+					case !m.allowedge[k] && m.label[w] == 0:
+						if m.bestedge[w] == -1 || kslack < m.slack(m.bestedge[w]) {
+							m.bestedge[w] = k
+						}
+					}
+
+					if augmented {
+						break
+					}
+				}
+			}
+
+			if augmented {
+				break
+			}
+
+			// No tight edge remains; adjust the duals by the largest delta that
+			// keeps them feasible. Type 1: a vertex dual reaches zero. Type 2: an
+			// edge between S and a free vertex becomes tight. Type 3: an edge
+			// between two S-blossoms becomes tight. Type 4: a T-blossom's dual
+			// reaches zero.
+			deltatype := 1
+			delta := m.dualvar[0]
+			for v := 1; v < n; v++ {
+				if m.dualvar[v] < delta {
+					delta = m.dualvar[v]
+				}
+			}
+			var deltaedge, deltablossom int
+
+			for v := 0; v < n; v++ {
+				if m.label[m.inblossom[v]] == 0 && m.bestedge[v] != -1 {
+					if d := m.slack(m.bestedge[v]); d < delta {
+						delta, deltatype, deltaedge = d, 2, m.bestedge[v]
+					}
+				}
+			}
+
+			for b := 0; b < 2*n; b++ {
+				if m.blossomparent[b] == -1 && m.label[b] == 1 && m.bestedge[b] != -1 {
+					if d := m.slack(m.bestedge[b]) / 2; d < delta {
+						delta, deltatype, deltaedge = d, 3, m.bestedge[b]
+					}
+				}
+			}
+
+			for b := n; b < 2*n; b++ {
+				if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 && m.label[b] == 2 && m.dualvar[b] < delta {
+					delta, deltatype, deltablossom = m.dualvar[b], 4, b
+				}
+			}
+
+			for v := 0; v < n; v++ {
+				switch m.label[m.inblossom[v]] {
+				case 1:
+					m.dualvar[v] -= delta
+				case 2:
+					m.dualvar[v] += delta
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 {
+					switch m.label[b] {
+					case 1:
+						m.dualvar[b] += delta
+					case 2:
+						m.dualvar[b] -= delta
+					}
+				}
+			}
+
+			if deltatype == 1 {
+				// No further improvement is possible.
+				break
+			}
+
+			switch deltatype {
+			case 2:
+				m.allowedge[deltaedge] = true
+				i := m.edges[deltaedge].i
+				if m.label[m.inblossom[i]] == 0 {
+					i = m.edges[deltaedge].j
+				}
+				m.queue = append(m.queue, i)
+			case 3:
+				m.allowedge[deltaedge] = true
+				m.queue = append(m.queue, m.edges[deltaedge].i)
+			case 4:
+				m.expandBlossom(deltablossom, false)
+			}
+		}
+
+		if !augmented {
+			break
+		}
+
+		// Expand S-blossoms whose dual has dropped to zero.
+		for b := n; b < 2*n; b++ {
+			if m.blossomparent[b] == -1 && m.blossombase[b] >= 0 && m.label[b] == 1 && m.dualvar[b] == 0 {
+				m.expandBlossom(b, true)
+			}
+		}
+	}
+
+	mate := make([]int, n)
+	for v := range mate {
+		mate[v] = -1
+		if m.mate[v] >= 0 {
+			mate[v] = m.endpoint[m.mate[v]]
+		}
+	}
+	return mate
+}
+
+func indexOf(s []int, x int) int {
+	for i, y := range s {
+		if y == x {
+			return i
+		}
+	}
+	return -1
+}
+
+func reverseInts(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
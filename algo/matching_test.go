@@ -0,0 +1,139 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type MatchingSuite struct{}
+
+var _ = Suite(&MatchingSuite{})
+
+// Finds the weight of a maximum-weight matching by trying every matching, over
+// vertices 0..n-1 with weights given by w (0 meaning no edge).
+func bruteForceMatching(w [][]float64, n int) float64 {
+	used := make([]bool, n)
+	var best func(i int) float64
+	best = func(i int) float64 {
+		for i < n && used[i] {
+			i++
+		}
+		if i == n {
+			return 0
+		}
+
+		used[i] = true
+		b := best(i + 1) // leave i unmatched
+		for j := i + 1; j < n; j++ {
+			if !used[j] && w[i][j] > 0 {
+				used[j] = true
+				if t := w[i][j] + best(i+1); t > b {
+					b = t
+				}
+				used[j] = false
+			}
+		}
+		used[i] = false
+		return b
+	}
+	return best(0)
+}
+
+// Checks that the matching is symmetric, uses only edges of g, and has the given weight.
+func checkMatching(c *C, g gogl.WeightedGraph, matching map[gogl.Vertex]gogl.Vertex, total float64) {
+	var sum float64
+	for u, v := range matching {
+		c.Assert(matching[v], Equals, u)
+		c.Assert(u != v, Equals, true)
+
+		found := false
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			if a, b := e.Both(); a == u && b == v || a == v && b == u {
+				sum += e.(gogl.WeightedEdge).Weight()
+				found = true
+				return true
+			}
+			return
+		})
+		c.Assert(found, Equals, true, Commentf("matched %v-%v is not an edge", u, v))
+	}
+	// Each edge was counted from both ends.
+	c.Assert(sum/2, Equals, total)
+}
+
+func (s *MatchingSuite) TestOddCycle(c *C) {
+	// A 5-cycle: at most two edges can be matched. The best pair avoiding each other
+	// is 1-2 and 3-4 (weight 12), beating 5-1 alone plus 3-4 (11) and the like.
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 6),
+		gogl.NewWeightedEdge(2, 3, 5),
+		gogl.NewWeightedEdge(3, 4, 6),
+		gogl.NewWeightedEdge(4, 5, 4),
+		gogl.NewWeightedEdge(5, 1, 7),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	matching, total := MaximumWeightMatching(g)
+	checkMatching(c, g, matching, total)
+	c.Assert(total, Equals, 13.0)
+	c.Assert(matching, DeepEquals, map[gogl.Vertex]gogl.Vertex{5: 1, 1: 5, 3: 4, 4: 3})
+}
+
+func (s *MatchingSuite) TestNestedBlossoms(c *C) {
+	// Two triangles sharing a path, forcing blossoms to form and expand.
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 9),
+		gogl.NewWeightedEdge(1, 3, 9),
+		gogl.NewWeightedEdge(2, 3, 10),
+		gogl.NewWeightedEdge(2, 4, 8),
+		gogl.NewWeightedEdge(3, 5, 8),
+		gogl.NewWeightedEdge(4, 5, 10),
+		gogl.NewWeightedEdge(5, 6, 6),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	matching, total := MaximumWeightMatching(g)
+	checkMatching(c, g, matching, total)
+	c.Assert(total, Equals, 23.0)
+}
+
+func (s *MatchingSuite) TestMatchesBruteForce(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		n := 2 + r.Intn(9)
+		w := make([][]float64, n)
+		for i := range w {
+			w[i] = make([]float64, n)
+		}
+
+		g := gogl.Spec().Weighted().Create(al.G).(gogl.MutableWeightedGraph)
+		for i := 0; i < n; i++ {
+			g.EnsureVertex(i)
+			for j := i + 1; j < n; j++ {
+				if r.Float64() < 0.5 {
+					wt := float64(1 + r.Intn(20))
+					w[i][j] = wt
+					g.AddEdges(gogl.NewWeightedEdge(i, j, wt))
+				}
+			}
+		}
+
+		matching, total := MaximumWeightMatching(g)
+		checkMatching(c, g, matching, total)
+		c.Assert(total, Equals, bruteForceMatching(w, n), Commentf("trial %d", trial))
+	}
+}
+
+func (s *MatchingSuite) TestTrivial(c *C) {
+	g := gogl.Spec().Weighted().Create(al.G).(gogl.MutableWeightedGraph)
+	matching, total := MaximumWeightMatching(g)
+	c.Assert(matching, HasLen, 0)
+	c.Assert(total, Equals, 0.0)
+
+	// Non-positive edges are never worth matching.
+	g.AddEdges(gogl.NewWeightedEdge("a", "b", -1), gogl.NewWeightedEdge("b", "c", 0))
+	matching, total = MaximumWeightMatching(g)
+	c.Assert(matching, HasLen, 0)
+	c.Assert(total, Equals, 0.0)
+}
@@ -0,0 +1,38 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Counts the number of distinct copies of the motif graph contained within g.
+//
+// Copies are not necessarily induced: a triangle motif is counted once for each
+// triangle in g, regardless of what other edges connect its vertices. Two copies
+// are distinct if they differ in the set of g's edges (and vertices) they cover;
+// the motif's own symmetries are factored out, so a triangle is not counted six
+// times over. For digraphs, arc direction is respected, which permits counting
+// directed motifs such as feed-forward loops.
+//
+// A Digraph motif will never be found in an undirected graph, or vice versa.
+//
+// Matching uses the same VF2-style search as VF2, enumerating every subgraph
+// monomorphism; this is exponential in the size of the motif, so motifs should
+// be kept small.
+func CountMotif(g gogl.Graph, motif gogl.Graph) int {
+	mm := newMatchGraph(motif)
+	mg := newMatchGraph(g)
+	if mm.directed != mg.directed || len(mm.vertices) > len(mg.vertices) {
+		return 0
+	}
+
+	count := func(target *matchGraph, induced bool) (n int) {
+		newMatchState(mm, target, induced, nil).match(func([]int) bool {
+			n++
+			return false
+		})
+		return
+	}
+
+	// Every copy of the motif is hit once per automorphism of the motif.
+	return count(mg, false) / count(mm, true)
+}
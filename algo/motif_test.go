@@ -0,0 +1,100 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type MotifSuite struct{}
+
+var _ = Suite(&MotifSuite{})
+
+var triangleMotif = gogl.Spec().Using(gogl.EdgeList{
+	gogl.NewEdge(1, 2),
+	gogl.NewEdge(2, 3),
+	gogl.NewEdge(3, 1),
+}).Create(al.G)
+
+// Counts triangles by brute force, checking every vertex triple.
+func bruteForceTriangles(g gogl.Graph) (count int) {
+	vs := gogl.CollectVertices(g)
+	for i := range vs {
+		for j := i + 1; j < len(vs); j++ {
+			for k := j + 1; k < len(vs); k++ {
+				if g.HasEdge(gogl.NewEdge(vs[i], vs[j])) &&
+					g.HasEdge(gogl.NewEdge(vs[j], vs[k])) &&
+					g.HasEdge(gogl.NewEdge(vs[i], vs[k])) {
+					count++
+				}
+			}
+		}
+	}
+	return
+}
+
+func (s *MotifSuite) TestTriangles(c *C) {
+	// K4 contains four triangles.
+	k4 := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("a", "c"),
+		gogl.NewEdge("a", "d"),
+		gogl.NewEdge("b", "c"),
+		gogl.NewEdge("b", "d"),
+		gogl.NewEdge("c", "d"),
+	}).Create(al.G)
+	c.Assert(CountMotif(k4, triangleMotif), Equals, 4)
+
+	// The Petersen graph has none, while a wheel is full of them.
+	c.Assert(CountMotif(relabeledGraph(petersen, identity), triangleMotif), Equals, 0)
+
+	wheel := relabeledGraph([][2]int{
+		{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 1},
+		{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5},
+		{5, 6}, {6, 1},
+	}, identity)
+	c.Assert(CountMotif(wheel, triangleMotif), Equals, bruteForceTriangles(wheel))
+	c.Assert(CountMotif(wheel, triangleMotif), Equals, 6)
+}
+
+func (s *MotifSuite) TestNonInducedCopies(c *C) {
+	// A 3-vertex path is not induced within a triangle, but is still counted:
+	// once per choice of center vertex.
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}}, identity)
+	c.Assert(CountMotif(triangleMotif, path), Equals, 3)
+
+	// A 4-cycle contains exactly one square.
+	square := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}}, identity)
+	c.Assert(CountMotif(square, square), Equals, 1)
+	// K4 contains three.
+	c.Assert(CountMotif(relabeledGraph([][2]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, identity), square), Equals, 3)
+}
+
+func (s *MotifSuite) TestFeedForwardLoops(c *C) {
+	ffl := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("x", "y"),
+		gogl.NewArc("y", "z"),
+		gogl.NewArc("x", "z"),
+	}).Create(al.G)
+
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		// one feed-forward loop: 1 -> 2 -> 3, 1 -> 3
+		gogl.NewArc(1, 2),
+		gogl.NewArc(2, 3),
+		gogl.NewArc(1, 3),
+		// a directed cycle is not a feed-forward loop: 4 -> 5 -> 6 -> 4
+		gogl.NewArc(4, 5),
+		gogl.NewArc(5, 6),
+		gogl.NewArc(6, 4),
+		// a second feed-forward loop sharing vertex 3: 3 -> 7 -> 8, 3 -> 8
+		gogl.NewArc(3, 7),
+		gogl.NewArc(7, 8),
+		gogl.NewArc(3, 8),
+	}).Create(al.G)
+
+	c.Assert(CountMotif(g, ffl), Equals, 2)
+
+	// Directedness must agree.
+	c.Assert(CountMotif(g, triangleMotif), Equals, 0)
+	c.Assert(CountMotif(triangleMotif, ffl), Equals, 0)
+}
@@ -0,0 +1,125 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Generates the biased random walks of node2vec, for use as the corpus from which
+// an external trainer learns vertex embeddings. walksPerNode rounds are made, each
+// starting one walk from every vertex, so the result holds walksPerNode walks per
+// vertex, grouped by round.
+//
+// Each walk lists up to length vertices, starting vertex included. The first step
+// from the start is taken at random. After that, having just stepped from t to v,
+// the walk moves on to each neighbor x of v with probability proportional to the
+// weight of the edge between them, multiplied by:
+//
+//	1/p if x is t, returning where the walk came from;
+//	1   if x is a neighbor of t, staying near it;
+//	1/q otherwise, moving outward from t.
+//
+// A low return parameter p thus keeps walks close to home, while the in-out
+// parameter q trades between local, breadth-first-like walks (q > 1) and
+// exploratory, depth-first-like ones (q < 1). p = q = 1 gives plain random walks.
+//
+// Edges that are not WeightedEdges have unit weight. Arc direction is respected
+// for Digraphs, and a walk that reaches a vertex with no out-arcs ends there,
+// shorter than length. The order in which the vertices start their walks in each
+// round is not meaningful.
+//
+// p, q and length must be positive, and walksPerNode non-negative, else panic.
+// If no rand source is provided, the stdlib math's global rand source is used.
+func Node2VecWalks(g gogl.Graph, walksPerNode, length int, p, q float64, src stdrand.Source) [][]gogl.Vertex {
+	if p <= 0 || q <= 0 {
+		panic("p and q must be positive.")
+	}
+	if walksPerNode < 0 || length < 1 {
+		panic("walksPerNode must be non-negative, and length positive.")
+	}
+
+	float64n := stdrand.Float64
+	if src != nil {
+		float64n = stdrand.New(src).Float64
+	}
+
+	type step struct {
+		to     int
+		weight float64
+	}
+
+	vi := gogl.NewVertexIndex(g)
+	n := vi.Len()
+	steps := make([][]step, n)
+	adjacent := make([]map[int]bool, n)
+	for v, vertex := range vi.List() {
+		adjacent[v] = make(map[int]bool)
+		eachOutEdge(g, vertex, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+			wi := vi.IndexOf(w)
+			steps[v] = append(steps[v], step{wi, weightOf(e)})
+			adjacent[v][wi] = true
+			return
+		})
+	}
+
+	// Picks one of v's steps with probability proportional to its weight times
+	// bias(step), or returns false if none has any chance.
+	choose := func(v int, bias func(x int) float64) (int, bool) {
+		var total float64
+		for _, s := range steps[v] {
+			total += s.weight * bias(s.to)
+		}
+		if total <= 0 {
+			return -1, false
+		}
+
+		r := float64n() * total
+		for _, s := range steps[v] {
+			if r -= s.weight * bias(s.to); r < 0 {
+				return s.to, true
+			}
+		}
+		// Rounding may leave r a hair above zero; fall back to the last step
+		// with any chance.
+		for i := len(steps[v]) - 1; i >= 0; i-- {
+			if s := steps[v][i]; s.weight*bias(s.to) > 0 {
+				return s.to, true
+			}
+		}
+		return -1, false
+	}
+
+	unbiased := func(int) float64 { return 1 }
+
+	walks := make([][]gogl.Vertex, 0, walksPerNode*n)
+
+	for round := 0; round < walksPerNode; round++ {
+		for start := 0; start < n; start++ {
+			walk := make([]gogl.Vertex, 1, length)
+			walk[0] = vi.VertexAt(start)
+
+			t := start
+			next, ok := choose(start, unbiased)
+			for ok && len(walk) < length {
+				walk = append(walk, vi.VertexAt(next))
+
+				v := next
+				next, ok = choose(v, func(x int) float64 {
+					switch {
+					case x == t:
+						return 1 / p
+					case adjacent[t][x]:
+						return 1
+					default:
+						return 1 / q
+					}
+				})
+				t = v
+			}
+			walks = append(walks, walk)
+		}
+	}
+
+	return walks
+}
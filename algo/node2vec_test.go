@@ -0,0 +1,96 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type Node2VecSuite struct{}
+
+var _ = Suite(&Node2VecSuite{})
+
+func (s *Node2VecSuite) TestLengths(c *C) {
+	var pairs [][2]int
+	for i := 0; i < 10; i++ {
+		pairs = append(pairs, [2]int{i, (i + 1) % 10})
+	}
+	g := relabeledGraph(pairs, identity)
+
+	walks := Node2VecWalks(g, 3, 7, 0.5, 2, stdrand.NewSource(1))
+	c.Assert(walks, HasLen, 30)
+
+	starts := make(map[gogl.Vertex]int)
+	for _, walk := range walks {
+		c.Assert(walk, HasLen, 7)
+		c.Assert(IsWalk(g, walk), Equals, true, Commentf("walk %v", walk))
+		starts[walk[0]]++
+	}
+	for v := 0; v < 10; v++ {
+		c.Assert(starts[v], Equals, 3)
+	}
+}
+
+func (s *Node2VecSuite) TestDeadEnds(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+	}).Create(al.G)
+
+	lengths := make(map[gogl.Vertex]int)
+	for _, walk := range Node2VecWalks(g, 1, 5, 1, 1, stdrand.NewSource(1)) {
+		c.Assert(IsWalk(g, walk), Equals, true)
+		lengths[walk[0]] = len(walk)
+	}
+	c.Assert(lengths, DeepEquals, map[gogl.Vertex]int{"a": 3, "b": 2, "c": 1})
+
+	c.Assert(Node2VecWalks(gogl.NullGraph, 2, 5, 1, 1, nil), HasLen, 0)
+	c.Assert(func() { Node2VecWalks(g, 1, 5, 0, 1, nil) }, PanicMatches, "p and q must be positive.")
+	c.Assert(func() { Node2VecWalks(g, 1, 0, 1, 1, nil) }, PanicMatches, ".*length positive.")
+}
+
+// Measures, over all steps after the first of each walk, the fraction that return
+// to the vertex just left, and the fraction that move outward from it; and the
+// mean number of distinct vertices per walk.
+func walkStats(g gogl.Graph, walks [][]gogl.Vertex) (returns, outward, distinctPerWalk float64) {
+	var steps int
+	for _, walk := range walks {
+		seen := make(map[gogl.Vertex]bool)
+		for _, v := range walk {
+			seen[v] = true
+		}
+		distinctPerWalk += float64(len(seen))
+
+		for i := 2; i < len(walk); i++ {
+			t, x := walk[i-2], walk[i]
+			steps++
+			if x == t {
+				returns++
+			} else if !g.HasEdge(gogl.NewEdge(t, x)) {
+				outward++
+			}
+		}
+	}
+	return returns / float64(steps), outward / float64(steps), distinctPerWalk / float64(len(walks))
+}
+
+func (s *Node2VecSuite) TestBias(c *C) {
+	g := gogl.Spec().Using(rand.BernoulliDistribution(150, 0.06, false, true, stdrand.NewSource(1))).Create(al.G)
+
+	// A small return parameter keeps walks doubling back.
+	homebody, _, _ := walkStats(g, Node2VecWalks(g, 5, 20, 0.05, 1, stdrand.NewSource(2)))
+	plain, _, _ := walkStats(g, Node2VecWalks(g, 5, 20, 1, 1, stdrand.NewSource(3)))
+	wanderer, _, _ := walkStats(g, Node2VecWalks(g, 5, 20, 20, 1, stdrand.NewSource(4)))
+	c.Assert(homebody > 0.5, Equals, true, Commentf("return rate %v", homebody))
+	c.Assert(homebody > plain && plain > wanderer, Equals, true, Commentf("return rates %v, %v, %v", homebody, plain, wanderer))
+
+	// A small in-out parameter sends walks outward, covering more ground; a large
+	// one keeps them local.
+	_, dfsOut, dfsDistinct := walkStats(g, Node2VecWalks(g, 5, 20, 1, 0.05, stdrand.NewSource(5)))
+	_, bfsOut, bfsDistinct := walkStats(g, Node2VecWalks(g, 5, 20, 1, 20, stdrand.NewSource(6)))
+	c.Assert(dfsOut > bfsOut, Equals, true, Commentf("outward rates %v, %v", dfsOut, bfsOut))
+	c.Assert(dfsDistinct > bfsDistinct, Equals, true, Commentf("distinct vertices per walk %v, %v", dfsDistinct, bfsDistinct))
+}
@@ -0,0 +1,53 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/traverse"
+)
+
+// Numbers the vertices reachable from start in the order a breadth-first search
+// visits them, from 0 at start itself. Arc direction is respected for Digraphs.
+//
+// Vertices that cannot be reached from start are absent from the returned map; if
+// start is not in the graph, the map is empty.
+func BFSNumbering(g gogl.Graph, start gogl.Vertex) map[gogl.Vertex]int {
+	num := make(map[gogl.Vertex]int)
+	if !g.HasVertex(start) {
+		return num
+	}
+
+	num[start] = 0
+	queue := []gogl.Vertex{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+
+		eachOutEdge(g, v, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+			if _, seen := num[w]; !seen {
+				num[w] = len(num)
+				queue = append(queue, w)
+			}
+			return
+		})
+	}
+
+	return num
+}
+
+// Numbers the vertices reachable from start in the order a depth-first search
+// discovers them (its preorder), from 0 at start itself. Arc direction is respected
+// for Digraphs.
+//
+// This is the order of OnDiscover calls in traverse.DepthFirstSearch. Vertices that
+// cannot be reached from start are absent from the returned map; if start is not
+// in the graph, the map is empty.
+func DFSNumbering(g gogl.Graph, start gogl.Vertex) map[gogl.Vertex]int {
+	num := make(map[gogl.Vertex]int)
+	traverse.DepthFirstSearch(g, start, traverse.DFSVisitor{
+		OnDiscover: func(v gogl.Vertex) {
+			num[v] = len(num)
+		},
+	})
+
+	return num
+}
@@ -0,0 +1,126 @@
+package algo
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type NumberingSuite struct{}
+
+var _ = Suite(&NumberingSuite{})
+
+// Inverts a numbering into the sequence of vertices it describes, checking that
+// it numbers exactly the vertices reachable from start, densely from 0.
+func numberedOrder(c *C, g gogl.Graph, start gogl.Vertex, num map[gogl.Vertex]int) []gogl.Vertex {
+	reach, _, _ := bfsDistances(g, start, -1)
+	c.Assert(num, HasLen, len(reach))
+
+	order := make([]gogl.Vertex, len(num))
+	for v, i := range num {
+		_, reachable := reach[v]
+		c.Assert(reachable, Equals, true, Commentf("unreachable vertex %v numbered", v))
+		c.Assert(order[i], IsNil, Commentf("number %d used twice", i))
+		order[i] = v
+	}
+	c.Assert(order[0], Equals, start)
+	return order
+}
+
+// Checks that the numbering is a valid BFS order: taking each vertex's parent to be
+// its lowest-numbered in-neighbor, every non-start vertex must have a parent with
+// a smaller number, and parents must be nondecreasing along the order.
+func checkBFSNumbering(c *C, g gogl.Graph, start gogl.Vertex, num map[gogl.Vertex]int) {
+	order := numberedOrder(c, g, start, num)
+
+	last := 0
+	for i, v := range order[1:] {
+		parent := -1
+		for u := range neighborSet(g, v, InNeighbors) {
+			if n, numbered := num[u]; numbered && (parent == -1 || n < parent) {
+				parent = n
+			}
+		}
+		c.Assert(parent >= 0 && parent <= i, Equals, true, Commentf("vertex %v numbered %d has parent %d", v, i+1, parent))
+		c.Assert(parent >= last, Equals, true, Commentf("vertex %v visited out of BFS order", v))
+		last = parent
+	}
+}
+
+// Checks that the numbering is a valid DFS preorder, by replaying the search: each
+// vertex must be a successor of the deepest vertex on the stack that still has
+// undiscovered successors.
+func checkDFSNumbering(c *C, g gogl.Graph, start gogl.Vertex, num map[gogl.Vertex]int) {
+	order := numberedOrder(c, g, start, num)
+
+	discovered := map[gogl.Vertex]bool{start: true}
+	stack := []gogl.Vertex{start}
+	for i, v := range order[1:] {
+		for len(stack) > 0 {
+			var open bool
+			eachOutEdge(g, stack[len(stack)-1], func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+				open = !discovered[w]
+				return open
+			})
+			if open {
+				break
+			}
+			stack = stack[:len(stack)-1]
+		}
+		c.Assert(stack, Not(HasLen), 0, Commentf("vertex %v numbered %d after the search ended", v, i+1))
+
+		_, adjacent := neighborSet(g, stack[len(stack)-1], OutNeighbors)[v]
+		c.Assert(adjacent, Equals, true, Commentf("vertex %v numbered %d is not a successor of %v", v, i+1, stack[len(stack)-1]))
+
+		discovered[v] = true
+		stack = append(stack, v)
+	}
+}
+
+func (s *NumberingSuite) TestKnownOrders(c *C) {
+	// A path has only one order of either kind.
+	path := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("x", "a"),
+	}).Create(al.G)
+
+	want := map[gogl.Vertex]int{"a": 0, "b": 1, "c": 2}
+	c.Assert(BFSNumbering(path, "a"), DeepEquals, want)
+	c.Assert(DFSNumbering(path, "a"), DeepEquals, want)
+
+	// x cannot be reached from a, as arcs are followed forward only.
+	c.Assert(BFSNumbering(path, "c"), DeepEquals, map[gogl.Vertex]int{"c": 0})
+	c.Assert(BFSNumbering(path, "missing"), HasLen, 0)
+	c.Assert(DFSNumbering(path, "missing"), HasLen, 0)
+
+	// With two branches, BFS numbers both of the root's children before either
+	// grandchild, while DFS descends one branch before the other.
+	tree := relabeledGraph([][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 4}}, identity)
+	bfs := BFSNumbering(tree, 0)
+	c.Assert(bfs[3] > bfs[2] && bfs[4] > bfs[1], Equals, true)
+	dfs := DFSNumbering(tree, 0)
+	c.Assert(dfs[3] == dfs[1]+1 || dfs[4] == dfs[2]+1, Equals, true)
+}
+
+func (s *NumberingSuite) TestRandomGraphs(c *C) {
+	for seed := int64(1); seed <= 10; seed++ {
+		directed := seed%2 == 0
+		src := rand.BernoulliDistribution(40, 0.06, directed, true, stdrand.NewSource(seed))
+
+		var g gogl.Graph
+		if directed {
+			g = gogl.Spec().Directed().Using(src).Create(al.G)
+		} else {
+			g = gogl.Spec().Using(src).Create(al.G)
+		}
+
+		for _, start := range []gogl.Vertex{0, 17, 39} {
+			checkBFSNumbering(c, g, start, BFSNumbering(g, start))
+			checkDFSNumbering(c, g, start, DFSNumbering(g, start))
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package algo
+
+import (
+	"container/heap"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Finds the Pareto-optimal paths from source to target under two objectives at
+// once - say, time and money - as given for each edge by cost. A path is Pareto-
+// optimal if no other path costs at most as much on both criteria and less on at
+// least one; no single path need be best on both, so the result is the whole
+// trade-off curve. Costs must be non-negative.
+//
+// This is Martins' multi-criteria generalization of Dijkstra's algorithm. Each
+// vertex gathers a set of labels, one per non-dominated path found to it, rather
+// than a single distance. Labels are settled in lexicographic order of cost, so
+// that when one is settled, no cheaper path to its vertex remains to be found,
+// and it is kept iff no label already settled there dominates it. Labels that
+// some settled label at the target already dominates are pruned, as extending
+// them cannot help.
+//
+// Where several paths have exactly the same costs, only one is reported. The
+// paths are returned in increasing order of the first criterion, and so in
+// decreasing order of the second. Arc direction is respected for Digraphs. If
+// either vertex is not present in the graph, or target is unreachable, nil is
+// returned.
+func ParetoShortestPaths(g gogl.Graph, source, target gogl.Vertex, cost func(e gogl.Edge) [2]float64) [][]gogl.Vertex {
+	if !g.HasVertex(source) || !g.HasVertex(target) {
+		return nil
+	}
+
+	settled := make(map[interface{}][]*paretoLabel, sizeHint(g)) // keyed by gogl.VertexKey
+	dominated := func(v gogl.Vertex, c [2]float64) bool {
+		for _, l := range settled[gogl.VertexKey(v)] {
+			if l.cost[0] <= c[0] && l.cost[1] <= c[1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	pq := &paretoQueue{&paretoLabel{v: source}}
+	for pq.Len() > 0 {
+		l := heap.Pop(pq).(*paretoLabel)
+		if dominated(l.v, l.cost) || dominated(target, l.cost) {
+			continue
+		}
+		k := gogl.VertexKey(l.v)
+		settled[k] = append(settled[k], l)
+		if k == gogl.VertexKey(target) {
+			continue
+		}
+
+		eachOutEdge(g, l.v, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+			ec := cost(e)
+			c := [2]float64{l.cost[0] + ec[0], l.cost[1] + ec[1]}
+			if !dominated(w, c) && !dominated(target, c) {
+				heap.Push(pq, &paretoLabel{v: w, cost: c, prev: l})
+			}
+			return
+		})
+	}
+
+	var paths [][]gogl.Vertex
+	for _, l := range settled[gogl.VertexKey(target)] {
+		var path []gogl.Vertex
+		for ; l != nil; l = l.prev {
+			path = append(path, l.v)
+		}
+		for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+			path[i], path[j] = path[j], path[i]
+		}
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// A path to a vertex, as a chain of labels back to the source, with its costs.
+type paretoLabel struct {
+	v    gogl.Vertex
+	cost [2]float64
+	prev *paretoLabel
+}
+
+// A min-heap of labels keyed lexicographically on cost, for use with container/heap.
+type paretoQueue []*paretoLabel
+
+func (q paretoQueue) Len() int { return len(q) }
+func (q paretoQueue) Less(i, j int) bool {
+	a, b := q[i].cost, q[j].cost
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+func (q paretoQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *paretoQueue) Push(x interface{}) { *q = append(*q, x.(*paretoLabel)) }
+func (q *paretoQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
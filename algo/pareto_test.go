@@ -0,0 +1,166 @@
+package algo
+
+import (
+	"fmt"
+	stdrand "math/rand"
+	"sort"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type ParetoSuite struct{}
+
+var _ = Suite(&ParetoSuite{})
+
+// Edge costs for tests, keyed by endpoints in the order given.
+type costTable map[[2]int][2]float64
+
+func (t costTable) cost(e gogl.Edge) [2]float64 {
+	u, v := e.Both()
+	if c, ok := t[[2]int{u.(int), v.(int)}]; ok {
+		return c
+	}
+	return t[[2]int{v.(int), u.(int)}]
+}
+
+func (t costTable) graph(directed bool) gogl.Graph {
+	if directed {
+		g := gogl.Spec().Directed().Create(al.G)
+		for k := range t {
+			g.(gogl.ArcSetMutator).AddArcs(gogl.NewArc(k[0], k[1]))
+		}
+		return g
+	}
+	g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	for k := range t {
+		g.AddEdges(gogl.NewEdge(k[0], k[1]))
+	}
+	return g
+}
+
+// Sums the costs along the given path, failing if it uses an edge that is absent.
+func (t costTable) pathCost(c *C, g gogl.Graph, path []gogl.Vertex) [2]float64 {
+	var total [2]float64
+	for i := 1; i < len(path); i++ {
+		e := gogl.NewEdge(path[i-1], path[i])
+		if dg, ok := g.(gogl.Digraph); ok {
+			c.Assert(dg.HasArc(gogl.NewArc(path[i-1], path[i])), Equals, true, Commentf("path %v", path))
+		} else {
+			c.Assert(g.HasEdge(e), Equals, true, Commentf("path %v", path))
+		}
+		ec := t.cost(e)
+		total[0] += ec[0]
+		total[1] += ec[1]
+	}
+	return total
+}
+
+// Finds the Pareto front of the costs of every simple path from source to target.
+func (t costTable) bruteForceFront(g gogl.Graph, source, target gogl.Vertex) [][2]float64 {
+	var costs [][2]float64
+	onPath := map[gogl.Vertex]bool{source: true}
+	var extend func(v gogl.Vertex, sofar [2]float64)
+	extend = func(v gogl.Vertex, sofar [2]float64) {
+		if v == target {
+			costs = append(costs, sofar)
+			return
+		}
+		eachOutEdge(g, v, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+			if !onPath[w] {
+				onPath[w] = true
+				ec := t.cost(e)
+				extend(w, [2]float64{sofar[0] + ec[0], sofar[1] + ec[1]})
+				onPath[w] = false
+			}
+			return
+		})
+	}
+	extend(source, [2]float64{})
+
+	var front [][2]float64
+	seen := make(map[[2]float64]bool)
+	for _, a := range costs {
+		optimal := !seen[a]
+		for _, b := range costs {
+			if b[0] <= a[0] && b[1] <= a[1] && b != a {
+				optimal = false
+			}
+		}
+		if optimal {
+			seen[a] = true
+			front = append(front, a)
+		}
+	}
+	sort.Slice(front, func(i, j int) bool { return front[i][0] < front[j][0] })
+	return front
+}
+
+func (s *ParetoSuite) TestKnownFront(c *C) {
+	// From 1 to 4: the highway 1-2-4 is fast but tolled, the back road 1-3-4 is
+	// slow but free, and the mix 1-2-3-4 is worse than the back road on both.
+	t := costTable{
+		{1, 2}: {1, 5},
+		{2, 4}: {1, 5},
+		{1, 3}: {4, 0},
+		{3, 4}: {4, 0},
+		{2, 3}: {4, 10},
+	}
+	g := t.graph(false)
+
+	paths := ParetoShortestPaths(g, 1, 4, t.cost)
+	c.Assert(paths, DeepEquals, [][]gogl.Vertex{seq(1, 2, 4), seq(1, 3, 4)})
+
+	// Making the back road a little dearer in time makes no difference, but
+	// charging for it makes the highway better on both counts.
+	t[[2]int{3, 4}] = [2]float64{5, 0}
+	c.Assert(ParetoShortestPaths(g, 1, 4, t.cost), DeepEquals, [][]gogl.Vertex{seq(1, 2, 4), seq(1, 3, 4)})
+	t[[2]int{3, 4}] = [2]float64{5, 10}
+	c.Assert(ParetoShortestPaths(g, 1, 4, t.cost), DeepEquals, [][]gogl.Vertex{seq(1, 2, 4)})
+}
+
+func (s *ParetoSuite) TestEdgeCases(c *C) {
+	t := costTable{{1, 2}: {1, 1}, {3, 4}: {1, 1}}
+	g := t.graph(true)
+
+	c.Assert(ParetoShortestPaths(g, 1, 1, t.cost), DeepEquals, [][]gogl.Vertex{seq(1)})
+	c.Assert(ParetoShortestPaths(g, 1, 3, t.cost), IsNil)
+	c.Assert(ParetoShortestPaths(g, 2, 1, t.cost), IsNil)
+	c.Assert(ParetoShortestPaths(g, 1, 5, t.cost), IsNil)
+	c.Assert(ParetoShortestPaths(g, 1, 2, t.cost), DeepEquals, [][]gogl.Vertex{seq(1, 2)})
+}
+
+func (s *ParetoSuite) TestMatchesBruteForce(c *C) {
+	r := stdrand.New(stdrand.NewSource(1))
+	for trial := 0; trial < 60; trial++ {
+		const n = 8
+		t := make(costTable)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j && r.Float64() < 0.35 {
+					if _, dup := t[[2]int{j, i}]; !dup {
+						t[[2]int{i, j}] = [2]float64{float64(r.Intn(10)), float64(r.Intn(10))}
+					}
+				}
+			}
+		}
+
+		for _, directed := range []bool{true, false} {
+			comment := Commentf("trial %d, directed %v", trial, directed)
+			g := t.graph(directed)
+			if !g.HasVertex(0) || !g.HasVertex(n-1) {
+				continue
+			}
+
+			paths := ParetoShortestPaths(g, 0, n-1, t.cost)
+			var costs [][2]float64
+			for _, p := range paths {
+				c.Assert(p[0], Equals, 0, comment)
+				c.Assert(p[len(p)-1], Equals, n-1, comment)
+				costs = append(costs, t.pathCost(c, g, p))
+			}
+			c.Assert(fmt.Sprint(costs), Equals, fmt.Sprint(t.bruteForceFront(g, 0, n-1)), comment)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Computes the k-th power of the graph: a graph on the same vertices, in which
+// two distinct vertices are adjacent iff some path of at most k edges joins them
+// in the original. The square, k = 2, figures in clustering and in approximation
+// algorithms for problems like the k-center.
+//
+// Each vertex's neighborhood is found by a breadth-first search cut off at depth
+// k. The power of a Digraph is a Digraph, with an arc from u to v iff a path of
+// at most k arcs leads from u to v. Self-loops are not included, and weights,
+// labels and data are not carried over, so the first power of a simple, basic
+// graph equals it. The zeroth power has no edges. k must not be negative, else
+// panic. The result is a mutable adjacency list.
+func Power(g gogl.Graph, k int) gogl.Graph {
+	if k < 0 {
+		panic("k must be non-negative.")
+	}
+
+	_, directed := g.(gogl.Digraph)
+	spec := gogl.Spec()
+	if directed {
+		spec = spec.Directed()
+	}
+	pg := spec.Create(al.G)
+
+	vertices := gogl.CollectVertices(g)
+	pg.(gogl.VertexSetMutator).EnsureVertex(vertices...)
+
+	pos := make(map[interface{}]int, len(vertices)) // keyed by gogl.VertexKey
+	for i, v := range vertices {
+		pos[gogl.VertexKey(v)] = i
+	}
+
+	var edges []gogl.Edge
+	var arcs []gogl.Arc
+	for i, v := range vertices {
+		_, reached, _ := bfsDistances(g, v, k)
+		for _, w := range reached {
+			switch j := pos[gogl.VertexKey(w)]; {
+			case j == i:
+			case directed:
+				arcs = append(arcs, gogl.NewArc(v, w))
+			case i < j:
+				// The search from w finds the same edge; only one end adds it.
+				edges = append(edges, gogl.NewEdge(v, w))
+			}
+		}
+	}
+
+	if directed {
+		pg.(gogl.MutableDigraph).AddArcs(arcs...)
+	} else {
+		pg.(gogl.MutableGraph).AddEdges(edges...)
+	}
+
+	return pg
+}
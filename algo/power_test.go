@@ -0,0 +1,76 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type PowerSuite struct{}
+
+var _ = Suite(&PowerSuite{})
+
+// Checks that the two graphs have the same vertices and edges.
+func checkSameGraph(c *C, a, b gogl.Graph) {
+	c.Assert(gogl.Order(a), Equals, gogl.Order(b))
+	c.Assert(gogl.Size(a), Equals, gogl.Size(b))
+	a.Vertices(func(v gogl.Vertex) (terminate bool) {
+		c.Assert(b.HasVertex(v), Equals, true, Commentf("vertex %v", v))
+		return
+	})
+	a.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(b.HasEdge(e), Equals, true, Commentf("edge %v", e))
+		return
+	})
+}
+
+func (s *PowerSuite) TestPathSquare(c *C) {
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}, identity)
+
+	sq := Power(path, 2)
+	checkSameGraph(c, sq, relabeledGraph([][2]int{
+		{1, 2}, {2, 3}, {3, 4}, {4, 5},
+		{1, 3}, {2, 4}, {3, 5},
+	}, identity))
+
+	// A high enough power is complete.
+	c.Assert(gogl.Size(Power(path, 4)), Equals, 10)
+	c.Assert(gogl.Size(Power(path, 40)), Equals, 10)
+
+	zero := Power(path, 0)
+	c.Assert(gogl.Order(zero), Equals, 5)
+	c.Assert(gogl.Size(zero), Equals, 0)
+
+	c.Assert(func() { Power(path, -1) }, PanicMatches, "k must be non-negative.")
+}
+
+func (s *PowerSuite) TestFirstPower(c *C) {
+	g := relabeledGraph(petersen, identity).(gogl.MutableGraph)
+	g.EnsureVertex("isolate")
+	checkSameGraph(c, Power(g, 1), g)
+
+	dg := randomDigraph(30, 0.1, 1)
+	p := Power(dg, 1)
+	_, directed := p.(gogl.Digraph)
+	c.Assert(directed, Equals, true)
+	checkSameGraph(c, p, dg)
+}
+
+func (s *PowerSuite) TestDirected(c *C) {
+	// A directed cycle of four; its square reaches two steps ahead, but never back.
+	dg := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "d"),
+		gogl.NewArc("d", "a"),
+	}).Create(al.G)
+
+	sq := Power(dg, 2).(gogl.Digraph)
+	c.Assert(gogl.Size(sq), Equals, 8)
+	c.Assert(sq.HasArc(gogl.NewArc("a", "c")), Equals, true)
+	c.Assert(sq.HasArc(gogl.NewArc("c", "a")), Equals, true)
+	c.Assert(sq.HasArc(gogl.NewArc("b", "a")), Equals, false)
+
+	// The cube connects every ordered pair.
+	c.Assert(gogl.Size(Power(dg, 3)), Equals, 12)
+}
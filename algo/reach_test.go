@@ -0,0 +1,72 @@
+package algo
+
+import (
+	stdrand "math/rand"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type ReachSuite struct{}
+
+var _ = Suite(&ReachSuite{})
+
+// Determines the set of vertices reachable from u by at least one arc, via BFS.
+func bfsReachable(g gogl.Digraph, u gogl.Vertex) map[gogl.Vertex]bool {
+	reached := make(map[gogl.Vertex]bool)
+	queue := []gogl.Vertex{u}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		g.SuccessorsOf(v, func(w gogl.Vertex) (terminate bool) {
+			if !reached[w] {
+				reached[w] = true
+				queue = append(queue, w)
+			}
+			return
+		})
+	}
+	return reached
+}
+
+func (s *ReachSuite) TestMatchesBFS(c *C) {
+	// Over 64 vertices, so rows span several words.
+	g := gogl.Spec().Directed().Using(rand.BernoulliDistribution(150, 0.01, true, true, stdrand.NewSource(1))).Create(al.G).(gogl.Digraph)
+
+	reachable, order := ReachabilityMatrix(g)
+	c.Assert(order, HasLen, 150)
+
+	for _, u := range order {
+		want := bfsReachable(g, u)
+		for _, v := range order {
+			c.Assert(reachable(u, v), Equals, want[v], Commentf("u=%v, v=%v", u, v))
+		}
+	}
+}
+
+func (s *ReachSuite) TestCyclesAndUnknowns(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "b"),
+	}).Create(al.G).(gogl.Digraph)
+
+	reachable, _ := ReachabilityMatrix(g)
+	c.Assert(reachable("a", "c"), Equals, true)
+	c.Assert(reachable("c", "a"), Equals, false)
+	c.Assert(reachable("a", "a"), Equals, false)
+	c.Assert(reachable("b", "b"), Equals, true)
+	c.Assert(reachable("a", "z"), Equals, false)
+	c.Assert(reachable("z", "a"), Equals, false)
+}
+
+func BenchmarkReachabilityMatrix(b *testing.B) {
+	g := gogl.Spec().Directed().Using(rand.BernoulliDistribution(400, 0.005, true, true, stdrand.NewSource(1))).Create(al.G).(gogl.Digraph)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ReachabilityMatrix(g)
+	}
+}
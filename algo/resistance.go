@@ -0,0 +1,136 @@
+package algo
+
+import (
+	"errors"
+	"math"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Computes the resistance distance between u and v: the effective resistance
+// between them when the graph is taken as an electrical network, each edge a
+// resistor whose conductance is its weight. Unlike the shortest path distance,
+// it accounts for every route between the two, shrinking as more routes join
+// them, which makes it a robust measure of how well connected they are.
+//
+// The resistance is (e_u - e_v)' L+ (e_u - e_v), where L+ is the pseudoinverse
+// of the graph's Laplacian. Rather than form the pseudoinverse, an equivalent
+// system is solved: v is grounded, by dropping its row and column from the
+// Laplacian of the component holding u and v, and a unit current is injected at
+// u. The potential this raises at u is the resistance. This takes O(n^3) time
+// for a component of n vertices.
+//
+// Weights must be positive; an edge of zero weight conducts nothing. Self-loops
+// carry no current, and are ignored. An error is returned if either vertex is not
+// present in the graph, if the graph is a Digraph, or if no path joins u and v,
+// so that the resistance between them is infinite.
+func ResistanceDistance(g gogl.WeightedGraph, u, v gogl.Vertex) (float64, error) {
+	if _, directed := g.(gogl.Digraph); directed {
+		return 0, errors.New("Resistance distance is not defined for digraphs.")
+	}
+	if !g.HasVertex(u) || !g.HasVertex(v) {
+		return 0, errors.New("Vertex is not present in graph.")
+	}
+	uk, vk := gogl.VertexKey(u), gogl.VertexKey(v)
+	if uk == vk {
+		return 0, nil
+	}
+
+	l, vertices := Laplacian(g)
+	var ui, vi int
+	for i, x := range vertices {
+		switch gogl.VertexKey(x) {
+		case uk:
+			ui = i
+		case vk:
+			vi = i
+		}
+	}
+
+	// Find the vertices of u's component, through edges that conduct.
+	comp := []int{ui}
+	seen := map[int]bool{ui: true}
+	for k := 0; k < len(comp); k++ {
+		for j, x := range l[comp[k]] {
+			if x < 0 && !seen[j] {
+				seen[j] = true
+				comp = append(comp, j)
+			}
+		}
+	}
+	if !seen[vi] {
+		return 0, errors.New("Vertices are not connected, so the resistance between them is infinite.")
+	}
+
+	// Ground v, leaving u at index 0 of the reduced system.
+	var rows []int
+	for _, i := range comp {
+		if i != vi {
+			rows = append(rows, i)
+		}
+	}
+	a := make([][]float64, len(rows))
+	for r, i := range rows {
+		a[r] = make([]float64, len(rows))
+		for c, j := range rows {
+			a[r][c] = l[i][j]
+		}
+	}
+	b := make([][]float64, len(rows))
+	for r := range b {
+		b[r] = []float64{0}
+	}
+	b[0][0] = 1
+
+	x, ok := solveLinear(a, b)
+	if !ok {
+		return 0, errors.New("Grounded Laplacian is singular; weights must be positive.")
+	}
+	return x[0][0], nil
+}
+
+// Solves the square linear system ax = b by Gaussian elimination with partial
+// pivoting, overwriting a and b. b may hold several right-hand sides, one per
+// column, and x then holds the solution for each in the same column. Returns
+// false if a is singular.
+func solveLinear(a, b [][]float64) ([][]float64, bool) {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if a[pivot][col] == 0 {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for r := col + 1; r < n; r++ {
+			f := a[r][col] / a[col][col]
+			if f == 0 {
+				continue
+			}
+			for c := col; c < n; c++ {
+				a[r][c] -= f * a[col][c]
+			}
+			for k := range b[r] {
+				b[r][k] -= f * b[col][k]
+			}
+		}
+	}
+
+	x := make([][]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		x[r] = make([]float64, len(b[r]))
+		for k, sum := range b[r] {
+			for c := r + 1; c < n; c++ {
+				sum -= a[r][c] * x[c][k]
+			}
+			x[r][k] = sum / a[r][r]
+		}
+	}
+	return x, true
+}
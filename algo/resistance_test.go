@@ -0,0 +1,84 @@
+package algo
+
+import (
+	"math"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type ResistanceSuite struct{}
+
+var _ = Suite(&ResistanceSuite{})
+
+func (s *ResistanceSuite) assertResistance(c *C, g gogl.WeightedGraph, u, v gogl.Vertex, want float64) {
+	for _, pair := range [][2]gogl.Vertex{{u, v}, {v, u}} {
+		r, err := ResistanceDistance(g, pair[0], pair[1])
+		c.Assert(err, IsNil)
+		c.Assert(math.Abs(r-want) < 1e-9, Equals, true, Commentf("%v to %v: got %v, want %v", pair[0], pair[1], r, want))
+	}
+}
+
+func (s *ResistanceSuite) TestSeriesParallel(c *C) {
+	// A 1 ohm resistor from a to b, then two branches to c: one of two 1 ohm
+	// resistors in series, through d, and one of a single 2 ohm resistor. The
+	// branches are 2 ohms each, so 1 ohm in parallel, and 2 ohms in all. Weights
+	// are conductances, the reciprocals of resistances.
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("b", "d", 1),
+		gogl.NewWeightedEdge("d", "c", 1),
+		gogl.NewWeightedEdge("b", "c", 0.5),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	s.assertResistance(c, g, "a", "c", 2)
+	s.assertResistance(c, g, "a", "b", 1)
+	// From b to d, 1 ohm in parallel with 3 ohms around through c.
+	s.assertResistance(c, g, "b", "d", 0.75)
+	s.assertResistance(c, g, "a", "a", 0)
+}
+
+func (s *ResistanceSuite) TestKnownGraphs(c *C) {
+	// Between any two vertices of the complete graph on n vertices, 2/n.
+	var complete gogl.WeightedEdgeList
+	for i := 0; i < 6; i++ {
+		for j := i + 1; j < 6; j++ {
+			complete = append(complete, gogl.NewWeightedEdge(i, j, 1))
+		}
+	}
+	g := gogl.Spec().Weighted().Using(complete).Create(al.G).(gogl.WeightedGraph)
+	s.assertResistance(c, g, 0, 4, 2.0/6)
+
+	// Between vertices k apart on a cycle of n, the two arcs in parallel:
+	// k(n-k)/n.
+	var cycle gogl.WeightedEdgeList
+	for i := 0; i < 7; i++ {
+		cycle = append(cycle, gogl.NewWeightedEdge(i, (i+1)%7, 1))
+	}
+	g = gogl.Spec().Weighted().Using(cycle).Create(al.G).(gogl.WeightedGraph)
+	for k := 1; k < 7; k++ {
+		s.assertResistance(c, g, 0, k, float64(k*(7-k))/7)
+	}
+}
+
+func (s *ResistanceSuite) TestErrors(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1),
+		gogl.NewWeightedEdge("c", "d", 1),
+		gogl.NewWeightedEdge("d", "e", 0),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	_, err := ResistanceDistance(g, "a", "c")
+	c.Assert(err, ErrorMatches, "Vertices are not connected.*")
+	_, err = ResistanceDistance(g, "d", "e")
+	c.Assert(err, ErrorMatches, "Vertices are not connected.*")
+	_, err = ResistanceDistance(g, "a", "z")
+	c.Assert(err, ErrorMatches, "Vertex is not present in graph.")
+
+	dg := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "b", 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+	_, err = ResistanceDistance(dg, "a", "b")
+	c.Assert(err, ErrorMatches, ".*not defined for digraphs.")
+}
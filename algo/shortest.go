@@ -0,0 +1,506 @@
+package algo
+
+import (
+	"container/heap"
+	"container/list"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/sdboyer/gogl"
+)
+
+// ErrNegativeCycle is returned by shortest path algorithms that support negative
+// edge weights when they encounter a reachable cycle of negative total weight;
+// shortest paths are undefined in such graphs.
+var ErrNegativeCycle = errors.New("Negative weight cycle detected in graph.")
+
+// ErrNegativeWeight is returned by shortest path algorithms that require edge
+// weights to be non-negative, such as Dijkstra's, when an edge's weight is not.
+var ErrNegativeWeight = errors.New("Negative edge weight found; use SPFA for such graphs.")
+
+// ErrNoPath is returned when no path joins the requested vertices.
+var ErrNoPath = errors.New("No path exists between the given vertices.")
+
+// Calculates single-source shortest path distances on a graph whose edge weights
+// are all either 0 or 1, using a double-ended queue in place of a priority queue.
+//
+// This runs in O(V+E) time, making it considerably faster than Dijkstra's algorithm
+// for this special case (e.g., grid movement where some moves are free). Arc
+// direction is respected for Digraphs.
+//
+// The returned map contains an entry for each vertex reachable from the source.
+// An error is returned if the source vertex is not present in the graph, or if any
+// edge has a weight other than 0 or 1.
+func ZeroOneBFS(g gogl.WeightedGraph, source gogl.Vertex) (map[gogl.Vertex]float64, error) {
+	if !g.HasVertex(source) {
+		return nil, errors.New("Source vertex is not present in graph.")
+	}
+
+	var err error
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		if w := weightOf(e); w != 0 && w != 1 {
+			u, v := e.Both()
+			err = fmt.Errorf("Edge (%v, %v) has weight %v; only 0 and 1 are permitted.", u, v, w)
+			return true
+		}
+		return
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	dist := make(map[gogl.Vertex]float64, sizeHint(g))
+	dist[source] = 0
+	deque := list.New()
+	deque.PushBack(source)
+
+	for deque.Len() > 0 {
+		v := deque.Remove(deque.Front())
+		d := dist[v]
+
+		eachOutEdge(g, v, func(e gogl.Edge, adj gogl.Vertex) (terminate bool) {
+			w := weightOf(e)
+			if cur, seen := dist[adj]; !seen || d+w < cur {
+				dist[adj] = d + w
+				// Zero-weight edges keep the vertex at the current distance level, so
+				// it goes to the front; unit-weight edges go to the back.
+				if w == 0 {
+					deque.PushFront(adj)
+				} else {
+					deque.PushBack(adj)
+				}
+			}
+			return
+		})
+	}
+
+	return dist, nil
+}
+
+// Calculates single-source shortest paths using the Shortest Path Faster Algorithm,
+// a queue-based refinement of Bellman-Ford.
+//
+// Only vertices whose distance has just improved are re-examined, which makes SPFA
+// much faster than plain Bellman-Ford on typical graphs, while retaining its ability
+// to handle negative edge weights. Its worst case remains O(VE).
+//
+// Arc direction is respected for Digraphs; an undirected edge may be relaxed in
+// either direction (and thus any negative undirected edge forms a negative cycle).
+//
+// The returned distance map contains an entry for every vertex reachable from the
+// source; the predecessor map records the previous vertex on each such vertex's
+// shortest path (the source has no entry). If a negative cycle is reachable from
+// the source, ErrNegativeCycle is returned.
+func SPFA(g gogl.WeightedGraph, source gogl.Vertex) (map[gogl.Vertex]float64, map[gogl.Vertex]gogl.Vertex, error) {
+	if !g.HasVertex(source) {
+		return nil, nil, errors.New("Source vertex is not present in graph.")
+	}
+
+	order := gogl.Order(g)
+	hint := sizeHint(g)
+	dist := make(map[gogl.Vertex]float64, hint)
+	pred := make(map[gogl.Vertex]gogl.Vertex, hint)
+	dist[source] = 0
+
+	// Tracks the number of times each vertex has been enqueued. In the absence of
+	// negative cycles, no vertex can be enqueued more than order times.
+	enqueued := make(map[gogl.Vertex]int, hint)
+	queued := make(map[gogl.Vertex]bool, hint)
+	enqueued[source], queued[source] = 1, true
+
+	queue := list.New()
+	queue.PushBack(source)
+
+	var negcycle bool
+	for queue.Len() > 0 && !negcycle {
+		v := queue.Remove(queue.Front())
+		queued[v] = false
+		d := dist[v]
+
+		eachOutEdge(g, v, func(e gogl.Edge, adj gogl.Vertex) (terminate bool) {
+			nd := d + weightOf(e)
+			if cur, seen := dist[adj]; !seen || nd < cur {
+				dist[adj] = nd
+				pred[adj] = v
+
+				if !queued[adj] {
+					enqueued[adj]++
+					if enqueued[adj] > order {
+						negcycle = true
+						return true
+					}
+					queued[adj] = true
+					queue.PushBack(adj)
+				}
+			}
+			return
+		})
+	}
+
+	if negcycle {
+		return nil, nil, ErrNegativeCycle
+	}
+
+	return dist, pred, nil
+}
+
+// Calculates single-source shortest paths with the Bellman-Ford algorithm,
+// which, unlike Dijkstra's, tolerates negative edge weights.
+//
+// Every edge is relaxed in each of |V|-1 passes over Edges, or Arcs for a
+// Digraph, stopping early once a pass changes nothing; a final pass that still
+// finds an improvement reveals a negative cycle. This takes O(VE) time. SPFA
+// gives the same results, and is usually much faster; Bellman-Ford's running
+// time is more predictable.
+//
+// Arc direction is respected for Digraphs; an undirected edge is relaxed in
+// both directions, so any negative undirected edge reachable from the source
+// forms a negative cycle. The returned maps are as for SPFA: distances to every
+// vertex reachable from the source, and the predecessor of each on its shortest
+// path. If a negative cycle is reachable from the source, ErrNegativeCycle is
+// returned.
+func BellmanFord(g gogl.WeightedGraph, source gogl.Vertex) (dist map[gogl.Vertex]float64, pred map[gogl.Vertex]gogl.Vertex, err error) {
+	if !g.HasVertex(source) {
+		return nil, nil, errors.New("Source vertex is not present in graph.")
+	}
+
+	hint := sizeHint(g)
+	dist = make(map[gogl.Vertex]float64, hint)
+	pred = make(map[gogl.Vertex]gogl.Vertex, hint)
+	dist[source] = 0
+
+	relax := func(u, v gogl.Vertex, w float64) bool {
+		du, reached := dist[u]
+		if !reached {
+			return false
+		}
+		if dv, seen := dist[v]; !seen || du+w < dv {
+			dist[v], pred[v] = du+w, u
+			return true
+		}
+		return false
+	}
+
+	// Performs one relaxation pass over every edge, reporting whether any
+	// distance improved.
+	pass := func() (changed bool) {
+		if dg, ok := g.(gogl.Digraph); ok {
+			dg.Arcs(func(a gogl.Arc) (terminate bool) {
+				changed = relax(a.Source(), a.Target(), weightOf(a)) || changed
+				return
+			})
+		} else {
+			g.Edges(func(e gogl.Edge) (terminate bool) {
+				u, v := e.Both()
+				changed = relax(u, v, weightOf(e)) || changed
+				changed = relax(v, u, weightOf(e)) || changed
+				return
+			})
+		}
+		return
+	}
+
+	for i := 1; i < gogl.Order(g); i++ {
+		if !pass() {
+			return dist, pred, nil
+		}
+	}
+	if pass() {
+		return nil, nil, ErrNegativeCycle
+	}
+	return dist, pred, nil
+}
+
+// Finds a shortest path from one vertex to another with Dijkstra's algorithm,
+// returning the vertices along it, from first to last, and its total weight.
+//
+// A binary heap orders the vertices by tentative distance, and the search stops
+// as soon as the target is settled. Arc direction is respected for Digraphs.
+// Edges that are not WeightedEdges have unit weight. Should any edge have a
+// negative weight, ErrNegativeWeight is returned; SPFA handles such graphs. If
+// the target cannot be reached, ErrNoPath is returned. An error is also
+// returned if either vertex is not present in the graph.
+func ShortestPath(g gogl.WeightedGraph, from, to gogl.Vertex) (path []gogl.Vertex, cost float64, err error) {
+	if !g.HasVertex(to) {
+		return nil, 0, errors.New("Target vertex is not present in graph.")
+	}
+	dist, pred, err := dijkstraPaths(g, from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cost, reached := dist[gogl.VertexKey(to)]
+	if !reached {
+		return nil, 0, ErrNoPath
+	}
+	for v := to; gogl.VertexKey(v) != gogl.VertexKey(from); v = pred[gogl.VertexKey(v)] {
+		path = append(path, v)
+	}
+	path = append(path, from)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, cost, nil
+}
+
+// Calculates shortest path distances between every pair of vertices with the
+// Floyd-Warshall algorithm. It takes O(V^3) time and O(V^2) space regardless of
+// the number of edges, so it suits small, dense graphs, where the whole matrix
+// is wanted at once; for sparse ones, MultiSourceShortestPaths is faster.
+//
+// Unlike Dijkstra's algorithm, negative weights are allowed. Arc direction is
+// respected for Digraphs; an undirected edge may be walked either way, so, as for
+// BellmanFord, a negative undirected edge forms a negative cycle. If the graph
+// has a negative cycle anywhere, ErrNegativeCycle is returned.
+//
+// The distance map holds an entry dist[u][v] iff v is reachable from u; every
+// vertex reaches itself, at distance 0. The next map gives, for each such pair
+// of distinct vertices, the vertex following u on a shortest path to v, so that
+// the path may be recovered by following next[u][v], then next[next[u][v]][v],
+// and so on until v is reached.
+func AllPairsShortestPaths(g gogl.WeightedGraph) (dist map[gogl.Vertex]map[gogl.Vertex]float64, next map[gogl.Vertex]map[gogl.Vertex]gogl.Vertex, err error) {
+	vi := gogl.NewVertexIndex(g)
+	n := vi.Len()
+
+	// Row-major matrices: d holds distances, and hop the index of the next
+	// vertex on the path, or -1 where there is none.
+	d := make([]float64, n*n)
+	hop := make([]int, n*n)
+	for i := range d {
+		d[i] = math.Inf(1)
+		hop[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		d[i*n+i] = 0
+	}
+
+	relax := func(e gogl.Edge, u, v gogl.Vertex) {
+		i, j := vi.IndexOf(u), vi.IndexOf(v)
+		if w := weightOf(e); w < d[i*n+j] {
+			d[i*n+j] = w
+			hop[i*n+j] = j
+		}
+	}
+	if dg, ok := g.(gogl.Digraph); ok {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			relax(a, a.Source(), a.Target())
+			return
+		})
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			relax(e, u, v)
+			relax(e, v, u)
+			return
+		})
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			dik := d[i*n+k]
+			if math.IsInf(dik, 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if nd := dik + d[k*n+j]; nd < d[i*n+j] {
+					d[i*n+j] = nd
+					hop[i*n+j] = hop[i*n+k]
+				}
+			}
+		}
+		// A vertex whose distance to itself has gone negative lies on a
+		// negative cycle.
+		for i := 0; i < n; i++ {
+			if d[i*n+i] < 0 {
+				return nil, nil, ErrNegativeCycle
+			}
+		}
+	}
+
+	dist = make(map[gogl.Vertex]map[gogl.Vertex]float64, n)
+	next = make(map[gogl.Vertex]map[gogl.Vertex]gogl.Vertex, n)
+	for i, u := range vi.List() {
+		dist[u] = make(map[gogl.Vertex]float64)
+		next[u] = make(map[gogl.Vertex]gogl.Vertex)
+		for j, v := range vi.List() {
+			if math.IsInf(d[i*n+j], 1) {
+				continue
+			}
+			dist[u][v] = d[i*n+j]
+			if i != j {
+				next[u][v] = vi.VertexAt(hop[i*n+j])
+			}
+		}
+	}
+
+	return dist, next, nil
+}
+
+// Calculates single-source shortest path distances with Dijkstra's algorithm,
+// as for ShortestPath, but to every vertex. The returned map contains an entry
+// for each vertex reachable from the source.
+func ShortestPaths(g gogl.WeightedGraph, from gogl.Vertex) (map[gogl.Vertex]float64, error) {
+	dist, _, err := dijkstraPaths(g, from, nil)
+	if err != nil {
+		return nil, err
+	}
+	return distancesByVertex(g, dist), nil
+}
+
+// Runs Dijkstra's algorithm from source, stopping once target is settled if it
+// is non-nil, and returns the distance and predecessor maps, both keyed by
+// gogl.VertexKey, after checking the source and weights are valid.
+func dijkstraPaths(g gogl.WeightedGraph, source, target gogl.Vertex) (map[interface{}]float64, map[interface{}]gogl.Vertex, error) {
+	if !g.HasVertex(source) {
+		return nil, nil, errors.New("Source vertex is not present in graph.")
+	}
+
+	if hasNegativeWeight(g) {
+		return nil, nil, ErrNegativeWeight
+	}
+
+	pred := make(map[interface{}]gogl.Vertex, sizeHint(g))
+	return dijkstraSearch(g, source, target, pred), pred, nil
+}
+
+// Indicates whether any edge of the graph has a negative weight.
+func hasNegativeWeight(g gogl.Graph) (negative bool) {
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		negative = weightOf(e) < 0
+		return negative
+	})
+	return
+}
+
+// Calculates shortest path distances from each of the given sources, running
+// Dijkstra's algorithm from each concurrently across the given number of
+// goroutines. This is the usual route to all-pairs distances on sparse graphs,
+// where running Dijkstra from every vertex beats Floyd-Warshall. If workers is
+// not positive, GOMAXPROCS workers are used.
+//
+// The result maps each source to its distance map, which, as with ZeroOneBFS,
+// contains an entry for each vertex reachable from that source. Sources that are
+// not present in the graph are omitted. Arc direction is respected for Digraphs.
+//
+// Edge weights must not be negative; use SPFA for graphs where they may be. The
+// graph is only read, but it is read from several goroutines at once, so it must
+// not be modified until this returns.
+func MultiSourceShortestPaths(g gogl.WeightedGraph, sources []gogl.Vertex, workers int) map[gogl.Vertex]map[gogl.Vertex]float64 {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var todo []gogl.Vertex
+	seen := make(map[interface{}]bool, len(sources)) // keyed by gogl.VertexKey
+	for _, s := range sources {
+		if k := gogl.VertexKey(s); !seen[k] && g.HasVertex(s) {
+			seen[k] = true
+			todo = append(todo, s)
+		}
+	}
+
+	result := make(map[gogl.Vertex]map[gogl.Vertex]float64, len(todo))
+	for i, dist := range dijkstraEach(g, todo, workers) {
+		result[todo[i]] = distancesByVertex(g, dist)
+	}
+	return result
+}
+
+// Runs Dijkstra's algorithm from each of the given sources, which must be present
+// in the graph, across the given number of goroutines, or GOMAXPROCS if it is not
+// positive. The distance maps, keyed by gogl.VertexKey, are returned in the order
+// of the sources.
+func dijkstraEach(g gogl.Graph, sources []gogl.Vertex, workers int) []map[interface{}]float64 {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(sources) {
+		workers = len(sources)
+	}
+
+	dists := make([]map[interface{}]float64, len(sources))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				dists[i] = dijkstra(g, sources[i])
+			}
+		}()
+	}
+	for i := range sources {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return dists
+}
+
+// Re-keys a distance map keyed by gogl.VertexKey by the vertices of g themselves.
+func distancesByVertex(g gogl.VertexEnumerator, dist map[interface{}]float64) map[gogl.Vertex]float64 {
+	result := make(map[gogl.Vertex]float64, len(dist))
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		if d, reached := dist[gogl.VertexKey(v)]; reached {
+			result[v] = d
+		}
+		return
+	})
+	return result
+}
+
+// Calculates single-source shortest path distances with Dijkstra's algorithm,
+// summing weights, keyed by gogl.VertexKey. The source must be present in the
+// graph.
+func dijkstra(g gogl.Graph, source gogl.Vertex) map[interface{}]float64 {
+	return dijkstraSearch(g, source, nil, nil)
+}
+
+// Runs Dijkstra's algorithm from source, summing weights, and returns the distance
+// to each vertex reached, keyed by gogl.VertexKey. If pred is not nil, each
+// vertex's predecessor on its shortest path is recorded in it, under the same
+// key. If target is not nil, the search stops once target is settled, so only the
+// distances to target and the vertices settled before it are final.
+func dijkstraSearch(g gogl.Graph, source, target gogl.Vertex, pred map[interface{}]gogl.Vertex) map[interface{}]float64 {
+	hint := sizeHint(g)
+	dist := make(map[interface{}]float64, hint)
+	done := make(map[interface{}]bool, hint)
+	dist[gogl.VertexKey(source)] = 0
+
+	pq := &distQueue{{source, 0}}
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(distItem)
+		v, vk := item.v, gogl.VertexKey(item.v)
+		if done[vk] {
+			continue
+		}
+		done[vk] = true
+		if target != nil && vk == gogl.VertexKey(target) {
+			break
+		}
+
+		eachOutEdge(g, v, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+			wk := gogl.VertexKey(w)
+			if done[wk] {
+				return
+			}
+			nd := item.d + weightOf(e)
+			if dw, seen := dist[wk]; !seen || nd < dw {
+				dist[wk] = nd
+				if pred != nil {
+					pred[wk] = v
+				}
+				heap.Push(pq, distItem{w, nd})
+			}
+			return
+		})
+	}
+
+	return dist
+}
@@ -0,0 +1,484 @@
+package algo
+
+import (
+	"math"
+	stdrand "math/rand"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+// A small grid-ish digraph where some moves are free (weight 0) and some cost 1.
+var zeroOneArcs = gogl.WeightedArcList{
+	gogl.NewWeightedArc("a", "b", 1),
+	gogl.NewWeightedArc("a", "c", 0),
+	gogl.NewWeightedArc("c", "b", 0),
+	gogl.NewWeightedArc("b", "d", 1),
+	gogl.NewWeightedArc("c", "d", 1),
+	gogl.NewWeightedArc("d", "e", 0),
+	gogl.NewWeightedArc("e", "f", 1),
+	gogl.NewWeightedArc("b", "f", 1),
+	gogl.NewWeightedArc("g", "a", 0),
+}
+
+type ZeroOneBFSSuite struct{}
+
+var _ = Suite(&ZeroOneBFSSuite{})
+
+func (s *ZeroOneBFSSuite) TestDirected(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(zeroOneArcs).Create(al.G).(gogl.WeightedGraph)
+
+	dist, err := ZeroOneBFS(g, "a")
+	c.Assert(err, IsNil)
+	c.Assert(dist, DeepEquals, map[gogl.Vertex]float64{
+		"a": 0,
+		"b": 0,
+		"c": 0,
+		"d": 1,
+		"e": 1,
+		"f": 1,
+	})
+}
+
+func (s *ZeroOneBFSSuite) TestUndirected(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 1),
+		gogl.NewWeightedEdge(2, 3, 1),
+		gogl.NewWeightedEdge(3, 4, 1),
+		gogl.NewWeightedEdge(1, 5, 0),
+		gogl.NewWeightedEdge(5, 4, 1),
+		gogl.NewWeightedEdge(6, 7, 0),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	dist, err := ZeroOneBFS(g, 3)
+	c.Assert(err, IsNil)
+	c.Assert(dist, DeepEquals, map[gogl.Vertex]float64{
+		1: 2,
+		2: 1,
+		3: 0,
+		4: 1,
+		5: 2,
+	})
+}
+
+func (s *ZeroOneBFSSuite) TestErrors(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "b", 1),
+		gogl.NewWeightedArc("b", "c", 2),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	_, err := ZeroOneBFS(g, "a")
+	c.Assert(err, ErrorMatches, "Edge \\(b, c\\) has weight 2; only 0 and 1 are permitted.")
+
+	_, err = ZeroOneBFS(g, "quark")
+	c.Assert(err, ErrorMatches, "Source vertex is not present in graph.")
+}
+
+// A digraph with mixed-sign weights, but no negative cycles.
+var mixedSignArcs = gogl.WeightedArcList{
+	gogl.NewWeightedArc("s", "a", 4),
+	gogl.NewWeightedArc("s", "b", 5),
+	gogl.NewWeightedArc("a", "c", -3),
+	gogl.NewWeightedArc("b", "a", -2),
+	gogl.NewWeightedArc("c", "d", 2),
+	gogl.NewWeightedArc("b", "d", 6),
+	gogl.NewWeightedArc("d", "e", -1),
+	gogl.NewWeightedArc("x", "s", -10),
+}
+
+type SPFASuite struct{}
+
+var _ = Suite(&SPFASuite{})
+
+func (s *SPFASuite) TestMixedSigns(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(mixedSignArcs).Create(al.G).(gogl.WeightedGraph)
+
+	dist, pred, err := SPFA(g, "s")
+	c.Assert(err, IsNil)
+	c.Assert(dist, DeepEquals, map[gogl.Vertex]float64{
+		"s": 0,
+		"a": 3,
+		"b": 5,
+		"c": 0,
+		"d": 2,
+		"e": 1,
+	})
+	c.Assert(pred, DeepEquals, map[gogl.Vertex]gogl.Vertex{
+		"a": "b",
+		"b": "s",
+		"c": "a",
+		"d": "c",
+		"e": "d",
+	})
+}
+
+func (s *SPFASuite) TestNegativeCycle(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(mixedSignArcs).Create(al.G)
+	m := g.(gogl.WeightedArcSetMutator)
+
+	m.AddArcs(gogl.NewWeightedArc("e", "b", -3))
+	_, _, err := SPFA(g.(gogl.WeightedGraph), "s")
+	c.Assert(err, Equals, ErrNegativeCycle)
+
+	// A negative cycle that is unreachable from the source has no bearing on the result.
+	m.RemoveArcs(gogl.NewWeightedArc("e", "b", -3))
+	m.AddArcs(gogl.NewWeightedArc("p", "q", -1), gogl.NewWeightedArc("q", "p", -1))
+	_, _, err = SPFA(g.(gogl.WeightedGraph), "s")
+	c.Assert(err, IsNil)
+	_, _, err = SPFA(g.(gogl.WeightedGraph), "p")
+	c.Assert(err, Equals, ErrNegativeCycle)
+}
+
+func (s *SPFASuite) TestUndirected(c *C) {
+	el := gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 2),
+		gogl.NewWeightedEdge(2, 3, 2),
+		gogl.NewWeightedEdge(1, 3, 5),
+	}
+	g := gogl.Spec().Weighted().Using(el).Create(al.G).(gogl.MutableWeightedGraph)
+
+	dist, _, err := SPFA(g, 1)
+	c.Assert(err, IsNil)
+	c.Assert(dist, DeepEquals, map[gogl.Vertex]float64{1: 0, 2: 2, 3: 4})
+
+	// Any negative undirected edge can be walked back and forth forever.
+	g.AddEdges(gogl.NewWeightedEdge(3, 4, -1))
+	_, _, err = SPFA(g, 1)
+	c.Assert(err, Equals, ErrNegativeCycle)
+}
+
+type BellmanFordSuite struct{}
+
+var _ = Suite(&BellmanFordSuite{})
+
+func (s *BellmanFordSuite) TestMatchesSPFA(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(mixedSignArcs).Create(al.G).(gogl.WeightedGraph)
+	wantDist, wantPred, _ := SPFA(g, "s")
+
+	dist, pred, err := BellmanFord(g, "s")
+	c.Assert(err, IsNil)
+	c.Assert(dist, DeepEquals, wantDist)
+	c.Assert(pred, DeepEquals, wantPred)
+
+	for seed := int64(1); seed <= 4; seed++ {
+		g := weightedBernoulli(40, 0.1, seed%2 == 0, seed)
+		for _, v := range gogl.CollectVertices(g)[:5] {
+			want, _, _ := SPFA(g, v)
+			dist, _, err := BellmanFord(g, v)
+			c.Assert(err, IsNil)
+			c.Assert(dist, DeepEquals, want, Commentf("seed %d, source %v", seed, v))
+		}
+	}
+}
+
+func (s *BellmanFordSuite) TestNegativeCycle(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(mixedSignArcs).Create(al.G)
+	m := g.(gogl.WeightedArcSetMutator)
+
+	m.AddArcs(gogl.NewWeightedArc("e", "b", -3))
+	_, _, err := BellmanFord(g.(gogl.WeightedGraph), "s")
+	c.Assert(err, Equals, ErrNegativeCycle)
+
+	// Only cycles reachable from the source matter.
+	m.RemoveArcs(gogl.NewWeightedArc("e", "b", -3))
+	m.AddArcs(gogl.NewWeightedArc("p", "q", -1), gogl.NewWeightedArc("q", "p", -1))
+	_, _, err = BellmanFord(g.(gogl.WeightedGraph), "s")
+	c.Assert(err, IsNil)
+	_, _, err = BellmanFord(g.(gogl.WeightedGraph), "p")
+	c.Assert(err, Equals, ErrNegativeCycle)
+
+	_, _, err = BellmanFord(g.(gogl.WeightedGraph), "nope")
+	c.Assert(err, ErrorMatches, "Source vertex is not present in graph.")
+}
+
+func (s *BellmanFordSuite) TestUndirected(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 2),
+		gogl.NewWeightedEdge(2, 3, 2),
+		gogl.NewWeightedEdge(1, 3, 5),
+	}).Create(al.G).(gogl.MutableWeightedGraph)
+
+	dist, pred, err := BellmanFord(g, 3)
+	c.Assert(err, IsNil)
+	c.Assert(dist, DeepEquals, map[gogl.Vertex]float64{1: 4, 2: 2, 3: 0})
+	c.Assert(pred, DeepEquals, map[gogl.Vertex]gogl.Vertex{1: 2, 2: 3})
+
+	g.AddEdges(gogl.NewWeightedEdge(3, 4, -1))
+	_, _, err = BellmanFord(g, 1)
+	c.Assert(err, Equals, ErrNegativeCycle)
+}
+
+// A Bernoulli graph with integral weights in [0, 10), so that distances summed in
+// any order come out exactly equal.
+func weightedBernoulli(n uint, p float64, directed bool, seed int64) gogl.WeightedGraph {
+	r := stdrand.New(stdrand.NewSource(seed))
+	src := rand.BernoulliDistribution(n, p, directed, true, stdrand.NewSource(seed))
+
+	if directed {
+		g := gogl.Spec().Directed().Weighted().Create(al.G)
+		src.(gogl.DigraphSource).Arcs(func(a gogl.Arc) (terminate bool) {
+			g.(gogl.WeightedArcSetMutator).AddArcs(gogl.NewWeightedArc(a.Source(), a.Target(), float64(r.Intn(10))))
+			return
+		})
+		return g.(gogl.WeightedGraph)
+	}
+
+	g := gogl.Spec().Weighted().Create(al.G).(gogl.MutableWeightedGraph)
+	src.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		g.AddEdges(gogl.NewWeightedEdge(u, v, float64(r.Intn(10))))
+		return
+	})
+	return g
+}
+
+type DijkstraSuite struct{}
+
+var _ = Suite(&DijkstraSuite{})
+
+func (s *DijkstraSuite) TestZeroOne(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(zeroOneArcs).Create(al.G).(gogl.WeightedGraph)
+
+	// Free moves lead from g through a and c to b; only the last step costs.
+	path, cost, err := ShortestPath(g, "g", "f")
+	c.Assert(err, IsNil)
+	c.Assert(cost, Equals, float64(1))
+	c.Assert(path, DeepEquals, []gogl.Vertex{"g", "a", "c", "b", "f"})
+
+	// Dijkstra's algorithm agrees with the specialized search.
+	want, err := ZeroOneBFS(g, "g")
+	c.Assert(err, IsNil)
+	dist, err := ShortestPaths(g, "g")
+	c.Assert(err, IsNil)
+	c.Assert(dist, DeepEquals, want)
+
+	// Arcs are only followed forward.
+	_, _, err = ShortestPath(g, "f", "g")
+	c.Assert(err, Equals, ErrNoPath)
+
+	path, cost, err = ShortestPath(g, "a", "a")
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []gogl.Vertex{"a"})
+	c.Assert(cost, Equals, float64(0))
+}
+
+func (s *DijkstraSuite) TestRandom(c *C) {
+	for seed := int64(1); seed <= 4; seed++ {
+		g := weightedBernoulli(40, 0.1, seed%2 == 0, seed)
+		vertices := gogl.CollectVertices(g)
+
+		for _, from := range vertices[:5] {
+			want, _, err := SPFA(g, from)
+			c.Assert(err, IsNil)
+			dist, err := ShortestPaths(g, from)
+			c.Assert(err, IsNil)
+			c.Assert(dist, DeepEquals, want, Commentf("seed %d, source %v", seed, from))
+
+			for _, to := range vertices {
+				path, cost, err := ShortestPath(g, from, to)
+				if _, reachable := want[to]; !reachable {
+					c.Assert(err, Equals, ErrNoPath)
+					continue
+				}
+				c.Assert(err, IsNil)
+				c.Assert(cost, Equals, want[to], Commentf("seed %d, %v to %v", seed, from, to))
+
+				// The path's own weight is its cost.
+				var total float64
+				for i := 1; i < len(path); i++ {
+					least := -1.0
+					eachOutEdge(g, path[i-1], func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+						if w == path[i] && (least < 0 || weightOf(e) < least) {
+							least = weightOf(e)
+						}
+						return
+					})
+					c.Assert(least >= 0, Equals, true, Commentf("no edge from %v to %v", path[i-1], path[i]))
+					total += least
+				}
+				c.Assert(total, Equals, cost)
+			}
+		}
+	}
+}
+
+func (s *DijkstraSuite) TestErrors(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(mixedSignArcs).Create(al.G).(gogl.WeightedGraph)
+
+	_, _, err := ShortestPath(g, "s", "e")
+	c.Assert(err, Equals, ErrNegativeWeight)
+	_, err = ShortestPaths(g, "s")
+	c.Assert(err, Equals, ErrNegativeWeight)
+
+	_, _, err = ShortestPath(g, "s", "nope")
+	c.Assert(err, ErrorMatches, "Target vertex is not present in graph.")
+	_, err = ShortestPaths(g, "nope")
+	c.Assert(err, ErrorMatches, "Source vertex is not present in graph.")
+}
+
+type MultiSourceSuite struct{}
+
+var _ = Suite(&MultiSourceSuite{})
+
+func (s *MultiSourceSuite) TestMatchesSequential(c *C) {
+	for i, g := range []gogl.WeightedGraph{
+		weightedBernoulli(60, 0.08, false, 1),
+		weightedBernoulli(60, 0.05, true, 2),
+	} {
+		sources := gogl.CollectVertices(g)
+		want := make(map[gogl.Vertex]map[gogl.Vertex]float64)
+		for _, v := range sources {
+			want[v] = distancesByVertex(g, dijkstra(g, v))
+
+			// Cross-check against an independent algorithm.
+			dist, _, err := SPFA(g, v)
+			c.Assert(err, IsNil)
+			c.Assert(want[v], DeepEquals, dist, Commentf("graph %d, source %v", i, v))
+		}
+
+		for _, workers := range []int{0, 1, 3, 1000} {
+			got := MultiSourceShortestPaths(g, sources, workers)
+			c.Assert(got, DeepEquals, want, Commentf("graph %d, %d workers", i, workers))
+		}
+	}
+}
+
+func (s *MultiSourceSuite) TestSources(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(zeroOneArcs).Create(al.G).(gogl.WeightedGraph)
+
+	// Duplicates are computed once, and missing sources are left out.
+	got := MultiSourceShortestPaths(g, []gogl.Vertex{"a", "missing", "e", "a"}, 2)
+	c.Assert(got, HasLen, 2)
+	c.Assert(got["a"], DeepEquals, map[gogl.Vertex]float64{"a": 0, "b": 0, "c": 0, "d": 1, "e": 1, "f": 1})
+	c.Assert(got["e"], DeepEquals, map[gogl.Vertex]float64{"e": 0, "f": 1})
+
+	c.Assert(MultiSourceShortestPaths(g, nil, 4), HasLen, 0)
+}
+
+type FloydWarshallSuite struct{}
+
+var _ = Suite(&FloydWarshallSuite{})
+
+// Follows the next map from u to v, checking that each step is an edge of g and
+// that the weights along the way sum to the given distance.
+func checkNextPath(c *C, g gogl.WeightedGraph, next map[gogl.Vertex]map[gogl.Vertex]gogl.Vertex, u, v gogl.Vertex, d float64) {
+	var sum float64
+	for at := u; at != v; {
+		hop, exists := next[at][v]
+		c.Assert(exists, Equals, true, Commentf("no next hop from %v toward %v", at, v))
+
+		best := math.Inf(1)
+		eachOutEdge(g, at, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+			if w == hop && weightOf(e) < best {
+				best = weightOf(e)
+			}
+			return
+		})
+		c.Assert(math.IsInf(best, 1), Equals, false, Commentf("no edge from %v to %v", at, hop))
+		sum += best
+		at = hop
+	}
+	c.Assert(sum, Equals, d, Commentf("path from %v to %v", u, v))
+}
+
+func (s *FloydWarshallSuite) TestMatchesSPFA(c *C) {
+	mixed := gogl.Spec().Directed().Weighted().Using(mixedSignArcs).Create(al.G).(gogl.WeightedGraph)
+	for i, g := range []gogl.WeightedGraph{
+		mixed,
+		weightedBernoulli(40, 0.1, false, 1),
+		weightedBernoulli(40, 0.08, true, 2),
+	} {
+		dist, next, err := AllPairsShortestPaths(g)
+		c.Assert(err, IsNil)
+		c.Assert(dist, HasLen, gogl.Order(g))
+
+		g.Vertices(func(u gogl.Vertex) (terminate bool) {
+			want, _, err := SPFA(g, u)
+			c.Assert(err, IsNil)
+			c.Assert(dist[u], DeepEquals, want, Commentf("graph %d, source %v", i, u))
+
+			for v, d := range dist[u] {
+				checkNextPath(c, g, next, u, v, d)
+			}
+			_, self := next[u][u]
+			c.Assert(self, Equals, false)
+			return
+		})
+	}
+}
+
+func (s *FloydWarshallSuite) TestNegativeCycle(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(mixedSignArcs).Create(al.G)
+	m := g.(gogl.WeightedArcSetMutator)
+
+	// Unlike SPFA, any negative cycle counts, reachable or not.
+	m.AddArcs(gogl.NewWeightedArc("p", "q", -1), gogl.NewWeightedArc("q", "p", -1))
+	dist, next, err := AllPairsShortestPaths(g.(gogl.WeightedGraph))
+	c.Assert(err, Equals, ErrNegativeCycle)
+	c.Assert(dist, IsNil)
+	c.Assert(next, IsNil)
+
+	loop := gogl.Spec().Directed().Weighted().Loop().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "b", 1),
+		gogl.NewWeightedArc("b", "b", -1),
+	}).Create(al.G).(gogl.WeightedGraph)
+	_, _, err = AllPairsShortestPaths(loop)
+	c.Assert(err, Equals, ErrNegativeCycle)
+
+	// A negative undirected edge can be walked back and forth.
+	ug := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 2),
+		gogl.NewWeightedEdge(2, 3, -1),
+	}).Create(al.G).(gogl.WeightedGraph)
+	_, _, err = AllPairsShortestPaths(ug)
+	c.Assert(err, Equals, ErrNegativeCycle)
+}
+
+func (s *FloydWarshallSuite) TestUnreachable(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "b", 2),
+		gogl.NewWeightedArc("b", "c", 3),
+		gogl.NewWeightedArc("a", "c", 6),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	dist, next, err := AllPairsShortestPaths(g)
+	c.Assert(err, IsNil)
+	c.Assert(dist, DeepEquals, map[gogl.Vertex]map[gogl.Vertex]float64{
+		"a": {"a": 0, "b": 2, "c": 5},
+		"b": {"b": 0, "c": 3},
+		"c": {"c": 0},
+	})
+	c.Assert(next, DeepEquals, map[gogl.Vertex]map[gogl.Vertex]gogl.Vertex{
+		"a": {"b": "b", "c": "b"},
+		"b": {"c": "c"},
+		"c": {},
+	})
+
+	dist, next, err = AllPairsShortestPaths(gogl.Spec().Weighted().Create(al.G).(gogl.WeightedGraph))
+	c.Assert(err, IsNil)
+	c.Assert(dist, HasLen, 0)
+	c.Assert(next, HasLen, 0)
+}
+
+func benchmarkMultiSource(b *testing.B, workers int) {
+	g := weightedBernoulli(1000, 0.01, false, 1)
+	sources := gogl.CollectVertices(g)[:200]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MultiSourceShortestPaths(g, sources, workers)
+	}
+}
+
+func BenchmarkMultiSourceShortestPathsSequential(b *testing.B) {
+	benchmarkMultiSource(b, 1)
+}
+
+func BenchmarkMultiSourceShortestPathsParallel(b *testing.B) {
+	benchmarkMultiSource(b, 0)
+}
@@ -0,0 +1,129 @@
+package algo
+
+import (
+	"math"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Calculates the Jaccard similarity of two vertices: the size of the
+// intersection of their neighbor sets, divided by the size of the union.
+//
+// Vertices with identical neighborhoods score 1.0; vertices with disjoint
+// neighborhoods (or with no neighbors at all) score 0. For Digraphs, all
+// adjacent vertices are considered; see JaccardSimilarityMode to restrict
+// this to in- or out-neighbors.
+func JaccardSimilarity(g gogl.Graph, u, v gogl.Vertex) float64 {
+	return JaccardSimilarityMode(g, u, v, AllNeighbors)
+}
+
+// Calculates the Jaccard similarity of two vertices, considering only the
+// neighbors selected by the given mode.
+func JaccardSimilarityMode(g gogl.Graph, u, v gogl.Vertex, mode NeighborMode) float64 {
+	return jaccard(neighborSet(g, u, mode), neighborSet(g, v, mode))
+}
+
+// Calculates the Jaccard similarity between all pairs of distinct vertices that
+// share at least one neighbor, as selected by the given mode.
+//
+// Pairs that share no neighbors have a similarity of 0, and are omitted. Each
+// qualifying pair appears twice in the result - once in each direction - so that
+// result[u][v] can be looked up without regard to order.
+func AllPairsJaccard(g gogl.Graph, mode NeighborMode) map[gogl.Vertex]map[gogl.Vertex]float64 {
+	neighbors := make(map[gogl.Vertex]map[interface{}]struct{}, sizeHint(g))
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		neighbors[v] = neighborSet(g, v, mode)
+		return
+	})
+
+	// Invert the neighbor relation: vertices sharing a neighbor w are exactly those
+	// listing w in their neighbor sets. This keeps the work proportional to the
+	// number of two-hop pairs, rather than V^2.
+	sharers := make(map[interface{}][]gogl.Vertex, len(neighbors))
+	for v, ns := range neighbors {
+		for w := range ns {
+			sharers[w] = append(sharers[w], v)
+		}
+	}
+
+	result := make(map[gogl.Vertex]map[gogl.Vertex]float64)
+	for _, vs := range sharers {
+		for i, u := range vs {
+			for _, v := range vs[i+1:] {
+				if _, done := result[u][v]; done {
+					continue
+				}
+
+				sim := jaccard(neighbors[u], neighbors[v])
+				if result[u] == nil {
+					result[u] = make(map[gogl.Vertex]float64)
+				}
+				if result[v] == nil {
+					result[v] = make(map[gogl.Vertex]float64)
+				}
+				result[u][v], result[v][u] = sim, sim
+			}
+		}
+	}
+
+	return result
+}
+
+// Counts the vertices adjacent to both of the given vertices.
+//
+// This is the simplest link prediction score: the more neighbors two vertices
+// have in common, the more likely they are to be (or become) linked themselves.
+func CommonNeighbors(g gogl.Graph, u, v gogl.Vertex) int {
+	var count int
+	eachCommonNeighbor(g, u, v, func(gogl.Vertex) {
+		count++
+	})
+	return count
+}
+
+// Calculates the Adamic-Adar link prediction score for the given vertices: the sum,
+// over all their common neighbors, of 1/log(degree).
+//
+// This refines CommonNeighbors by weighting rarer connections more heavily - a
+// common neighbor with few links of its own says more about u and v than a hub that
+// is adjacent to everything. A common neighbor with degree 1 (possible only when u
+// and v are the same vertex) would divide by log(1) = 0; such neighbors contribute
+// nothing to the score.
+func AdamicAdar(g gogl.Graph, u, v gogl.Vertex) float64 {
+	var score float64
+	eachCommonNeighbor(g, u, v, func(w gogl.Vertex) {
+		if deg, _ := g.DegreeOf(w); deg > 1 {
+			score += 1 / math.Log(float64(deg))
+		}
+	})
+	return score
+}
+
+// Calls the provided function once with each vertex adjacent to both u and v.
+func eachCommonNeighbor(g gogl.Graph, u, v gogl.Vertex, f func(gogl.Vertex)) {
+	un := neighborSet(g, u, AllNeighbors)
+	g.AdjacentTo(v, func(w gogl.Vertex) (terminate bool) {
+		k := gogl.VertexKey(w)
+		if _, exists := un[k]; exists {
+			// Guard against graphs that report the same neighbor more than once.
+			delete(un, k)
+			f(w)
+		}
+		return
+	})
+}
+
+func jaccard(a, b map[interface{}]struct{}) float64 {
+	var inter int
+	for v := range a {
+		if _, exists := b[v]; exists {
+			inter++
+		}
+	}
+
+	union := len(a) + len(b) - inter
+	if union == 0 {
+		return 0
+	}
+	return float64(inter) / float64(union)
+}
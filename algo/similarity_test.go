@@ -0,0 +1,108 @@
+package algo
+
+import (
+	"math"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type SimilaritySuite struct{}
+
+var _ = Suite(&SimilaritySuite{})
+
+// a and b share all of their neighbors; c shares one of them; d shares none.
+var similarityEdges = gogl.EdgeList{
+	gogl.NewEdge("a", "x"),
+	gogl.NewEdge("a", "y"),
+	gogl.NewEdge("b", "x"),
+	gogl.NewEdge("b", "y"),
+	gogl.NewEdge("c", "x"),
+	gogl.NewEdge("c", "z"),
+	gogl.NewEdge("d", "w"),
+}
+
+func (s *SimilaritySuite) TestJaccard(c *C) {
+	g := gogl.Spec().Using(similarityEdges).Create(al.G)
+
+	c.Assert(JaccardSimilarity(g, "a", "b"), Equals, 1.0)
+	c.Assert(JaccardSimilarity(g, "a", "c"), Equals, 1.0/3.0)
+	c.Assert(JaccardSimilarity(g, "a", "d"), Equals, 0.0)
+	c.Assert(JaccardSimilarity(g, "x", "y"), Equals, 2.0/3.0)
+	c.Assert(JaccardSimilarity(g, "a", "missing"), Equals, 0.0)
+}
+
+func (s *SimilaritySuite) TestJaccardDirected(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "x"),
+		gogl.NewArc("b", "x"),
+		gogl.NewArc("y", "a"),
+		gogl.NewArc("z", "b"),
+	}).Create(al.G)
+
+	c.Assert(JaccardSimilarityMode(g, "a", "b", OutNeighbors), Equals, 1.0)
+	c.Assert(JaccardSimilarityMode(g, "a", "b", InNeighbors), Equals, 0.0)
+	c.Assert(JaccardSimilarityMode(g, "a", "b", AllNeighbors), Equals, 1.0/3.0)
+	c.Assert(JaccardSimilarity(g, "a", "b"), Equals, 1.0/3.0)
+}
+
+func (s *SimilaritySuite) TestAllPairsJaccard(c *C) {
+	g := gogl.Spec().Using(similarityEdges).Create(al.G)
+
+	all := AllPairsJaccard(g, AllNeighbors)
+	c.Assert(all["a"]["b"], Equals, 1.0)
+	c.Assert(all["b"]["a"], Equals, 1.0)
+	c.Assert(all["a"]["c"], Equals, 1.0/3.0)
+	c.Assert(all["x"]["y"], Equals, 2.0/3.0)
+
+	// Pairs without common neighbors are omitted entirely.
+	_, exists := all["a"]["d"]
+	c.Assert(exists, Equals, false)
+	_, exists = all["a"]["a"]
+	c.Assert(exists, Equals, false)
+
+	// Every reported score must agree with the single-pair calculation.
+	for u, row := range all {
+		for v, sim := range row {
+			c.Assert(sim, Equals, JaccardSimilarity(g, u, v))
+		}
+	}
+}
+
+func (s *SimilaritySuite) TestCommonNeighbors(c *C) {
+	g := gogl.Spec().Using(similarityEdges).Create(al.G)
+
+	c.Assert(CommonNeighbors(g, "a", "b"), Equals, 2)
+	c.Assert(CommonNeighbors(g, "a", "c"), Equals, 1)
+	c.Assert(CommonNeighbors(g, "a", "d"), Equals, 0)
+	c.Assert(CommonNeighbors(g, "a", "missing"), Equals, 0)
+}
+
+func (s *SimilaritySuite) TestAdamicAdar(c *C) {
+	// u and v share a hub (degree 5) and a low-degree connector (degree 2).
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("u", "hub"),
+		gogl.NewEdge("v", "hub"),
+		gogl.NewEdge("hub", 1),
+		gogl.NewEdge("hub", 2),
+		gogl.NewEdge("hub", 3),
+		gogl.NewEdge("u", "link"),
+		gogl.NewEdge("v", "link"),
+		gogl.NewEdge("w", "leaf"),
+	}).Create(al.G)
+
+	c.Assert(AdamicAdar(g, "u", "v"), Equals, 1/math.Log(5)+1/math.Log(2))
+
+	// The rarely-connected common neighbor contributes more than the hub.
+	g.(gogl.MutableGraph).RemoveEdges(gogl.NewEdge("u", "link"))
+	hubOnly := AdamicAdar(g, "u", "v")
+	g.(gogl.MutableGraph).AddEdges(gogl.NewEdge("u", "link"))
+	g.(gogl.MutableGraph).RemoveEdges(gogl.NewEdge("u", "hub"))
+	linkOnly := AdamicAdar(g, "u", "v")
+	c.Assert(linkOnly > hubOnly, Equals, true)
+
+	// A degree-1 common neighbor (only possible for u == v) must not produce +Inf.
+	c.Assert(AdamicAdar(g, "w", "w"), Equals, 0.0)
+	c.Assert(AdamicAdar(g, "u", "w"), Equals, 0.0)
+}
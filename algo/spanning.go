@@ -0,0 +1,271 @@
+package algo
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Attempts to find a spanning tree of g in which no vertex has degree greater than
+// maxDegree, returning it as a mutable, undirected adjacency list.
+//
+// Finding such a tree is NP-hard in general (for maxDegree 2, it is the Hamiltonian
+// path problem), so a local search heuristic is used: starting from a depth-first
+// spanning tree, whose vertices tend to have low degree, each tree edge at an
+// overloaded vertex is a candidate for replacement by a non-tree edge reconnecting
+// the tree between two vertices with degree to spare. The search stops when no
+// vertex is overloaded, or no replacement helps.
+//
+// Edge direction and self-loops are ignored. An error is returned if g is not
+// connected, or if no tree satisfying the bound is found - which can mean that
+// none exists (a star's center can go nowhere but the middle of the tree), or
+// that the heuristic was unable to find one.
+func BoundedDegreeSpanningTree(g gogl.Graph, maxDegree int) (gogl.Graph, error) {
+	adj := undirectedAdjacency(g)
+	tree := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	if adj.Len() == 0 {
+		return tree, nil
+	}
+	if adj.Len() > 1 && maxDegree < 1 {
+		return nil, fmt.Errorf("No spanning tree exists with maximum degree %d.", maxDegree)
+	}
+
+	t, reached := depthFirstTree(adj)
+	if reached < adj.Len() {
+		return nil, errors.New("Graph is not connected, so has no spanning tree.")
+	}
+
+	for {
+		var overloaded []int
+		for v, ns := range t {
+			if len(ns) > maxDegree {
+				overloaded = append(overloaded, v)
+			}
+		}
+		if len(overloaded) == 0 {
+			break
+		}
+
+		var improved bool
+		for _, w := range overloaded {
+			if len(t[w]) > maxDegree && relieve(adj, t, w, maxDegree) {
+				improved = true
+			}
+		}
+		if !improved {
+			return nil, fmt.Errorf("No spanning tree found with maximum degree %d.", maxDegree)
+		}
+	}
+
+	for v, ns := range t {
+		tree.EnsureVertex(adj.VertexAt(v))
+		for w := range ns {
+			tree.AddEdges(gogl.NewEdge(adj.VertexAt(v), adj.VertexAt(w)))
+		}
+	}
+
+	return tree, nil
+}
+
+// Builds a depth-first spanning tree of the component containing the first
+// vertex, as a symmetric adjacency set for each vertex id; those of unreached
+// vertices are nil. The number of vertices reached is returned as well.
+func depthFirstTree(adj adjacency) ([]map[int]struct{}, int) {
+	t := make([]map[int]struct{}, adj.Len())
+	t[0] = make(map[int]struct{})
+	reached := 1
+	stack := []int{0}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+
+		next := -1
+		for w := range adj.nbrs[v] {
+			if t[w] == nil {
+				next = w
+				break
+			}
+		}
+
+		if next < 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		t[next] = map[int]struct{}{v: {}}
+		t[v][next] = struct{}{}
+		reached++
+		stack = append(stack, next)
+	}
+
+	return t, reached
+}
+
+// Tries to lower the degree of w in the tree t by one, by swapping one of its tree
+// edges for a non-tree edge of the graph that reconnects the two halves without
+// pushing any vertex over maxDegree. Reports whether a swap was made.
+func relieve(adj adjacency, t []map[int]struct{}, w int, maxDegree int) bool {
+	for x := range t[w] {
+		// Collect the half of the tree that removing w-x would cut off.
+		half := map[int]struct{}{x: {}}
+		queue := []int{x}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for u := range t[v] {
+				if _, in := half[u]; !in && u != w {
+					half[u] = struct{}{}
+					queue = append(queue, u)
+				}
+			}
+		}
+
+		// Degrees as they would be with w-x removed.
+		room := func(v int) bool {
+			d := len(t[v])
+			if v == x {
+				d--
+			}
+			return v != w && d < maxDegree
+		}
+
+		for a := range half {
+			if !room(a) {
+				continue
+			}
+			for b := range adj.nbrs[a] {
+				if _, in := half[b]; in || !room(b) {
+					continue
+				}
+
+				delete(t[w], x)
+				delete(t[x], w)
+				t[a][b] = struct{}{}
+				t[b][a] = struct{}{}
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Finds a minimum spanning tree of the weighted, undirected graph g with
+// Kruskal's algorithm, returning the tree and its total weight.
+//
+// The edges given by Edges are sorted by weight and taken in turn, each joining
+// two trees of a growing forest, tracked by a disjoint-set forest; edges whose
+// endpoints already share a tree, self-loops among them, are skipped. This
+// takes O(E log E) time. Should g be disconnected, the result is a minimum
+// spanning forest, with a tree for each connected component; isolated vertices
+// are kept.
+//
+// The result is built through Spec(), as a weighted adjacency list. An error is
+// returned if g is a Digraph, for which minimum spanning trees are not defined.
+func MinimumSpanningTree(g gogl.WeightedGraph) (gogl.WeightedGraph, float64, error) {
+	if _, directed := g.(gogl.Digraph); directed {
+		return nil, 0, errors.New("Minimum spanning trees are not defined for digraphs.")
+	}
+
+	var edges []gogl.WeightedEdge
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		edges = append(edges, gogl.NewWeightedEdge(u, v, weightOf(e)))
+		return
+	})
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].Weight() < edges[j].Weight() })
+
+	vi := gogl.NewVertexIndex(g)
+	ds := newDisjointSet(vi.Len())
+	var tree gogl.WeightedEdgeList
+	var total float64
+	for _, e := range edges {
+		u, v := e.Both()
+		if ds.union(vi.IndexOf(u), vi.IndexOf(v)) {
+			tree = append(tree, e)
+			total += e.Weight()
+		}
+	}
+
+	return spanningForest(vi.List(), tree), total, nil
+}
+
+// Builds a weighted graph holding the given vertices and edges.
+func spanningForest(vertices []gogl.Vertex, edges gogl.WeightedEdgeList) gogl.WeightedGraph {
+	forest := gogl.Spec().Weighted().Using(edges).Create(al.G).(gogl.MutableWeightedGraph)
+	forest.EnsureVertex(vertices...)
+	return forest
+}
+
+// Finds a minimum spanning tree of the weighted, undirected graph g with Prim's
+// algorithm, returning the tree and its total weight, as MinimumSpanningTree.
+//
+// A single tree is grown from start, each step adding the vertex joined to it by
+// the lightest edge; a binary heap keyed on that weight holds the candidates,
+// found through IncidentTo. Kruskal's algorithm must sort every edge up front,
+// while Prim's settles each vertex as soon as it is reached, which tends to pay
+// off on dense graphs. Should g be disconnected, start's component is spanned
+// first, then a tree is grown in each remaining component, giving a minimum
+// spanning forest.
+//
+// An error is returned if g is a Digraph, or if start is not present in g.
+func MinimumSpanningTreePrim(g gogl.WeightedGraph, start gogl.Vertex) (gogl.WeightedGraph, float64, error) {
+	if _, directed := g.(gogl.Digraph); directed {
+		return nil, 0, errors.New("Minimum spanning trees are not defined for digraphs.")
+	}
+	if !g.HasVertex(start) {
+		return nil, 0, errors.New("Start vertex is not present in graph.")
+	}
+
+	hint := sizeHint(g)
+	// All keyed by gogl.VertexKey.
+	key := make(map[interface{}]float64, hint)
+	via := make(map[interface{}]gogl.Vertex, hint)
+	done := make(map[interface{}]bool, hint)
+	var tree gogl.WeightedEdgeList
+	var total float64
+
+	grow := func(root gogl.Vertex) {
+		pq := &distQueue{{root, 0}}
+		key[gogl.VertexKey(root)] = 0
+		for pq.Len() > 0 {
+			item := heap.Pop(pq).(distItem)
+			v, vk := item.v, gogl.VertexKey(item.v)
+			if done[vk] || item.d > key[vk] {
+				continue
+			}
+			done[vk] = true
+			if vk != gogl.VertexKey(root) {
+				tree = append(tree, gogl.NewWeightedEdge(via[vk], v, item.d))
+				total += item.d
+			}
+
+			eachOutEdge(g, v, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+				wk := gogl.VertexKey(w)
+				if done[wk] {
+					return
+				}
+				if k, seen := key[wk]; !seen || weightOf(e) < k {
+					key[wk], via[wk] = weightOf(e), v
+					heap.Push(pq, distItem{w, weightOf(e)})
+				}
+				return
+			})
+		}
+	}
+
+	grow(start)
+	var vertices []gogl.Vertex
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		vertices = append(vertices, v)
+		if !done[gogl.VertexKey(v)] {
+			grow(v)
+		}
+		return
+	})
+
+	return spanningForest(vertices, tree), total, nil
+}
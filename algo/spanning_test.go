@@ -0,0 +1,217 @@
+package algo
+
+import (
+	"math"
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type SpanningSuite struct{}
+
+var _ = Suite(&SpanningSuite{})
+
+// Checks that tree is a spanning tree of g whose degrees do not exceed maxDegree.
+func checkBoundedTree(c *C, g, tree gogl.Graph, maxDegree int) {
+	c.Assert(gogl.Order(tree), Equals, gogl.Order(g))
+
+	var root gogl.Vertex
+	tree.Vertices(func(v gogl.Vertex) (terminate bool) {
+		root = v
+		return true
+	})
+	_, _, _, err := rootTree(tree, root)
+	c.Assert(err, IsNil)
+
+	tree.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(g.HasEdge(e), Equals, true, Commentf("tree edge %v is not in the graph", e))
+		return
+	})
+	tree.Vertices(func(v gogl.Vertex) (terminate bool) {
+		d, _ := tree.DegreeOf(v)
+		c.Assert(d <= maxDegree, Equals, true, Commentf("vertex %v has degree %d", v, d))
+		return
+	})
+}
+
+func (s *SpanningSuite) TestHamiltonianPath(c *C) {
+	// The wheel's rim is a Hamiltonian path, so a bound of 2 is feasible despite the hub.
+	g := relabeledGraph([][2]int{
+		{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 1},
+		{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}, {0, 6},
+	}, identity)
+
+	tree, err := BoundedDegreeSpanningTree(g, 2)
+	c.Assert(err, IsNil)
+	checkBoundedTree(c, g, tree, 2)
+}
+
+func (s *SpanningSuite) TestRandomGraphs(c *C) {
+	for seed := int64(1); seed <= 5; seed++ {
+		g := gogl.Spec().Using(rand.BernoulliDistribution(60, 0.15, false, true, stdrand.NewSource(seed))).Create(al.G)
+
+		tree, err := BoundedDegreeSpanningTree(g, 3)
+		c.Assert(err, IsNil, Commentf("seed %d", seed))
+		checkBoundedTree(c, g, tree, 3)
+	}
+}
+
+func (s *SpanningSuite) TestStarInfeasible(c *C) {
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}}, identity)
+
+	_, err := BoundedDegreeSpanningTree(star, 2)
+	c.Assert(err, ErrorMatches, "No spanning tree found with maximum degree 2.")
+
+	tree, err := BoundedDegreeSpanningTree(star, 4)
+	c.Assert(err, IsNil)
+	checkBoundedTree(c, star, tree, 4)
+}
+
+func (s *SpanningSuite) TestDisconnected(c *C) {
+	g := relabeledGraph([][2]int{{0, 1}, {2, 3}}, identity)
+	_, err := BoundedDegreeSpanningTree(g, 2)
+	c.Assert(err, ErrorMatches, "Graph is not connected, so has no spanning tree.")
+}
+
+func (s *SpanningSuite) TestTrivial(c *C) {
+	tree, err := BoundedDegreeSpanningTree(gogl.NullGraph, 0)
+	c.Assert(err, IsNil)
+	c.Assert(gogl.Order(tree), Equals, 0)
+
+	g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex("solo")
+	tree, err = BoundedDegreeSpanningTree(g, 0)
+	c.Assert(err, IsNil)
+	c.Assert(tree.HasVertex("solo"), Equals, true)
+
+	g.AddEdges(gogl.NewEdge("solo", "duo"))
+	_, err = BoundedDegreeSpanningTree(g, 0)
+	c.Assert(err, ErrorMatches, "No spanning tree exists with maximum degree 0.")
+}
+
+// Checks that tree is a minimum spanning forest of g with the given weight: it
+// spans every component of g with a tree of g's edges, and no edge of g outside
+// it is lighter than the heaviest tree edge on the path between its endpoints.
+func checkMinimumForest(c *C, g, tree gogl.WeightedGraph, total float64) {
+	c.Assert(gogl.Order(tree), Equals, gogl.Order(g))
+	c.Assert(gogl.Size(tree), Equals, gogl.Order(g)-len(ConnectedComponents(g)))
+
+	var sum float64
+	tree.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		c.Assert(g.HasWeightedEdge(gogl.NewWeightedEdge(u, v, weightOf(e))), Equals, true, Commentf("tree edge %v is not in the graph", e))
+		sum += weightOf(e)
+		return
+	})
+	c.Assert(sum, Equals, total)
+
+	// The heaviest edge on the tree path from s to every vertex it reaches.
+	heaviest := func(s gogl.Vertex) map[gogl.Vertex]float64 {
+		most := map[gogl.Vertex]float64{s: 0}
+		queue := []gogl.Vertex{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			eachOutEdge(tree, v, func(e gogl.Edge, w gogl.Vertex) (terminate bool) {
+				if _, seen := most[w]; !seen {
+					most[w] = math.Max(most[v], weightOf(e))
+					queue = append(queue, w)
+				}
+				return
+			})
+		}
+		return most
+	}
+
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		m, connected := heaviest(u)[v]
+		c.Assert(connected, Equals, true, Commentf("%v and %v are not joined in the tree", u, v))
+		c.Assert(m <= weightOf(e), Equals, true, Commentf("edge %v is lighter than the tree path it would replace", e))
+		return
+	})
+}
+
+func (s *SpanningSuite) TestKruskal(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 4),
+		gogl.NewWeightedEdge("a", "c", 1),
+		gogl.NewWeightedEdge("b", "c", 2),
+		gogl.NewWeightedEdge("b", "d", 5),
+		gogl.NewWeightedEdge("c", "d", 8),
+		gogl.NewWeightedEdge("d", "e", 3),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	tree, total, err := MinimumSpanningTree(g)
+	c.Assert(err, IsNil)
+	c.Assert(total, Equals, float64(11))
+	c.Assert(tree.HasEdge(gogl.NewEdge("a", "b")), Equals, false)
+	c.Assert(tree.HasEdge(gogl.NewEdge("c", "d")), Equals, false)
+	checkMinimumForest(c, g, tree, total)
+
+	for seed := int64(1); seed <= 5; seed++ {
+		g := weightedBernoulli(40, 0.08, false, seed)
+		tree, total, err := MinimumSpanningTree(g)
+		c.Assert(err, IsNil)
+		checkMinimumForest(c, g, tree, total)
+	}
+}
+
+func (s *SpanningSuite) TestKruskalForest(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 1),
+		gogl.NewWeightedEdge(3, 4, 2),
+	}).Create(al.G).(gogl.MutableWeightedGraph)
+	g.EnsureVertex(5)
+
+	tree, total, err := MinimumSpanningTree(g)
+	c.Assert(err, IsNil)
+	c.Assert(total, Equals, float64(3))
+	c.Assert(tree.HasVertex(5), Equals, true)
+	checkMinimumForest(c, g, tree, total)
+
+	dg := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc(1, 2, 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+	_, _, err = MinimumSpanningTree(dg)
+	c.Assert(err, ErrorMatches, "Minimum spanning trees are not defined for digraphs.")
+}
+
+func (s *SpanningSuite) TestPrim(c *C) {
+	for seed := int64(1); seed <= 5; seed++ {
+		g := weightedBernoulli(40, 0.08, false, seed)
+		_, want, _ := MinimumSpanningTree(g)
+
+		for _, start := range gogl.CollectVertices(g)[:3] {
+			tree, total, err := MinimumSpanningTreePrim(g, start)
+			c.Assert(err, IsNil)
+			c.Assert(total, Equals, want, Commentf("seed %d, start %v", seed, start))
+			checkMinimumForest(c, g, tree, total)
+		}
+	}
+
+	// Dense graphs too.
+	g := weightedBernoulli(30, 0.9, false, 1)
+	_, want, _ := MinimumSpanningTree(g)
+	tree, total, err := MinimumSpanningTreePrim(g, gogl.CollectVertices(g)[0])
+	c.Assert(err, IsNil)
+	c.Assert(total, Equals, want)
+	checkMinimumForest(c, g, tree, total)
+}
+
+func (s *SpanningSuite) TestPrimErrors(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+	_, _, err := MinimumSpanningTreePrim(g, 3)
+	c.Assert(err, ErrorMatches, "Start vertex is not present in graph.")
+
+	dg := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc(1, 2, 1),
+	}).Create(al.G).(gogl.WeightedGraph)
+	_, _, err = MinimumSpanningTreePrim(dg, 1)
+	c.Assert(err, ErrorMatches, "Minimum spanning trees are not defined for digraphs.")
+}
@@ -0,0 +1,54 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Produces a copy of the given graph with each vertex's out-edge weights scaled to
+// sum to 1, making the result a row-stochastic transition matrix: the weight of
+// arc u->v is the probability that a random walk at u steps next to v. This is the
+// natural input for Markov chain and random walk analyses.
+//
+// The result is always a weighted digraph that permits loops, as normalization is
+// per row: in an undirected graph, an edge u-v becomes two arcs, weighted relative
+// to u's and v's edges respectively. Weights are assumed to be non-negative.
+//
+// Vertices whose out-edges have no total weight - most commonly, because they have
+// none - are dangling, and cannot be normalized. If selfLoops is false, they are
+// left unchanged; if it is true, they are given a loop of weight 1, making them
+// absorbing states of the chain and the result fully stochastic.
+func RowNormalize(g gogl.WeightedGraph, selfLoops bool) gogl.WeightedGraph {
+	ng := gogl.Spec().Directed().Weighted().Loop().Create(al.G)
+	vm := ng.(gogl.VertexSetMutator)
+
+	var arcs []gogl.WeightedArc
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		vm.EnsureVertex(v)
+
+		var total float64
+		start := len(arcs)
+		eachOutEdge(g, v, func(e gogl.Edge, adj gogl.Vertex) (terminate bool) {
+			w := weightOf(e)
+			total += w
+			arcs = append(arcs, gogl.NewWeightedArc(v, adj, w))
+			return
+		})
+
+		if total == 0 {
+			if selfLoops {
+				arcs = append(arcs[:start], gogl.NewWeightedArc(v, v, 1))
+			}
+			return
+		}
+
+		for i := start; i < len(arcs); i++ {
+			a := arcs[i]
+			arcs[i] = gogl.NewWeightedArc(a.Source(), a.Target(), a.Weight()/total)
+		}
+		return
+	})
+
+	ng.(gogl.WeightedArcSetMutator).AddArcs(arcs...)
+	return ng.(gogl.WeightedGraph)
+}
@@ -0,0 +1,81 @@
+package algo
+
+import (
+	"math"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type StochasticSuite struct{}
+
+var _ = Suite(&StochasticSuite{})
+
+// Sums the weights of each vertex's out-arcs.
+func rowSums(g gogl.WeightedGraph) map[gogl.Vertex]float64 {
+	sums := make(map[gogl.Vertex]float64)
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		sums[v] = 0
+		return
+	})
+	g.(gogl.Digraph).Arcs(func(a gogl.Arc) (terminate bool) {
+		sums[a.Source()] += a.(gogl.WeightedArc).Weight()
+		return
+	})
+	return sums
+}
+
+func (s *StochasticSuite) TestDirected(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "b", 1),
+		gogl.NewWeightedArc("a", "c", 3),
+		gogl.NewWeightedArc("b", "c", 2.5),
+		gogl.NewWeightedArc("c", "a", 0.1),
+		gogl.NewWeightedArc("c", "b", 0.2),
+		gogl.NewWeightedArc("c", "d", 0.7),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	ng := RowNormalize(g, false)
+	wd := ng.(gogl.WeightedDigraph)
+	c.Assert(wd.HasWeightedArc(gogl.NewWeightedArc("a", "b", 0.25)), Equals, true)
+	c.Assert(wd.HasWeightedArc(gogl.NewWeightedArc("a", "c", 0.75)), Equals, true)
+	c.Assert(wd.HasWeightedArc(gogl.NewWeightedArc("b", "c", 1)), Equals, true)
+
+	for v, sum := range rowSums(ng) {
+		if v == "d" {
+			// Dangling, and left that way.
+			c.Assert(sum, Equals, 0.0)
+		} else {
+			c.Assert(math.Abs(sum-1) < 1e-12, Equals, true, Commentf("row %v sums to %v", v, sum))
+		}
+	}
+	c.Assert(gogl.Order(ng), Equals, 4)
+
+	// With loops, the dangling vertex becomes absorbing.
+	ng = RowNormalize(g, true)
+	c.Assert(ng.(gogl.WeightedDigraph).HasWeightedArc(gogl.NewWeightedArc("d", "d", 1)), Equals, true)
+	for v, sum := range rowSums(ng) {
+		c.Assert(math.Abs(sum-1) < 1e-12, Equals, true, Commentf("row %v sums to %v", v, sum))
+	}
+}
+
+func (s *StochasticSuite) TestUndirected(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(1, 2, 2),
+		gogl.NewWeightedEdge(2, 3, 6),
+	}).Create(al.G).(gogl.WeightedGraph)
+
+	ng := RowNormalize(g, true)
+	wd := ng.(gogl.WeightedDigraph)
+
+	// The edge 1-2 is all of 1's weight, but only a quarter of 2's.
+	c.Assert(wd.HasWeightedArc(gogl.NewWeightedArc(1, 2, 1)), Equals, true)
+	c.Assert(wd.HasWeightedArc(gogl.NewWeightedArc(2, 1, 0.25)), Equals, true)
+	c.Assert(wd.HasWeightedArc(gogl.NewWeightedArc(2, 3, 0.75)), Equals, true)
+	c.Assert(wd.HasWeightedArc(gogl.NewWeightedArc(3, 2, 1)), Equals, true)
+
+	for v, sum := range rowSums(ng) {
+		c.Assert(sum, Equals, 1.0, Commentf("row %v", v))
+	}
+}
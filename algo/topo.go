@@ -0,0 +1,226 @@
+package algo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sdboyer/gogl"
+)
+
+// DynamicTopoOrder wraps a MutableDigraph, maintaining a topological ordering of
+// its vertices as arcs and vertices are added and removed. The graph must be
+// acyclic to begin with, and must only be modified through the wrapper.
+//
+// An added arc that already points forward in the ordering costs nothing more.
+// Otherwise, the ordering is repaired with the online algorithm of Pearce and
+// Kelly: only vertices positioned between the arc's endpoints are searched, and
+// of those, only the ones reachable forward from the arc's target or backward
+// from its source are moved. The same search discovers any cycle the arc would
+// close, in which case the arc is rejected.
+//
+// Removing arcs cannot invalidate a topological ordering, so RemoveArcs passes
+// straight through to the graph. Removing vertices takes time linear in the order
+// of the graph.
+type DynamicTopoOrder struct {
+	gogl.MutableDigraph
+	dg  gogl.Digraph
+	pos map[interface{}]int // keyed by gogl.VertexKey
+	at  []gogl.Vertex
+}
+
+// Creates a DynamicTopoOrder maintaining a topological ordering of the provided
+// graph, which must also be a Digraph. An error is returned if it is not, or if
+// it has a cycle.
+func NewDynamicTopoOrder(g gogl.MutableDigraph) (*DynamicTopoOrder, error) {
+	dg, ok := g.(gogl.Digraph)
+	if !ok {
+		return nil, errors.New("Graph must be a Digraph.")
+	}
+
+	order, _, indegree := kahnOrder(dg)
+	n := len(indegree)
+	if len(order) < n {
+		return nil, errors.New("Graph is not acyclic.")
+	}
+
+	dto := &DynamicTopoOrder{
+		MutableDigraph: g,
+		dg:             dg,
+		pos:            make(map[interface{}]int, n),
+		at:             order,
+	}
+	for i, v := range order {
+		dto.pos[gogl.VertexKey(v)] = i
+	}
+
+	return dto, nil
+}
+
+// Adds arcs to the graph, updating the ordering to suit. Unlike the graph's own
+// AddArcs, this returns an error: if any of the arcs would create a cycle (a loop
+// included), none of them are added, and the graph is left as it was.
+func (dto *DynamicTopoOrder) AddArcs(arcs ...gogl.Arc) error {
+	var added []gogl.Arc
+	var created []gogl.Vertex
+
+	for _, a := range arcs {
+		if dto.dg.HasArc(a) {
+			continue
+		}
+
+		u, v := a.Both()
+		for _, w := range [...]gogl.Vertex{u, v} {
+			if _, tracked := dto.pos[gogl.VertexKey(w)]; !tracked {
+				dto.track(w)
+				created = append(created, w)
+			}
+		}
+
+		if !dto.reorder(u, v) {
+			dto.MutableDigraph.RemoveArcs(added...)
+			dto.RemoveVertex(created...)
+			return fmt.Errorf("Arc from %v to %v would create a cycle.", u, v)
+		}
+
+		dto.MutableDigraph.AddArcs(a)
+		added = append(added, a)
+	}
+
+	return nil
+}
+
+// Ensures the provided vertices are present in the graph. New vertices are placed
+// at the end of the ordering.
+func (dto *DynamicTopoOrder) EnsureVertex(vertices ...gogl.Vertex) {
+	dto.MutableDigraph.EnsureVertex(vertices...)
+	for _, v := range vertices {
+		if _, tracked := dto.pos[gogl.VertexKey(v)]; !tracked {
+			dto.track(v)
+		}
+	}
+}
+
+// Removes the provided vertices from the graph, if present.
+func (dto *DynamicTopoOrder) RemoveVertex(vertices ...gogl.Vertex) {
+	dto.MutableDigraph.RemoveVertex(vertices...)
+
+	var removed int
+	for _, v := range vertices {
+		if _, tracked := dto.pos[gogl.VertexKey(v)]; tracked {
+			delete(dto.pos, gogl.VertexKey(v))
+			removed++
+		}
+	}
+	if removed == 0 {
+		return
+	}
+
+	at := dto.at[:0]
+	for _, v := range dto.at {
+		if _, tracked := dto.pos[gogl.VertexKey(v)]; tracked {
+			dto.pos[gogl.VertexKey(v)] = len(at)
+			at = append(at, v)
+		}
+	}
+	dto.at = at
+}
+
+// Returns the vertices of the graph in topological order: every arc leads from an
+// earlier vertex to a later one. The returned slice is a copy.
+func (dto *DynamicTopoOrder) TopologicalOrder() []gogl.Vertex {
+	return append([]gogl.Vertex(nil), dto.at...)
+}
+
+// Returns the position of the given vertex in the topological order, or -1 if it
+// is not in the graph.
+func (dto *DynamicTopoOrder) IndexOf(v gogl.Vertex) int {
+	if i, tracked := dto.pos[gogl.VertexKey(v)]; tracked {
+		return i
+	}
+	return -1
+}
+
+// Places the given vertex at the end of the ordering.
+func (dto *DynamicTopoOrder) track(v gogl.Vertex) {
+	dto.pos[gogl.VertexKey(v)] = len(dto.at)
+	dto.at = append(dto.at, v)
+}
+
+// Repairs the ordering ahead of the addition of an arc from u to v, returning
+// false, and leaving the ordering untouched, if the arc would create a cycle.
+func (dto *DynamicTopoOrder) reorder(u, v gogl.Vertex) bool {
+	lb, ub := dto.pos[gogl.VertexKey(v)], dto.pos[gogl.VertexKey(u)]
+	if lb > ub {
+		return true
+	}
+	if lb == ub {
+		return false
+	}
+
+	forward, acyclic := dto.search(v, ub, true)
+	if !acyclic {
+		return false
+	}
+	backward, _ := dto.search(u, lb, false)
+
+	// Between them, the two sets keep the positions they already occupy, but
+	// everything reaching u must now precede everything reachable from v. Within
+	// each set, the existing relative order is valid, and is kept.
+	byPos := func(vs []gogl.Vertex) {
+		sort.Slice(vs, func(i, j int) bool { return dto.pos[gogl.VertexKey(vs[i])] < dto.pos[gogl.VertexKey(vs[j])] })
+	}
+	byPos(backward)
+	byPos(forward)
+
+	moved := append(backward, forward...)
+	slots := make([]int, len(moved))
+	for i, w := range moved {
+		slots[i] = dto.pos[gogl.VertexKey(w)]
+	}
+	sort.Ints(slots)
+
+	for i, w := range moved {
+		dto.pos[gogl.VertexKey(w)] = slots[i]
+		dto.at[slots[i]] = w
+	}
+
+	return true
+}
+
+// Collects the vertices reachable from start by following arcs forward (or
+// backward, if forward is false), without passing beyond the given position in
+// the ordering. Should the vertex at that position be reached, false is returned.
+func (dto *DynamicTopoOrder) search(start gogl.Vertex, bound int, forward bool) ([]gogl.Vertex, bool) {
+	seen := map[interface{}]bool{gogl.VertexKey(start): true} // keyed by gogl.VertexKey
+	found := []gogl.Vertex{start}
+	stack := []gogl.Vertex{start}
+
+	var hit bool
+	step := func(w gogl.Vertex) (terminate bool) {
+		p := dto.pos[gogl.VertexKey(w)]
+		if p == bound {
+			hit = true
+			return true
+		}
+		if seen[gogl.VertexKey(w)] || (forward && p > bound) || (!forward && p < bound) {
+			return
+		}
+		seen[gogl.VertexKey(w)] = true
+		found = append(found, w)
+		stack = append(stack, w)
+		return
+	}
+
+	for len(stack) > 0 && !hit {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if forward {
+			dto.dg.SuccessorsOf(v, step)
+		} else {
+			dto.dg.PredecessorsOf(v, step)
+		}
+	}
+
+	return found, !hit
+}
@@ -0,0 +1,137 @@
+package algo
+
+import (
+	"fmt"
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type TopoSuite struct{}
+
+var _ = Suite(&TopoSuite{})
+
+// Checks that order lists every vertex of g exactly once, with every arc of g
+// pointing forward along it.
+func checkTopoOrder(c *C, g gogl.Digraph, order []gogl.Vertex, comment string) {
+	pos := make(map[gogl.Vertex]int, len(order))
+	for i, v := range order {
+		_, dup := pos[v]
+		c.Assert(dup, Equals, false, Commentf("%s", comment))
+		c.Assert(g.HasVertex(v), Equals, true, Commentf("%s", comment))
+		pos[v] = i
+	}
+	c.Assert(len(pos), Equals, gogl.Order(g), Commentf("%s", comment))
+
+	g.Arcs(func(a gogl.Arc) (terminate bool) {
+		c.Assert(pos[a.Source()] < pos[a.Target()], Equals, true, Commentf("arc %v out of order; %s", a, comment))
+		return
+	})
+}
+
+func newMutableDigraph() gogl.MutableDigraph {
+	return gogl.Spec().Directed().Create(al.G).(gogl.MutableDigraph)
+}
+
+func (s *TopoSuite) TestNew(c *C) {
+	g := newMutableDigraph()
+	g.AddArcs(gogl.NewArc(1, 2), gogl.NewArc(2, 3), gogl.NewArc(3, 1))
+	_, err := NewDynamicTopoOrder(g)
+	c.Assert(err, ErrorMatches, "Graph is not acyclic.")
+
+	g.RemoveArcs(gogl.NewArc(3, 1))
+	g.AddArcs(gogl.NewArc(1, 3), gogl.NewArc(4, 2))
+	g.EnsureVertex(5)
+	dto, err := NewDynamicTopoOrder(g)
+	c.Assert(err, IsNil)
+	checkTopoOrder(c, g.(gogl.Digraph), dto.TopologicalOrder(), "")
+	c.Assert(dto.IndexOf(6), Equals, -1)
+}
+
+func (s *TopoSuite) TestReorder(c *C) {
+	g := newMutableDigraph()
+	dto, err := NewDynamicTopoOrder(g)
+	c.Assert(err, IsNil)
+
+	// Two chains, the second placed entirely after the first.
+	dto.EnsureVertex("a", "b", "c", "x", "y", "z")
+	c.Assert(dto.AddArcs(gogl.NewArc("a", "b"), gogl.NewArc("b", "c")), IsNil)
+	c.Assert(dto.AddArcs(gogl.NewArc("x", "y"), gogl.NewArc("y", "z")), IsNil)
+
+	// Linking the end of the second to the start of the first forces a shuffle.
+	c.Assert(dto.AddArcs(gogl.NewArc("z", "a")), IsNil)
+	checkTopoOrder(c, g.(gogl.Digraph), dto.TopologicalOrder(), "")
+	c.Assert(dto.IndexOf("x"), Equals, 0)
+	c.Assert(dto.IndexOf("c"), Equals, 5)
+
+	// And now any arc back up the combined chain is a cycle.
+	err = dto.AddArcs(gogl.NewArc("c", "y"))
+	c.Assert(err, ErrorMatches, "Arc from c to y would create a cycle.")
+	c.Assert(g.(gogl.Digraph).HasArc(gogl.NewArc("c", "y")), Equals, false)
+
+	err = dto.AddArcs(gogl.NewArc("b", "b"))
+	c.Assert(err, ErrorMatches, "Arc from b to b would create a cycle.")
+	c.Assert(gogl.Size(g), Equals, 5)
+}
+
+func (s *TopoSuite) TestRejectedBatch(c *C) {
+	g := newMutableDigraph()
+	g.AddArcs(gogl.NewArc(1, 2), gogl.NewArc(2, 3))
+	dto, err := NewDynamicTopoOrder(g)
+	c.Assert(err, IsNil)
+
+	// The first two arcs are fine on their own, but together with the third
+	// close a cycle through a new vertex.
+	err = dto.AddArcs(gogl.NewArc(3, 4), gogl.NewArc(1, 3), gogl.NewArc(4, 1))
+	c.Assert(err, ErrorMatches, "Arc from 4 to 1 would create a cycle.")
+	c.Assert(gogl.Size(g), Equals, 2)
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(dto.IndexOf(4), Equals, -1)
+	c.Assert(dto.TopologicalOrder(), HasLen, 3)
+	checkTopoOrder(c, g.(gogl.Digraph), dto.TopologicalOrder(), "")
+}
+
+func (s *TopoSuite) TestRandomInterleaved(c *C) {
+	for seed := int64(0); seed < 10; seed++ {
+		r := stdrand.New(stdrand.NewSource(seed))
+		g := newMutableDigraph()
+		ref := newMutableDigraph()
+		dto, err := NewDynamicTopoOrder(g)
+		c.Assert(err, IsNil)
+
+		const n = 30
+		for i := 0; i < 400; i++ {
+			comment := fmt.Sprintf("seed %d, step %d", seed, i)
+
+			switch op := r.Intn(20); {
+			case op == 0:
+				v := r.Intn(n)
+				dto.RemoveVertex(v)
+				ref.RemoveVertex(v)
+			case op < 3:
+				a := gogl.NewArc(r.Intn(n), r.Intn(n))
+				dto.RemoveArcs(a)
+				ref.RemoveArcs(a)
+			default:
+				u, v := r.Intn(n), r.Intn(n)
+				a := gogl.NewArc(u, v)
+				acyclic := u != v
+				if acyclic && !ref.(gogl.Digraph).HasArc(a) {
+					ref.AddArcs(a)
+					if !IsDAG(ref.(gogl.Digraph)) {
+						ref.RemoveArcs(a)
+						acyclic = false
+					}
+				}
+
+				err := dto.AddArcs(a)
+				c.Assert(err == nil, Equals, acyclic, Commentf("%s", comment))
+			}
+
+			c.Assert(gogl.Size(g), Equals, gogl.Size(ref), Commentf("%s", comment))
+			checkTopoOrder(c, g.(gogl.Digraph), dto.TopologicalOrder(), comment)
+		}
+	}
+}
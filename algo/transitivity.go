@@ -0,0 +1,60 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Counts the connected triples in g: paths of length two, whether open or closed
+// into a triangle. Each vertex with d neighbors is the center of d choose 2
+// triples.
+//
+// Together with the triangle count (see CountMotif), this gives the global
+// transitivity of the graph, 3 × triangles / triples. The graph is assumed to be
+// undirected. Self-loops are ignored, as a triple's vertices must be distinct;
+// a loop is not counted among a vertex's neighbors, even though it counts
+// toward its degree.
+func ConnectedTriples(g gogl.Graph) int {
+	var triples int
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		d, _ := g.DegreeOf(v)
+		if g.HasEdge(gogl.NewEdge(v, v)) {
+			d--
+		}
+		triples += d * (d - 1) / 2
+		return
+	})
+
+	return triples
+}
+
+// Calculates the global clustering coefficient, or transitivity, of g: the
+// fraction of connected triples that are closed, with their endpoints adjacent.
+//
+// Each triple is checked directly, by testing every pair of each vertex's
+// neighbors for adjacency. Edge direction and self-loops are ignored. A graph
+// with no connected triples has a transitivity of 0.
+func Transitivity(g gogl.Graph) float64 {
+	adj := undirectedAdjacency(g)
+
+	var closed, triples int
+	for _, nbrs := range adj.nbrs {
+		ns := make([]int, 0, len(nbrs))
+		for u := range nbrs {
+			ns = append(ns, u)
+		}
+
+		for i := range ns {
+			for j := i + 1; j < len(ns); j++ {
+				triples++
+				if _, exists := adj.nbrs[ns[i]][ns[j]]; exists {
+					closed++
+				}
+			}
+		}
+	}
+
+	if triples == 0 {
+		return 0
+	}
+	return float64(closed) / float64(triples)
+}
@@ -0,0 +1,78 @@
+package algo
+
+import (
+	"math"
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type TransitivitySuite struct{}
+
+var _ = Suite(&TransitivitySuite{})
+
+func (s *TransitivitySuite) TestConnectedTriples(c *C) {
+	// A star with four leaves has 4 choose 2 triples, all open.
+	star := relabeledGraph([][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}}, identity)
+	c.Assert(ConnectedTriples(star), Equals, 6)
+	c.Assert(Transitivity(star), Equals, 0.0)
+
+	// Every triple in a triangle is closed.
+	c.Assert(ConnectedTriples(triangleMotif), Equals, 3)
+	c.Assert(Transitivity(triangleMotif), Equals, 1.0)
+
+	// A path has one triple per internal vertex.
+	path := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}, identity)
+	c.Assert(ConnectedTriples(path), Equals, 3)
+
+	// The Petersen graph is 3-regular on ten vertices.
+	c.Assert(ConnectedTriples(relabeledGraph(petersen, identity)), Equals, 30)
+
+	empty := gogl.Spec().Create(al.G)
+	c.Assert(ConnectedTriples(empty), Equals, 0)
+	c.Assert(Transitivity(empty), Equals, 0.0)
+}
+
+func (s *TransitivitySuite) TestTransitivityFromTriples(c *C) {
+	fixtures := map[string]gogl.Graph{
+		"petersen": relabeledGraph(petersen, identity),
+		"wheel": relabeledGraph([][2]int{
+			{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 1},
+			{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5},
+			{5, 6}, {6, 1},
+		}, identity),
+		"k4":     relabeledGraph([][2]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, identity),
+		"bowtie": relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 3}}, identity),
+	}
+	for i := int64(0); i < 3; i++ {
+		src := rand.BernoulliDistribution(20, 0.3, false, true, stdrand.NewSource(i))
+		fixtures[string(rune('a'+i))+"-random"] = gogl.Spec().Using(src).Create(al.G)
+	}
+
+	for name, g := range fixtures {
+		triples := ConnectedTriples(g)
+		c.Assert(triples > 0, Equals, true, Commentf("fixture %s", name))
+
+		t := 3 * float64(CountMotif(g, triangleMotif)) / float64(triples)
+		c.Assert(math.Abs(t-Transitivity(g)) < 1e-12, Equals, true, Commentf("fixture %s: %v != %v", name, t, Transitivity(g)))
+	}
+
+	// The bowtie's two triangles close six of its ten triples.
+	c.Assert(Transitivity(fixtures["bowtie"]), Equals, 0.6)
+}
+
+func (s *TransitivitySuite) TestLoops(c *C) {
+	// A loop adds neither triples nor triangles.
+	g := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge(1, 2),
+		gogl.NewEdge(2, 3),
+		gogl.NewEdge(3, 1),
+		gogl.NewEdge(1, 1),
+	}).Create(al.G)
+
+	c.Assert(ConnectedTriples(g), Equals, 3)
+	c.Assert(Transitivity(g), Equals, 1.0)
+}
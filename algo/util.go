@@ -0,0 +1,196 @@
+// Contains general-purpose graph algorithms, written strictly against gogl's exported interfaces.
+package algo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Enumerates the edges leaving the given vertex, passing each edge along with
+// the vertex at its far end to the provided step function.
+//
+// Direction is respected: for a Digraph only out-arcs are enumerated; for any
+// other graph, all incident edges are.
+func eachOutEdge(g gogl.Graph, v gogl.Vertex, f func(e gogl.Edge, adjacent gogl.Vertex) (terminate bool)) {
+	if dg, ok := g.(gogl.Digraph); ok {
+		dg.ArcsFrom(v, func(a gogl.Arc) bool {
+			return f(a, a.Target())
+		})
+	} else {
+		g.IncidentTo(v, func(e gogl.Edge) bool {
+			return f(e, otherEnd(e, v))
+		})
+	}
+}
+
+// Returns the end of e that is not v, or v itself for a self-loop.
+func otherEnd(e gogl.Edge, v gogl.Vertex) gogl.Vertex {
+	u, w := e.Both()
+	if gogl.VertexKey(u) == gogl.VertexKey(v) {
+		return w
+	}
+	return u
+}
+
+// Returns a check, for a search to consult at each step, of whether the context
+// is done. Once it is, the check records the context's error in err.
+func haltOn(ctx context.Context, err *error) func() bool {
+	return func() bool {
+		*err = ctx.Err()
+		return *err != nil
+	}
+}
+
+// Returns the number of vertices in g, for presizing maps, if the graph can report
+// it exactly and cheaply; otherwise, 0.
+func sizeHint(g gogl.VertexEnumerator) int {
+	n, _ := gogl.VertexCountHint(g)
+	return n
+}
+
+// Returns the weight of the given edge. Edges that are not WeightedEdges are
+// treated as having unit weight.
+func weightOf(e gogl.Edge) float64 {
+	if we, ok := e.(gogl.WeightedEdge); ok {
+		return we.Weight()
+	}
+	return 1
+}
+
+// NeighborMode selects which of a vertex's neighbors are considered by
+// neighborhood-based algorithms.
+//
+// The distinction only matters for Digraphs; in an undirected graph, all modes
+// are equivalent to AllNeighbors.
+type NeighborMode int
+
+const (
+	// All adjacent vertices - both successors and predecessors, in a Digraph.
+	AllNeighbors NeighborMode = iota
+	// Only vertices with an arc into the given vertex.
+	InNeighbors
+	// Only vertices with an arc from the given vertex.
+	OutNeighbors
+)
+
+// Collects the neighbors of the given vertex into a set, keyed by gogl.VertexKey,
+// per the given mode.
+func neighborSet(g gogl.Graph, v gogl.Vertex, mode NeighborMode) map[interface{}]struct{} {
+	set := make(map[interface{}]struct{})
+	f := func(adj gogl.Vertex) (terminate bool) {
+		set[gogl.VertexKey(adj)] = struct{}{}
+		return
+	}
+
+	dg, directed := g.(gogl.Digraph)
+	switch {
+	case directed && mode == InNeighbors:
+		dg.PredecessorsOf(v, f)
+	case directed && mode == OutNeighbors:
+		dg.SuccessorsOf(v, f)
+	default:
+		g.AdjacentTo(v, f)
+	}
+
+	return set
+}
+
+// The neighbor sets of a graph's vertices, which are numbered by the embedded
+// VertexIndex; nbrs holds the ids of each vertex's neighbors. Keeping them by id
+// spares hashing the vertices, which need not be comparable.
+type adjacency struct {
+	*gogl.VertexIndex
+	nbrs []map[int]struct{}
+}
+
+// Collects each vertex's neighbor set, ignoring direction and self-loops.
+func undirectedAdjacency(g gogl.Graph) adjacency {
+	vi := gogl.NewVertexIndex(g)
+	adj := adjacency{vi, make([]map[int]struct{}, vi.Len())}
+	for i, v := range vi.List() {
+		adj.nbrs[i] = make(map[int]struct{})
+		g.AdjacentTo(v, func(w gogl.Vertex) (terminate bool) {
+			if j := vi.IndexOf(w); j != i {
+				adj.nbrs[i][j] = struct{}{}
+			}
+			return
+		})
+	}
+
+	return adj
+}
+
+// Returns the vertices with the given ids.
+func (adj adjacency) vertices(ids []int) []gogl.Vertex {
+	vs := make([]gogl.Vertex, len(ids))
+	for i, id := range ids {
+		vs[i] = adj.VertexAt(id)
+	}
+	return vs
+}
+
+// Roots the given tree at root, treating edges as undirected. Returned are the
+// vertices in breadth-first order from the root, along with the parent of each,
+// and the children of each, given by their positions in that order. The root's
+// parent is -1.
+//
+// An error is returned if root is not present in the graph, or if the graph is
+// not a tree.
+func rootTree(g gogl.Graph, root gogl.Vertex) (order []gogl.Vertex, parent []int, children [][]int, err error) {
+	if !g.HasVertex(root) {
+		return nil, nil, nil, errors.New("Root vertex is not present in graph.")
+	}
+
+	n := gogl.Order(g)
+	parent = append(make([]int, 0, n), -1)
+	children = make([][]int, 0, n)
+	seen := map[interface{}]struct{}{gogl.VertexKey(root): {}} // keyed by gogl.VertexKey
+
+	order = append(make([]gogl.Vertex, 0, n), root)
+	for i := 0; i < len(order); i++ {
+		children = append(children, nil)
+		g.IncidentTo(order[i], func(e gogl.Edge) (terminate bool) {
+			w := otherEnd(e, order[i])
+			if _, visited := seen[gogl.VertexKey(w)]; !visited {
+				seen[gogl.VertexKey(w)] = struct{}{}
+				parent = append(parent, i)
+				children[i] = append(children[i], len(order))
+				order = append(order, w)
+			}
+			return
+		})
+	}
+
+	if len(order) != n {
+		return nil, nil, nil, errors.New("Graph is not a tree: it is not connected.")
+	}
+	if gogl.Size(g) != n-1 {
+		return nil, nil, nil, errors.New("Graph is not a tree: it contains a cycle.")
+	}
+
+	return order, parent, children, nil
+}
+
+// Builds a mutable adjacency list of the same directedness and edge type as g -
+// weighted, labeled, data or basic - holding the given vertices and edges, which
+// should be g's own, or of the same type. Self-loops are permitted.
+func rebuildLike(g gogl.Graph, vertices []gogl.Vertex, edges []gogl.Edge) gogl.Graph {
+	spec := gogl.Spec().Loop()
+	if _, directed := g.(gogl.Digraph); directed {
+		spec = spec.Directed().Using(gogl.VertexArcList{V: vertices, E: edges})
+	} else {
+		spec = spec.Using(gogl.VertexEdgeList{V: vertices, E: edges})
+	}
+	switch g.(type) {
+	case gogl.WeightedGraph:
+		spec = spec.Weighted()
+	case gogl.LabeledGraph:
+		spec = spec.Labeled()
+	case gogl.DataGraph:
+		spec = spec.DataEdges()
+	}
+	return spec.Create(al.G)
+}
@@ -0,0 +1,49 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type SizeHintSuite struct{}
+
+var _ = Suite(&SizeHintSuite{})
+
+// Embedding only the interface hides the underlying graph's VertexCount method.
+type hintlessDigraph struct {
+	gogl.WeightedDigraph
+}
+
+type hintlessGraph struct {
+	gogl.Graph
+}
+
+func (s *SizeHintSuite) TestSizeHint(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(mixedSignArcs).Create(al.G).(gogl.WeightedDigraph)
+	c.Assert(sizeHint(g), Equals, gogl.Order(g))
+	c.Assert(sizeHint(hintlessDigraph{g}), Equals, 0)
+}
+
+func (s *SizeHintSuite) TestAlgorithmsWithoutHint(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(mixedSignArcs).Create(al.G).(gogl.WeightedDigraph)
+	h := hintlessDigraph{g}
+
+	dist, pred, err := SPFA(g, "s")
+	c.Assert(err, IsNil)
+	hdist, hpred, herr := SPFA(h, "s")
+	c.Assert(herr, IsNil)
+	c.Assert(hdist, DeepEquals, dist)
+	c.Assert(hpred, DeepEquals, pred)
+
+	zo := gogl.Spec().Directed().Weighted().Using(zeroOneArcs).Create(al.G).(gogl.WeightedDigraph)
+	dist, err = ZeroOneBFS(zo, "a")
+	c.Assert(err, IsNil)
+	hdist, herr = ZeroOneBFS(hintlessDigraph{zo}, "a")
+	c.Assert(herr, IsNil)
+	c.Assert(hdist, DeepEquals, dist)
+
+	ug := relabeledGraph(petersen, identity)
+	c.Assert(AllPairsJaccard(hintlessGraph{ug}, AllNeighbors), DeepEquals, AllPairsJaccard(ug, AllNeighbors))
+	c.Assert(EqualUpToIsomorphism(hintlessGraph{ug}, ug), Equals, true)
+}
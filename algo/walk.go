@@ -0,0 +1,69 @@
+package algo
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// Indicates whether the given sequence of vertices forms a walk in g: every
+// vertex is present, and each consecutive pair is joined by an edge. For Digraphs,
+// each pair must be joined by an arc in the direction of travel.
+//
+// A single vertex is a walk of length zero; an empty sequence is not a walk.
+func IsWalk(g gogl.Graph, vertices []gogl.Vertex) bool {
+	if len(vertices) == 0 || !g.HasVertex(vertices[0]) {
+		return false
+	}
+
+	dg, directed := g.(gogl.Digraph)
+	for i := 1; i < len(vertices); i++ {
+		u, v := vertices[i-1], vertices[i]
+		if directed {
+			if !dg.HasArc(gogl.NewArc(u, v)) {
+				return false
+			}
+		} else if !g.HasEdge(gogl.NewEdge(u, v)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Indicates whether the given sequence of vertices forms a path in g: a walk in
+// which no vertex is repeated.
+func IsPath(g gogl.Graph, vertices []gogl.Vertex) bool {
+	return IsWalk(g, vertices) && distinct(vertices)
+}
+
+// Indicates whether the given sequence of vertices forms a cycle in g: a walk
+// that returns to its first vertex, and repeats no other.
+//
+// The sequence must close the cycle explicitly, by ending with the vertex it
+// starts with; so a loop is written [v, v], and a triangle [a, b, c, a]. As an
+// undirected cycle cannot traverse the same edge twice, in undirected graphs a
+// cycle of two vertices, [u, v, u], is not a cycle.
+func IsCycle(g gogl.Graph, vertices []gogl.Vertex) bool {
+	n := len(vertices)
+	if n < 2 || gogl.VertexKey(vertices[0]) != gogl.VertexKey(vertices[n-1]) {
+		return false
+	}
+
+	if _, directed := g.(gogl.Digraph); !directed && n == 3 {
+		return false
+	}
+
+	return IsWalk(g, vertices) && distinct(vertices[:n-1])
+}
+
+// Indicates whether no vertex appears more than once in the given sequence.
+func distinct(vertices []gogl.Vertex) bool {
+	seen := make(map[interface{}]struct{}, len(vertices)) // keyed by gogl.VertexKey
+	for _, v := range vertices {
+		k := gogl.VertexKey(v)
+		if _, exists := seen[k]; exists {
+			return false
+		}
+		seen[k] = struct{}{}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package algo
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type WalkSuite struct{}
+
+var _ = Suite(&WalkSuite{})
+
+// Shorthand for writing vertex sequences.
+func seq(vs ...gogl.Vertex) []gogl.Vertex { return vs }
+
+func (s *WalkSuite) TestUndirected(c *C) {
+	// A square 1-2-3-4, with a pendant 5 off of 1.
+	g := relabeledGraph([][2]int{{1, 2}, {2, 3}, {3, 4}, {4, 1}, {1, 5}}, identity)
+
+	c.Assert(IsWalk(g, seq(5, 1, 2, 3)), Equals, true)
+	c.Assert(IsWalk(g, seq(3, 2, 1, 5)), Equals, true)
+	c.Assert(IsWalk(g, seq(1, 2, 1, 5)), Equals, true)
+	c.Assert(IsWalk(g, seq(1)), Equals, true)
+	c.Assert(IsWalk(g, seq(1, 3)), Equals, false) // no such edge
+	c.Assert(IsWalk(g, seq(42)), Equals, false)
+	c.Assert(IsWalk(g, seq()), Equals, false)
+
+	c.Assert(IsPath(g, seq(5, 1, 2, 3)), Equals, true)
+	c.Assert(IsPath(g, seq(1, 2, 1, 5)), Equals, false)
+	c.Assert(IsPath(g, seq(5, 1, 3)), Equals, false)
+
+	c.Assert(IsCycle(g, seq(1, 2, 3, 4, 1)), Equals, true)
+	c.Assert(IsCycle(g, seq(3, 2, 1, 4, 3)), Equals, true)
+	c.Assert(IsCycle(g, seq(1, 2, 3, 4)), Equals, false)    // not closed
+	c.Assert(IsCycle(g, seq(1, 5, 1)), Equals, false)       // reuses an edge
+	c.Assert(IsCycle(g, seq(1, 2, 3, 5, 1)), Equals, false) // 3-5 is missing
+	c.Assert(IsCycle(g, seq(1, 2, 1, 4, 3, 4, 1)), Equals, false)
+}
+
+func (s *WalkSuite) TestDirected(c *C) {
+	g := gogl.Spec().Directed().Loop().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "a"),
+		gogl.NewArc("b", "a"),
+		gogl.NewArc("d", "d"),
+	}).Create(al.G)
+
+	c.Assert(IsWalk(g, seq("a", "b", "c")), Equals, true)
+	c.Assert(IsWalk(g, seq("c", "b")), Equals, false) // against the arc
+	c.Assert(IsPath(g, seq("a", "b", "c")), Equals, true)
+	c.Assert(IsPath(g, seq("c", "b", "a")), Equals, false)
+
+	c.Assert(IsCycle(g, seq("a", "b", "c", "a")), Equals, true)
+	c.Assert(IsCycle(g, seq("a", "c", "b", "a")), Equals, false)
+	c.Assert(IsCycle(g, seq("a", "b", "a")), Equals, true) // two distinct arcs
+	c.Assert(IsCycle(g, seq("d", "d")), Equals, true)
+	c.Assert(IsCycle(g, seq("a", "a")), Equals, false)
+}
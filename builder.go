@@ -1,14 +1,20 @@
 package gogl
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 /* Graph type constants. Used primarily for specs. */
 
 // Describes the properties of a graph as a bitfield.
 type GraphProperties uint16
 
 const (
-	// Edge directedness. Flags are provided for both, though gogl does not really support
-	// a hybrid graph containing both directed and undirected edges. Algorithms would have
-	// undefined results.
+	// Edge directedness. Setting both flags describes a mixed graph, containing both
+	// directed and undirected edges; see MixedGraph. Most algorithms do not support
+	// mixed graphs, and would have undefined results.
 	G_UNDIRECTED = 1 << iota
 	G_DIRECTED
 
@@ -75,6 +81,13 @@ func (b GraphSpec) Directed() GraphSpec {
 	return b
 }
 
+// Specify that the graph should be mixed, admitting both undirected edges and
+// directed arcs. See MixedGraph.
+func (b GraphSpec) Mixed() GraphSpec {
+	b.Props |= G_UNDIRECTED | G_DIRECTED
+	return b
+}
+
 // Specify that the edges should be "basic" - no weights, labels, or data.
 func (b GraphSpec) Basic() GraphSpec {
 	b.Props &^= G_LABELED | G_WEIGHTED | G_DATA
@@ -124,7 +137,8 @@ func (b GraphSpec) PseudoGraph() GraphSpec {
 	return b
 }
 
-// Specify that the graph allows parallel edges.
+// Specify that the graph allows parallel edges. The graph/multi package provides
+// an immutable, labeled implementation.
 func (b GraphSpec) Parallel() GraphSpec {
 	b.Props &^= G_SIMPLE
 	b.Props |= G_PARALLEL
@@ -152,13 +166,14 @@ func (b GraphSpec) Immutable() GraphSpec {
 	return b
 }
 
-// Specify that the graph is persistent.
-// TODO Commented out until this actually gets implemented
-//func (b GraphSpec) Persistent() GraphSpec {
-//b.Props &^= G_IMMUTABLE
-//b.Props |= G_PERSISTENT
-//return b
-//}
+// Specify that the graph is persistent: never modified in place, its mutators
+// instead returning new versions of it. See PersistentGraph; the graph/persist
+// package provides an implementation.
+func (b GraphSpec) Persistent() GraphSpec {
+	b.Props &^= G_IMMUTABLE
+	b.Props |= G_PERSISTENT
+	return b
+}
 
 // Creates a graph from the spec, using the provided creator function.
 //
@@ -167,3 +182,124 @@ func (b GraphSpec) Immutable() GraphSpec {
 func (b GraphSpec) Create(f func(GraphSpec) Graph) Graph {
 	return f(b)
 }
+
+// The text form of each property, grouped as the builder methods treat them.
+// Within a group, the order here is the order of the text form.
+var specTokens = [...]struct {
+	token string
+	props GraphProperties
+}{
+	{"mixed", G_UNDIRECTED | G_DIRECTED},
+	{"undirected", G_UNDIRECTED},
+	{"directed", G_DIRECTED},
+	{"basic", G_BASIC},
+	{"labeled", G_LABELED},
+	{"weighted", G_WEIGHTED},
+	{"data", G_DATA},
+	{"simple", G_SIMPLE},
+	{"loops", G_LOOPS},
+	{"parallel", G_PARALLEL},
+	{"persistent", G_PERSISTENT},
+	{"mutable", G_MUTABLE},
+	{"immutable", G_IMMUTABLE},
+}
+
+// The groups into which the properties fall.
+const (
+	specDirectedness = G_UNDIRECTED | G_DIRECTED
+	specEdgeType     = G_BASIC | G_LABELED | G_WEIGHTED | G_DATA
+	specMultiplicity = G_SIMPLE | G_LOOPS | G_PARALLEL
+	specMutability   = G_PERSISTENT | G_MUTABLE | G_IMMUTABLE
+)
+
+// Checks that the properties do not contradict each other.
+func checkProps(p GraphProperties) error {
+	switch {
+	case p&G_BASIC != 0 && p&(G_LABELED|G_WEIGHTED|G_DATA) != 0:
+		return errors.New("Edges cannot be both basic and labeled, weighted, or data.")
+	case p&G_SIMPLE != 0 && p&(G_LOOPS|G_PARALLEL) != 0:
+		return errors.New("A simple graph cannot have loops or parallel edges.")
+	case p&G_IMMUTABLE != 0 && p&G_MUTABLE != 0:
+		return errors.New("A graph cannot be both mutable and immutable.")
+	}
+	return nil
+}
+
+// Encodes the spec's properties as a comma-separated list of their names, such
+// as "directed,weighted,simple,mutable", for storing what kind of graph to
+// build alongside its data. Directedness is one of "undirected", "directed" or
+// "mixed"; edge type, "basic" or any of "labeled", "weighted" and "data";
+// multiplicity, "simple" or either or both of "loops" and "parallel"; and
+// mutability, one of "immutable", "mutable" or "persistent". The names always
+// appear in that order, so equal specs give equal text.
+//
+// The spec's Source is not encoded. An error is returned if the properties
+// contradict each other, as when a spec is both simple and parallel.
+func (b GraphSpec) MarshalText() ([]byte, error) {
+	if err := checkProps(b.Props); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	var seen GraphProperties
+	for _, t := range specTokens {
+		if b.Props&t.props == t.props && seen&t.props != t.props {
+			names = append(names, t.token)
+			seen |= t.props
+		}
+	}
+
+	return []byte(strings.Join(names, ",")), nil
+}
+
+// Decodes properties in the form produced by MarshalText, replacing those of the
+// spec. The names may come in any order. Any group of properties left unnamed
+// takes its value from Spec(): undirected, basic, simple, or mutable. The spec's
+// Source is left as it is.
+//
+// An error is returned, and the spec left unchanged, if a name is not
+// recognized, or if the named properties contradict each other, as with
+// "directed,undirected" or "simple,parallel".
+func (b *GraphSpec) UnmarshalText(text []byte) error {
+	var props GraphProperties
+	for _, name := range strings.Split(string(text), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		var found bool
+		for _, t := range specTokens {
+			if t.token != name {
+				continue
+			}
+			// Only one name may be given for each of these groups.
+			for _, group := range [...]GraphProperties{specDirectedness, specMutability} {
+				if t.props&group != 0 && props&group != 0 && props&group != t.props {
+					return fmt.Errorf("Graph property %q conflicts with another.", name)
+				}
+			}
+			props |= t.props
+			found = true
+			break
+		}
+
+		if !found {
+			return fmt.Errorf("Unknown graph property %q.", name)
+		}
+	}
+
+	if err := checkProps(props); err != nil {
+		return err
+	}
+
+	defaults := Spec().Props
+	for _, group := range [...]GraphProperties{specDirectedness, specEdgeType, specMultiplicity, specMutability} {
+		if props&group == 0 {
+			props |= defaults & group
+		}
+	}
+
+	b.Props = props
+	return nil
+}
@@ -1,6 +1,8 @@
 package gogl
 
 import (
+	"regexp"
+
 	. "github.com/sdboyer/gocheck"
 )
 
@@ -36,6 +38,10 @@ func (s *SpecTestSuite) TestMutators(c *C) {
 		c.Assert(spec.Undirected().Props&G_DIRECTED == 0, Equals, true)
 	}
 
+	for _, spec.Props = range s.permuteField() {
+		c.Assert(spec.Mixed().Props&(G_DIRECTED|G_UNDIRECTED) == G_DIRECTED|G_UNDIRECTED, Equals, true)
+	}
+
 	for _, spec.Props = range s.permuteField() {
 		c.Assert(spec.Basic().Props&G_BASIC == G_BASIC, Equals, true)
 		c.Assert(spec.Basic().Props&(G_LABELED|G_WEIGHTED|G_DATA) == 0, Equals, true)
@@ -90,4 +96,100 @@ func (s *SpecTestSuite) TestMutators(c *C) {
 		c.Assert(spec.Immutable().Props&G_IMMUTABLE == G_IMMUTABLE, Equals, true)
 		c.Assert(spec.Immutable().Props&G_MUTABLE == 0, Equals, true)
 	}
+
+	for _, spec.Props = range s.permuteField() {
+		c.Assert(spec.Persistent().Props&G_PERSISTENT == G_PERSISTENT, Equals, true)
+		c.Assert(spec.Persistent().Props&G_IMMUTABLE == 0, Equals, true)
+		// Going back to mutable must shed persistence.
+		c.Assert(spec.Persistent().Mutable().Props&G_PERSISTENT == G_PERSISTENT, Equals, false)
+	}
+}
+
+// Every consistent, complete combination of properties.
+func (s *SpecTestSuite) allSpecs() []GraphSpec {
+	var specs []GraphSpec
+	for _, dir := range []GraphProperties{G_UNDIRECTED, G_DIRECTED, G_UNDIRECTED | G_DIRECTED} {
+		// Basic, or any non-empty combination of the other edge types.
+		edgeTypes := []GraphProperties{G_BASIC}
+		for m := GraphProperties(1); m < 8; m++ {
+			var et GraphProperties
+			for i, t := range []GraphProperties{G_LABELED, G_WEIGHTED, G_DATA} {
+				if m&(1<<uint(i)) != 0 {
+					et |= t
+				}
+			}
+			edgeTypes = append(edgeTypes, et)
+		}
+
+		for _, et := range edgeTypes {
+			for _, mult := range []GraphProperties{G_SIMPLE, G_LOOPS, G_PARALLEL, G_LOOPS | G_PARALLEL} {
+				for _, mut := range []GraphProperties{G_IMMUTABLE, G_MUTABLE, G_PERSISTENT} {
+					specs = append(specs, GraphSpec{Props: dir | et | mult | mut})
+				}
+			}
+		}
+	}
+	return specs
+}
+
+func (s *SpecTestSuite) TestMarshalText(c *C) {
+	text, err := Spec().Directed().Weighted().MarshalText()
+	c.Assert(err, IsNil)
+	c.Assert(string(text), Equals, "directed,weighted,simple,mutable")
+
+	text, err = Spec().Mixed().Labeled().DataEdges().PseudoGraph().Immutable().MarshalText()
+	c.Assert(err, IsNil)
+	c.Assert(string(text), Equals, "mixed,labeled,data,loops,parallel,immutable")
+
+	text, err = GraphSpec{Props: G_DIRECTED | G_BASIC | G_SIMPLE | G_PERSISTENT}.MarshalText()
+	c.Assert(err, IsNil)
+	c.Assert(string(text), Equals, "directed,basic,simple,persistent")
+
+	_, err = GraphSpec{Props: baseline | G_PARALLEL}.MarshalText()
+	c.Assert(err, ErrorMatches, "A simple graph cannot .*")
+}
+
+func (s *SpecTestSuite) TestTextRoundTrip(c *C) {
+	specs := s.allSpecs()
+	c.Assert(specs, HasLen, 3*8*4*3)
+
+	texts := make(map[string]bool)
+	for _, spec := range specs {
+		text, err := spec.MarshalText()
+		c.Assert(err, IsNil)
+		texts[string(text)] = true
+
+		var back GraphSpec
+		c.Assert(back.UnmarshalText(text), IsNil)
+		c.Assert(back.Props, Equals, spec.Props, Commentf("%s", text))
+	}
+	c.Assert(texts, HasLen, len(specs))
+}
+
+func (s *SpecTestSuite) TestUnmarshalText(c *C) {
+	src := EdgeList{NewEdge("a", "b")}
+	spec := Spec().Using(src)
+
+	// Unnamed groups take the defaults; the source is kept.
+	c.Assert(spec.UnmarshalText([]byte(" parallel, directed ")), IsNil)
+	c.Assert(spec.Props, Equals, GraphProperties(G_DIRECTED|G_BASIC|G_PARALLEL|G_MUTABLE))
+	c.Assert(spec.Source, DeepEquals, src)
+
+	c.Assert(spec.UnmarshalText(nil), IsNil)
+	c.Assert(spec.Props, Equals, Spec().Props)
+
+	for text, msg := range map[string]string{
+		"directed,undirected":  `Graph property "undirected" conflicts with another.`,
+		"mixed,directed":       `Graph property "directed" conflicts with another.`,
+		"persistent,immutable": `Graph property "immutable" conflicts with another.`,
+		"mutable,persistent":   `Graph property "persistent" conflicts with another.`,
+		"simple,parallel":      "A simple graph cannot have loops or parallel edges.",
+		"basic,weighted":       "Edges cannot be both basic and labeled, weighted, or data.",
+		"directed,multigraph":  `Unknown graph property "multigraph".`,
+		"Directed":             `Unknown graph property "Directed".`,
+	} {
+		spec := Spec().Directed()
+		c.Assert(spec.UnmarshalText([]byte(text)), ErrorMatches, regexp.QuoteMeta(msg))
+		c.Assert(spec.Props, Equals, Spec().Directed().Props, Commentf("%s", text))
+	}
 }
@@ -32,7 +32,7 @@ func Search(g gogl.Graph, target gogl.Vertex, start gogl.Vertex) (path []gogl.Ve
 	w := walker{
 		vis:    visitor,
 		g:      g,
-		colors: make(map[gogl.Vertex]uint),
+		colors: make(map[interface{}]uint),
 		target: target,
 	}
 
@@ -106,7 +106,7 @@ func Toposort(g gogl.Graph, start ...gogl.Vertex) ([]gogl.Vertex, error) {
 	w := &walker{
 		vis:    visitor,
 		g:      g,
-		colors: make(map[gogl.Vertex]uint),
+		colors: make(map[interface{}]uint),
 	}
 
 	var traverser func(*walker, gogl.Vertex)
@@ -144,7 +144,7 @@ func Traverse(g gogl.Graph, visitor Visitor, start ...gogl.Vertex) (Visitor, err
 	w := &walker{
 		vis:    visitor,
 		g:      g,
-		colors: make(map[gogl.Vertex]uint),
+		colors: make(map[interface{}]uint),
 	}
 
 	if dg, ok := g.(gogl.Digraph); ok {
@@ -168,12 +168,12 @@ func FindSources(g gogl.Digraph) (sources []gogl.Vertex, err error) {
 	incomings := set.NewNonTS()
 
 	g.Arcs(func(e gogl.Arc) (terminate bool) {
-		incomings.Add(e.Target())
+		incomings.Add(gogl.VertexKey(e.Target()))
 		return
 	})
 
 	g.Vertices(func(v gogl.Vertex) (terminate bool) {
-		if !incomings.Has(v) {
+		if !incomings.Has(gogl.VertexKey(v)) {
 			sources = append(sources, v)
 		}
 		return
@@ -343,12 +343,13 @@ type walker struct {
 	complete bool
 	target   gogl.Vertex
 	// TODO is there ANY way to do this more efficiently without mutating/coloring the vertex objects directly? this means lots of hashtable lookups
-	colors map[gogl.Vertex]uint
+	colors map[interface{}]uint // keyed by gogl.VertexKey
 	ll     linkedlist
 }
 
 func (w *walker) dftraverse(v gogl.Vertex) {
-	color, exists := w.colors[v]
+	key := gogl.VertexKey(v)
+	color, exists := w.colors[key]
 	if !exists {
 		color = white
 	}
@@ -356,7 +357,7 @@ func (w *walker) dftraverse(v gogl.Vertex) {
 	if color == grey {
 		w.vis.OnBackEdge(v)
 	} else if color == white {
-		w.colors[v] = grey
+		w.colors[key] = grey
 		w.vis.OnStartVertex(v)
 
 		w.dg.ArcsFrom(v, func(e gogl.Arc) (terminate bool) {
@@ -366,18 +367,19 @@ func (w *walker) dftraverse(v gogl.Vertex) {
 		})
 
 		w.vis.OnFinishVertex(v)
-		w.colors[v] = black
+		w.colors[key] = black
 	}
 }
 
 func (w *walker) dfsearch(v gogl.Vertex) {
-	if v == w.target {
+	key := gogl.VertexKey(v)
+	if key == gogl.VertexKey(w.target) {
 		w.complete = true
 		w.vis.OnStartVertex(v)
 		return
 	}
 
-	color, exists := w.colors[v]
+	color, exists := w.colors[key]
 	if !exists {
 		color = white
 	}
@@ -385,7 +387,7 @@ func (w *walker) dfsearch(v gogl.Vertex) {
 	if color == grey {
 		w.vis.OnBackEdge(v)
 	} else if color == white {
-		w.colors[v] = grey
+		w.colors[key] = grey
 		w.vis.OnStartVertex(v)
 
 		w.dg.ArcsFrom(v, func(e gogl.Arc) bool {
@@ -402,19 +404,20 @@ func (w *walker) dfsearch(v gogl.Vertex) {
 		}
 
 		w.vis.OnFinishVertex(v)
-		w.colors[v] = black
+		w.colors[key] = black
 	}
 }
 
 func (w *walker) dfutraverse(v gogl.Vertex) {
-	if _, exists := w.colors[v]; !exists {
-		w.colors[v] = grey
+	key := gogl.VertexKey(v)
+	if _, exists := w.colors[key]; !exists {
+		w.colors[key] = grey
 		w.vis.OnStartVertex(v)
 
 		w.g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
 			w.vis.OnExamineEdge(e)
 			v1, v2 := e.Both()
-			if v == v1 {
+			if key == gogl.VertexKey(v1) {
 				w.dfutraverse(v2)
 			} else {
 				w.dfutraverse(v1)
@@ -423,6 +426,6 @@ func (w *walker) dfutraverse(v gogl.Vertex) {
 		})
 
 		w.vis.OnFinishVertex(v)
-		w.colors[v] = black
+		w.colors[key] = black
 	}
 }
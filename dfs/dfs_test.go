@@ -107,6 +107,43 @@ func (s *DepthFirstSearchSuite) TestToposort(c *C) {
 	c.Assert(tsl, DeepEquals, []gogl.Vertex{"qux", "baz", "bar", "foo"})
 }
 
+// A vertex that is not comparable, but is Identifiable.
+type step struct {
+	name string
+	deps []string
+}
+
+func (s step) ID() string { return s.name }
+
+func (s *DepthFirstSearchSuite) TestIdentifiableVertices(c *C) {
+	fetch, build, test := step{"fetch", nil}, step{"build", []string{"fetch"}}, step{"test", []string{"build"}}
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(fetch, build),
+		gogl.NewArc(build, test),
+	}).Create(al.G).(gogl.Digraph)
+
+	sources, err := FindSources(g)
+	c.Assert(err, IsNil)
+	c.Assert(sources, HasLen, 1)
+	c.Assert(sources[0].(step).name, Equals, "fetch")
+
+	tsl, err := Toposort(g)
+	c.Assert(err, IsNil)
+	c.Assert(tsl, HasLen, 3)
+	for i, name := range []string{"test", "build", "fetch"} {
+		c.Assert(tsl[i].(step).name, Equals, name)
+	}
+
+	path, err := Search(g, step{name: "test"}, step{name: "fetch"})
+	c.Assert(err, IsNil)
+	c.Assert(path, HasLen, 3)
+
+	// A cycle is still found, though no two of its vertices are ==.
+	g.(gogl.MutableDigraph).AddArcs(gogl.NewArc(test, step{name: "fetch"}))
+	_, err = Toposort(g, fetch)
+	c.Assert(err, ErrorMatches, "Cycle detected in graph")
+}
+
 // This is a bit wackyhacky, but works well enough
 var _ = Suite(&TestVisitor{})
 
@@ -183,3 +183,25 @@ func (e baseDataArc) Data() interface{} {
 func NewDataArc(u, v Vertex, data interface{}) DataArc {
 	return baseDataArc{baseArc{baseEdge{u: u, v: v}}, data}
 }
+
+// Returns an edge of the same kind as the given one, with the same weight, label
+// or data, but joining the vertices u and v instead (from u to v, for arcs).
+func withEndpoints(e Edge, u, v Vertex) Edge {
+	switch e := e.(type) {
+	case WeightedArc:
+		return NewWeightedArc(u, v, e.Weight())
+	case LabeledArc:
+		return NewLabeledArc(u, v, e.Label())
+	case DataArc:
+		return NewDataArc(u, v, e.Data())
+	case WeightedEdge:
+		return NewWeightedEdge(u, v, e.Weight())
+	case LabeledEdge:
+		return NewLabeledEdge(u, v, e.Label())
+	case DataEdge:
+		return NewDataEdge(u, v, e.Data())
+	case Arc:
+		return NewArc(u, v)
+	}
+	return NewEdge(u, v)
+}
@@ -1,19 +1,17 @@
 package gogl
 
-import (
-	"gopkg.in/fatih/set.v0"
-)
-
 // Shared helper function for edge lists to enumerate vertices.
 func elVertices(el interface{}, fn VertexStep) {
-	set := set.NewNonTS()
+	vertices := make(map[interface{}]Vertex)
 
 	el.(EdgeEnumerator).Edges(func(e Edge) (terminate bool) {
-		set.Add(e.Both())
+		u, v := e.Both()
+		vertices[VertexKey(u)] = u
+		vertices[VertexKey(v)] = v
 		return
 	})
 
-	for _, v := range set.List() {
+	for _, v := range vertices {
 		if fn(v) {
 			return
 		}
@@ -185,3 +183,122 @@ func (el DataArcList) Arcs(fn ArcStep) {
 		}
 	}
 }
+
+// An AdjacencyMap is a GraphSource (and DigraphSource) implementation backed by a map
+// from each vertex to the vertices it is adjacent to.
+//
+// AdjacencyMaps are primarily intended for writing fixtures by hand. Unlike edge lists,
+// they can represent vertex isolates, by mapping a vertex to an empty slice.
+//
+// As a DigraphSource, each listing is an arc from the key to the listed vertex. As an
+// undirected GraphSource, an edge may be listed from either side or both; it is only
+// enumerated once. Listed vertices are told apart by VertexKey, so they may be
+// Identifiable vertices that are not comparable; the keys, of course, may not.
+type AdjacencyMap map[Vertex][]Vertex
+
+func (m AdjacencyMap) Vertices(fn VertexStep) {
+	seen := make(map[interface{}]struct{}, len(m)) // keyed by VertexKey
+	visit := func(v Vertex) bool {
+		k := VertexKey(v)
+		if _, exists := seen[k]; exists {
+			return false
+		}
+		seen[k] = struct{}{}
+		return fn(v)
+	}
+
+	for u, adj := range m {
+		if visit(u) {
+			return
+		}
+		for _, v := range adj {
+			if visit(v) {
+				return
+			}
+		}
+	}
+}
+
+func (m AdjacencyMap) Edges(fn EdgeStep) {
+	type pair struct{ u, v interface{} } // keyed by VertexKey
+	seen := make(map[pair]struct{})
+
+	for u, adj := range m {
+		uk := VertexKey(u)
+		for _, v := range adj {
+			vk := VertexKey(v)
+			if _, exists := seen[pair{vk, uk}]; exists {
+				continue
+			}
+			if _, exists := seen[pair{uk, vk}]; exists {
+				continue
+			}
+			seen[pair{uk, vk}] = struct{}{}
+
+			if fn(NewEdge(u, v)) {
+				return
+			}
+		}
+	}
+}
+
+func (m AdjacencyMap) Arcs(fn ArcStep) {
+	for u, adj := range m {
+		for _, v := range adj {
+			if fn(NewArc(u, v)) {
+				return
+			}
+		}
+	}
+}
+
+// A VertexEdgeList is a GraphSource implementation backed by a vertex slice and an edge
+// slice, each enumerated in the order given.
+//
+// Unlike the edge lists, it can represent vertex isolates, which makes it a convenient
+// way to hand a graph that has been read or computed piecemeal to a GraphSpec. Its
+// vertices should include every endpoint of its edges. It is not a DigraphSource, even
+// if its edges are Arcs; for that, use a VertexArcList.
+type VertexEdgeList struct {
+	V []Vertex
+	E []Edge
+}
+
+func (l VertexEdgeList) Vertices(fn VertexStep) {
+	for _, v := range l.V {
+		if fn(v) {
+			return
+		}
+	}
+}
+
+func (l VertexEdgeList) Edges(fn EdgeStep) {
+	for _, e := range l.E {
+		if fn(e) {
+			return
+		}
+	}
+}
+
+// A VertexArcList is a DigraphSource implementation backed by a vertex slice and an edge
+// slice, as a VertexEdgeList, but every edge must be an Arc.
+type VertexArcList struct {
+	V []Vertex
+	E []Edge
+}
+
+func (l VertexArcList) Vertices(fn VertexStep) {
+	VertexEdgeList(l).Vertices(fn)
+}
+
+func (l VertexArcList) Edges(fn EdgeStep) {
+	VertexEdgeList(l).Edges(fn)
+}
+
+func (l VertexArcList) Arcs(fn ArcStep) {
+	for _, e := range l.E {
+		if fn(e.(Arc)) {
+			return
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package gogl_test
 
 import (
+	"sort"
+
 	. "github.com/sdboyer/gocheck"
 	. "github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
 	"github.com/sdboyer/gogl/spec"
 	"gopkg.in/fatih/set.v0"
 )
@@ -232,3 +235,82 @@ func (s *ArcSuite) TestArcs(c *C) {
 	c.Assert(a, Equals, "a")
 	c.Assert(b, Equals, "b")
 }
+
+type VertexEdgeListSuite struct{}
+
+var _ = Suite(&VertexEdgeListSuite{})
+
+func (s *VertexEdgeListSuite) TestEnumeration(c *C) {
+	l := VertexEdgeList{
+		V: []Vertex{"a", "b", "isolate"},
+		E: []Edge{NewEdge("a", "b")},
+	}
+
+	c.Assert(CollectVertices(l), DeepEquals, []Vertex{"a", "b", "isolate"})
+	c.Assert(CollectEdges(l), DeepEquals, []Edge{NewEdge("a", "b")})
+
+	_, directed := interface{}(l).(DigraphSource)
+	c.Assert(directed, Equals, false)
+
+	g := Spec().Using(l).Create(al.G)
+	c.Assert(Order(g), Equals, 3)
+	c.Assert(Size(g), Equals, 1)
+}
+
+func (s *VertexEdgeListSuite) TestArcs(c *C) {
+	l := VertexArcList{
+		V: []Vertex{"a", "b", "c"},
+		E: []Edge{NewArc("a", "b"), NewArc("b", "c")},
+	}
+
+	var arcs []Arc
+	l.Arcs(func(a Arc) (terminate bool) {
+		arcs = append(arcs, a)
+		return true
+	})
+	c.Assert(arcs, DeepEquals, []Arc{NewArc("a", "b")})
+
+	g := Spec().Directed().Using(l).Create(al.G).(Digraph)
+	c.Assert(Order(g), Equals, 3)
+	c.Assert(g.HasArc(NewArc("b", "c")), Equals, true)
+	c.Assert(g.HasArc(NewArc("b", "a")), Equals, false)
+}
+
+type AdjacencyMapSuite struct{}
+
+var _ = Suite(&AdjacencyMapSuite{})
+
+// An Identifiable vertex that is not comparable, so can only be listed, not a key.
+type listedVertex struct {
+	name  string
+	attrs map[string]int
+}
+
+func (v listedVertex) ID() string { return v.name }
+
+func (s *AdjacencyMapSuite) TestIdentifiable(c *C) {
+	x := func() Vertex { return listedVertex{"x", map[string]int{}} }
+	m := AdjacencyMap{
+		"a": {x(), listedVertex{"y", nil}},
+		"b": {x()},
+		"c": {},
+	}
+
+	var names []string
+	m.Vertices(func(v Vertex) (terminate bool) {
+		if lv, ok := v.(listedVertex); ok {
+			names = append(names, lv.name)
+		} else {
+			names = append(names, v.(string))
+		}
+		return
+	})
+	sort.Strings(names)
+	c.Assert(names, DeepEquals, []string{"a", "b", "c", "x", "y"})
+	c.Assert(CollectEdges(m), HasLen, 3)
+
+	g := Spec().Using(m).Create(al.G)
+	c.Assert(Order(g), Equals, 5)
+	c.Assert(Size(g), Equals, 3)
+	c.Assert(g.HasEdge(NewEdge(x(), "b")), Equals, true)
+}
@@ -70,6 +70,40 @@ type DigraphSource interface {
 	ArcEnumerator
 }
 
+// MixedGraph describes a Graph that may contain both undirected edges and arcs,
+// such as a road network with both two-way and one-way segments.
+//
+// As gogl otherwise treats directedness as a property of the whole graph, a
+// MixedGraph is deliberately not a Digraph: it cannot be transposed. Its Edges()
+// enumerator passes every connection, undirected edges as Edges and arcs as Arcs,
+// so that a type assertion tells them apart; UndirectedEdges() and Arcs() each
+// pass only their own kind. Likewise, HasEdge() reports a connection of either
+// kind, while HasUndirectedEdge() and HasArc() are specific.
+//
+// The ProcessionEnumerator methods treat an undirected edge as leading both
+// ways, so an algorithm that follows only SuccessorsOf() traverses a MixedGraph
+// as it should be travelled. ArcsFrom(), ArcsTo(), InDegreeOf() and OutDegreeOf()
+// consider arcs alone.
+type MixedGraph interface {
+	Graph
+	ArcEnumerator                   // Enumerates only arcs to an injected step function
+	UndirectedEdgeEnumerator        // Enumerates only undirected edges to an injected step function
+	IncidentArcEnumerator           // Enumerates a vertex's incident in- or out-arcs to an injected step function
+	ProcessionEnumerator            // Enumerates the vertices reachable from, or leading to, a vertex in one step
+	DirectedDegreeChecker           // Reports in- and out-degree of vertices, counting only arcs
+	ArcMembershipChecker            // Allows inspection of contained arcs
+	UndirectedEdgeMembershipChecker // Allows inspection of contained undirected edges
+}
+
+// MutableMixedGraph describes a mixed graph with basic edges and arcs that can be
+// modified freely by adding or removing vertices, undirected edges, or arcs.
+type MutableMixedGraph interface {
+	MixedGraph
+	VertexSetMutator
+	EdgeSetMutator
+	ArcSetMutator
+}
+
 // MutableGraph describes a graph with basic edges (no weighting, labeling, etc.)
 // that can be modified freely by adding or removing vertices or edges.
 type MutableGraph interface {
@@ -86,6 +120,29 @@ type MutableDigraph interface {
 	ArcSetMutator
 }
 
+// PersistentGraph describes an undirected graph with basic edges that is never
+// modified in place. Instead, each of its mutators returns a new version of the
+// graph with the change applied, sharing what did not change with the version it
+// came from. Every version remains valid and unchanged for as long as it is held,
+// so versions may be kept as cheap snapshots, and read concurrently without locks.
+type PersistentGraph interface {
+	Graph
+	EnsureVertex(vertices ...Vertex) PersistentGraph
+	RemoveVertex(vertices ...Vertex) PersistentGraph
+	AddEdges(edges ...Edge) PersistentGraph
+	RemoveEdges(edges ...Edge) PersistentGraph
+}
+
+// PersistentDigraph describes a digraph with basic arcs that is never modified in
+// place; its mutators return new versions of the digraph, as for PersistentGraph.
+type PersistentDigraph interface {
+	Digraph
+	EnsureVertex(vertices ...Vertex) PersistentDigraph
+	RemoveVertex(vertices ...Vertex) PersistentDigraph
+	AddArcs(arcs ...Arc) PersistentDigraph
+	RemoveArcs(arcs ...Arc) PersistentDigraph
+}
+
 // A simple graph is in opposition to a multigraph or pseudograph: it disallows loops and
 // parallel edges.
 type SimpleGraph interface {
@@ -238,6 +295,14 @@ type ArcEnumerator interface {
 	Arcs(ArcStep)
 }
 
+// An UndirectedEdgeEnumerator iteratively enumerates only the undirected edges of
+// a graph that may also contain arcs.
+type UndirectedEdgeEnumerator interface {
+	// Calls the provided step function once with each undirected edge in the
+	// graph, passing over any arcs.
+	UndirectedEdges(EdgeStep)
+}
+
 // An IncidentEdgeEnumerator iteratively enumerates a given vertex's incident edges.
 type IncidentEdgeEnumerator interface {
 	// Calls the provided step function once with each edge incident to the
@@ -299,6 +364,12 @@ type ArcMembershipChecker interface {
 	HasArc(Arc) bool
 }
 
+// An UndirectedEdgeMembershipChecker can indicate the presence of an undirected
+// edge, as distinct from an arc, in a graph that may contain both.
+type UndirectedEdgeMembershipChecker interface {
+	HasUndirectedEdge(Edge) bool
+}
+
 // A VertexSetMutator allows the addition and removal of vertices from a set.
 type VertexSetMutator interface {
 	// Ensures the provided vertices are present in the graph.
@@ -376,12 +447,26 @@ type VertexCounter interface {
 	Order() int
 }
 
+// A VertexCountHinter can report how many vertices it will enumerate, so that
+// callers may size their data structures in advance.
+//
+// Unlike VertexCounter, it may decline to give an exact answer: the second return
+// value is true only if the count is guaranteed exact. Implementations backed by
+// generators, which may not be able to guarantee their vertex set, return false.
+type VertexCountHinter interface {
+	VertexCount() (count int, exact bool)
+}
+
 // An EdgeCounter provides a numeric count of the number of unique edges in a graph.
 type EdgeCounter interface {
 	Size() int
 }
 
 // A Transposer produces a transposed version of a Digraph.
+//
+// There is no in-place counterpart. The adjacency lists store only each vertex's
+// outbound arcs, so reversing them in place would cost as much as building the
+// transposed copy; to reverse a graph, use Transpose.
 type Transposer interface {
 	Transpose() Digraph
 }
@@ -46,6 +46,44 @@ var alCreators = map[GraphProperties]func() Graph{
 	GraphProperties(G_MUTABLE | G_UNDIRECTED | G_DATA | G_SIMPLE): func() Graph {
 		return &dataUndirected{baseData{list: make(map[Vertex]map[Vertex]interface{}), size: 0, mu: sync.RWMutex{}}}
 	},
+
+	// Loop-permitting variants. These are identical to the simple graphs, except
+	// that an edge from a vertex to itself is stored rather than dropped.
+	GraphProperties(G_IMMUTABLE | G_DIRECTED | G_BASIC | G_LOOPS): func() Graph {
+		return &immutableDirected{al_basic_immut{al_basic{list: make(map[Vertex]map[Vertex]struct{}), loops: true}}}
+	},
+	GraphProperties(G_MUTABLE | G_DIRECTED | G_BASIC | G_LOOPS): func() Graph {
+		return &mutableDirected{al_basic_mut{al_basic{list: make(map[Vertex]map[Vertex]struct{}), loops: true}, sync.RWMutex{}}}
+	},
+	GraphProperties(G_MUTABLE | G_UNDIRECTED | G_BASIC | G_LOOPS): func() Graph {
+		return &mutableUndirected{al_basic_mut{al_basic{list: make(map[Vertex]map[Vertex]struct{}), loops: true}, sync.RWMutex{}}}
+	},
+	GraphProperties(G_MUTABLE | G_DIRECTED | G_WEIGHTED | G_LOOPS): func() Graph {
+		return &weightedDirected{baseWeighted{list: make(map[Vertex]map[Vertex]float64), size: 0, mu: sync.RWMutex{}, loops: true}}
+	},
+	GraphProperties(G_MUTABLE | G_UNDIRECTED | G_WEIGHTED | G_LOOPS): func() Graph {
+		return &weightedUndirected{baseWeighted{list: make(map[Vertex]map[Vertex]float64), size: 0, mu: sync.RWMutex{}, loops: true}}
+	},
+	GraphProperties(G_MUTABLE | G_DIRECTED | G_LABELED | G_LOOPS): func() Graph {
+		return &labeledDirected{baseLabeled{list: make(map[Vertex]map[Vertex]string), size: 0, mu: sync.RWMutex{}, loops: true}}
+	},
+	GraphProperties(G_MUTABLE | G_UNDIRECTED | G_LABELED | G_LOOPS): func() Graph {
+		return &labeledUndirected{baseLabeled{list: make(map[Vertex]map[Vertex]string), size: 0, mu: sync.RWMutex{}, loops: true}}
+	},
+	GraphProperties(G_MUTABLE | G_DIRECTED | G_DATA | G_LOOPS): func() Graph {
+		return &dataDirected{baseData{list: make(map[Vertex]map[Vertex]interface{}), size: 0, mu: sync.RWMutex{}, loops: true}}
+	},
+	GraphProperties(G_MUTABLE | G_UNDIRECTED | G_DATA | G_LOOPS): func() Graph {
+		return &dataUndirected{baseData{list: make(map[Vertex]map[Vertex]interface{}), size: 0, mu: sync.RWMutex{}, loops: true}}
+	},
+
+	// Mixed graphs, holding both undirected edges and arcs.
+	GraphProperties(G_MUTABLE | G_DIRECTED | G_UNDIRECTED | G_BASIC | G_SIMPLE): func() Graph {
+		return &mutableMixed{list: make(map[Vertex]map[Vertex]mixedLink)}
+	},
+	GraphProperties(G_MUTABLE | G_DIRECTED | G_UNDIRECTED | G_BASIC | G_LOOPS): func() Graph {
+		return &mutableMixed{list: make(map[Vertex]map[Vertex]mixedLink), loops: true}
+	},
 }
 
 // Create a graph implementation in the adjacency list style from the provided GraphSpec.
@@ -54,12 +92,24 @@ var alCreators = map[GraphProperties]func() Graph{
 // If the GraphSpec indicates a graph type that is not currently implemented, this function
 // will panic.
 func G(gs GraphSpec) Graph {
+	// Persistent specs carry the mutable flag, too, but no adjacency list is persistent.
+	if gs.Props&G_PERSISTENT == G_PERSISTENT {
+		panic("No graph implementation found for spec")
+	}
+
+	mixed := gs.Props&(G_DIRECTED|G_UNDIRECTED) == G_DIRECTED|G_UNDIRECTED
 	for gp, gf := range alCreators {
+		// A mixed spec would satisfy the directed and undirected creators, too.
+		if mixed && gp&(G_DIRECTED|G_UNDIRECTED) != G_DIRECTED|G_UNDIRECTED {
+			continue
+		}
 
 		// TODO satisfiability here is not so narrow
 		if gp&^gs.Props == 0 {
 			if gs.Source != nil {
-				if gs.Props&G_DIRECTED == G_DIRECTED {
+				if mixed {
+					return functorToMixedAdjacencyList(gs.Source, gf().(*mutableMixed))
+				} else if gs.Props&G_DIRECTED == G_DIRECTED {
 					if dgs, ok := gs.Source.(DigraphSource); ok {
 						return functorToDirectedAdjacencyList(dgs, gf().(al_digraph))
 					} else {
@@ -77,9 +127,26 @@ func G(gs GraphSpec) Graph {
 	panic("No graph implementation found for spec")
 }
 
+// Create a basic, mutable adjacency list graph from the provided adjacency map, which
+// maps each vertex to the vertices it is adjacent to.
+//
+// If directed is true, a MutableDigraph is returned, with an arc for each listing.
+// Otherwise, a MutableGraph is returned; an edge may be listed from either or both of
+// its vertices. Vertices mapped to an empty slice become isolates.
+func FromAdjacencyMap(m map[Vertex][]Vertex, directed bool) Graph {
+	spec := Spec().Using(AdjacencyMap(m))
+	if directed {
+		spec = spec.Directed()
+	}
+
+	return G(spec)
+}
+
 type al_basic struct {
-	list map[Vertex]map[Vertex]struct{}
-	size int
+	vertexKeys
+	list  map[Vertex]map[Vertex]struct{}
+	size  int
+	loops bool // whether self-loops are permitted; if not, they are silently dropped
 }
 
 // Helper to not have to write struct{} everywhere.
@@ -87,7 +154,7 @@ var keyExists = struct{}{}
 
 // Indicates whether or not the given vertex is present in the graph.
 func (g *al_basic) hasVertex(vertex Vertex) (exists bool) {
-	_, exists = g.list[vertex]
+	_, exists = g.list[g.key(vertex)]
 	return
 }
 
@@ -100,15 +167,74 @@ func (g *al_basic) Size() int {
 // already present in the graph, it is a no-op (for that vertex only).
 func (g *al_basic) ensureVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
-		if !g.hasVertex(vertex) {
+		if key := g.keep(vertex); !g.hasVertex(key) {
 			// TODO experiment with different lengths...possibly by analyzing existing density?
-			g.list[vertex] = make(map[Vertex]struct{}, 10)
+			g.list[key] = make(map[Vertex]struct{}, 10)
 		}
 	}
 
 	return
 }
 
+// Below this many edges, the bookkeeping done by presize costs more than it saves.
+const presizeThreshold = 64
+
+// Prepares the adjacency list to receive a batch of n edges, whose endpoints are
+// enumerated by each.
+//
+// Vertices not yet in the graph are created with their adjacency maps sized to
+// the number of edges they receive in the batch, and the vertex map itself is
+// grown in a single step. This avoids the repeated incremental rehashing that
+// otherwise dominates the cost of bulk loading. If symmetric is true (undirected
+// graphs), both endpoints of each edge are counted; otherwise, only the first.
+// Self-loops the graph would drop are skipped, so that, as with smaller batches,
+// they add no vertices.
+func (g *al_basic) presize(n int, symmetric bool, each func(f func(u, v Vertex))) {
+	if n < presizeThreshold {
+		return
+	}
+
+	counts := make(map[Vertex]int, n)
+	each(func(u, v Vertex) {
+		if !g.loops && g.key(u) == g.key(v) {
+			return
+		}
+
+		u, v = g.keep(u), g.keep(v)
+		counts[u]++
+		if symmetric {
+			counts[v]++
+		} else if _, exists := counts[v]; !exists {
+			counts[v] = 0
+		}
+	})
+
+	var added int
+	for v := range counts {
+		if !g.hasVertex(v) {
+			added++
+		}
+	}
+
+	// A map can't be grown in place, so reallocate - but only if that at least
+	// doubles it, as copying the existing entries is not free.
+	if len(g.list) == 0 {
+		g.list = make(map[Vertex]map[Vertex]struct{}, added)
+	} else if added > len(g.list) {
+		list := make(map[Vertex]map[Vertex]struct{}, len(g.list)+added)
+		for v, adj := range g.list {
+			list[v] = adj
+		}
+		g.list = list
+	}
+
+	for v, c := range counts {
+		if !g.hasVertex(v) {
+			g.list[v] = make(map[Vertex]struct{}, c)
+		}
+	}
+}
+
 type al_basic_immut struct {
 	al_basic
 }
@@ -117,7 +243,7 @@ type al_basic_immut struct {
 // provided closure.
 func (g *al_basic_immut) Vertices(f VertexStep) {
 	for v := range g.list {
-		if f(v) {
+		if f(g.vertex(v)) {
 			return
 		}
 	}
@@ -133,6 +259,11 @@ func (g *al_basic_immut) Order() int {
 	return len(g.list)
 }
 
+// Returns the number of vertices in the graph. The count is always exact.
+func (g *al_basic_immut) VertexCount() (count int, exact bool) {
+	return len(g.list), true
+}
+
 type al_basic_mut struct {
 	al_basic
 	mu sync.RWMutex
@@ -147,7 +278,7 @@ func (g *al_basic_mut) Vertices(f VertexStep) {
 	defer g.mu.RUnlock()
 
 	for v := range g.list {
-		if f(v) {
+		if f(g.vertex(v)) {
 			return
 		}
 	}
@@ -170,6 +301,14 @@ func (g *al_basic_mut) Order() int {
 	return len(g.list)
 }
 
+// Returns the number of vertices in the graph. The count is always exact.
+func (g *al_basic_mut) VertexCount() (count int, exact bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.list), true
+}
+
 // Adds the provided vertices to the graph. If a provided vertex is
 // already present in the graph, it is a no-op (for that vertex only).
 func (g *al_basic_mut) EnsureVertex(vertices ...Vertex) {
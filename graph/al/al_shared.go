@@ -6,6 +6,82 @@ import (
 
 // Contains behaviors shared across adjacency list implementations.
 
+// Adjacency lists store each vertex under its VertexKey, so that Identifiable
+// vertices need not be comparable. vertexKeys records the vertices stored under
+// some other key, so they can be handed back out as they were given; every other
+// vertex is its own key, and is not recorded.
+//
+// Vertices coming into a graph's methods must be converted with key() (or keep(),
+// when they are to be stored) before use, and keys going out must be converted
+// back with vertex().
+type vertexKeys struct {
+	ids map[Vertex]Vertex
+}
+
+// Returns the key under which the given vertex is stored. Keys are their own keys.
+func (k *vertexKeys) key(v Vertex) Vertex {
+	return VertexKey(v)
+}
+
+// Returns the keys under which the given pair of vertices are stored.
+func (k *vertexKeys) keys(u, v Vertex) (Vertex, Vertex) {
+	return VertexKey(u), VertexKey(v)
+}
+
+// Returns the key under which the given vertex is stored, recording the vertex
+// if it is stored under another and none with its key has been recorded yet.
+func (k *vertexKeys) keep(v Vertex) Vertex {
+	key := VertexKey(v)
+	if _, ok := v.(Identifiable); ok {
+		if k.ids == nil {
+			k.ids = make(map[Vertex]Vertex)
+		}
+		if _, exists := k.ids[key]; !exists {
+			k.ids[key] = v
+		}
+	}
+	return key
+}
+
+// Returns the vertex stored under the given key.
+func (k *vertexKeys) vertex(key Vertex) Vertex {
+	if len(k.ids) == 0 {
+		return key
+	}
+	if v, exists := k.ids[key]; exists {
+		return v
+	}
+	return key
+}
+
+// Wraps the given step function, which expects vertices, to accept keys.
+func (k *vertexKeys) vertexStep(f VertexStep) VertexStep {
+	if len(k.ids) == 0 {
+		return f
+	}
+	return func(key Vertex) bool {
+		return f(k.vertex(key))
+	}
+}
+
+// Drops the record, if any, of the vertex stored under the given key.
+func (k *vertexKeys) forget(key Vertex) {
+	delete(k.ids, key)
+}
+
+// Returns a copy of the records, for a new graph over the same vertices.
+func (k *vertexKeys) clone() vertexKeys {
+	if len(k.ids) == 0 {
+		return vertexKeys{}
+	}
+
+	ids := make(map[Vertex]Vertex, len(k.ids))
+	for key, v := range k.ids {
+		ids[key] = v
+	}
+	return vertexKeys{ids}
+}
+
 type al_graph interface {
 	Graph
 	ensureVertex(...Vertex)
@@ -74,10 +150,13 @@ func functorToAdjacencyList(from GraphSource, to al_graph) Graph {
 	}
 
 	if g, ok := to.(al_ea); ok {
+		// Gather the edges so they are added as one batch, which lets the graph presize.
+		var edges []Edge
 		from.Edges(func(edge Edge) (terminate bool) {
-			g.addEdges(edge)
+			edges = append(edges, edge)
 			return
 		})
+		g.addEdges(edges...)
 		vf(from, g)
 	} else if g, ok := to.(al_wea); ok {
 		from.Edges(func(edge Edge) (terminate bool) {
@@ -141,10 +220,13 @@ func functorToDirectedAdjacencyList(from DigraphSource, to al_digraph) Digraph {
 	}
 
 	if g, ok := to.(al_dea); ok {
+		// Gather the arcs so they are added as one batch, which lets the graph presize.
+		var arcs []Arc
 		from.Arcs(func(arc Arc) (terminate bool) {
-			g.addArcs(arc)
+			arcs = append(arcs, arc)
 			return
 		})
+		g.addArcs(arcs...)
 		vf(from, g)
 	} else if g, ok := to.(al_dwea); ok {
 		from.Arcs(func(arc Arc) (terminate bool) {
@@ -281,7 +363,7 @@ func eachPredecessorOf(list interface{}, vertex Vertex, vs VertexStep) {
 func inDegreeOf(g al_digraph, v Vertex) (degree int, exists bool) {
 	if exists = g.hasVertex(v); exists {
 		g.Arcs(func(e Arc) (terminate bool) {
-			if v == e.Target() {
+			if VertexKey(v) == VertexKey(e.Target()) {
 				degree++
 			}
 			return
@@ -1,9 +1,12 @@
 package al
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
 	"github.com/sdboyer/gogl/spec"
 )
 
@@ -15,3 +18,235 @@ func init() {
 		spec.SetUpTestsFromSpec(gp, G)
 	}
 }
+
+// 100k edges spread across 20k vertices.
+func bulkEdges() []Edge {
+	edges := make([]Edge, 100000)
+	for i := range edges {
+		edges[i] = NewEdge(i%20000, (i*7919+1)%20000)
+	}
+	return edges
+}
+
+func TestBatchAddEdgesAllocs(t *testing.T) {
+	edges := bulkEdges()
+	spec := Spec()
+
+	batched := testing.AllocsPerRun(3, func() {
+		G(spec).(MutableGraph).AddEdges(edges...)
+	})
+	single := testing.AllocsPerRun(3, func() {
+		g := G(spec).(MutableGraph)
+		for _, e := range edges {
+			g.AddEdges(e)
+		}
+	})
+
+	if batched >= single {
+		t.Errorf("Batch AddEdges made %v allocations, versus %v for single-edge calls; expected fewer.", batched, single)
+	}
+
+	// Presizing must not change the outcome.
+	a, b := G(spec).(MutableGraph), G(spec).(MutableGraph)
+	a.AddEdges(edges...)
+	for _, e := range edges {
+		b.AddEdges(e)
+	}
+	if Size(a) != Size(b) || Order(a) != Order(b) {
+		t.Errorf("Batched graph has order %v and size %v; single-edge graph has %v and %v.", Order(a), Size(a), Order(b), Size(b))
+	}
+	b.Edges(func(e Edge) (terminate bool) {
+		if !a.HasEdge(e) {
+			t.Errorf("Batched graph is missing edge %v.", e)
+			return true
+		}
+		return
+	})
+
+	// Re-adding the same batch is a no-op.
+	a.AddEdges(edges...)
+	if Size(a) != Size(b) {
+		t.Errorf("Re-adding edges changed size from %v to %v.", Size(b), Size(a))
+	}
+}
+
+func TestFromAdjacencyMap(t *testing.T) {
+	m := map[Vertex][]Vertex{
+		"a": {"b", "c"},
+		"b": {"a"}, // a-b is listed from both sides
+		"c": {"d"}, // c-d only from one
+		"e": {},
+	}
+
+	g := FromAdjacencyMap(m, false)
+	if _, ok := g.(MutableGraph); !ok {
+		t.Fatalf("Expected a MutableGraph, got %T.", g)
+	}
+	if Order(g) != 5 {
+		t.Errorf("Expected order 5, got %v.", Order(g))
+	}
+	if Size(g) != 3 {
+		t.Errorf("Expected size 3, got %v.", Size(g))
+	}
+	for _, e := range []Edge{NewEdge("a", "b"), NewEdge("c", "a"), NewEdge("d", "c")} {
+		if !g.HasEdge(e) {
+			t.Errorf("Missing edge %v.", e)
+		}
+	}
+	if !g.HasVertex("e") {
+		t.Errorf("Isolate e was not created.")
+	}
+
+	dg, ok := FromAdjacencyMap(m, true).(MutableDigraph)
+	if !ok {
+		t.Fatalf("Expected a MutableDigraph.")
+	}
+	if Size(dg) != 4 {
+		t.Errorf("Expected size 4, got %v.", Size(dg))
+	}
+	for u, adj := range m {
+		for _, v := range adj {
+			if !dg.(Digraph).HasArc(NewArc(u, v)) {
+				t.Errorf("Missing arc %v -> %v.", u, v)
+			}
+		}
+	}
+	if dg.(Digraph).HasArc(NewArc("d", "c")) {
+		t.Errorf("Unlisted arc d -> c should not exist.")
+	}
+}
+
+// An Identifiable vertex that is not comparable, by virtue of its slice.
+type room struct {
+	name  string
+	doors []string
+}
+
+func (r room) ID() string { return r.name }
+
+func TestIdentifiableVertices(t *testing.T) {
+	hall, kitchen, study := room{"hall", []string{"front"}}, room{"kitchen", []string{"back"}}, room{"study", nil}
+	arcs := ArcList{NewArc(hall, kitchen), NewArc(hall, study)}
+
+	for gp := range alCreators {
+		g := G(GraphSpec{Props: gp, Source: arcs})
+
+		if Order(g) != 3 || Size(g) != 2 {
+			t.Errorf("%T: expected order 3 and size 2, got %v and %v.", g, Order(g), Size(g))
+		}
+		// Lookups go by ID, so a different value with the same ID will do.
+		if !g.HasVertex(room{name: "hall"}) {
+			t.Errorf("%T: hall not found by ID.", g)
+		}
+		if !g.HasEdge(NewEdge(room{name: "kitchen"}, room{name: "hall"})) {
+			t.Errorf("%T: edge between hall and kitchen not found by ID.", g)
+		}
+		if deg, _ := g.DegreeOf(room{name: "hall"}); deg != 2 {
+			t.Errorf("%T: expected hall to have degree 2, got %v.", g, deg)
+		}
+
+		// Enumeration hands back the vertices as they were given.
+		var doors int
+		g.AdjacentTo(room{name: "hall"}, func(v Vertex) (terminate bool) {
+			doors += len(v.(room).doors)
+			return
+		})
+		g.IncidentTo(hall, func(e Edge) (terminate bool) {
+			u, v := e.Both()
+			doors += len(u.(room).doors) + len(v.(room).doors)
+			return
+		})
+		if doors != 4 {
+			t.Errorf("%T: enumerated vertices lost their fields.", g)
+		}
+
+		if dg, ok := g.(Digraph); ok {
+			if !dg.Transpose().HasArc(NewArc(room{name: "study"}, room{name: "hall"})) {
+				t.Errorf("%T: transposed arc from study to hall not found by ID.", g)
+			}
+		}
+
+		if mg, ok := g.(MutableGraph); ok {
+			mg.RemoveVertex(room{name: "hall"})
+			if Order(mg) != 2 || Size(mg) != 0 {
+				t.Errorf("%T: expected order 2 and size 0 after removal, got %v and %v.", g, Order(mg), Size(mg))
+			}
+			mg.Vertices(func(v Vertex) (terminate bool) {
+				if v.(room).name == "hall" {
+					t.Errorf("%T: removed vertex still enumerated.", g)
+				}
+				return
+			})
+		}
+	}
+}
+
+func BenchmarkAddEdgesBatch(b *testing.B) {
+	edges := bulkEdges()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		G(Spec()).(MutableGraph).AddEdges(edges...)
+	}
+}
+
+func BenchmarkAddEdgesSingle(b *testing.B) {
+	edges := bulkEdges()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		g := G(Spec()).(MutableGraph)
+		for _, e := range edges {
+			g.AddEdges(e)
+		}
+	}
+}
+
+func TestTransposeKeepsArcProperties(t *testing.T) {
+	sources := []GraphSource{
+		ArcList{NewArc("a", "b"), NewArc("b", "c"), NewArc("c", "c"), NewArc("c", "a")},
+		WeightedArcList{NewWeightedArc("a", "b", 1.5), NewWeightedArc("b", "a", -2), NewWeightedArc("b", "c", 0)},
+		LabeledArcList{NewLabeledArc("a", "b", "ab"), NewLabeledArc("b", "a", "ba"), NewLabeledArc("b", "c", "bc")},
+		DataArcList{NewDataArc("a", "b", 1), NewDataArc("b", "a", "two"), NewDataArc("b", "c", nil)},
+	}
+	reversed := []GraphSource{
+		ArcList{NewArc("b", "a"), NewArc("c", "b"), NewArc("c", "c"), NewArc("a", "c")},
+		WeightedArcList{NewWeightedArc("b", "a", 1.5), NewWeightedArc("a", "b", -2), NewWeightedArc("c", "b", 0)},
+		LabeledArcList{NewLabeledArc("b", "a", "ab"), NewLabeledArc("a", "b", "ba"), NewLabeledArc("c", "b", "bc")},
+		DataArcList{NewDataArc("b", "a", 1), NewDataArc("a", "b", "two"), NewDataArc("c", "b", nil)},
+	}
+	specs := []GraphSpec{
+		Spec().Directed().Loop(),
+		Spec().Directed().Weighted(),
+		Spec().Directed().Labeled(),
+		Spec().Directed().DataEdges(),
+	}
+
+	// Renders each arc along with its weight, label or data.
+	arcs := func(g Digraph) map[string]bool {
+		set := make(map[string]bool)
+		g.Arcs(func(a Arc) (terminate bool) {
+			set[fmt.Sprintf("%#v", a)] = true
+			return
+		})
+		return set
+	}
+
+	for i, src := range sources {
+		g := specs[i].Using(src).Create(G).(Digraph)
+		want := arcs(specs[i].Using(reversed[i]).Create(G).(Digraph))
+
+		tg := g.Transpose()
+		if got := arcs(tg); !reflect.DeepEqual(got, want) {
+			t.Errorf("%T: transpose gave arcs %v, expected %v.", g, got, want)
+		}
+		if Size(tg) != len(want) {
+			t.Errorf("%T: expected size %v after transposing, got %v.", g, len(want), Size(tg))
+		}
+		if Order(tg) != 3 {
+			t.Errorf("%T: expected order 3 after transposing, got %v.", g, Order(tg))
+		}
+	}
+}
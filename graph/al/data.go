@@ -9,9 +9,11 @@ import (
 
 // This is implemented as an adjacency list, because those are simple.
 type baseData struct {
-	list map[Vertex]map[Vertex]interface{}
-	size int
-	mu   sync.RWMutex
+	vertexKeys
+	list  map[Vertex]map[Vertex]interface{}
+	size  int
+	mu    sync.RWMutex
+	loops bool // whether self-loops are permitted; if not, they are silently dropped
 }
 
 /* baseData shared methods */
@@ -23,7 +25,7 @@ func (g *baseData) Vertices(f VertexStep) {
 	defer g.mu.RUnlock()
 
 	for v := range g.list {
-		if f(v) {
+		if f(g.vertex(v)) {
 			return
 		}
 	}
@@ -40,7 +42,7 @@ func (g *baseData) HasVertex(vertex Vertex) (exists bool) {
 
 // Indicates whether or not the given vertex is present in the graph.
 func (g *baseData) hasVertex(vertex Vertex) (exists bool) {
-	_, exists = g.list[vertex]
+	_, exists = g.list[g.key(vertex)]
 	return
 }
 
@@ -52,6 +54,14 @@ func (g *baseData) Order() int {
 	return len(g.list)
 }
 
+// Returns the number of vertices in the graph. The count is always exact.
+func (g *baseData) VertexCount() (count int, exact bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.list), true
+}
+
 // Returns the size (number of edges) in the graph.
 func (g *baseData) Size() int {
 	return g.size
@@ -74,9 +84,9 @@ func (g *baseData) EnsureVertex(vertices ...Vertex) {
 // already present in the graph, it is a no-op (for that vertex only).
 func (g *baseData) ensureVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
-		if !g.hasVertex(vertex) {
+		if key := g.keep(vertex); !g.hasVertex(key) {
 			// TODO experiment with different lengths...possibly by analyzing existing density?
-			g.list[vertex] = make(map[Vertex]interface{}, 10)
+			g.list[key] = make(map[Vertex]interface{}, 10)
 		}
 	}
 
@@ -96,7 +106,7 @@ func (g *dataDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
 	defer g.mu.RUnlock()
 
 	if exists = g.hasVertex(vertex); exists {
-		degree = len(g.list[vertex])
+		degree = len(g.list[g.key(vertex)])
 	}
 	return
 }
@@ -136,7 +146,7 @@ func (g *dataDirected) AdjacentTo(start Vertex, f VertexStep) {
 
 	g.IncidentTo(start, func(e Edge) bool {
 		u, v := e.Both()
-		if u == start {
+		if g.key(u) == g.key(start) {
 			return f(v)
 		} else {
 			return f(u)
@@ -153,8 +163,9 @@ func (g *dataDirected) ArcsFrom(v Vertex, f ArcStep) {
 		return
 	}
 
-	for adjacent, data := range g.list[v] {
-		if f(NewDataArc(v, adjacent, data)) {
+	k := g.key(v)
+	for adjacent, data := range g.list[k] {
+		if f(NewDataArc(g.vertex(k), g.vertex(adjacent), data)) {
 			return
 		}
 	}
@@ -164,7 +175,7 @@ func (g *dataDirected) SuccessorsOf(v Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachVertexInAdjacencyList(g.list, v, f)
+	eachVertexInAdjacencyList(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Enumerates the set of in-edges for the provided vertex.
@@ -176,10 +187,11 @@ func (g *dataDirected) ArcsTo(v Vertex, f ArcStep) {
 		return
 	}
 
+	k := g.key(v)
 	for candidate, adjacent := range g.list {
 		for target, data := range adjacent {
-			if target == v {
-				if f(NewDataArc(candidate, target, data)) {
+			if target == k {
+				if f(NewDataArc(g.vertex(candidate), g.vertex(target), data)) {
 					return
 				}
 			}
@@ -191,7 +203,7 @@ func (g *dataDirected) PredecessorsOf(v Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachPredecessorOf(g.list, v, f)
+	eachPredecessorOf(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Traverses the set of edges in the graph, passing each edge to the
@@ -202,7 +214,7 @@ func (g *dataDirected) Edges(f EdgeStep) {
 
 	for source, adjacent := range g.list {
 		for target, data := range adjacent {
-			if f(NewDataEdge(source, target, data)) {
+			if f(NewDataEdge(g.vertex(source), g.vertex(target), data)) {
 				return
 			}
 		}
@@ -217,7 +229,7 @@ func (g *dataDirected) Arcs(f ArcStep) {
 
 	for source, adjacent := range g.list {
 		for target, data := range adjacent {
-			if f(NewDataArc(source, target, data)) {
+			if f(NewDataArc(g.vertex(source), g.vertex(target), data)) {
 				return
 			}
 		}
@@ -230,7 +242,7 @@ func (g *dataDirected) HasEdge(edge Edge) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	_, exists := g.list[u][v]
 	if !exists {
 		_, exists = g.list[v][u]
@@ -243,7 +255,7 @@ func (g *dataDirected) HasArc(arc Arc) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	_, exists := g.list[arc.Source()][arc.Target()]
+	_, exists := g.list[g.key(arc.Source())][g.key(arc.Target())]
 	return exists
 }
 
@@ -254,7 +266,7 @@ func (g *dataDirected) HasDataEdge(edge DataEdge) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	if data, exists := g.list[u][v]; exists {
 		return data == edge.Data()
 	} else if data, exists = g.list[v][u]; exists {
@@ -270,7 +282,7 @@ func (g *dataDirected) HasDataArc(arc DataArc) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	if data, exists := g.list[arc.Source()][arc.Target()]; exists {
+	if data, exists := g.list[g.key(arc.Source())][g.key(arc.Target())]; exists {
 		return data == arc.Data()
 	}
 	return false
@@ -296,10 +308,11 @@ func (g *dataDirected) RemoveVertex(vertices ...Vertex) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	for _, vertex := range vertices {
-		if g.hasVertex(vertex) {
+	for _, v := range vertices {
+		if vertex := g.key(v); g.hasVertex(vertex) {
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.forget(vertex)
 
 			for _, adjacent := range g.list {
 				if _, has := adjacent[vertex]; has {
@@ -327,8 +340,11 @@ func (g *dataDirected) AddArcs(arcs ...DataArc) {
 // Adds a new arc to the graph.
 func (g *dataDirected) addArcs(arcs ...DataArc) {
 	for _, arc := range arcs {
-		u, v := arc.Both()
-		g.ensureVertex(u, v)
+		u, v := g.keys(arc.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		g.ensureVertex(arc.Both())
 
 		if _, exists := g.list[u][v]; !exists {
 			g.list[u][v] = arc.Data()
@@ -348,7 +364,7 @@ func (g *dataDirected) RemoveArcs(arcs ...DataArc) {
 	defer g.mu.Unlock()
 
 	for _, arc := range arcs {
-		s, t := arc.Both()
+		s, t := g.keys(arc.Both())
 		if _, exists := g.list[s][t]; exists {
 			delete(g.list[s], t)
 			g.size--
@@ -362,9 +378,15 @@ func (g *dataDirected) Transpose() Digraph {
 
 	g2 := &dataDirected{}
 	g2.list = make(map[Vertex]map[Vertex]interface{})
+	g2.vertexKeys = g.clone()
+	g2.loops = g.loops
+	g2.size = g.size
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if len(g.list) > 0 {
+		startcap = g.size / len(g.list)
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -395,7 +417,7 @@ func (g *dataUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
 	defer g.mu.RUnlock()
 
 	if exists = g.hasVertex(vertex); exists {
-		degree = len(g.list[vertex])
+		degree = len(g.list[g.key(vertex)])
 	}
 	return
 }
@@ -414,7 +436,7 @@ func (g *dataUndirected) Edges(f EdgeStep) {
 			e = NewDataEdge(source, target, data)
 			if !visited.Has(NewEdge(e.Both())) {
 				visited.Add(NewEdge(target, source))
-				if f(e) {
+				if f(NewDataEdge(g.vertex(source), g.vertex(target), data)) {
 					return
 				}
 			}
@@ -431,8 +453,9 @@ func (g *dataUndirected) IncidentTo(v Vertex, f EdgeStep) {
 		return
 	}
 
-	for adjacent, data := range g.list[v] {
-		if f(NewDataEdge(v, adjacent, data)) {
+	k := g.key(v)
+	for adjacent, data := range g.list[k] {
+		if f(NewDataEdge(g.vertex(k), g.vertex(adjacent), data)) {
 			return
 		}
 	}
@@ -443,7 +466,7 @@ func (g *dataUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachVertexInAdjacencyList(g.list, vertex, f)
+	eachVertexInAdjacencyList(g.list, g.key(vertex), g.vertexStep(f))
 }
 
 // Indicates whether or not the given edge is present in the graph. It matches
@@ -453,7 +476,7 @@ func (g *dataUndirected) HasEdge(edge Edge) bool {
 	defer g.mu.RUnlock()
 
 	// Spread it into two expressions to avoid evaluating the second if possible
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	_, exists := g.list[u][v]
 	return exists
 }
@@ -466,7 +489,7 @@ func (g *dataUndirected) HasDataEdge(edge DataEdge) bool {
 	defer g.mu.RUnlock()
 
 	// Spread it into two expressions to avoid evaluating the second if possible
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	if data, exists := g.list[u][v]; exists {
 		return edge.Data() == data
 	} else if data, exists := g.list[v][u]; exists {
@@ -495,14 +518,16 @@ func (g *dataUndirected) RemoveVertex(vertices ...Vertex) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	for _, vertex := range vertices {
-		if g.hasVertex(vertex) {
+	for _, v := range vertices {
+		if vertex := g.key(v); g.hasVertex(vertex) {
+			// Count before unlinking, so a self-loop is not missed
+			g.size -= len(g.list[vertex])
 			eachVertexInAdjacencyList(g.list, vertex, func(adjacent Vertex) (terminate bool) {
 				delete(g.list[adjacent], vertex)
 				return
 			})
-			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.forget(vertex)
 		}
 	}
 	return
@@ -523,8 +548,11 @@ func (g *dataUndirected) AddEdges(edges ...DataEdge) {
 // Adds a new edge to the graph.
 func (g *dataUndirected) addEdges(edges ...DataEdge) {
 	for _, edge := range edges {
-		u, v := edge.Both()
-		g.ensureVertex(u, v)
+		u, v := g.keys(edge.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		g.ensureVertex(edge.Both())
 
 		if _, exists := g.list[u][v]; !exists {
 			d := edge.Data()
@@ -546,7 +574,7 @@ func (g *dataUndirected) RemoveEdges(edges ...DataEdge) {
 	defer g.mu.Unlock()
 
 	for _, edge := range edges {
-		s, t := edge.Both()
+		s, t := g.keys(edge.Both())
 		if _, exists := g.list[s][t]; exists {
 			delete(g.list[s], t)
 			delete(g.list[t], s)
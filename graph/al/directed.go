@@ -17,7 +17,7 @@ func (g *mutableDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
 	defer g.mu.RUnlock()
 
 	if exists = g.hasVertex(vertex); exists {
-		degree = len(g.list[vertex])
+		degree = len(g.list[g.key(vertex)])
 	}
 	return
 }
@@ -51,7 +51,7 @@ func (g *mutableDirected) Edges(f EdgeStep) {
 
 	for source, adjacent := range g.list {
 		for target := range adjacent {
-			if f(NewEdge(source, target)) {
+			if f(NewEdge(g.vertex(source), g.vertex(target))) {
 				return
 			}
 		}
@@ -66,7 +66,7 @@ func (g *mutableDirected) Arcs(f ArcStep) {
 
 	for source, adjacent := range g.list {
 		for target := range adjacent {
-			if f(NewArc(source, target)) {
+			if f(NewArc(g.vertex(source), g.vertex(target))) {
 				return
 			}
 		}
@@ -87,7 +87,7 @@ func (g *mutableDirected) AdjacentTo(start Vertex, f VertexStep) {
 
 	g.IncidentTo(start, func(e Edge) bool {
 		u, v := e.Both()
-		if u == start {
+		if g.key(u) == g.key(start) {
 			return f(v)
 		} else {
 			return f(u)
@@ -104,8 +104,9 @@ func (g *mutableDirected) ArcsFrom(v Vertex, f ArcStep) {
 		return
 	}
 
-	for adjacent := range g.list[v] {
-		if f(NewArc(v, adjacent)) {
+	k := g.key(v)
+	for adjacent := range g.list[k] {
+		if f(NewArc(g.vertex(k), g.vertex(adjacent))) {
 			return
 		}
 	}
@@ -115,7 +116,7 @@ func (g *mutableDirected) SuccessorsOf(v Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachVertexInAdjacencyList(g.list, v, f)
+	eachVertexInAdjacencyList(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Enumerates the set of in-edges for the provided vertex.
@@ -127,10 +128,11 @@ func (g *mutableDirected) ArcsTo(v Vertex, f ArcStep) {
 		return
 	}
 
+	k := g.key(v)
 	for candidate, adjacent := range g.list {
 		for target := range adjacent {
-			if target == v {
-				if f(NewArc(candidate, target)) {
+			if target == k {
+				if f(NewArc(g.vertex(candidate), g.vertex(target))) {
 					return
 				}
 			}
@@ -142,7 +144,7 @@ func (g *mutableDirected) PredecessorsOf(v Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachPredecessorOf(g.list, v, f)
+	eachPredecessorOf(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Indicates whether or not the given edge is present in the graph.
@@ -150,7 +152,7 @@ func (g *mutableDirected) HasEdge(edge Edge) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	_, exists := g.list[u][v]
 	if !exists {
 		_, exists = g.list[v][u]
@@ -163,7 +165,7 @@ func (g *mutableDirected) HasArc(arc Arc) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	_, exists := g.list[arc.Source()][arc.Target()]
+	_, exists := g.list[g.key(arc.Source())][g.key(arc.Target())]
 	return exists
 }
 
@@ -187,12 +189,13 @@ func (g *mutableDirected) RemoveVertex(vertices ...Vertex) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	for _, vertex := range vertices {
-		if g.hasVertex(vertex) {
+	for _, v := range vertices {
+		if vertex := g.key(v); g.hasVertex(vertex) {
 			// TODO Is the expensive search good to do here and now...
 			// while read-locked?
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.forget(vertex)
 
 			// TODO consider chunking the list and parallelizing into goroutines
 			for _, adjacent := range g.list {
@@ -220,11 +223,21 @@ func (g *mutableDirected) AddArcs(arcs ...Arc) {
 
 // Adds a new arc to the graph.
 func (g *mutableDirected) addArcs(arcs ...Arc) {
+	g.presize(len(arcs), false, func(f func(u, v Vertex)) {
+		for _, arc := range arcs {
+			f(arc.Source(), arc.Target())
+		}
+	})
+
 	for _, arc := range arcs {
-		g.ensureVertex(arc.Source(), arc.Target())
+		u, v := g.keys(arc.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		g.ensureVertex(arc.Both())
 
-		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
-			g.list[arc.Source()][arc.Target()] = keyExists
+		if _, exists := g.list[u][v]; !exists {
+			g.list[u][v] = keyExists
 			g.size++
 		}
 	}
@@ -241,7 +254,7 @@ func (g *mutableDirected) RemoveArcs(arcs ...Arc) {
 	defer g.mu.Unlock()
 
 	for _, arc := range arcs {
-		s, t := arc.Both()
+		s, t := g.keys(arc.Both())
 		if _, exists := g.list[s][t]; exists {
 			delete(g.list[s], t)
 			g.size--
@@ -260,9 +273,15 @@ func (g *mutableDirected) Transpose() Digraph {
 
 	g2 := &mutableDirected{}
 	g2.list = make(map[Vertex]map[Vertex]struct{})
+	g2.vertexKeys = g.clone()
+	g2.loops = g.loops
+	g2.size = g.size
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if len(g.list) > 0 {
+		startcap = g.size / len(g.list)
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -290,7 +309,7 @@ type immutableDirected struct {
 func (g *immutableDirected) Edges(f EdgeStep) {
 	for source, adjacent := range g.list {
 		for target := range adjacent {
-			if f(NewEdge(source, target)) {
+			if f(NewEdge(g.vertex(source), g.vertex(target))) {
 				return
 			}
 		}
@@ -302,7 +321,7 @@ func (g *immutableDirected) Edges(f EdgeStep) {
 func (g *immutableDirected) Arcs(f ArcStep) {
 	for source, adjacent := range g.list {
 		for target := range adjacent {
-			if f(NewArc(source, target)) {
+			if f(NewArc(g.vertex(source), g.vertex(target))) {
 				return
 			}
 		}
@@ -318,7 +337,7 @@ func (g *immutableDirected) IncidentTo(v Vertex, f EdgeStep) {
 func (g *immutableDirected) AdjacentTo(start Vertex, f VertexStep) {
 	g.IncidentTo(start, func(e Edge) bool {
 		u, v := e.Both()
-		if u == start {
+		if g.key(u) == g.key(start) {
 			return f(v)
 		} else {
 			return f(u)
@@ -332,15 +351,16 @@ func (g *immutableDirected) ArcsFrom(v Vertex, f ArcStep) {
 		return
 	}
 
-	for adjacent := range g.list[v] {
-		if f(NewArc(v, adjacent)) {
+	k := g.key(v)
+	for adjacent := range g.list[k] {
+		if f(NewArc(g.vertex(k), g.vertex(adjacent))) {
 			return
 		}
 	}
 }
 
 func (g *immutableDirected) SuccessorsOf(v Vertex, f VertexStep) {
-	eachVertexInAdjacencyList(g.list, v, f)
+	eachVertexInAdjacencyList(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Enumerates the set of in-edges for the provided vertex.
@@ -349,10 +369,11 @@ func (g *immutableDirected) ArcsTo(v Vertex, f ArcStep) {
 		return
 	}
 
+	k := g.key(v)
 	for candidate, adjacent := range g.list {
 		for target := range adjacent {
-			if target == v {
-				if f(NewArc(candidate, target)) {
+			if target == k {
+				if f(NewArc(g.vertex(candidate), g.vertex(target))) {
 					return
 				}
 			}
@@ -361,7 +382,7 @@ func (g *immutableDirected) ArcsTo(v Vertex, f ArcStep) {
 }
 
 func (g *immutableDirected) PredecessorsOf(v Vertex, f VertexStep) {
-	eachPredecessorOf(g.list, v, f)
+	eachPredecessorOf(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Returns the density of the graph. Density is the ratio of edge count to the
@@ -373,7 +394,7 @@ func (g *immutableDirected) Density() float64 {
 
 // Indicates whether or not the given edge is present in the graph.
 func (g *immutableDirected) HasEdge(edge Edge) bool {
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	_, exists := g.list[u][v]
 	if !exists {
 		_, exists = g.list[v][u]
@@ -383,7 +404,7 @@ func (g *immutableDirected) HasEdge(edge Edge) bool {
 
 // Indicates whether or not the given arc is present in the graph.
 func (g *immutableDirected) HasArc(arc Arc) bool {
-	_, exists := g.list[arc.Source()][arc.Target()]
+	_, exists := g.list[g.key(arc.Source())][g.key(arc.Target())]
 	return exists
 }
 
@@ -391,7 +412,7 @@ func (g *immutableDirected) HasArc(arc Arc) bool {
 // graph, the second return value will be false.
 func (g *immutableDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
 	if exists = g.hasVertex(vertex); exists {
-		degree = len(g.list[vertex])
+		degree = len(g.list[g.key(vertex)])
 	}
 	return
 }
@@ -404,7 +425,7 @@ func (g *immutableDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool)
 func (g *immutableDirected) InDegreeOf(vertex Vertex) (degree int, exists bool) {
 	if exists = g.hasVertex(vertex); exists {
 		g.Arcs(func(e Arc) (terminate bool) {
-			if vertex == e.Target() {
+			if g.key(vertex) == g.key(e.Target()) {
 				degree++
 			}
 			return
@@ -429,9 +450,15 @@ func (g *immutableDirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
 func (g *immutableDirected) Transpose() Digraph {
 	g2 := &immutableDirected{}
 	g2.list = make(map[Vertex]map[Vertex]struct{})
+	g2.vertexKeys = g.clone()
+	g2.loops = g.loops
+	g2.size = g.size
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if len(g.list) > 0 {
+		startcap = g.size / len(g.list)
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -450,11 +477,21 @@ func (g *immutableDirected) Transpose() Digraph {
 
 // Adds a new arc to the graph.
 func (g *immutableDirected) addArcs(arcs ...Arc) {
+	g.presize(len(arcs), false, func(f func(u, v Vertex)) {
+		for _, arc := range arcs {
+			f(arc.Source(), arc.Target())
+		}
+	})
+
 	for _, arc := range arcs {
-		g.ensureVertex(arc.Source(), arc.Target())
+		u, v := g.keys(arc.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		g.ensureVertex(arc.Both())
 
-		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
-			g.list[arc.Source()][arc.Target()] = keyExists
+		if _, exists := g.list[u][v]; !exists {
+			g.list[u][v] = keyExists
 			g.size++
 		}
 	}
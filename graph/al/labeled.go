@@ -9,9 +9,11 @@ import (
 
 // This is implemented as an adjacency list, because those are simple.
 type baseLabeled struct {
-	list map[Vertex]map[Vertex]string
-	size int
-	mu   sync.RWMutex
+	vertexKeys
+	list  map[Vertex]map[Vertex]string
+	size  int
+	mu    sync.RWMutex
+	loops bool // whether self-loops are permitted; if not, they are silently dropped
 }
 
 /* baseLabeled shared methods */
@@ -23,7 +25,7 @@ func (g *baseLabeled) Vertices(f VertexStep) {
 	defer g.mu.RUnlock()
 
 	for v := range g.list {
-		if f(v) {
+		if f(g.vertex(v)) {
 			return
 		}
 	}
@@ -40,7 +42,7 @@ func (g *baseLabeled) HasVertex(vertex Vertex) (exists bool) {
 
 // Indicates whether or not the given vertex is present in the graph.
 func (g *baseLabeled) hasVertex(vertex Vertex) (exists bool) {
-	_, exists = g.list[vertex]
+	_, exists = g.list[g.key(vertex)]
 	return
 }
 
@@ -52,6 +54,14 @@ func (g *baseLabeled) Order() int {
 	return len(g.list)
 }
 
+// Returns the number of vertices in the graph. The count is always exact.
+func (g *baseLabeled) VertexCount() (count int, exact bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.list), true
+}
+
 // Returns the size (number of edges) in the graph.
 func (g *baseLabeled) Size() int {
 	return g.size
@@ -74,9 +84,9 @@ func (g *baseLabeled) EnsureVertex(vertices ...Vertex) {
 // already present in the graph, it is a no-op (for that vertex only).
 func (g *baseLabeled) ensureVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
-		if !g.hasVertex(vertex) {
+		if key := g.keep(vertex); !g.hasVertex(key) {
 			// TODO experiment with different lengths...possibly by analyzing existing density?
-			g.list[vertex] = make(map[Vertex]string, 10)
+			g.list[key] = make(map[Vertex]string, 10)
 		}
 	}
 
@@ -96,7 +106,7 @@ func (g *labeledDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
 	defer g.mu.RUnlock()
 
 	if exists = g.hasVertex(vertex); exists {
-		degree = len(g.list[vertex])
+		degree = len(g.list[g.key(vertex)])
 	}
 	return
 }
@@ -130,7 +140,7 @@ func (g *labeledDirected) Edges(f EdgeStep) {
 
 	for source, adjacent := range g.list {
 		for target, label := range adjacent {
-			if f(NewLabeledEdge(source, target, label)) {
+			if f(NewLabeledEdge(g.vertex(source), g.vertex(target), label)) {
 				return
 			}
 		}
@@ -145,7 +155,7 @@ func (g *labeledDirected) Arcs(f ArcStep) {
 
 	for source, adjacent := range g.list {
 		for target, label := range adjacent {
-			if f(NewLabeledArc(source, target, label)) {
+			if f(NewLabeledArc(g.vertex(source), g.vertex(target), label)) {
 				return
 			}
 		}
@@ -166,7 +176,7 @@ func (g *labeledDirected) AdjacentTo(start Vertex, f VertexStep) {
 
 	g.IncidentTo(start, func(e Edge) bool {
 		u, v := e.Both()
-		if u == start {
+		if g.key(u) == g.key(start) {
 			return f(v)
 		} else {
 			return f(u)
@@ -183,8 +193,9 @@ func (g *labeledDirected) ArcsFrom(v Vertex, f ArcStep) {
 		return
 	}
 
-	for adjacent, label := range g.list[v] {
-		if f(NewLabeledArc(v, adjacent, label)) {
+	k := g.key(v)
+	for adjacent, label := range g.list[k] {
+		if f(NewLabeledArc(g.vertex(k), g.vertex(adjacent), label)) {
 			return
 		}
 	}
@@ -194,7 +205,7 @@ func (g *labeledDirected) SuccessorsOf(v Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachVertexInAdjacencyList(g.list, v, f)
+	eachVertexInAdjacencyList(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Enumerates the set of in-edges for the provided vertex.
@@ -206,10 +217,11 @@ func (g *labeledDirected) ArcsTo(v Vertex, f ArcStep) {
 		return
 	}
 
+	k := g.key(v)
 	for candidate, adjacent := range g.list {
 		for target, label := range adjacent {
-			if target == v {
-				if f(NewLabeledArc(candidate, target, label)) {
+			if target == k {
+				if f(NewLabeledArc(g.vertex(candidate), g.vertex(target), label)) {
 					return
 				}
 			}
@@ -221,7 +233,7 @@ func (g *labeledDirected) PredecessorsOf(v Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachPredecessorOf(g.list, v, f)
+	eachPredecessorOf(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Indicates whether or not the given edge is present in the graph. It matches
@@ -230,7 +242,7 @@ func (g *labeledDirected) HasEdge(edge Edge) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	_, exists := g.list[u][v]
 	if !exists {
 		_, exists = g.list[v][u]
@@ -243,7 +255,7 @@ func (g *labeledDirected) HasArc(arc Arc) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	_, exists := g.list[arc.Source()][arc.Target()]
+	_, exists := g.list[g.key(arc.Source())][g.key(arc.Target())]
 	return exists
 }
 
@@ -254,7 +266,7 @@ func (g *labeledDirected) HasLabeledEdge(edge LabeledEdge) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	if label, exists := g.list[u][v]; exists {
 		return label == edge.Label()
 	} else if label, exists = g.list[v][u]; exists {
@@ -270,7 +282,7 @@ func (g *labeledDirected) HasLabeledArc(arc LabeledArc) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	if label, exists := g.list[arc.Source()][arc.Target()]; exists {
+	if label, exists := g.list[g.key(arc.Source())][g.key(arc.Target())]; exists {
 		return label == arc.Label()
 	}
 	return false
@@ -296,10 +308,11 @@ func (g *labeledDirected) RemoveVertex(vertices ...Vertex) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	for _, vertex := range vertices {
-		if g.hasVertex(vertex) {
+	for _, v := range vertices {
+		if vertex := g.key(v); g.hasVertex(vertex) {
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.forget(vertex)
 
 			for _, adjacent := range g.list {
 				if _, has := adjacent[vertex]; has {
@@ -327,10 +340,14 @@ func (g *labeledDirected) AddArcs(arcs ...LabeledArc) {
 // Adds a new arc to the graph.
 func (g *labeledDirected) addArcs(arcs ...LabeledArc) {
 	for _, arc := range arcs {
-		g.ensureVertex(arc.Source(), arc.Target())
+		u, v := g.keys(arc.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		g.ensureVertex(arc.Both())
 
-		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
-			g.list[arc.Source()][arc.Target()] = arc.Label()
+		if _, exists := g.list[u][v]; !exists {
+			g.list[u][v] = arc.Label()
 			g.size++
 		}
 	}
@@ -347,7 +364,7 @@ func (g *labeledDirected) RemoveArcs(arcs ...LabeledArc) {
 	defer g.mu.Unlock()
 
 	for _, arc := range arcs {
-		s, t := arc.Both()
+		s, t := g.keys(arc.Both())
 		if _, exists := g.list[s][t]; exists {
 			delete(g.list[s], t)
 			g.size--
@@ -361,9 +378,15 @@ func (g *labeledDirected) Transpose() Digraph {
 
 	g2 := &labeledDirected{}
 	g2.list = make(map[Vertex]map[Vertex]string)
+	g2.vertexKeys = g.clone()
+	g2.loops = g.loops
+	g2.size = g.size
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if len(g.list) > 0 {
+		startcap = g.size / len(g.list)
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -394,7 +417,7 @@ func (g *labeledUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
 	defer g.mu.RUnlock()
 
 	if exists = g.hasVertex(vertex); exists {
-		degree = len(g.list[vertex])
+		degree = len(g.list[g.key(vertex)])
 	}
 	return
 }
@@ -413,7 +436,7 @@ func (g *labeledUndirected) Edges(f EdgeStep) {
 			e = NewLabeledEdge(source, target, label)
 			if !visited.Has(NewEdge(e.Both())) {
 				visited.Add(NewEdge(target, source))
-				if f(e) {
+				if f(NewLabeledEdge(g.vertex(source), g.vertex(target), label)) {
 					return
 				}
 			}
@@ -430,8 +453,9 @@ func (g *labeledUndirected) IncidentTo(v Vertex, f EdgeStep) {
 		return
 	}
 
-	for adjacent, label := range g.list[v] {
-		if f(NewLabeledEdge(v, adjacent, label)) {
+	k := g.key(v)
+	for adjacent, label := range g.list[k] {
+		if f(NewLabeledEdge(g.vertex(k), g.vertex(adjacent), label)) {
 			return
 		}
 	}
@@ -442,7 +466,7 @@ func (g *labeledUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachVertexInAdjacencyList(g.list, vertex, f)
+	eachVertexInAdjacencyList(g.list, g.key(vertex), g.vertexStep(f))
 }
 
 // Indicates whether or not the given edge is present in the graph. It matches
@@ -452,7 +476,7 @@ func (g *labeledUndirected) HasEdge(edge Edge) bool {
 	defer g.mu.RUnlock()
 
 	// Spread it into two expressions to avoid evaluating the second if possible
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	if _, exists := g.list[u][v]; exists {
 		return true
 	} else if _, exists := g.list[v][u]; exists {
@@ -469,7 +493,7 @@ func (g *labeledUndirected) HasLabeledEdge(edge LabeledEdge) bool {
 	defer g.mu.RUnlock()
 
 	// Spread it into two expressions to avoid evaluating the second if possible
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	if label, exists := g.list[u][v]; exists {
 		return edge.Label() == label
 	} else if label, exists := g.list[v][u]; exists {
@@ -498,14 +522,16 @@ func (g *labeledUndirected) RemoveVertex(vertices ...Vertex) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	for _, vertex := range vertices {
-		if g.hasVertex(vertex) {
+	for _, v := range vertices {
+		if vertex := g.key(v); g.hasVertex(vertex) {
+			// Count before unlinking, so a self-loop is not missed
+			g.size -= len(g.list[vertex])
 			eachVertexInAdjacencyList(g.list, vertex, func(adjacent Vertex) (terminate bool) {
 				delete(g.list[adjacent], vertex)
 				return
 			})
-			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.forget(vertex)
 		}
 	}
 	return
@@ -526,8 +552,11 @@ func (g *labeledUndirected) AddEdges(edges ...LabeledEdge) {
 // Adds a new edge to the graph.
 func (g *labeledUndirected) addEdges(edges ...LabeledEdge) {
 	for _, edge := range edges {
-		u, v := edge.Both()
-		g.ensureVertex(u, v)
+		u, v := g.keys(edge.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		g.ensureVertex(edge.Both())
 
 		if _, exists := g.list[u][v]; !exists {
 			l := edge.Label()
@@ -549,7 +578,7 @@ func (g *labeledUndirected) RemoveEdges(edges ...LabeledEdge) {
 	defer g.mu.Unlock()
 
 	for _, edge := range edges {
-		s, t := edge.Both()
+		s, t := g.keys(edge.Both())
 		if _, exists := g.list[s][t]; exists {
 			delete(g.list[s], t)
 			delete(g.list[t], s)
@@ -0,0 +1,523 @@
+package al
+
+import (
+	"sync"
+
+	. "github.com/sdboyer/gogl"
+	"gopkg.in/fatih/set.v0"
+)
+
+// The kinds of connection recorded between a vertex and one of its neighbors in
+// a mixed graph. An undirected edge is recorded as mixedEdge at both of its ends;
+// an arc, as mixedOut at its source and mixedIn at its target. Arcs running both
+// ways between two vertices combine as mixedOut|mixedIn.
+type mixedLink uint8
+
+const (
+	mixedEdge mixedLink = 1 << iota
+	mixedOut
+	mixedIn
+)
+
+// A mutable adjacency list holding both undirected edges and arcs.
+//
+// Like the other adjacency lists, it is simple: a pair of vertices may be joined
+// either by one undirected edge, or by at most one arc in each direction, but not
+// both. An edge or arc that would join vertices already joined by the other kind
+// is silently dropped, as are loops unless they are permitted.
+type mutableMixed struct {
+	vertexKeys
+	list  map[Vertex]map[Vertex]mixedLink
+	size  int
+	loops bool // whether self-loops are permitted; if not, they are silently dropped
+	mu    sync.RWMutex
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *mutableMixed) hasVertex(vertex Vertex) (exists bool) {
+	_, exists = g.list[g.key(vertex)]
+	return
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *mutableMixed) ensureVertex(vertices ...Vertex) {
+	for _, vertex := range vertices {
+		if key := g.keep(vertex); !g.hasVertex(key) {
+			g.list[key] = make(map[Vertex]mixedLink, 10)
+		}
+	}
+}
+
+// Traverses the graph's vertices in random order, passing each vertex to the
+// provided closure.
+func (g *mutableMixed) Vertices(f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for v := range g.list {
+		if f(g.vertex(v)) {
+			return
+		}
+	}
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *mutableMixed) HasVertex(vertex Vertex) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.hasVertex(vertex)
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *mutableMixed) Order() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.list)
+}
+
+// Returns the number of vertices in the graph. The count is always exact.
+func (g *mutableMixed) VertexCount() (count int, exact bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.list), true
+}
+
+// Returns the size (number of edges and arcs) in the graph.
+func (g *mutableMixed) Size() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.size
+}
+
+// Traverses all the connections in the graph, passing each undirected edge to the
+// provided closure as an Edge, and each arc as an Arc.
+func (g *mutableMixed) Edges(f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	visited := set.NewNonTS()
+
+	for source, adjacent := range g.list {
+		for target, link := range adjacent {
+			if link&mixedEdge != 0 {
+				e := NewEdge(source, target)
+				if !visited.Has(NewEdge(target, source)) {
+					visited.Add(e)
+					if f(NewEdge(g.vertex(source), g.vertex(target))) {
+						return
+					}
+				}
+			} else if link&mixedOut != 0 {
+				if f(NewArc(g.vertex(source), g.vertex(target))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Traverses the undirected edges in the graph, passing each to the provided
+// closure.
+func (g *mutableMixed) UndirectedEdges(f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	visited := set.NewNonTS()
+
+	for source, adjacent := range g.list {
+		for target, link := range adjacent {
+			if link&mixedEdge == 0 {
+				continue
+			}
+
+			e := NewEdge(source, target)
+			if !visited.Has(NewEdge(target, source)) {
+				visited.Add(e)
+				if f(NewEdge(g.vertex(source), g.vertex(target))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Traverses the arcs in the graph, passing each to the provided closure.
+func (g *mutableMixed) Arcs(f ArcStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for source, adjacent := range g.list {
+		for target, link := range adjacent {
+			if link&mixedOut != 0 {
+				if f(NewArc(g.vertex(source), g.vertex(target))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerates the set of all edges and arcs incident to the provided vertex, in
+// either direction. As in a digraph, a loop arc is passed twice.
+func (g *mutableMixed) IncidentTo(v Vertex, f EdgeStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	k := g.key(v)
+	for adjacent, link := range g.list[k] {
+		if link&mixedEdge != 0 && f(NewEdge(g.vertex(k), g.vertex(adjacent))) {
+			return
+		}
+		if link&mixedOut != 0 && f(NewArc(g.vertex(k), g.vertex(adjacent))) {
+			return
+		}
+		if link&mixedIn != 0 && f(NewArc(g.vertex(adjacent), g.vertex(k))) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex, whatever joins them.
+func (g *mutableMixed) AdjacentTo(v Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for adjacent := range g.list[g.key(v)] {
+		if f(g.vertex(adjacent)) {
+			return
+		}
+	}
+}
+
+// Enumerates the arcs outbound from the provided vertex.
+func (g *mutableMixed) ArcsFrom(v Vertex, f ArcStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	k := g.key(v)
+	for adjacent, link := range g.list[k] {
+		if link&mixedOut != 0 && f(NewArc(g.vertex(k), g.vertex(adjacent))) {
+			return
+		}
+	}
+}
+
+// Enumerates the arcs inbound to the provided vertex.
+func (g *mutableMixed) ArcsTo(v Vertex, f ArcStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	k := g.key(v)
+	for adjacent, link := range g.list[k] {
+		if link&mixedIn != 0 && f(NewArc(g.vertex(adjacent), g.vertex(k))) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices that can be reached from the provided vertex in one
+// step: the targets of its out-arcs, and its neighbors along undirected edges.
+func (g *mutableMixed) SuccessorsOf(v Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for adjacent, link := range g.list[g.key(v)] {
+		if link&(mixedEdge|mixedOut) != 0 && f(g.vertex(adjacent)) {
+			return
+		}
+	}
+}
+
+// Enumerates the vertices from which the provided vertex can be reached in one
+// step: the sources of its in-arcs, and its neighbors along undirected edges.
+func (g *mutableMixed) PredecessorsOf(v Vertex, f VertexStep) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for adjacent, link := range g.list[g.key(v)] {
+		if link&(mixedEdge|mixedIn) != 0 && f(g.vertex(adjacent)) {
+			return
+		}
+	}
+}
+
+// Indicates whether the two vertices are joined by an edge or by an arc in
+// either direction.
+func (g *mutableMixed) HasEdge(edge Edge) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	u, v := g.keys(edge.Both())
+	return g.list[u][v] != 0
+}
+
+// Indicates whether the two vertices are joined by an undirected edge.
+func (g *mutableMixed) HasUndirectedEdge(edge Edge) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	u, v := g.keys(edge.Both())
+	return g.list[u][v]&mixedEdge != 0
+}
+
+// Indicates whether or not the given arc is present in the graph. An undirected
+// edge between the same vertices does not count.
+func (g *mutableMixed) HasArc(arc Arc) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	s, t := g.keys(arc.Both())
+	return g.list[s][t]&mixedOut != 0
+}
+
+// Returns the degree of the provided vertex, counting its undirected edges and
+// both its in- and out-arcs. If the vertex is not present in the graph, the
+// second return value will be false.
+func (g *mutableMixed) DegreeOf(vertex Vertex) (degree int, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if exists = g.hasVertex(vertex); exists {
+		for _, link := range g.list[g.key(vertex)] {
+			for _, kind := range [...]mixedLink{mixedEdge, mixedOut, mixedIn} {
+				if link&kind != 0 {
+					degree++
+				}
+			}
+		}
+	}
+	return
+}
+
+// Returns the number of arcs inbound to the provided vertex. If the vertex is not
+// present in the graph, the second return value will be false.
+func (g *mutableMixed) InDegreeOf(vertex Vertex) (degree int, exists bool) {
+	return g.countLinks(vertex, mixedIn)
+}
+
+// Returns the number of arcs outbound from the provided vertex. If the vertex is
+// not present in the graph, the second return value will be false.
+func (g *mutableMixed) OutDegreeOf(vertex Vertex) (degree int, exists bool) {
+	return g.countLinks(vertex, mixedOut)
+}
+
+func (g *mutableMixed) countLinks(vertex Vertex, kind mixedLink) (degree int, exists bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if exists = g.hasVertex(vertex); exists {
+		for _, link := range g.list[g.key(vertex)] {
+			if link&kind != 0 {
+				degree++
+			}
+		}
+	}
+	return
+}
+
+// Adds the provided vertices to the graph. If a provided vertex is
+// already present in the graph, it is a no-op (for that vertex only).
+func (g *mutableMixed) EnsureVertex(vertices ...Vertex) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.ensureVertex(vertices...)
+}
+
+// Removes a vertex from the graph. Also removes any edges or arcs of which that
+// vertex is a member.
+func (g *mutableMixed) RemoveVertex(vertices ...Vertex) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, v := range vertices {
+		vertex := g.key(v)
+		if !g.hasVertex(vertex) {
+			continue
+		}
+
+		for adjacent, link := range g.list[vertex] {
+			switch {
+			case link&mixedEdge != 0, adjacent == vertex:
+				// A loop arc is recorded as both out and in, but is one arc.
+				g.size--
+			case link == mixedOut|mixedIn:
+				g.size -= 2
+			default:
+				g.size--
+			}
+			delete(g.list[adjacent], vertex)
+		}
+		delete(g.list, vertex)
+		g.forget(vertex)
+	}
+}
+
+// Adds undirected edges to the graph. An edge between vertices already joined by
+// an arc is dropped.
+func (g *mutableMixed) AddEdges(edges ...Edge) {
+	if len(edges) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.addEdges(edges...)
+}
+
+func (g *mutableMixed) addEdges(edges ...Edge) {
+	for _, edge := range edges {
+		u, v := g.keys(edge.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		if g.list[u][v] != 0 {
+			continue
+		}
+
+		g.ensureVertex(edge.Both())
+		g.list[u][v] = mixedEdge
+		g.list[v][u] = mixedEdge
+		g.size++
+	}
+}
+
+// Removes undirected edges from the graph. Arcs between the same vertices are
+// left alone, as are the vertex members of the removed edges.
+func (g *mutableMixed) RemoveEdges(edges ...Edge) {
+	if len(edges) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, edge := range edges {
+		u, v := g.keys(edge.Both())
+		if g.list[u][v]&mixedEdge != 0 {
+			delete(g.list[u], v)
+			delete(g.list[v], u)
+			g.size--
+		}
+	}
+}
+
+// Adds arcs to the graph. An arc between vertices already joined by an undirected
+// edge is dropped.
+func (g *mutableMixed) AddArcs(arcs ...Arc) {
+	if len(arcs) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.addArcs(arcs...)
+}
+
+func (g *mutableMixed) addArcs(arcs ...Arc) {
+	for _, arc := range arcs {
+		s, t := g.keys(arc.Both())
+		if s == t && !g.loops {
+			continue
+		}
+		if g.list[s][t]&(mixedEdge|mixedOut) != 0 {
+			continue
+		}
+
+		g.ensureVertex(arc.Both())
+		g.list[s][t] |= mixedOut
+		g.list[t][s] |= mixedIn
+		g.size++
+	}
+}
+
+// Removes arcs from the graph. Undirected edges between the same vertices are
+// left alone, as are the vertex members of the removed arcs.
+func (g *mutableMixed) RemoveArcs(arcs ...Arc) {
+	if len(arcs) == 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, arc := range arcs {
+		s, t := g.keys(arc.Both())
+		if g.list[s][t]&mixedOut == 0 {
+			continue
+		}
+
+		g.unlink(s, t, mixedOut)
+		g.unlink(t, s, mixedIn)
+		g.size--
+	}
+}
+
+// Clears the given kind of link from u to v, dropping the entry once none remain.
+func (g *mutableMixed) unlink(u, v Vertex, kind mixedLink) {
+	if link := g.list[u][v] &^ kind; link != 0 {
+		g.list[u][v] = link
+	} else {
+		delete(g.list[u], v)
+	}
+}
+
+// Copies an incoming graph into a mixed adjacency list.
+//
+// If the source can enumerate its undirected edges apart from its arcs, as a
+// MixedGraph can, it is copied kind by kind; a DigraphSource contributes only
+// arcs. Otherwise, each edge it enumerates is added as an arc if it is an Arc,
+// and as an undirected edge if not.
+func functorToMixedAdjacencyList(from GraphSource, to *mutableMixed) Graph {
+	mixed, isMixed := from.(interface {
+		UndirectedEdgeEnumerator
+		ArcEnumerator
+	})
+
+	if isMixed {
+		mixed.UndirectedEdges(func(e Edge) (terminate bool) {
+			to.addEdges(e)
+			return
+		})
+		mixed.Arcs(func(a Arc) (terminate bool) {
+			to.addArcs(a)
+			return
+		})
+	} else if dgs, ok := from.(DigraphSource); ok {
+		dgs.Arcs(func(a Arc) (terminate bool) {
+			to.addArcs(a)
+			return
+		})
+	} else {
+		from.Edges(func(e Edge) (terminate bool) {
+			if a, ok := e.(Arc); ok {
+				to.addArcs(a)
+			} else {
+				to.addEdges(e)
+			}
+			return
+		})
+	}
+
+	from.Vertices(func(v Vertex) (terminate bool) {
+		to.ensureVertex(v)
+		return
+	})
+
+	return to
+}
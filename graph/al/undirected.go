@@ -16,7 +16,7 @@ func (g *mutableUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
 	defer g.mu.RUnlock()
 
 	if exists = g.hasVertex(vertex); exists {
-		degree = len(g.list[vertex])
+		degree = len(g.list[g.key(vertex)])
 	}
 	return
 }
@@ -34,7 +34,7 @@ func (g *mutableUndirected) Edges(f EdgeStep) {
 			e := NewEdge(source, target)
 			if !visited.Has(NewEdge(target, source)) {
 				visited.Add(e)
-				if f(e) {
+				if f(NewEdge(g.vertex(source), g.vertex(target))) {
 					return
 				}
 			}
@@ -51,8 +51,9 @@ func (g *mutableUndirected) IncidentTo(v Vertex, f EdgeStep) {
 		return
 	}
 
-	for adjacent := range g.list[v] {
-		if f(NewEdge(v, adjacent)) {
+	k := g.key(v)
+	for adjacent := range g.list[k] {
+		if f(NewEdge(g.vertex(k), g.vertex(adjacent))) {
 			return
 		}
 	}
@@ -63,7 +64,7 @@ func (g *mutableUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachVertexInAdjacencyList(g.list, vertex, f)
+	eachVertexInAdjacencyList(g.list, g.key(vertex), g.vertexStep(f))
 }
 
 // Indicates whether or not the given edge is present in the graph.
@@ -72,7 +73,7 @@ func (g *mutableUndirected) HasEdge(edge Edge) bool {
 	defer g.mu.RUnlock()
 
 	// Spread it into two expressions to avoid evaluating the second if possible
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	if _, exists := g.list[u][v]; exists {
 		return true
 	} else if _, exists := g.list[v][u]; exists {
@@ -101,14 +102,16 @@ func (g *mutableUndirected) RemoveVertex(vertices ...Vertex) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	for _, vertex := range vertices {
-		if g.hasVertex(vertex) {
+	for _, v := range vertices {
+		if vertex := g.key(v); g.hasVertex(vertex) {
+			// Count before unlinking, so a self-loop is not missed
+			g.size -= len(g.list[vertex])
 			eachVertexInAdjacencyList(g.list, vertex, func(adjacent Vertex) (terminate bool) {
 				delete(g.list[adjacent], vertex)
 				return
 			})
-			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.forget(vertex)
 		}
 	}
 	return
@@ -128,9 +131,18 @@ func (g *mutableUndirected) AddEdges(edges ...Edge) {
 
 // Adds a new edge to the graph.
 func (g *mutableUndirected) addEdges(edges ...Edge) {
+	g.presize(len(edges), true, func(f func(u, v Vertex)) {
+		for _, edge := range edges {
+			f(edge.Both())
+		}
+	})
+
 	for _, edge := range edges {
-		u, v := edge.Both()
-		g.ensureVertex(u, v)
+		u, v := g.keys(edge.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		g.ensureVertex(edge.Both())
 
 		if _, exists := g.list[u][v]; !exists {
 			g.list[u][v] = keyExists
@@ -151,7 +163,7 @@ func (g *mutableUndirected) RemoveEdges(edges ...Edge) {
 	defer g.mu.Unlock()
 
 	for _, edge := range edges {
-		s, t := edge.Both()
+		s, t := g.keys(edge.Both())
 		if _, exists := g.list[s][t]; exists {
 			delete(g.list[s], t)
 			delete(g.list[t], s)
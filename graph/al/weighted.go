@@ -9,9 +9,11 @@ import (
 
 // This is implemented as an adjacency list, because those are simple.
 type baseWeighted struct {
-	list map[Vertex]map[Vertex]float64
-	size int
-	mu   sync.RWMutex
+	vertexKeys
+	list  map[Vertex]map[Vertex]float64
+	size  int
+	mu    sync.RWMutex
+	loops bool // whether self-loops are permitted; if not, they are silently dropped
 }
 
 /* baseWeighted shared methods */
@@ -23,7 +25,7 @@ func (g *baseWeighted) Vertices(f VertexStep) {
 	defer g.mu.RUnlock()
 
 	for v := range g.list {
-		if f(v) {
+		if f(g.vertex(v)) {
 			return
 		}
 	}
@@ -40,7 +42,7 @@ func (g *baseWeighted) HasVertex(vertex Vertex) (exists bool) {
 
 // Indicates whether or not the given vertex is present in the graph.
 func (g *baseWeighted) hasVertex(vertex Vertex) (exists bool) {
-	_, exists = g.list[vertex]
+	_, exists = g.list[g.key(vertex)]
 	return
 }
 
@@ -52,6 +54,14 @@ func (g *baseWeighted) Order() int {
 	return len(g.list)
 }
 
+// Returns the number of vertices in the graph. The count is always exact.
+func (g *baseWeighted) VertexCount() (count int, exact bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.list), true
+}
+
 // Returns the size (number of edges) in the graph.
 func (g *baseWeighted) Size() int {
 	return g.size
@@ -74,9 +84,9 @@ func (g *baseWeighted) EnsureVertex(vertices ...Vertex) {
 // already present in the graph, it is a no-op (for that vertex only).
 func (g *baseWeighted) ensureVertex(vertices ...Vertex) {
 	for _, vertex := range vertices {
-		if !g.hasVertex(vertex) {
+		if key := g.keep(vertex); !g.hasVertex(key) {
 			// TODO experiment with different lengths...possibly by analyzing existing density?
-			g.list[vertex] = make(map[Vertex]float64, 10)
+			g.list[key] = make(map[Vertex]float64, 10)
 		}
 	}
 
@@ -96,7 +106,7 @@ func (g *weightedDirected) OutDegreeOf(vertex Vertex) (degree int, exists bool)
 	defer g.mu.RUnlock()
 
 	if exists = g.hasVertex(vertex); exists {
-		degree = len(g.list[vertex])
+		degree = len(g.list[g.key(vertex)])
 	}
 	return
 }
@@ -136,7 +146,7 @@ func (g *weightedDirected) AdjacentTo(start Vertex, f VertexStep) {
 
 	g.IncidentTo(start, func(e Edge) bool {
 		u, v := e.Both()
-		if u == start {
+		if g.key(u) == g.key(start) {
 			return f(v)
 		} else {
 			return f(u)
@@ -153,8 +163,9 @@ func (g *weightedDirected) ArcsFrom(v Vertex, f ArcStep) {
 		return
 	}
 
-	for adjacent, weight := range g.list[v] {
-		if f(NewWeightedArc(v, adjacent, weight)) {
+	k := g.key(v)
+	for adjacent, weight := range g.list[k] {
+		if f(NewWeightedArc(g.vertex(k), g.vertex(adjacent), weight)) {
 			return
 		}
 	}
@@ -164,7 +175,7 @@ func (g *weightedDirected) SuccessorsOf(v Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachVertexInAdjacencyList(g.list, v, f)
+	eachVertexInAdjacencyList(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Enumerates the set of in-edges for the provided vertex.
@@ -176,10 +187,11 @@ func (g *weightedDirected) ArcsTo(v Vertex, f ArcStep) {
 		return
 	}
 
+	k := g.key(v)
 	for candidate, adjacent := range g.list {
 		for target, weight := range adjacent {
-			if target == v {
-				if f(NewWeightedArc(candidate, target, weight)) {
+			if target == k {
+				if f(NewWeightedArc(g.vertex(candidate), g.vertex(target), weight)) {
 					return
 				}
 			}
@@ -191,7 +203,7 @@ func (g *weightedDirected) PredecessorsOf(v Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachPredecessorOf(g.list, v, f)
+	eachPredecessorOf(g.list, g.key(v), g.vertexStep(f))
 }
 
 // Traverses the set of edges in the graph, passing each edge to the
@@ -202,7 +214,7 @@ func (g *weightedDirected) Edges(f EdgeStep) {
 
 	for source, adjacent := range g.list {
 		for target, weight := range adjacent {
-			if f(NewWeightedEdge(source, target, weight)) {
+			if f(NewWeightedEdge(g.vertex(source), g.vertex(target), weight)) {
 				return
 			}
 		}
@@ -215,7 +227,7 @@ func (g *weightedDirected) HasEdge(edge Edge) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	_, exists := g.list[u][v]
 	if !exists {
 		_, exists = g.list[v][u]
@@ -228,7 +240,7 @@ func (g *weightedDirected) HasArc(arc Arc) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	_, exists := g.list[arc.Source()][arc.Target()]
+	_, exists := g.list[g.key(arc.Source())][g.key(arc.Target())]
 	return exists
 }
 
@@ -239,7 +251,7 @@ func (g *weightedDirected) HasWeightedEdge(edge WeightedEdge) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	if weight, exists := g.list[u][v]; exists {
 		return weight == edge.Weight()
 	} else if weight, exists = g.list[v][u]; exists {
@@ -255,7 +267,7 @@ func (g *weightedDirected) HasWeightedArc(arc WeightedArc) bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	if weight, exists := g.list[arc.Source()][arc.Target()]; exists {
+	if weight, exists := g.list[g.key(arc.Source())][g.key(arc.Target())]; exists {
 		return weight == arc.Weight()
 	}
 	return false
@@ -281,10 +293,11 @@ func (g *weightedDirected) RemoveVertex(vertices ...Vertex) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	for _, vertex := range vertices {
-		if g.hasVertex(vertex) {
+	for _, v := range vertices {
+		if vertex := g.key(v); g.hasVertex(vertex) {
 			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.forget(vertex)
 
 			for _, adjacent := range g.list {
 				if _, has := adjacent[vertex]; has {
@@ -312,10 +325,14 @@ func (g *weightedDirected) AddArcs(arcs ...WeightedArc) {
 // Adds a new arc to the graph.
 func (g *weightedDirected) addArcs(arcs ...WeightedArc) {
 	for _, arc := range arcs {
-		g.ensureVertex(arc.Source(), arc.Target())
+		u, v := g.keys(arc.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		g.ensureVertex(arc.Both())
 
-		if _, exists := g.list[arc.Source()][arc.Target()]; !exists {
-			g.list[arc.Source()][arc.Target()] = arc.Weight()
+		if _, exists := g.list[u][v]; !exists {
+			g.list[u][v] = arc.Weight()
 			g.size++
 		}
 	}
@@ -332,7 +349,7 @@ func (g *weightedDirected) RemoveArcs(arcs ...WeightedArc) {
 	defer g.mu.Unlock()
 
 	for _, arc := range arcs {
-		s, t := arc.Both()
+		s, t := g.keys(arc.Both())
 		if _, exists := g.list[s][t]; exists {
 			delete(g.list[s], t)
 			g.size--
@@ -346,9 +363,15 @@ func (g *weightedDirected) Transpose() Digraph {
 
 	g2 := &weightedDirected{}
 	g2.list = make(map[Vertex]map[Vertex]float64)
+	g2.vertexKeys = g.clone()
+	g2.loops = g.loops
+	g2.size = g.size
 
 	// Guess at average indegree by looking at ratio of edges to vertices, use that to initially size the adjacency maps
-	startcap := int(g.Size() / g.Order())
+	var startcap int
+	if len(g.list) > 0 {
+		startcap = g.size / len(g.list)
+	}
 
 	for source, adjacent := range g.list {
 		if !g2.hasVertex(source) {
@@ -379,7 +402,7 @@ func (g *weightedUndirected) DegreeOf(vertex Vertex) (degree int, exists bool) {
 	defer g.mu.RUnlock()
 
 	if exists = g.hasVertex(vertex); exists {
-		degree = len(g.list[vertex])
+		degree = len(g.list[g.key(vertex)])
 	}
 	return
 }
@@ -398,7 +421,7 @@ func (g *weightedUndirected) Edges(f EdgeStep) {
 			e = NewWeightedEdge(source, target, weight)
 			if !visited.Has(NewEdge(e.Both())) {
 				visited.Add(NewEdge(target, source))
-				if f(e) {
+				if f(NewWeightedEdge(g.vertex(source), g.vertex(target), weight)) {
 					return
 				}
 			}
@@ -414,7 +437,7 @@ func (g *weightedDirected) Arcs(f ArcStep) {
 
 	for source, adjacent := range g.list {
 		for target, weight := range adjacent {
-			if f(NewWeightedArc(source, target, weight)) {
+			if f(NewWeightedArc(g.vertex(source), g.vertex(target), weight)) {
 				return
 			}
 		}
@@ -430,8 +453,9 @@ func (g *weightedUndirected) IncidentTo(v Vertex, f EdgeStep) {
 		return
 	}
 
-	for adjacent, weight := range g.list[v] {
-		if f(NewWeightedEdge(v, adjacent, weight)) {
+	k := g.key(v)
+	for adjacent, weight := range g.list[k] {
+		if f(NewWeightedEdge(g.vertex(k), g.vertex(adjacent), weight)) {
 			return
 		}
 	}
@@ -442,7 +466,7 @@ func (g *weightedUndirected) AdjacentTo(vertex Vertex, f VertexStep) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
-	eachVertexInAdjacencyList(g.list, vertex, f)
+	eachVertexInAdjacencyList(g.list, g.key(vertex), g.vertexStep(f))
 }
 
 // Indicates whether or not the given edge is present in the graph. It matches
@@ -452,7 +476,7 @@ func (g *weightedUndirected) HasEdge(edge Edge) bool {
 	defer g.mu.RUnlock()
 
 	// Spread it into two expressions to avoid evaluating the second if possible
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	if _, exists := g.list[u][v]; exists {
 		return true
 	} else if _, exists := g.list[v][u]; exists {
@@ -469,7 +493,7 @@ func (g *weightedUndirected) HasWeightedEdge(edge WeightedEdge) bool {
 	defer g.mu.RUnlock()
 
 	// Spread it into two expressions to avoid evaluating the second if possible
-	u, v := edge.Both()
+	u, v := g.keys(edge.Both())
 	if weight, exists := g.list[u][v]; exists {
 		return edge.Weight() == weight
 	} else if weight, exists := g.list[v][u]; exists {
@@ -498,14 +522,16 @@ func (g *weightedUndirected) RemoveVertex(vertices ...Vertex) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	for _, vertex := range vertices {
-		if g.hasVertex(vertex) {
+	for _, v := range vertices {
+		if vertex := g.key(v); g.hasVertex(vertex) {
+			// Count before unlinking, so a self-loop is not missed
+			g.size -= len(g.list[vertex])
 			eachVertexInAdjacencyList(g.list, vertex, func(adjacent Vertex) (terminate bool) {
 				delete(g.list[adjacent], vertex)
 				return
 			})
-			g.size -= len(g.list[vertex])
 			delete(g.list, vertex)
+			g.forget(vertex)
 		}
 	}
 	return
@@ -526,8 +552,11 @@ func (g *weightedUndirected) AddEdges(edges ...WeightedEdge) {
 // Adds a new edge to the graph.
 func (g *weightedUndirected) addEdges(edges ...WeightedEdge) {
 	for _, edge := range edges {
-		u, v := edge.Both()
-		g.ensureVertex(u, v)
+		u, v := g.keys(edge.Both())
+		if u == v && !g.loops {
+			continue
+		}
+		g.ensureVertex(edge.Both())
 
 		if _, exists := g.list[u][v]; !exists {
 			w := edge.Weight()
@@ -549,7 +578,7 @@ func (g *weightedUndirected) RemoveEdges(edges ...WeightedEdge) {
 	defer g.mu.Unlock()
 
 	for _, edge := range edges {
-		s, t := edge.Both()
+		s, t := g.keys(edge.Both())
 		if _, exists := g.list[s][t]; exists {
 			delete(g.list[s], t)
 			delete(g.list[t], s)
@@ -0,0 +1,368 @@
+// Contains a read-only graph implementation backed by Compressed Sparse Row arrays.
+package csr
+
+import (
+	"sort"
+
+	"github.com/sdboyer/gogl"
+)
+
+/*
+Compressed Sparse Row (CSR) graphs store adjacency in a handful of flat slices,
+rather than in maps. For each vertex i, the targets of its out-edges occupy
+targets[offsets[i]:offsets[i+1]], sorted ascending; if the graph is weighted,
+the corresponding weights occupy the same range of a parallel weights slice.
+
+This representation is built once, and cannot be modified thereafter. In
+exchange, iteration is sequential and cache-friendly, degree lookups are O(1),
+edge membership checks are O(log d), and the memory overhead per edge is a
+single int (plus a float64, if weighted). That makes CSR well-suited to very
+large, read-only graphs, where the map-based adjacency lists are slow and
+memory-heavy.
+
+Vertices in a CSR graph are dense integers: 0, 1, ..., n-1. If the source's
+vertices are already exactly those integers, they are kept as-is. Otherwise,
+each source vertex is numbered in the order the source enumerates it (with
+vertices only seen as edge endpoints numbered last), and SourceVertex() maps
+a dense integer back to the original vertex.
+
+Like the adjacency lists, undirected CSR graphs store each edge in both
+directions, so their memory cost is proportional to V + 2E. Directed CSR
+graphs store both the out-edges and in-edges of each vertex, so that
+predecessor queries are as fast as successor queries; their cost is also
+V + 2E.
+*/
+
+// New builds a CSR graph from the provided source.
+//
+// If the source is a DigraphSource, the result is a gogl.Digraph, built from the
+// source's arcs. If the first edge the source produces is a gogl.WeightedEdge, the
+// result is a gogl.WeightedGraph (or gogl.WeightedDigraph), and any subsequent
+// unweighted edges are given a weight of 0. Other edge properties are discarded.
+//
+// Parallel edges in the source are collapsed; of their weights, the first seen is kept.
+func New(src gogl.GraphSource) gogl.Graph {
+	b := &builder{index: make(map[gogl.Vertex]int)}
+
+	src.Vertices(func(v gogl.Vertex) (terminate bool) {
+		b.vertex(v)
+		return
+	})
+
+	if dsrc, ok := src.(gogl.DigraphSource); ok {
+		dsrc.Arcs(func(a gogl.Arc) (terminate bool) {
+			b.edge(a, a.Source(), a.Target())
+			return
+		})
+		b.canonicalize()
+
+		g := &directed{
+			base: base{vertices: b.vertices, out: b.compress(b.us, b.vs)},
+			in:   b.compress(b.vs, b.us),
+		}
+		g.size = len(g.out.targets)
+
+		if b.weighted {
+			return &weightedDirected{*g}
+		}
+		return g
+	}
+
+	src.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		b.edge(e, u, v)
+		return
+	})
+	b.canonicalize()
+	b.dedupUndirected()
+
+	// Record each edge in both directions, except loops.
+	n := len(b.us)
+	for i := 0; i < n; i++ {
+		if b.us[i] != b.vs[i] {
+			b.us, b.vs = append(b.us, b.vs[i]), append(b.vs, b.us[i])
+			if b.weighted {
+				b.ws = append(b.ws, b.ws[i])
+			}
+		}
+	}
+
+	g := &undirected{base{vertices: b.vertices, out: b.compress(b.us, b.vs)}}
+	for i := range g.vertices {
+		row := g.out.row(i)
+		for _, t := range row {
+			if t >= i {
+				g.size++
+			}
+		}
+	}
+
+	if b.weighted {
+		return &weightedUndirected{*g}
+	}
+	return g
+}
+
+// Accumulates vertices and edges from a source prior to compression.
+type builder struct {
+	vertices []gogl.Vertex
+	index    map[gogl.Vertex]int
+	weighted bool
+	started  bool
+	us, vs   []int
+	ws       []float64
+}
+
+func (b *builder) vertex(v gogl.Vertex) int {
+	i, exists := b.index[v]
+	if !exists {
+		i = len(b.vertices)
+		b.index[v] = i
+		b.vertices = append(b.vertices, v)
+	}
+	return i
+}
+
+func (b *builder) edge(e gogl.Edge, u, v gogl.Vertex) {
+	if !b.started {
+		_, b.weighted = e.(gogl.WeightedEdge)
+		b.started = true
+	}
+
+	b.us = append(b.us, b.vertex(u))
+	b.vs = append(b.vs, b.vertex(v))
+
+	if b.weighted {
+		var w float64
+		if we, ok := e.(gogl.WeightedEdge); ok {
+			w = we.Weight()
+		}
+		b.ws = append(b.ws, w)
+	}
+}
+
+// If the source vertices are precisely the integers 0..n-1, renumbers so that each
+// vertex's dense integer is the vertex itself.
+func (b *builder) canonicalize() {
+	n := len(b.vertices)
+	perm := make([]int, n)
+	seen := make([]bool, n)
+	for i, v := range b.vertices {
+		iv, ok := v.(int)
+		if !ok || iv < 0 || iv >= n || seen[iv] {
+			return
+		}
+		perm[i], seen[iv] = iv, true
+	}
+
+	for i := range b.us {
+		b.us[i], b.vs[i] = perm[b.us[i]], perm[b.vs[i]]
+	}
+	for i := range b.vertices {
+		b.vertices[i] = i
+	}
+}
+
+// Drops all but the first of any undirected edges joining the same pair of
+// vertices, whichever way round they are given. This must precede mirroring, as
+// compress deduplicates each row separately, and so could otherwise keep a
+// different edge's weight in each direction.
+func (b *builder) dedupUndirected() {
+	seen := make(map[[2]int]bool, len(b.us))
+	w := 0
+	for i := range b.us {
+		u, v := b.us[i], b.vs[i]
+		if u > v {
+			u, v = v, u
+		}
+		if seen[[2]int{u, v}] {
+			continue
+		}
+		seen[[2]int{u, v}] = true
+
+		b.us[w], b.vs[w] = b.us[i], b.vs[i]
+		if b.weighted {
+			b.ws[w] = b.ws[i]
+		}
+		w++
+	}
+
+	b.us, b.vs = b.us[:w], b.vs[:w]
+	if b.weighted {
+		b.ws = b.ws[:w]
+	}
+}
+
+// Compresses the given edge endpoints into a sorted, deduplicated adjacency. Edge i
+// runs from from[i] to to[i], with weight b.ws[i] (if weighted).
+func (b *builder) compress(from, to []int) adjacency {
+	n := len(b.vertices)
+	adj := adjacency{offsets: make([]int, n+1)}
+
+	// Counting sort by source vertex; stable, so the first of any parallel edges wins.
+	for _, u := range from {
+		adj.offsets[u+1]++
+	}
+	for i := 0; i < n; i++ {
+		adj.offsets[i+1] += adj.offsets[i]
+	}
+
+	adj.targets = make([]int, len(from))
+	if b.weighted {
+		adj.weights = make([]float64, len(from))
+	}
+
+	next := make([]int, n)
+	copy(next, adj.offsets)
+	for i, u := range from {
+		adj.targets[next[u]] = to[i]
+		if b.weighted {
+			adj.weights[next[u]] = b.ws[i]
+		}
+		next[u]++
+	}
+
+	// Sort each row by target, then squeeze out duplicates, shifting rows down.
+	w := 0
+	for i := 0; i < n; i++ {
+		start, end := adj.offsets[i], adj.offsets[i+1]
+		sort.Stable(rowSorter{adj.targets[start:end], weightsOf(adj.weights, start, end)})
+
+		adj.offsets[i] = w
+		for j := start; j < end; j++ {
+			if j > start && adj.targets[j] == adj.targets[w-1] {
+				continue
+			}
+			adj.targets[w] = adj.targets[j]
+			if b.weighted {
+				adj.weights[w] = adj.weights[j]
+			}
+			w++
+		}
+	}
+	adj.offsets[n] = w
+
+	adj.targets = adj.targets[:w:w]
+	if b.weighted {
+		adj.weights = adj.weights[:w:w]
+	}
+	return adj
+}
+
+func weightsOf(weights []float64, start, end int) []float64 {
+	if weights == nil {
+		return nil
+	}
+	return weights[start:end]
+}
+
+type rowSorter struct {
+	targets []int
+	weights []float64
+}
+
+func (r rowSorter) Len() int           { return len(r.targets) }
+func (r rowSorter) Less(i, j int) bool { return r.targets[i] < r.targets[j] }
+func (r rowSorter) Swap(i, j int) {
+	r.targets[i], r.targets[j] = r.targets[j], r.targets[i]
+	if r.weights != nil {
+		r.weights[i], r.weights[j] = r.weights[j], r.weights[i]
+	}
+}
+
+// One direction of adjacency, in CSR form.
+type adjacency struct {
+	offsets []int
+	targets []int
+	weights []float64 // nil if unweighted
+}
+
+func (adj adjacency) row(i int) []int {
+	return adj.targets[adj.offsets[i]:adj.offsets[i+1]]
+}
+
+// Returns the position of the u->v entry within targets, or -1.
+func (adj adjacency) find(u, v int) int {
+	start, end := adj.offsets[u], adj.offsets[u+1]
+	j := start + sort.SearchInts(adj.targets[start:end], v)
+	if j < end && adj.targets[j] == v {
+		return j
+	}
+	return -1
+}
+
+// Produces the edge at the given position, weighted if the adjacency is.
+func (adj adjacency) edge(u, pos int) gogl.Edge {
+	if adj.weights != nil {
+		return gogl.NewWeightedEdge(u, adj.targets[pos], adj.weights[pos])
+	}
+	return gogl.NewEdge(u, adj.targets[pos])
+}
+
+// Produces the arc at the given position, weighted if the adjacency is.
+func (adj adjacency) arc(u, pos int) gogl.Arc {
+	if adj.weights != nil {
+		return gogl.NewWeightedArc(u, adj.targets[pos], adj.weights[pos])
+	}
+	return gogl.NewArc(u, adj.targets[pos])
+}
+
+// Functionality shared by the directed and undirected CSR graphs.
+type base struct {
+	vertices []gogl.Vertex // dense integer -> original source vertex
+	out      adjacency
+	size     int
+}
+
+// Converts a vertex to its dense integer, if it is present in the graph.
+func (g *base) id(v gogl.Vertex) (int, bool) {
+	i, ok := v.(int)
+	return i, ok && i >= 0 && i < len(g.vertices)
+}
+
+// Returns the vertex from the source graph that was numbered i, or nil if there
+// is no such vertex.
+func (g *base) SourceVertex(i int) gogl.Vertex {
+	if i < 0 || i >= len(g.vertices) {
+		return nil
+	}
+	return g.vertices[i]
+}
+
+// Traverses the graph's vertices in ascending order, passing each vertex to the
+// provided closure.
+func (g *base) Vertices(f gogl.VertexStep) {
+	for i := range g.vertices {
+		if f(i) {
+			return
+		}
+	}
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *base) HasVertex(v gogl.Vertex) bool {
+	_, exists := g.id(v)
+	return exists
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *base) Order() int {
+	return len(g.vertices)
+}
+
+// Returns the size (number of edges) in the graph.
+func (g *base) Size() int {
+	return g.size
+}
+
+// Enumerates the successors of the provided vertex; in an undirected graph, that
+// is all adjacent vertices.
+func (g *base) eachSuccessor(v gogl.Vertex, f gogl.VertexStep) {
+	if i, exists := g.id(v); exists {
+		for _, t := range g.out.row(i) {
+			if f(t) {
+				return
+			}
+		}
+	}
+}
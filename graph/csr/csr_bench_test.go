@@ -0,0 +1,36 @@
+package csr
+
+import (
+	stdrand "math/rand"
+	"testing"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+// A Bernoulli graph with 2000 vertices and ρ = 0.5 has ~1M edges.
+func millionEdgeSource() gogl.GraphSource {
+	return rand.BernoulliDistribution(2000, 0.5, false, true, stdrand.NewSource(1))
+}
+
+func benchmarkEdges(b *testing.B, g gogl.Graph) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var n int
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			n++
+			return
+		})
+	}
+}
+
+func BenchmarkEdgesCSR(b *testing.B) {
+	benchmarkEdges(b, New(millionEdgeSource()))
+}
+
+func BenchmarkEdgesAdjacencyList(b *testing.B) {
+	benchmarkEdges(b, gogl.Spec().Using(millionEdgeSource()).Create(al.G))
+}
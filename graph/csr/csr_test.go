@@ -0,0 +1,201 @@
+package csr
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type CSRSuite struct{}
+
+var _ = Suite(&CSRSuite{})
+
+// Source vertices are strings, so these also exercise renumbering.
+var csrArcs = gogl.WeightedArcList{
+	gogl.NewWeightedArc("foo", "bar", 1.5),
+	gogl.NewWeightedArc("bar", "baz", 2),
+	gogl.NewWeightedArc("foo", "qux", 3),
+	gogl.NewWeightedArc("qux", "foo", 4),
+	gogl.NewWeightedArc("foo", "bar", 99), // parallel; first weight wins
+}
+
+func (s *CSRSuite) TestDirectedWeighted(c *C) {
+	g := New(csrArcs)
+
+	wg, ok := g.(gogl.WeightedDigraph)
+	c.Assert(ok, Equals, true)
+
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(gogl.Size(g), Equals, 4)
+
+	// Source vertices are renumbered, in whatever order the source enumerated them.
+	id := make(map[gogl.Vertex]int)
+	for i := 0; i < 4; i++ {
+		id[g.(*weightedDirected).SourceVertex(i)] = i
+	}
+	c.Assert(len(id), Equals, 4)
+	c.Assert(g.(*weightedDirected).SourceVertex(4), IsNil)
+	c.Assert(g.HasVertex(3), Equals, true)
+	c.Assert(g.HasVertex(4), Equals, false)
+	c.Assert(g.HasVertex("foo"), Equals, false)
+
+	foo, bar, baz, qux := id["foo"], id["bar"], id["baz"], id["qux"]
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc(foo, bar, 1.5)), Equals, true)
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc(foo, bar, 99)), Equals, false)
+	c.Assert(wg.HasWeightedEdge(gogl.NewWeightedEdge(baz, bar, 2)), Equals, true)
+	c.Assert(wg.HasArc(gogl.NewArc(baz, bar)), Equals, false)
+	c.Assert(wg.HasEdge(gogl.NewEdge(baz, bar)), Equals, true)
+
+	deg, exists := wg.OutDegreeOf(foo)
+	c.Assert(exists, Equals, true)
+	c.Assert(deg, Equals, 2)
+	deg, _ = wg.InDegreeOf(foo)
+	c.Assert(deg, Equals, 1)
+	deg, _ = wg.DegreeOf(foo)
+	c.Assert(deg, Equals, 3)
+	_, exists = wg.DegreeOf(42)
+	c.Assert(exists, Equals, false)
+
+	var succ, pred []gogl.Vertex
+	wg.SuccessorsOf(foo, func(v gogl.Vertex) (terminate bool) {
+		succ = append(succ, v)
+		return
+	})
+	wg.PredecessorsOf(bar, func(v gogl.Vertex) (terminate bool) {
+		pred = append(pred, v)
+		return
+	})
+	c.Assert(len(succ), Equals, 2)
+	c.Assert(succ[0] == bar || succ[1] == bar, Equals, true)
+	c.Assert(succ[0] == qux || succ[1] == qux, Equals, true)
+	c.Assert(pred, DeepEquals, []gogl.Vertex{foo})
+
+	var total float64
+	wg.Arcs(func(a gogl.Arc) (terminate bool) {
+		total += a.(gogl.WeightedArc).Weight()
+		return
+	})
+	c.Assert(total, Equals, 10.5)
+
+	var in []gogl.Arc
+	wg.ArcsTo(foo, func(a gogl.Arc) (terminate bool) {
+		in = append(in, a)
+		return
+	})
+	c.Assert(in, DeepEquals, []gogl.Arc{gogl.NewWeightedArc(qux, foo, 4)})
+
+	var hit int
+	wg.IncidentTo(foo, func(e gogl.Edge) bool {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+
+	tg := wg.Transpose().(gogl.WeightedDigraph)
+	c.Assert(tg.HasWeightedArc(gogl.NewWeightedArc(bar, foo, 1.5)), Equals, true)
+	c.Assert(tg.HasArc(gogl.NewArc(foo, bar)), Equals, false)
+	c.Assert(gogl.Size(tg), Equals, 4)
+}
+
+func (s *CSRSuite) TestUndirected(c *C) {
+	g := New(gogl.EdgeList{
+		gogl.NewEdge(0, 1),
+		gogl.NewEdge(1, 2),
+		gogl.NewEdge(2, 1), // duplicate in the other direction
+		gogl.NewEdge(2, 2), // loop
+	})
+
+	_, directed := g.(gogl.Digraph)
+	c.Assert(directed, Equals, false)
+	_, weighted := g.(gogl.WeightedGraph)
+	c.Assert(weighted, Equals, false)
+
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(gogl.Size(g), Equals, 3)
+	c.Assert(g.HasEdge(gogl.NewEdge(1, 0)), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge(2, 2)), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge(0, 2)), Equals, false)
+
+	var edges int
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		edges++
+		return
+	})
+	c.Assert(edges, Equals, 3)
+
+	var adj []gogl.Vertex
+	g.AdjacentTo(1, func(v gogl.Vertex) (terminate bool) {
+		adj = append(adj, v)
+		return
+	})
+	// Integer source vertices forming 0..n-1 keep their identity.
+	c.Assert(adj, DeepEquals, []gogl.Vertex{0, 2})
+
+	deg, _ := g.DegreeOf(1)
+	c.Assert(deg, Equals, 2)
+	c.Assert(g.(gogl.SimpleGraph).Density(), Equals, 1.0)
+}
+
+func (s *CSRSuite) TestUndirectedParallelWeights(c *C) {
+	g := New(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge(0, 1, 5),
+		gogl.NewWeightedEdge(1, 0, 7), // duplicate in the other direction
+	}).(gogl.WeightedGraph)
+
+	// The first weight seen must be kept, whichever end the edge is viewed from.
+	c.Assert(gogl.Size(g), Equals, 1)
+	c.Assert(g.HasWeightedEdge(gogl.NewWeightedEdge(0, 1, 5)), Equals, true)
+	c.Assert(g.HasWeightedEdge(gogl.NewWeightedEdge(1, 0, 5)), Equals, true)
+	c.Assert(g.HasWeightedEdge(gogl.NewWeightedEdge(1, 0, 7)), Equals, false)
+
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		c.Assert(e.(gogl.WeightedEdge).Weight(), Equals, 5.0)
+		return
+	})
+	for _, v := range []int{0, 1} {
+		g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+			c.Assert(e.(gogl.WeightedEdge).Weight(), Equals, 5.0, Commentf("incident to %v", v))
+			return
+		})
+	}
+}
+
+func (s *CSRSuite) TestIsolatesAndEmpty(c *C) {
+	g := New(gogl.NullGraph)
+	c.Assert(gogl.Order(g), Equals, 0)
+	c.Assert(gogl.Size(g), Equals, 0)
+
+	src := gogl.Spec().Using(gogl.EdgeList{gogl.NewEdge("a", "b")}).Create(al.G).(gogl.MutableGraph)
+	src.EnsureVertex("isolate")
+
+	g = New(src)
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(gogl.Size(g), Equals, 1)
+}
+
+func (s *CSRSuite) TestMatchesAdjacencyList(c *C) {
+	bern := rand.BernoulliDistribution(50, 0.2, true, true, nil)
+	alg := gogl.Spec().Directed().Using(bern).Create(al.G).(gogl.Digraph)
+	g := New(bern).(gogl.Digraph)
+
+	c.Assert(gogl.Order(g), Equals, gogl.Order(alg))
+	c.Assert(gogl.Size(g), Equals, gogl.Size(alg))
+
+	// The Bernoulli generator's vertices are already 0..n-1 in order, so numbering is the identity.
+	alg.Arcs(func(a gogl.Arc) (terminate bool) {
+		c.Assert(g.HasArc(a), Equals, true)
+		return
+	})
+
+	for i := 0; i < 50; i++ {
+		ad, _ := alg.InDegreeOf(i)
+		gd, _ := g.InDegreeOf(i)
+		c.Assert(gd, Equals, ad)
+	}
+}
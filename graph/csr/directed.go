@@ -0,0 +1,202 @@
+package csr
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+type directed struct {
+	base
+	in adjacency // predecessors of each vertex; the transpose of out
+}
+
+// Produces the arc at the given position in the in-adjacency of vertex i.
+func (g *directed) inArc(i, pos int) gogl.Arc {
+	if g.in.weights != nil {
+		return gogl.NewWeightedArc(g.in.targets[pos], i, g.in.weights[pos])
+	}
+	return gogl.NewArc(g.in.targets[pos], i)
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *directed) Edges(f gogl.EdgeStep) {
+	for i := range g.vertices {
+		for pos := g.out.offsets[i]; pos < g.out.offsets[i+1]; pos++ {
+			if f(g.out.edge(i, pos)) {
+				return
+			}
+		}
+	}
+}
+
+// Traverses the set of arcs in the graph, passing each arc to the
+// provided closure.
+func (g *directed) Arcs(f gogl.ArcStep) {
+	for i := range g.vertices {
+		for pos := g.out.offsets[i]; pos < g.out.offsets[i+1]; pos++ {
+			if f(g.out.arc(i, pos)) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerates the set of out-edges for the provided vertex.
+func (g *directed) ArcsFrom(v gogl.Vertex, f gogl.ArcStep) {
+	if i, exists := g.id(v); exists {
+		for pos := g.out.offsets[i]; pos < g.out.offsets[i+1]; pos++ {
+			if f(g.out.arc(i, pos)) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerates the set of in-edges for the provided vertex.
+func (g *directed) ArcsTo(v gogl.Vertex, f gogl.ArcStep) {
+	if i, exists := g.id(v); exists {
+		for pos := g.in.offsets[i]; pos < g.in.offsets[i+1]; pos++ {
+			if f(g.inArc(i, pos)) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *directed) IncidentTo(v gogl.Vertex, f gogl.EdgeStep) {
+	var terminate bool
+	interloper := func(a gogl.Arc) bool {
+		terminate = terminate || f(a)
+		return terminate
+	}
+
+	g.ArcsFrom(v, interloper)
+	if !terminate {
+		g.ArcsTo(v, interloper)
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *directed) AdjacentTo(start gogl.Vertex, f gogl.VertexStep) {
+	g.IncidentTo(start, func(e gogl.Edge) bool {
+		u, v := e.Both()
+		if u == start {
+			return f(v)
+		}
+		return f(u)
+	})
+}
+
+func (g *directed) SuccessorsOf(v gogl.Vertex, f gogl.VertexStep) {
+	g.eachSuccessor(v, f)
+}
+
+func (g *directed) PredecessorsOf(v gogl.Vertex, f gogl.VertexStep) {
+	if i, exists := g.id(v); exists {
+		for _, s := range g.in.row(i) {
+			if f(s) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the outdegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *directed) OutDegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	var i int
+	if i, exists = g.id(v); exists {
+		degree = g.out.offsets[i+1] - g.out.offsets[i]
+	}
+	return
+}
+
+// Returns the indegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *directed) InDegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	var i int
+	if i, exists = g.id(v); exists {
+		degree = g.in.offsets[i+1] - g.in.offsets[i]
+	}
+	return
+}
+
+// Returns the degree of the provided vertex, counting both in and out-edges.
+func (g *directed) DegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	var i int
+	if i, exists = g.id(v); exists {
+		degree = g.out.offsets[i+1] - g.out.offsets[i] + g.in.offsets[i+1] - g.in.offsets[i]
+	}
+	return
+}
+
+// Indicates whether or not the given edge is present in the graph.
+func (g *directed) HasEdge(e gogl.Edge) bool {
+	u, v := e.Both()
+	ui, uexists := g.id(u)
+	vi, vexists := g.id(v)
+	return uexists && vexists && (g.out.find(ui, vi) != -1 || g.out.find(vi, ui) != -1)
+}
+
+// Indicates whether or not the given arc is present in the graph.
+func (g *directed) HasArc(a gogl.Arc) bool {
+	ui, uexists := g.id(a.Source())
+	vi, vexists := g.id(a.Target())
+	return uexists && vexists && g.out.find(ui, vi) != -1
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *directed) Density() float64 {
+	order := g.Order()
+	return float64(g.Size()) / float64(order*(order-1))
+}
+
+// Returns a graph with the same vertices and the directionality of every arc
+// reversed. The transpose shares storage with the original, so this is O(1).
+func (g *directed) Transpose() gogl.Digraph {
+	return &directed{base{vertices: g.vertices, out: g.in, size: g.size}, g.out}
+}
+
+type weightedDirected struct {
+	directed
+}
+
+// Indicates whether or not the given weighted edge is present in the graph.
+// It will only match if the provided WeightedEdge has the same weight as
+// the edge contained in the graph.
+func (g *weightedDirected) HasWeightedEdge(e gogl.WeightedEdge) bool {
+	u, v := e.Both()
+	ui, uexists := g.id(u)
+	vi, vexists := g.id(v)
+	if !uexists || !vexists {
+		return false
+	}
+
+	if pos := g.out.find(ui, vi); pos != -1 && g.out.weights[pos] == e.Weight() {
+		return true
+	}
+	pos := g.out.find(vi, ui)
+	return pos != -1 && g.out.weights[pos] == e.Weight()
+}
+
+// Indicates whether or not the given weighted arc is present in the graph.
+// It will only match if the provided WeightedArc has the same weight as
+// the arc contained in the graph.
+func (g *weightedDirected) HasWeightedArc(a gogl.WeightedArc) bool {
+	ui, uexists := g.id(a.Source())
+	vi, vexists := g.id(a.Target())
+	if !uexists || !vexists {
+		return false
+	}
+
+	pos := g.out.find(ui, vi)
+	return pos != -1 && g.out.weights[pos] == a.Weight()
+}
+
+// Returns a graph with the same vertices and the directionality of every arc
+// reversed, with weights preserved.
+func (g *weightedDirected) Transpose() gogl.Digraph {
+	return &weightedDirected{*g.directed.Transpose().(*directed)}
+}
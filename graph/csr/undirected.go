@@ -0,0 +1,84 @@
+package csr
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+type undirected struct {
+	base
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *undirected) Edges(f gogl.EdgeStep) {
+	for i := range g.vertices {
+		for pos := g.out.offsets[i]; pos < g.out.offsets[i+1]; pos++ {
+			// Each edge is stored from both ends; only report it from its lower end.
+			if g.out.targets[pos] >= i {
+				if f(g.out.edge(i, pos)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *undirected) IncidentTo(v gogl.Vertex, f gogl.EdgeStep) {
+	if i, exists := g.id(v); exists {
+		for pos := g.out.offsets[i]; pos < g.out.offsets[i+1]; pos++ {
+			if f(g.out.edge(i, pos)) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *undirected) AdjacentTo(v gogl.Vertex, f gogl.VertexStep) {
+	g.eachSuccessor(v, f)
+}
+
+// Returns the degree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *undirected) DegreeOf(v gogl.Vertex) (degree int, exists bool) {
+	var i int
+	if i, exists = g.id(v); exists {
+		degree = g.out.offsets[i+1] - g.out.offsets[i]
+	}
+	return
+}
+
+// Indicates whether or not the given edge is present in the graph.
+func (g *undirected) HasEdge(e gogl.Edge) bool {
+	u, v := e.Both()
+	ui, uexists := g.id(u)
+	vi, vexists := g.id(v)
+	return uexists && vexists && g.out.find(ui, vi) != -1
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *undirected) Density() float64 {
+	order := g.Order()
+	return 2 * float64(g.Size()) / float64(order*(order-1))
+}
+
+type weightedUndirected struct {
+	undirected
+}
+
+// Indicates whether or not the given weighted edge is present in the graph.
+// It will only match if the provided WeightedEdge has the same weight as
+// the edge contained in the graph.
+func (g *weightedUndirected) HasWeightedEdge(e gogl.WeightedEdge) bool {
+	u, v := e.Both()
+	ui, uexists := g.id(u)
+	vi, vexists := g.id(v)
+	if !uexists || !vexists {
+		return false
+	}
+
+	pos := g.out.find(ui, vi)
+	return pos != -1 && g.out.weights[pos] == e.Weight()
+}
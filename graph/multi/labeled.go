@@ -0,0 +1,306 @@
+package multi
+
+import (
+	. "github.com/sdboyer/gogl"
+)
+
+// Identifies an edge by its endpoints' keys, as oriented, and its label.
+type labeledKey struct {
+	u, v  interface{}
+	label string
+}
+
+func newLabeledKey(u, v Vertex, label string) labeledKey {
+	return labeledKey{VertexKey(u), VertexKey(v), label}
+}
+
+func (k labeledKey) reverse() labeledKey {
+	return labeledKey{k.v, k.u, k.label}
+}
+
+// A read-only labeled multigraph.
+//
+// Edges are kept in a slice, and each vertex records the positions of its
+// incident edges. In a digraph, out holds the positions of a vertex's out-arcs and
+// in those of its in-arcs; in an undirected graph, out holds all of its edges,
+// listing a loop once, and in is unused.
+type multigraph struct {
+	vertices []Vertex
+	index    map[interface{}]int // keyed by VertexKey
+	edges    []LabeledEdge
+	out, in  [][]int
+	labeled  map[labeledKey]bool     // each edge, in both orientations if undirected
+	pairs    map[[2]interface{}]bool // each pair of endpoints, likewise
+}
+
+// A multigraph holding arcs.
+type multidigraph struct {
+	*multigraph
+}
+
+var _ LabeledGraph = &multigraph{}
+var _ LabeledDigraph = multidigraph{}
+
+// Creates a labeled multigraph with the given vertices and edges. The endpoints
+// of the edges are added as vertices if they are not among those enumerated.
+// Duplicate edges are not removed.
+func newMultigraph(vertices VertexEnumerator, directed bool, edges []LabeledEdge) LabeledGraph {
+	g := &multigraph{
+		index:   make(map[interface{}]int),
+		edges:   edges,
+		labeled: make(map[labeledKey]bool, len(edges)),
+		pairs:   make(map[[2]interface{}]bool, len(edges)),
+	}
+
+	add := func(v Vertex) int {
+		k := VertexKey(v)
+		if i, exists := g.index[k]; exists {
+			return i
+		}
+		g.index[k] = len(g.vertices)
+		g.vertices = append(g.vertices, v)
+		g.out = append(g.out, nil)
+		g.in = append(g.in, nil)
+		return len(g.vertices) - 1
+	}
+
+	vertices.Vertices(func(v Vertex) (terminate bool) {
+		add(v)
+		return
+	})
+
+	for i, e := range edges {
+		u, v := e.Both()
+		ui, vi := add(u), add(v)
+
+		k := newLabeledKey(u, v, e.Label())
+		g.labeled[k] = true
+		g.pairs[[2]interface{}{k.u, k.v}] = true
+
+		if directed {
+			g.out[ui] = append(g.out[ui], i)
+			g.in[vi] = append(g.in[vi], i)
+		} else {
+			g.labeled[k.reverse()] = true
+			g.pairs[[2]interface{}{k.v, k.u}] = true
+
+			g.out[ui] = append(g.out[ui], i)
+			if ui != vi {
+				g.out[vi] = append(g.out[vi], i)
+			}
+		}
+	}
+
+	if directed {
+		return multidigraph{g}
+	}
+	return g
+}
+
+// Traverses the graph's vertices, passing each vertex to the provided closure.
+func (g *multigraph) Vertices(f VertexStep) {
+	for _, v := range g.vertices {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Traverses the graph's edges, passing each edge to the provided closure.
+// Parallel edges are each passed.
+func (g *multigraph) Edges(f EdgeStep) {
+	for _, e := range g.edges {
+		if f(e) {
+			return
+		}
+	}
+}
+
+// Enumerates the edges incident to the provided vertex. In a digraph, this
+// includes both its out- and in-arcs.
+func (g *multigraph) IncidentTo(v Vertex, f EdgeStep) {
+	i, exists := g.index[VertexKey(v)]
+	if !exists {
+		return
+	}
+
+	for _, list := range [][]int{g.out[i], g.in[i]} {
+		for _, ei := range list {
+			if f(g.edges[ei]) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex, each once, however
+// many edges join them.
+func (g *multigraph) AdjacentTo(v Vertex, f VertexStep) {
+	i, exists := g.index[VertexKey(v)]
+	if !exists {
+		return
+	}
+
+	g.eachNeighbor(func(e Edge) Vertex {
+		return otherEnd(e, v)
+	}, f, g.out[i], g.in[i])
+}
+
+// Passes the vertex picked from each of the edges at the listed positions,
+// skipping any that has already been passed.
+func (g *multigraph) eachNeighbor(pick func(Edge) Vertex, f VertexStep, lists ...[]int) {
+	seen := make(map[interface{}]bool)
+	for _, list := range lists {
+		for _, ei := range list {
+			w := pick(g.edges[ei])
+			if k := VertexKey(w); !seen[k] {
+				seen[k] = true
+				if f(w) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns the endpoint of the edge opposite the given vertex.
+func otherEnd(e Edge, v Vertex) Vertex {
+	u, w := e.Both()
+	if VertexKey(u) == VertexKey(v) {
+		return w
+	}
+	return u
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *multigraph) HasVertex(v Vertex) bool {
+	_, exists := g.index[VertexKey(v)]
+	return exists
+}
+
+// Indicates whether the graph has any edge between the given edge's endpoints,
+// regardless of label or direction.
+func (g *multigraph) HasEdge(e Edge) bool {
+	u, v := e.Both()
+	ku, kv := VertexKey(u), VertexKey(v)
+	return g.pairs[[2]interface{}{ku, kv}] || g.pairs[[2]interface{}{kv, ku}]
+}
+
+// Indicates whether the graph has an edge between the given edge's endpoints with
+// the same label, regardless of direction.
+func (g *multigraph) HasLabeledEdge(e LabeledEdge) bool {
+	u, v := e.Both()
+	k := newLabeledKey(u, v, e.Label())
+	return g.labeled[k] || g.labeled[k.reverse()]
+}
+
+// Returns the number of edges incident to the provided vertex, counting each of
+// a set of parallel edges. If the vertex is not present in the graph, the second
+// return value will be false.
+func (g *multigraph) DegreeOf(v Vertex) (degree int, exists bool) {
+	i, exists := g.index[VertexKey(v)]
+	if exists {
+		degree = len(g.out[i]) + len(g.in[i])
+	}
+	return
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *multigraph) Order() int {
+	return len(g.vertices)
+}
+
+// Returns the size (number of edges, counting each parallel edge) in the graph.
+func (g *multigraph) Size() int {
+	return len(g.edges)
+}
+
+// Traverses the graph's arcs, passing each arc to the provided closure.
+func (g multidigraph) Arcs(f ArcStep) {
+	for _, e := range g.edges {
+		if f(e.(Arc)) {
+			return
+		}
+	}
+}
+
+// Enumerates the arcs outbound from the provided vertex.
+func (g multidigraph) ArcsFrom(v Vertex, f ArcStep) {
+	if i, exists := g.index[VertexKey(v)]; exists {
+		g.eachArc(g.out[i], f)
+	}
+}
+
+// Enumerates the arcs inbound to the provided vertex.
+func (g multidigraph) ArcsTo(v Vertex, f ArcStep) {
+	if i, exists := g.index[VertexKey(v)]; exists {
+		g.eachArc(g.in[i], f)
+	}
+}
+
+func (g multidigraph) eachArc(list []int, f ArcStep) {
+	for _, ei := range list {
+		if f(g.edges[ei].(Arc)) {
+			return
+		}
+	}
+}
+
+// Enumerates the targets of the provided vertex's out-arcs, each once.
+func (g multidigraph) SuccessorsOf(v Vertex, f VertexStep) {
+	if i, exists := g.index[VertexKey(v)]; exists {
+		g.eachNeighbor(func(e Edge) Vertex {
+			return e.(Arc).Target()
+		}, f, g.out[i])
+	}
+}
+
+// Enumerates the sources of the provided vertex's in-arcs, each once.
+func (g multidigraph) PredecessorsOf(v Vertex, f VertexStep) {
+	if i, exists := g.index[VertexKey(v)]; exists {
+		g.eachNeighbor(func(e Edge) Vertex {
+			return e.(Arc).Source()
+		}, f, g.in[i])
+	}
+}
+
+// Returns the number of arcs inbound to the provided vertex. If the vertex is not
+// present in the graph, the second return value will be false.
+func (g multidigraph) InDegreeOf(v Vertex) (degree int, exists bool) {
+	i, exists := g.index[VertexKey(v)]
+	if exists {
+		degree = len(g.in[i])
+	}
+	return
+}
+
+// Returns the number of arcs outbound from the provided vertex. If the vertex is
+// not present in the graph, the second return value will be false.
+func (g multidigraph) OutDegreeOf(v Vertex) (degree int, exists bool) {
+	i, exists := g.index[VertexKey(v)]
+	if exists {
+		degree = len(g.out[i])
+	}
+	return
+}
+
+// Indicates whether the graph has any arc from the given arc's source to its
+// target, regardless of label.
+func (g multidigraph) HasArc(a Arc) bool {
+	return g.pairs[[2]interface{}{VertexKey(a.Source()), VertexKey(a.Target())}]
+}
+
+// Indicates whether the graph has an arc from the given arc's source to its
+// target with the same label.
+func (g multidigraph) HasLabeledArc(a LabeledArc) bool {
+	return g.labeled[newLabeledKey(a.Source(), a.Target(), a.Label())]
+}
+
+// Returns a new multigraph with every arc reversed.
+func (g multidigraph) Transpose() Digraph {
+	edges := make([]LabeledEdge, len(g.edges))
+	for i, e := range g.edges {
+		edges[i] = NewLabeledArc(e.(Arc).Target(), e.(Arc).Source(), e.Label())
+	}
+	return newMultigraph(g, true, edges).(Digraph)
+}
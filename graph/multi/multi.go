@@ -0,0 +1,79 @@
+// Contains a read-only labeled multigraph implementation.
+package multi
+
+import (
+	. "github.com/sdboyer/gogl"
+)
+
+/*
+The adjacency lists map each pair of vertices to at most one edge, so cannot
+hold parallel edges. The multigraphs here can: edges are kept in a slice, in
+the order they were imported, and each vertex records the positions of its
+incident edges. Edges that share their endpoints, and even their label, are
+all kept, and are each counted by Size and the degree methods.
+
+Lookups by label go through a set of (source, target, label) keys, so that
+HasLabeledEdge and HasLabeledArc cost O(1); HasEdge and HasArc ask only
+whether any edge joins the pair, whatever its label.
+
+Vertices must be comparable, unless they are Identifiable, in which case they
+are keyed by ID; the vertex first imported under a key is the one handed back.
+*/
+
+// Create a labeled multigraph from the provided GraphSpec, which must be
+// immutable, directed or undirected, labeled, and permit parallel edges; it may
+// permit loops. If the spec contains a GraphSource, it will be imported into the
+// graph, keeping every edge it enumerates. Unlabeled edges are given the empty
+// label.
+//
+// The result is a LabeledDigraph if the spec is directed, and a LabeledGraph
+// otherwise. If the spec cannot be satisfied, this panics.
+func G(gs GraphSpec) Graph {
+	p := gs.Props
+	if p&(G_IMMUTABLE|G_LABELED|G_PARALLEL) != G_IMMUTABLE|G_LABELED|G_PARALLEL ||
+		p&(G_DIRECTED|G_UNDIRECTED) == G_DIRECTED|G_UNDIRECTED ||
+		p&(G_WEIGHTED|G_DATA) != 0 {
+		panic("No graph implementation found for spec")
+	}
+	loops := p&G_LOOPS != 0
+	directed := p&G_DIRECTED != 0
+
+	var edges []LabeledEdge
+	keep := func(e Edge, le LabeledEdge) {
+		if u, v := e.Both(); loops || VertexKey(u) != VertexKey(v) {
+			edges = append(edges, le)
+		}
+	}
+
+	src := gs.Source
+	if src == nil {
+		src = NullGraph
+	}
+
+	if directed {
+		dgs, ok := src.(DigraphSource)
+		if !ok {
+			panic("Cannot create a digraph from a graph.")
+		}
+		dgs.Arcs(func(a Arc) (terminate bool) {
+			if la, ok := a.(LabeledArc); ok {
+				keep(a, la)
+			} else {
+				keep(a, NewLabeledArc(a.Source(), a.Target(), ""))
+			}
+			return
+		})
+	} else {
+		src.Edges(func(e Edge) (terminate bool) {
+			if le, ok := e.(LabeledEdge); ok {
+				keep(e, le)
+			} else {
+				u, v := e.Both()
+				keep(e, NewLabeledEdge(u, v, ""))
+			}
+			return
+		})
+	}
+
+	return newMultigraph(src, directed, edges)
+}
@@ -0,0 +1,105 @@
+package multi
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/spec"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+func init() {
+	for _, gp := range []GraphProperties{
+		G_IMMUTABLE | G_UNDIRECTED | G_LABELED | G_PARALLEL,
+		G_IMMUTABLE | G_UNDIRECTED | G_LABELED | G_PARALLEL | G_LOOPS,
+		G_IMMUTABLE | G_DIRECTED | G_LABELED | G_PARALLEL,
+		G_IMMUTABLE | G_DIRECTED | G_LABELED | G_PARALLEL | G_LOOPS,
+	} {
+		spec.SetUpTestsFromSpec(gp, G)
+	}
+}
+
+type MultiSuite struct{}
+
+var _ = Suite(&MultiSuite{})
+
+func (s *MultiSuite) TestBuilder(c *C) {
+	g := Spec().Immutable().Labeled().Parallel().Using(EdgeList{NewEdge("a", "b")}).Create(G)
+	lg, ok := g.(LabeledGraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(lg.HasLabeledEdge(NewLabeledEdge("b", "a", "")), Equals, true)
+	_, ok = g.(Digraph)
+	c.Assert(ok, Equals, false)
+
+	dg := Spec().Directed().Immutable().Labeled().Parallel().Using(ArcList{NewArc("a", "b")}).Create(G)
+	_, ok = dg.(LabeledDigraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(dg.(Digraph).HasArc(NewArc("a", "b")), Equals, true)
+	c.Assert(dg.(Digraph).HasArc(NewArc("b", "a")), Equals, false)
+
+	for _, gs := range []GraphSpec{
+		Spec(),
+		Spec().Immutable().Labeled(),
+		Spec().Immutable().Parallel(),
+		Spec().Labeled().Parallel(),
+		Spec().Immutable().Labeled().Parallel().Mixed(),
+	} {
+		c.Assert(func() { G(gs) }, PanicMatches, "No graph implementation found for spec")
+	}
+	c.Assert(func() {
+		Spec().Directed().Immutable().Labeled().Parallel().Using(EdgeList{NewEdge("a", "b")}).Create(G)
+	}, PanicMatches, "Cannot create a digraph from a graph.")
+}
+
+func (s *MultiSuite) TestParallelEdges(c *C) {
+	edges := LabeledEdgeList{
+		NewLabeledEdge("a", "b", "x"),
+		NewLabeledEdge("b", "a", "y"),
+		NewLabeledEdge("a", "b", "x"),
+		NewLabeledEdge("b", "b", "z"),
+	}
+
+	g := Spec().Immutable().Labeled().PseudoGraph().Using(edges).Create(G).(LabeledGraph)
+	c.Assert(Size(g), Equals, 4)
+	c.Assert(g.HasLabeledEdge(NewLabeledEdge("a", "b", "y")), Equals, true)
+	c.Assert(g.HasLabeledEdge(NewLabeledEdge("a", "b", "z")), Equals, false)
+
+	deg, _ := g.DegreeOf("b")
+	c.Assert(deg, Equals, 4)
+
+	var adjacent []Vertex
+	g.AdjacentTo("a", func(v Vertex) (terminate bool) {
+		adjacent = append(adjacent, v)
+		return
+	})
+	c.Assert(adjacent, DeepEquals, []Vertex{"b"})
+
+	// Without loops permitted, the loop is dropped, but its vertex is kept.
+	g = Spec().Immutable().Labeled().MultiGraph().Using(edges).Create(G).(LabeledGraph)
+	c.Assert(Size(g), Equals, 3)
+	c.Assert(g.HasLabeledEdge(NewLabeledEdge("b", "b", "z")), Equals, false)
+	c.Assert(g.HasVertex("b"), Equals, true)
+}
+
+func (s *MultiSuite) TestDirected(c *C) {
+	arcs := LabeledArcList{
+		NewLabeledArc("a", "b", "x"),
+		NewLabeledArc("a", "b", "y"),
+		NewLabeledArc("b", "a", "x"),
+	}
+
+	g := Spec().Directed().Immutable().Labeled().Parallel().Using(arcs).Create(G).(LabeledDigraph)
+	c.Assert(g.HasLabeledArc(NewLabeledArc("a", "b", "y")), Equals, true)
+	c.Assert(g.HasLabeledArc(NewLabeledArc("b", "a", "y")), Equals, false)
+
+	out, _ := g.OutDegreeOf("a")
+	in, _ := g.InDegreeOf("a")
+	c.Assert([2]int{out, in}, Equals, [2]int{2, 1})
+
+	t := g.Transpose().(LabeledDigraph)
+	c.Assert(t.HasLabeledArc(NewLabeledArc("b", "a", "y")), Equals, true)
+	c.Assert(Size(t), Equals, 3)
+}
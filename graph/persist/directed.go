@@ -0,0 +1,242 @@
+package persist
+
+import (
+	. "github.com/sdboyer/gogl"
+)
+
+type directed struct {
+	base
+}
+
+// Returns the outdegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *directed) OutDegreeOf(v Vertex) (degree int, exists bool) {
+	var e vertex
+	if e, exists = g.entry(VertexKey(v)); exists {
+		degree = e.out.size
+	}
+	return
+}
+
+// Returns the indegree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *directed) InDegreeOf(v Vertex) (degree int, exists bool) {
+	var e vertex
+	if e, exists = g.entry(VertexKey(v)); exists {
+		degree = e.in.size
+	}
+	return
+}
+
+// Returns the degree of the provided vertex, counting both in and out-edges.
+func (g *directed) DegreeOf(v Vertex) (degree int, exists bool) {
+	var e vertex
+	if e, exists = g.entry(VertexKey(v)); exists {
+		degree = e.in.size + e.out.size
+	}
+	return
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *directed) Edges(f EdgeStep) {
+	g.Arcs(func(a Arc) bool {
+		return f(a)
+	})
+}
+
+// Traverses the set of arcs in the graph, passing each arc to the
+// provided closure.
+func (g *directed) Arcs(f ArcStep) {
+	g.list.each(func(_, e interface{}) bool {
+		u := e.(vertex)
+		return eachNeighbor(u.out, func(v Vertex) bool {
+			return f(NewArc(u.v, v))
+		})
+	})
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *directed) IncidentTo(v Vertex, f EdgeStep) {
+	var terminate bool
+	interloper := func(a Arc) bool {
+		terminate = terminate || f(a)
+		return terminate
+	}
+
+	g.ArcsFrom(v, interloper)
+	if !terminate {
+		g.ArcsTo(v, interloper)
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *directed) AdjacentTo(start Vertex, f VertexStep) {
+	if e, exists := g.entry(VertexKey(start)); exists {
+		if !eachNeighbor(e.out, f) {
+			eachNeighbor(e.in, f)
+		}
+	}
+}
+
+// Enumerates the set of out-edges for the provided vertex.
+func (g *directed) ArcsFrom(v Vertex, f ArcStep) {
+	if e, exists := g.entry(VertexKey(v)); exists {
+		eachNeighbor(e.out, func(adj Vertex) bool {
+			return f(NewArc(e.v, adj))
+		})
+	}
+}
+
+// Enumerates the set of in-edges for the provided vertex.
+func (g *directed) ArcsTo(v Vertex, f ArcStep) {
+	if e, exists := g.entry(VertexKey(v)); exists {
+		eachNeighbor(e.in, func(adj Vertex) bool {
+			return f(NewArc(adj, e.v))
+		})
+	}
+}
+
+// Enumerates the successors of the provided vertex.
+func (g *directed) SuccessorsOf(v Vertex, f VertexStep) {
+	if e, exists := g.entry(VertexKey(v)); exists {
+		eachNeighbor(e.out, f)
+	}
+}
+
+// Enumerates the predecessors of the provided vertex.
+func (g *directed) PredecessorsOf(v Vertex, f VertexStep) {
+	if e, exists := g.entry(VertexKey(v)); exists {
+		eachNeighbor(e.in, f)
+	}
+}
+
+// Indicates whether or not the given edge is present in the graph. It matches
+// arcs in either direction.
+func (g *directed) HasEdge(edge Edge) bool {
+	u, v := edge.Both()
+	e, exists := g.entry(VertexKey(u))
+	return exists && (e.out.has(VertexKey(v)) || e.in.has(VertexKey(v)))
+}
+
+// Indicates whether or not the given arc is present in the graph.
+func (g *directed) HasArc(arc Arc) bool {
+	u, v := arc.Both()
+	e, exists := g.entry(VertexKey(u))
+	return exists && e.out.has(VertexKey(v))
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *directed) Density() float64 {
+	order := g.Order()
+	return float64(g.Size()) / float64(order*(order-1))
+}
+
+// Returns the transpose of the graph, in which every arc is reversed. Each
+// vertex's successors and predecessors simply trade places, so the transpose
+// shares all of the graph's adjacency, and is itself persistent.
+func (g *directed) Transpose() Digraph {
+	g2 := &directed{base{size: g.size, loops: g.loops}}
+	g.list.each(func(key, e interface{}) bool {
+		v := e.(vertex)
+		v.out, v.in = v.in, v.out
+		g2.list = g2.list.set(key, v)
+		return false
+	})
+	return g2
+}
+
+// Returns a version of the graph with the provided vertices present.
+func (g *directed) EnsureVertex(vertices ...Vertex) PersistentDigraph {
+	g2 := *g
+	g2.ensureVertex(vertices...)
+	return &g2
+}
+
+// Returns a version of the graph without the provided vertices, or any arcs of
+// which they are a member.
+func (g *directed) RemoveVertex(vertices ...Vertex) PersistentDigraph {
+	g2 := *g
+	for _, v := range vertices {
+		key := VertexKey(v)
+		e, exists := g2.entry(key)
+		if !exists {
+			continue
+		}
+
+		e.out.each(func(adj, _ interface{}) bool {
+			if adj != key {
+				n, _ := g2.entry(adj)
+				n.in = n.in.remove(key)
+				g2.list = g2.list.set(adj, n)
+			}
+			return false
+		})
+		e.in.each(func(adj, _ interface{}) bool {
+			if adj != key {
+				n, _ := g2.entry(adj)
+				n.out = n.out.remove(key)
+				g2.list = g2.list.set(adj, n)
+			}
+			return false
+		})
+
+		// A loop is in both adjacencies, but is only one arc.
+		g2.size -= e.out.size + e.in.size
+		if e.out.has(key) {
+			g2.size++
+		}
+		g2.list = g2.list.remove(key)
+	}
+	return &g2
+}
+
+// Returns a version of the graph with the provided arcs added, along with any of
+// their vertices not already present.
+func (g *directed) AddArcs(arcs ...Arc) PersistentDigraph {
+	g2 := *g
+	for _, arc := range arcs {
+		u, v := arc.Both()
+		ku, kv := VertexKey(u), VertexKey(v)
+		if ku == kv && !g2.loops {
+			continue
+		}
+
+		eu, ev := g2.ensure(u), g2.ensure(v)
+		if eu.out.has(kv) {
+			continue
+		}
+
+		eu.out = eu.out.set(kv, ev.v)
+		g2.list = g2.list.set(ku, eu)
+		// Fetch the target afresh, in case it is the source.
+		ev, _ = g2.entry(kv)
+		ev.in = ev.in.set(ku, eu.v)
+		g2.list = g2.list.set(kv, ev)
+		g2.size++
+	}
+	return &g2
+}
+
+// Returns a version of the graph without the provided arcs. Their vertices are
+// kept.
+func (g *directed) RemoveArcs(arcs ...Arc) PersistentDigraph {
+	g2 := *g
+	for _, arc := range arcs {
+		u, v := arc.Both()
+		ku, kv := VertexKey(u), VertexKey(v)
+		eu, exists := g2.entry(ku)
+		if !exists || !eu.out.has(kv) {
+			continue
+		}
+
+		eu.out = eu.out.remove(kv)
+		g2.list = g2.list.set(ku, eu)
+		ev, _ := g2.entry(kv)
+		ev.in = ev.in.remove(ku)
+		g2.list = g2.list.set(kv, ev)
+		g2.size--
+	}
+	return &g2
+}
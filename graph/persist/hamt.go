@@ -0,0 +1,279 @@
+package persist
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+/*
+A hash array mapped trie is a persistent map. Each level of the trie consumes a
+few bits of a key's hash, which select among a node's children; a bitmap
+records which children exist, so nodes hold only those, packed into a slice.
+
+Updates never modify a node. Instead, they copy the nodes along the path from
+the root to the change, and the copies share every other subtree with the
+original, so an update costs O(log n) time and space, and the original trie
+remains intact.
+
+Keys whose hashes agree in every bit share a collision node at the bottom of
+the trie, which is searched linearly.
+*/
+
+const (
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+	hashBits     = 64
+)
+
+var seed = maphash.MakeSeed()
+
+// Hashes a key for placement in the trie. Tests replace it to force collisions.
+var hashOf = func(key interface{}) uint64 {
+	return maphash.Comparable(seed, key)
+}
+
+// A persistent map from comparable keys to values. The zero value is an empty
+// map, and maps are passed by value; updates return a new map.
+type hamt struct {
+	root *node
+	size int
+}
+
+type node struct {
+	bitmap uint32
+	slots  []slot
+	// Entries whose hashes are equal in every bit, held only once the hash has
+	// run out, below the deepest level of slots.
+	collisions []entry
+}
+
+// A slot holds either an entry or, if child is not nil, a subtree.
+type slot struct {
+	entry
+	child *node
+}
+
+type entry struct {
+	hash       uint64
+	key, value interface{}
+}
+
+// Returns the value stored under the key, if any.
+func (m hamt) get(key interface{}) (interface{}, bool) {
+	h := hashOf(key)
+	n := m.root
+	for shift := uint(0); n != nil; shift += bitsPerLevel {
+		if shift >= hashBits {
+			for _, e := range n.collisions {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			return nil, false
+		}
+
+		bit := uint32(1) << (h >> shift & levelMask)
+		if n.bitmap&bit == 0 {
+			return nil, false
+		}
+		s := n.slots[n.index(bit)]
+		if s.child == nil {
+			if s.key == key {
+				return s.value, true
+			}
+			return nil, false
+		}
+		n = s.child
+	}
+	return nil, false
+}
+
+// Indicates whether a value is stored under the key.
+func (m hamt) has(key interface{}) bool {
+	_, exists := m.get(key)
+	return exists
+}
+
+// Returns a map with the value stored under the key, replacing any already there.
+func (m hamt) set(key, value interface{}) hamt {
+	root, added := insert(m.root, entry{hashOf(key), key, value}, 0)
+	m.root = root
+	if added {
+		m.size++
+	}
+	return m
+}
+
+// Returns a map without the key. If the key is absent, the map is returned as is.
+func (m hamt) remove(key interface{}) hamt {
+	root, removed := remove(m.root, key, hashOf(key), 0)
+	if removed {
+		m.root = root
+		m.size--
+	}
+	return m
+}
+
+// Calls the provided function once with each key and value in the map, in no
+// particular order, stopping if it returns true. Returns whether it was stopped.
+func (m hamt) each(f func(key, value interface{}) (terminate bool)) bool {
+	return m.root.each(f)
+}
+
+// Returns the position among the node's slots of the child selected by bit.
+func (n *node) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// Returns a copy of the (possibly nil) node with the entry added at the given
+// depth, and whether its key was new.
+func insert(n *node, e entry, shift uint) (*node, bool) {
+	if n == nil {
+		n = &node{}
+	}
+
+	if shift >= hashBits {
+		c := &node{collisions: make([]entry, len(n.collisions), len(n.collisions)+1)}
+		copy(c.collisions, n.collisions)
+		for i := range c.collisions {
+			if c.collisions[i].key == e.key {
+				c.collisions[i] = e
+				return c, false
+			}
+		}
+		c.collisions = append(c.collisions, e)
+		return c, true
+	}
+
+	bit := uint32(1) << (e.hash >> shift & levelMask)
+	i := n.index(bit)
+	c := &node{bitmap: n.bitmap | bit}
+
+	if n.bitmap&bit == 0 {
+		c.slots = make([]slot, len(n.slots)+1)
+		copy(c.slots, n.slots[:i])
+		c.slots[i] = slot{entry: e}
+		copy(c.slots[i+1:], n.slots[i:])
+		return c, true
+	}
+
+	c.slots = make([]slot, len(n.slots))
+	copy(c.slots, n.slots)
+
+	switch s := n.slots[i]; {
+	case s.child != nil:
+		child, added := insert(s.child, e, shift+bitsPerLevel)
+		c.slots[i] = slot{child: child}
+		return c, added
+	case s.key == e.key:
+		c.slots[i] = slot{entry: e}
+		return c, false
+	default:
+		// Two keys now share the slot, so push both down a level.
+		child, _ := insert(nil, s.entry, shift+bitsPerLevel)
+		child, _ = insert(child, e, shift+bitsPerLevel)
+		c.slots[i] = slot{child: child}
+		return c, true
+	}
+}
+
+// Returns a copy of the node with the key removed from the given depth, and
+// whether it was present; if it was not, the node itself is returned. A node left
+// empty becomes nil.
+func remove(n *node, key interface{}, h uint64, shift uint) (*node, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	if shift >= hashBits {
+		for i, e := range n.collisions {
+			if e.key != key {
+				continue
+			}
+			if len(n.collisions) == 1 {
+				return nil, true
+			}
+			c := &node{collisions: make([]entry, 0, len(n.collisions)-1)}
+			c.collisions = append(append(c.collisions, n.collisions[:i]...), n.collisions[i+1:]...)
+			return c, true
+		}
+		return n, false
+	}
+
+	bit := uint32(1) << (h >> shift & levelMask)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	i := n.index(bit)
+	s := n.slots[i]
+
+	var replacement slot
+	if s.child != nil {
+		child, removed := remove(s.child, key, h, shift+bitsPerLevel)
+		if !removed {
+			return n, false
+		}
+		if child == nil {
+			return n.without(i, bit), true
+		}
+		// A subtree reduced to a lone entry is pulled up in its place, keeping
+		// the trie no deeper than it needs to be.
+		if e, lone := child.lone(); lone {
+			replacement = slot{entry: e}
+		} else {
+			replacement = slot{child: child}
+		}
+	} else if s.key == key {
+		return n.without(i, bit), true
+	} else {
+		return n, false
+	}
+
+	c := &node{bitmap: n.bitmap, slots: make([]slot, len(n.slots))}
+	copy(c.slots, n.slots)
+	c.slots[i] = replacement
+	return c, true
+}
+
+// Returns a copy of the node without the slot at position i, selected by bit, or
+// nil if that was its only slot.
+func (n *node) without(i int, bit uint32) *node {
+	if len(n.slots) == 1 {
+		return nil
+	}
+	c := &node{bitmap: n.bitmap &^ bit, slots: make([]slot, 0, len(n.slots)-1)}
+	c.slots = append(append(c.slots, n.slots[:i]...), n.slots[i+1:]...)
+	return c
+}
+
+// Returns the node's entry, if it holds exactly one and no subtrees.
+func (n *node) lone() (entry, bool) {
+	switch {
+	case len(n.collisions) == 1:
+		return n.collisions[0], true
+	case len(n.slots) == 1 && n.slots[0].child == nil:
+		return n.slots[0].entry, true
+	}
+	return entry{}, false
+}
+
+func (n *node) each(f func(key, value interface{}) (terminate bool)) bool {
+	if n == nil {
+		return false
+	}
+	for _, e := range n.collisions {
+		if f(e.key, e.value) {
+			return true
+		}
+	}
+	for _, s := range n.slots {
+		if s.child != nil {
+			if s.child.each(f) {
+				return true
+			}
+		} else if f(s.key, s.value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package persist
+
+import (
+	. "github.com/sdboyer/gocheck"
+)
+
+type HAMTSuite struct{}
+
+var _ = Suite(&HAMTSuite{})
+
+// Checks that the map holds exactly the expected entries.
+func checkHAMT(c *C, m hamt, want map[interface{}]interface{}) {
+	c.Assert(m.size, Equals, len(want))
+	got := make(map[interface{}]interface{})
+	m.each(func(k, v interface{}) (terminate bool) {
+		got[k] = v
+		return
+	})
+	c.Assert(got, DeepEquals, want)
+	for k, v := range want {
+		value, exists := m.get(k)
+		c.Assert(exists, Equals, true, Commentf("key %v", k))
+		c.Assert(value, Equals, v)
+	}
+}
+
+func (s *HAMTSuite) TestSetRemove(c *C) {
+	var m hamt
+	want := make(map[interface{}]interface{})
+	var versions []hamt
+	var wants []map[interface{}]interface{}
+
+	for i := 0; i < 3000; i++ {
+		m = m.set(i, i*i)
+		want[i] = i * i
+		if i%1000 == 0 {
+			versions = append(versions, m)
+			snapshot := make(map[interface{}]interface{}, len(want))
+			for k, v := range want {
+				snapshot[k] = v
+			}
+			wants = append(wants, snapshot)
+		}
+	}
+	checkHAMT(c, m, want)
+
+	// Replacing a value does not change the size.
+	m = m.set(7, "seven")
+	want[7] = "seven"
+	checkHAMT(c, m, want)
+
+	for i := 0; i < 3000; i += 3 {
+		m = m.remove(i)
+		delete(want, i)
+	}
+	m = m.remove("absent")
+	checkHAMT(c, m, want)
+
+	// Earlier versions are untouched.
+	for i, v := range versions {
+		checkHAMT(c, v, wants[i])
+	}
+
+	for k := range want {
+		m = m.remove(k)
+	}
+	c.Assert(m.size, Equals, 0)
+	c.Assert(m.root, IsNil)
+}
+
+func (s *HAMTSuite) TestCollisions(c *C) {
+	defer func(h func(interface{}) uint64) { hashOf = h }(hashOf)
+	// Every even key hashes alike, as does every odd one.
+	hashOf = func(key interface{}) uint64 { return uint64(key.(int) % 2) }
+
+	var m hamt
+	want := make(map[interface{}]interface{})
+	for i := 0; i < 20; i++ {
+		m = m.set(i, i)
+		want[i] = i
+	}
+	checkHAMT(c, m, want)
+
+	before := m
+	for i := 0; i < 20; i += 2 {
+		m = m.remove(i)
+		delete(want, i)
+	}
+	checkHAMT(c, m, want)
+	c.Assert(before.size, Equals, 20)
+
+	// Once a collision node is down to one entry, it is pulled up to the root.
+	for i := 3; i < 20; i += 2 {
+		m = m.remove(i)
+	}
+	c.Assert(m.size, Equals, 1)
+	c.Assert(m.root.slots, HasLen, 1)
+	c.Assert(m.root.slots[0].child, IsNil)
+	v, exists := m.get(1)
+	c.Assert(exists, Equals, true)
+	c.Assert(v, Equals, 1)
+}
@@ -0,0 +1,152 @@
+// Contains persistent graph implementations, backed by hash array mapped tries.
+package persist
+
+import (
+	. "github.com/sdboyer/gogl"
+)
+
+/*
+Persistent graphs are never modified in place. Adding or removing vertices or
+edges instead returns a new version of the graph, and every earlier version
+remains valid, and unchanged, for as long as it is held. This makes versions
+cheap snapshots: an undo history, say, or a consistent view to hand to another
+goroutine. As no version ever changes, all may be read concurrently without
+locking.
+
+Versions share structure. A graph is a trie mapping each vertex to its
+adjacency, itself a trie mapping each neighbor to the neighbor as it was given.
+A change copies only the trie nodes on the paths to the entries it touches, so
+adding or removing an edge costs O(log V) time and space, with the rest of the
+graph shared with the previous version. Lookups are also O(log V); in practice,
+the tries are shallow, consuming five bits of a vertex's hash per level.
+
+Like the adjacency lists, undirected graphs record each edge at both of its
+ends; directed graphs record each arc at its source and target alike, so that
+in-degrees and predecessors are as cheap to find as out-degrees and successors.
+
+Vertices must be comparable, unless they are Identifiable, in which case they
+are keyed by ID; the vertex first added under a key is the one handed back.
+*/
+
+// Create a persistent graph from the provided GraphSpec, which must be persistent,
+// directed or undirected, and have basic edges; it may permit loops, but not
+// parallel edges. If the spec contains a GraphSource, it will be imported into
+// the graph.
+//
+// The result is a PersistentDigraph if the spec is directed, and a
+// PersistentGraph otherwise. If the spec cannot be satisfied, this panics.
+func G(gs GraphSpec) Graph {
+	p := gs.Props
+	if p&G_PERSISTENT != G_PERSISTENT ||
+		p&(G_DIRECTED|G_UNDIRECTED) == G_DIRECTED|G_UNDIRECTED ||
+		p&(G_LABELED|G_WEIGHTED|G_DATA|G_PARALLEL) != 0 {
+		panic("No graph implementation found for spec")
+	}
+	b := base{loops: p&G_LOOPS != 0}
+
+	if p&G_DIRECTED != 0 {
+		var g PersistentDigraph = &directed{b}
+		if gs.Source == nil {
+			return g
+		}
+
+		dgs, ok := gs.Source.(DigraphSource)
+		if !ok {
+			panic("Cannot create a digraph from a graph.")
+		}
+		g = g.EnsureVertex(CollectVertices(dgs)...)
+
+		var arcs []Arc
+		dgs.Arcs(func(a Arc) (terminate bool) {
+			arcs = append(arcs, a)
+			return
+		})
+		return g.AddArcs(arcs...)
+	}
+
+	var g PersistentGraph = &undirected{b}
+	if gs.Source == nil {
+		return g
+	}
+
+	g = g.EnsureVertex(CollectVertices(gs.Source)...)
+	return g.AddEdges(CollectEdges(gs.Source)...)
+}
+
+// A vertex's entry in the graph. Like the tries, entries are never modified once
+// stored; a change stores a modified copy.
+type vertex struct {
+	v Vertex // as it was first given
+	// The keys of the vertex's neighbors, each mapped to the neighbor as it was
+	// given: in an undirected graph, all of them; in a directed one, the
+	// successors in out and the predecessors in in.
+	out, in hamt
+}
+
+// Functionality shared by the directed and undirected graphs. A base is only ever
+// changed while building a new version; once handed out, it is fixed.
+type base struct {
+	list  hamt // vertex key -> vertex
+	size  int
+	loops bool // whether self-loops are permitted; if not, they are silently dropped
+}
+
+// Returns the entry stored under the given key.
+func (g *base) entry(key Vertex) (vertex, bool) {
+	e, exists := g.list.get(key)
+	if !exists {
+		return vertex{}, false
+	}
+	return e.(vertex), true
+}
+
+// Returns the entry for the given vertex, adding one if it is not yet present.
+func (g *base) ensure(v Vertex) vertex {
+	key := VertexKey(v)
+	e, exists := g.entry(key)
+	if !exists {
+		e = vertex{v: v}
+		g.list = g.list.set(key, e)
+	}
+	return e
+}
+
+func (g *base) ensureVertex(vertices ...Vertex) {
+	for _, v := range vertices {
+		g.ensure(v)
+	}
+}
+
+// Traverses the graph's vertices, passing each to the provided closure.
+func (g *base) Vertices(f VertexStep) {
+	g.list.each(func(_, e interface{}) bool {
+		return f(e.(vertex).v)
+	})
+}
+
+// Indicates whether or not the given vertex is present in the graph.
+func (g *base) HasVertex(v Vertex) bool {
+	return g.list.has(VertexKey(v))
+}
+
+// Returns the order (number of vertices) in the graph.
+func (g *base) Order() int {
+	return g.list.size
+}
+
+// Reports the number of vertices in the graph, which is always exact.
+func (g *base) VertexCount() (count int, exact bool) {
+	return g.list.size, true
+}
+
+// Returns the size (number of edges) in the graph.
+func (g *base) Size() int {
+	return g.size
+}
+
+// Calls the provided closure with each vertex in the given adjacency.
+func eachNeighbor(adj hamt, f VertexStep) bool {
+	return adj.each(func(_, v interface{}) bool {
+		return f(v.(Vertex))
+	})
+}
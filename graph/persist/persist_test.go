@@ -0,0 +1,174 @@
+package persist
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/spec"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+func init() {
+	for _, gp := range []GraphProperties{
+		G_PERSISTENT | G_UNDIRECTED | G_BASIC | G_SIMPLE,
+		G_PERSISTENT | G_UNDIRECTED | G_BASIC | G_LOOPS,
+		G_PERSISTENT | G_DIRECTED | G_BASIC | G_SIMPLE,
+		G_PERSISTENT | G_DIRECTED | G_BASIC | G_LOOPS,
+	} {
+		spec.SetUpTestsFromSpec(gp, G)
+	}
+}
+
+type PersistSuite struct{}
+
+var _ = Suite(&PersistSuite{})
+
+func (s *PersistSuite) TestBuilder(c *C) {
+	g := Spec().Persistent().Using(EdgeList{NewEdge("a", "b")}).Create(G)
+	_, ok := g.(PersistentGraph)
+	c.Assert(ok, Equals, true)
+
+	dg := Spec().Directed().Persistent().Using(ArcList{NewArc("a", "b")}).Create(G)
+	_, ok = dg.(PersistentDigraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(dg.(Digraph).HasArc(NewArc("a", "b")), Equals, true)
+
+	for _, gs := range []GraphSpec{
+		Spec(),
+		Spec().Persistent().Weighted(),
+		Spec().Persistent().Mixed(),
+		Spec().Persistent().Parallel(),
+	} {
+		c.Assert(func() { G(gs) }, PanicMatches, "No graph implementation found for spec")
+	}
+	c.Assert(func() { Spec().Directed().Persistent().Using(EdgeList{NewEdge("a", "b")}).Create(G) }, PanicMatches, "Cannot create a digraph from a graph.")
+}
+
+func (s *PersistSuite) TestVersionsUndirected(c *C) {
+	v0 := G(Spec().Persistent()).(PersistentGraph)
+	v1 := v0.AddEdges(NewEdge("a", "b"), NewEdge("b", "c"))
+	v2 := v1.AddEdges(NewEdge("c", "a"))
+	v3 := v2.RemoveVertex("b")
+	v4 := v3.RemoveEdges(NewEdge("a", "c"))
+	v5 := v4.EnsureVertex("d")
+
+	// Every version keeps exactly its own vertices and edges.
+	for i, want := range []struct {
+		order, size int
+		edges       []Edge
+	}{
+		{0, 0, nil},
+		{3, 2, []Edge{NewEdge("a", "b"), NewEdge("c", "b")}},
+		{3, 3, []Edge{NewEdge("a", "b"), NewEdge("b", "c"), NewEdge("a", "c")}},
+		{2, 1, []Edge{NewEdge("a", "c")}},
+		{2, 0, nil},
+		{3, 0, nil},
+	} {
+		g := []PersistentGraph{v0, v1, v2, v3, v4, v5}[i]
+		c.Assert(Order(g), Equals, want.order, Commentf("version %d", i))
+		c.Assert(Size(g), Equals, want.size, Commentf("version %d", i))
+		c.Assert(CollectEdges(g), HasLen, want.size, Commentf("version %d", i))
+		for _, e := range want.edges {
+			c.Assert(g.HasEdge(e), Equals, true, Commentf("version %d, edge %v", i, e))
+		}
+	}
+
+	c.Assert(v2.HasVertex("b"), Equals, true)
+	deg, _ := v2.DegreeOf("a")
+	c.Assert(deg, Equals, 2)
+	deg, _ = v3.DegreeOf("a")
+	c.Assert(deg, Equals, 1)
+
+	// Re-adding an edge that is present changes nothing.
+	c.Assert(Size(v2.AddEdges(NewEdge("b", "a"))), Equals, 3)
+	c.Assert(Size(v2.RemoveEdges(NewEdge("x", "y"))), Equals, 3)
+}
+
+func (s *PersistSuite) TestVersionsDirected(c *C) {
+	v0 := G(Spec().Directed().Persistent().Loop()).(PersistentDigraph)
+	v1 := v0.AddArcs(NewArc("a", "b"), NewArc("b", "c"), NewArc("c", "c"))
+	v2 := v1.RemoveArcs(NewArc("a", "b"))
+	v3 := v1.RemoveVertex("c")
+
+	c.Assert(Size(v0), Equals, 0)
+	c.Assert(Size(v1), Equals, 3)
+	c.Assert(Size(v2), Equals, 2)
+	c.Assert(Size(v3), Equals, 1)
+
+	c.Assert(v1.HasArc(NewArc("a", "b")), Equals, true)
+	c.Assert(v2.HasArc(NewArc("a", "b")), Equals, false)
+	c.Assert(v2.HasVertex("a"), Equals, true)
+
+	in, _ := v1.InDegreeOf("c")
+	c.Assert(in, Equals, 2)
+	out, _ := v3.OutDegreeOf("b")
+	c.Assert(out, Equals, 0)
+	in, _ = v2.InDegreeOf("b")
+	c.Assert(in, Equals, 0)
+	in, _ = v1.InDegreeOf("b")
+	c.Assert(in, Equals, 1)
+
+	// The transpose shares adjacency, yet changing it leaves the original be.
+	t := v1.Transpose().(PersistentDigraph)
+	c.Assert(t.HasArc(NewArc("b", "a")), Equals, true)
+	t2 := t.AddArcs(NewArc("a", "c"))
+	c.Assert(Size(t2), Equals, 4)
+	c.Assert(Size(t), Equals, 3)
+	c.Assert(v1.HasArc(NewArc("a", "c")), Equals, false)
+	c.Assert(v1.HasArc(NewArc("c", "a")), Equals, false)
+}
+
+// An Identifiable vertex, with a payload to tell instances apart.
+type named struct {
+	name    string
+	payload int
+}
+
+func (n named) ID() string { return n.name }
+
+func (s *PersistSuite) TestIdentifiable(c *C) {
+	g := G(Spec().Persistent()).(PersistentGraph)
+	g = g.AddEdges(NewEdge(named{"a", 1}, named{"b", 2}))
+	g = g.AddEdges(NewEdge(named{"a", 3}, named{"c", 4}))
+
+	c.Assert(Order(g), Equals, 3)
+	// The first vertex added under a key is the one handed back.
+	g.AdjacentTo(named{name: "b"}, func(v Vertex) (terminate bool) {
+		c.Assert(v, Equals, named{"a", 1})
+		return
+	})
+}
+
+func (s *PersistSuite) TestLarge(c *C) {
+	g := G(Spec().Directed().Persistent()).(PersistentDigraph)
+	var versions []PersistentDigraph
+	for i := 0; i < 2000; i++ {
+		g = g.AddArcs(NewArc(i, (i*7+1)%2000))
+		if i%500 == 0 {
+			versions = append(versions, g)
+		}
+	}
+	c.Assert(Order(g), Equals, 2000)
+	c.Assert(Size(g), Equals, 2000)
+
+	for i, v := range versions {
+		c.Assert(Size(v), Equals, i*500+1)
+	}
+
+	for i := 0; i < 2000; i += 2 {
+		g = g.RemoveVertex(i)
+	}
+	c.Assert(Order(g), Equals, 1000)
+	var arcs int
+	g.Arcs(func(a Arc) (terminate bool) {
+		u, v := a.Both()
+		c.Assert(u.(int)%2 == 1 && v.(int)%2 == 1, Equals, true, Commentf("arc %v", a))
+		arcs++
+		return
+	})
+	c.Assert(arcs, Equals, Size(g))
+	c.Assert(Size(versions[3]), Equals, 1501)
+}
@@ -0,0 +1,150 @@
+package persist
+
+import (
+	. "github.com/sdboyer/gogl"
+)
+
+type undirected struct {
+	base
+}
+
+// Returns the degree of the provided vertex. If the vertex is not present in the
+// graph, the second return value will be false.
+func (g *undirected) DegreeOf(v Vertex) (degree int, exists bool) {
+	var e vertex
+	if e, exists = g.entry(VertexKey(v)); exists {
+		degree = e.out.size
+	}
+	return
+}
+
+// Traverses the set of edges in the graph, passing each edge to the
+// provided closure.
+func (g *undirected) Edges(f EdgeStep) {
+	// Each edge is recorded at both ends; report it from whichever end comes first.
+	visited := make(map[interface{}]struct{}, g.list.size)
+	g.list.each(func(key, e interface{}) bool {
+		u := e.(vertex)
+		terminate := u.out.each(func(adj, v interface{}) bool {
+			if _, seen := visited[adj]; seen {
+				return false
+			}
+			return f(NewEdge(u.v, v.(Vertex)))
+		})
+		visited[key] = struct{}{}
+		return terminate
+	})
+}
+
+// Enumerates the set of all edges incident to the provided vertex.
+func (g *undirected) IncidentTo(v Vertex, f EdgeStep) {
+	if e, exists := g.entry(VertexKey(v)); exists {
+		eachNeighbor(e.out, func(adj Vertex) bool {
+			return f(NewEdge(e.v, adj))
+		})
+	}
+}
+
+// Enumerates the vertices adjacent to the provided vertex.
+func (g *undirected) AdjacentTo(v Vertex, f VertexStep) {
+	if e, exists := g.entry(VertexKey(v)); exists {
+		eachNeighbor(e.out, f)
+	}
+}
+
+// Indicates whether or not the given edge is present in the graph.
+func (g *undirected) HasEdge(edge Edge) bool {
+	u, v := edge.Both()
+	e, exists := g.entry(VertexKey(u))
+	return exists && e.out.has(VertexKey(v))
+}
+
+// Returns the density of the graph. Density is the ratio of edge count to the
+// number of edges there would be in complete graph (maximum edge count).
+func (g *undirected) Density() float64 {
+	order := g.Order()
+	return 2 * float64(g.Size()) / float64(order*(order-1))
+}
+
+// Returns a version of the graph with the provided vertices present.
+func (g *undirected) EnsureVertex(vertices ...Vertex) PersistentGraph {
+	g2 := *g
+	g2.ensureVertex(vertices...)
+	return &g2
+}
+
+// Returns a version of the graph without the provided vertices, or any edges of
+// which they are a member.
+func (g *undirected) RemoveVertex(vertices ...Vertex) PersistentGraph {
+	g2 := *g
+	for _, v := range vertices {
+		key := VertexKey(v)
+		e, exists := g2.entry(key)
+		if !exists {
+			continue
+		}
+
+		e.out.each(func(adj, _ interface{}) bool {
+			if adj != key {
+				n, _ := g2.entry(adj)
+				n.out = n.out.remove(key)
+				g2.list = g2.list.set(adj, n)
+			}
+			return false
+		})
+		g2.size -= e.out.size
+		g2.list = g2.list.remove(key)
+	}
+	return &g2
+}
+
+// Returns a version of the graph with the provided edges added, along with any
+// of their vertices not already present.
+func (g *undirected) AddEdges(edges ...Edge) PersistentGraph {
+	g2 := *g
+	for _, edge := range edges {
+		u, v := edge.Both()
+		ku, kv := VertexKey(u), VertexKey(v)
+		if ku == kv && !g2.loops {
+			continue
+		}
+
+		eu, ev := g2.ensure(u), g2.ensure(v)
+		if eu.out.has(kv) {
+			continue
+		}
+
+		eu.out = eu.out.set(kv, ev.v)
+		g2.list = g2.list.set(ku, eu)
+		if ku != kv {
+			ev.out = ev.out.set(ku, eu.v)
+			g2.list = g2.list.set(kv, ev)
+		}
+		g2.size++
+	}
+	return &g2
+}
+
+// Returns a version of the graph without the provided edges. Their vertices are
+// kept.
+func (g *undirected) RemoveEdges(edges ...Edge) PersistentGraph {
+	g2 := *g
+	for _, edge := range edges {
+		u, v := edge.Both()
+		ku, kv := VertexKey(u), VertexKey(v)
+		eu, exists := g2.entry(ku)
+		if !exists || !eu.out.has(kv) {
+			continue
+		}
+
+		eu.out = eu.out.remove(kv)
+		g2.list = g2.list.set(ku, eu)
+		if ku != kv {
+			ev, _ := g2.entry(kv)
+			ev.out = ev.out.remove(ku)
+			g2.list = g2.list.set(kv, ev)
+		}
+		g2.size--
+	}
+	return &g2
+}
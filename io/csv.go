@@ -0,0 +1,118 @@
+package io
+
+import (
+	"encoding/csv"
+	"fmt"
+	stdio "io"
+	"strconv"
+
+	"github.com/sdboyer/gogl"
+)
+
+// A Duplicate records a row of CSV input that repeated an edge already read.
+type Duplicate struct {
+	Line  int       // The line on which the duplicate appeared.
+	First int       // The line on which the edge first appeared.
+	Edge  gogl.Edge // The duplicate edge as read, including any weight or label.
+}
+
+// Reads an edge list in CSV format, and builds a graph from it per the given spec,
+// using the provided creation function (for example, al.G).
+//
+// Each row describes one edge: its two endpoints, followed by a weight if the
+// spec is weighted, or a label if it is labeled. Vertices are strings. Lines
+// beginning with # are ignored. If the spec is directed, each row is an arc from
+// the first endpoint to the second.
+//
+// Unless the spec permits parallel edges, an edge appearing in more than one row
+// is not silently collapsed: the first row is used, and each later one is
+// reported as a Duplicate (in undirected graphs, an edge may be repeated in either
+// orientation). For multigraph specs, every row is kept as an edge of its own, and
+// no Duplicates are reported.
+func ReadCSV(r stdio.Reader, spec gogl.GraphSpec, fn func(gogl.GraphSpec) gogl.Graph) (gogl.Graph, []Duplicate, error) {
+	directed := spec.Props&gogl.G_DIRECTED != 0
+	parallel := spec.Props&gogl.G_PARALLEL != 0
+
+	fields := 2
+	if spec.Props&(gogl.G_WEIGHTED|gogl.G_LABELED) != 0 {
+		fields = 3
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var vertices []gogl.Vertex
+	var edges []gogl.Edge
+	seen := make(map[string]bool)
+	first := make(map[[2]string]int)
+	var dupes []Duplicate
+
+	for {
+		row, err := cr.Read()
+		if err == stdio.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		if len(row) != fields {
+			return nil, nil, fmt.Errorf("Line %d: expected %d fields, found %d.", line, fields, len(row))
+		}
+
+		u, v := row[0], row[1]
+		var e gogl.Edge
+		switch {
+		case spec.Props&gogl.G_WEIGHTED != 0:
+			w, err := strconv.ParseFloat(row[2], 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("Line %d: invalid weight %q.", line, row[2])
+			}
+			if directed {
+				e = gogl.NewWeightedArc(u, v, w)
+			} else {
+				e = gogl.NewWeightedEdge(u, v, w)
+			}
+		case spec.Props&gogl.G_LABELED != 0:
+			if directed {
+				e = gogl.NewLabeledArc(u, v, row[2])
+			} else {
+				e = gogl.NewLabeledEdge(u, v, row[2])
+			}
+		default:
+			if directed {
+				e = gogl.NewArc(u, v)
+			} else {
+				e = gogl.NewEdge(u, v)
+			}
+		}
+
+		if !parallel {
+			key := [2]string{u, v}
+			if !directed && v < u {
+				key = [2]string{v, u}
+			}
+			if at, exists := first[key]; exists {
+				dupes = append(dupes, Duplicate{Line: line, First: at, Edge: e})
+				continue
+			}
+			first[key] = line
+		}
+
+		for _, x := range row[:2] {
+			if !seen[x] {
+				seen[x] = true
+				vertices = append(vertices, x)
+			}
+		}
+		edges = append(edges, e)
+	}
+
+	if directed {
+		return spec.Using(gogl.VertexArcList{V: vertices, E: edges}).Create(fn), dupes, nil
+	}
+	return spec.Using(gogl.VertexEdgeList{V: vertices, E: edges}).Create(fn), dupes, nil
+}
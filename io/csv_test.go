@@ -0,0 +1,81 @@
+package io
+
+import (
+	"strings"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type CSVSuite struct{}
+
+var _ = Suite(&CSVSuite{})
+
+const dupeCSV = `# source,target
+a,b
+b,c
+a,b
+c,a
+b,a
+`
+
+func (s *CSVSuite) TestDuplicatesReported(c *C) {
+	g, dupes, err := ReadCSV(strings.NewReader(dupeCSV), gogl.Spec(), al.G)
+	c.Assert(err, IsNil)
+	c.Assert(gogl.Size(g), Equals, 3)
+	c.Assert(gogl.Order(g), Equals, 3)
+
+	// Undirected, so b,a repeats a,b as well.
+	c.Assert(dupes, HasLen, 2)
+	c.Assert(dupes[0].Line, Equals, 4)
+	c.Assert(dupes[0].First, Equals, 2)
+	c.Assert(dupes[0].Edge, DeepEquals, gogl.NewEdge("a", "b"))
+	c.Assert(dupes[1].Line, Equals, 6)
+	c.Assert(dupes[1].First, Equals, 2)
+}
+
+func (s *CSVSuite) TestDirectedDuplicates(c *C) {
+	g, dupes, err := ReadCSV(strings.NewReader(dupeCSV), gogl.Spec().Directed(), al.G)
+	c.Assert(err, IsNil)
+
+	// b,a is a distinct arc; only the repeated a,b is a duplicate.
+	c.Assert(gogl.Size(g), Equals, 4)
+	c.Assert(g.(gogl.Digraph).HasArc(gogl.NewArc("b", "a")), Equals, true)
+	c.Assert(dupes, HasLen, 1)
+	c.Assert(dupes[0].Line, Equals, 4)
+}
+
+func (s *CSVSuite) TestFirstWeightWins(c *C) {
+	in := "x,y,1.5\ny,z,2\nx,y,99\n"
+	g, dupes, err := ReadCSV(strings.NewReader(in), gogl.Spec().Weighted(), al.G)
+	c.Assert(err, IsNil)
+
+	wg := g.(gogl.WeightedGraph)
+	c.Assert(wg.HasWeightedEdge(gogl.NewWeightedEdge("x", "y", 1.5)), Equals, true)
+	c.Assert(dupes, HasLen, 1)
+	c.Assert(dupes[0].Edge, DeepEquals, gogl.NewWeightedEdge("x", "y", 99))
+}
+
+func (s *CSVSuite) TestMultigraphKeepsDuplicates(c *C) {
+	// There is no multigraph implementation to build into, so capture the source
+	// the reader hands over instead.
+	var src gogl.GraphSource
+	capture := func(gs gogl.GraphSpec) gogl.Graph {
+		src = gs.Source
+		return gogl.NullGraph
+	}
+
+	_, dupes, err := ReadCSV(strings.NewReader(dupeCSV), gogl.Spec().MultiGraph(), capture)
+	c.Assert(err, IsNil)
+	c.Assert(dupes, HasLen, 0)
+	c.Assert(gogl.Size(src), Equals, 5)
+}
+
+func (s *CSVSuite) TestMalformedRows(c *C) {
+	_, _, err := ReadCSV(strings.NewReader("a,b\nc\n"), gogl.Spec(), al.G)
+	c.Assert(err, ErrorMatches, "Line 2: expected 2 fields, found 1.")
+
+	_, _, err = ReadCSV(strings.NewReader("a,b,heavy\n"), gogl.Spec().Weighted(), al.G)
+	c.Assert(err, ErrorMatches, `Line 1: invalid weight "heavy".`)
+}
@@ -0,0 +1,319 @@
+package io
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// A graph document holds a whole graph as a single JSON object, as written by
+// MarshalGraph and read by UnmarshalGraph. For example:
+//
+//	{
+//		"directed": true,
+//		"edgeType": "weighted",
+//		"vertices": ["a", "b", 3],
+//		"edges": [{"u": "a", "v": "b", "weight": 1.5}]
+//	}
+//
+// "edgeType" is one of the edge subtypes recorded in stream headers, and defaults
+// to basic. Each edge must carry a "weight" if the graph is weighted, and a
+// "label" if it is labeled, but not otherwise.
+type document struct {
+	Directed bool          `json:"directed"`
+	EdgeType string        `json:"edgeType"`
+	Vertices []gogl.Vertex `json:"vertices"`
+	Edges    []docEdge     `json:"edges"`
+}
+
+type docEdge struct {
+	U      gogl.Vertex `json:"u"`
+	V      gogl.Vertex `json:"v"`
+	Weight *float64    `json:"weight,omitempty"`
+	Label  *string     `json:"label,omitempty"`
+}
+
+// Writes the provided graph as a single JSON document.
+//
+// As with Encoder, a DigraphSource is written as directed, and the edge subtype is
+// determined from the graph. Graphs with data edges cannot be marshaled.
+func MarshalGraph(g gogl.GraphSource) ([]byte, error) {
+	edgeType, ok := edgeTypeOf(g)
+	if !ok {
+		return nil, errors.New("Graphs with data edges cannot be marshaled.")
+	}
+
+	dg, directed := g.(gogl.DigraphSource)
+	doc := document{
+		Directed: directed,
+		EdgeType: edgeType,
+		Vertices: gogl.CollectVertices(g),
+		Edges:    make([]docEdge, 0),
+	}
+
+	add := func(edge gogl.Edge) (terminate bool) {
+		var de docEdge
+		if a, ok := edge.(gogl.Arc); ok && directed {
+			de.U, de.V = a.Source(), a.Target()
+		} else {
+			de.U, de.V = edge.Both()
+		}
+
+		switch edgeType {
+		case WeightedEdges:
+			var w float64
+			if we, ok := edge.(gogl.WeightedEdge); ok {
+				w = we.Weight()
+			}
+			de.Weight = &w
+		case LabeledEdges:
+			var l string
+			if le, ok := edge.(gogl.LabeledEdge); ok {
+				l = le.Label()
+			}
+			de.Label = &l
+		}
+
+		doc.Edges = append(doc.Edges, de)
+		return
+	}
+
+	if directed {
+		dg.Arcs(func(a gogl.Arc) bool { return add(a) })
+	} else {
+		g.Edges(add)
+	}
+
+	return json.Marshal(doc)
+}
+
+// Reads a graph from a JSON document, as written by MarshalGraph, returning a
+// mutable adjacency list graph of the document's directedness and edge subtype.
+// If any edge is a loop, the graph permits loops.
+//
+// The document is validated in full before the graph is built, so that malformed
+// input yields an error describing the first problem found, rather than a partial
+// graph. Unrecognized fields are rejected; vertices must be strings or numbers;
+// if "vertices" is given, every edge's endpoints must be listed in it; and no edge
+// may appear twice - which, in an undirected graph, includes appearing in the
+// opposite direction.
+func UnmarshalGraph(data []byte) (gogl.Graph, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return nil, errors.New("Document is not a JSON object.")
+	}
+	if err := checkFields(fields, "document", "directed", "edgeType", "vertices", "edges"); err != nil {
+		return nil, err
+	}
+
+	var doc document
+	raw, exists := fields["directed"]
+	if !exists {
+		return nil, errors.New(`Document is missing "directed".`)
+	}
+	if err := json.Unmarshal(raw, &doc.Directed); err != nil || isNull(raw) {
+		return nil, errors.New(`"directed" must be a boolean.`)
+	}
+
+	doc.EdgeType = BasicEdges
+	if raw, exists := fields["edgeType"]; exists {
+		if err := json.Unmarshal(raw, &doc.EdgeType); err != nil || isNull(raw) {
+			return nil, errors.New(`"edgeType" must be a string.`)
+		}
+		switch doc.EdgeType {
+		case BasicEdges, WeightedEdges, LabeledEdges:
+		default:
+			return nil, fmt.Errorf("Unrecognized edge type %q.", doc.EdgeType)
+		}
+	}
+
+	var listed map[gogl.Vertex]int
+	if raw, exists := fields["vertices"]; exists {
+		var vertices []json.RawMessage
+		if err := json.Unmarshal(raw, &vertices); err != nil || isNull(raw) {
+			return nil, errors.New(`"vertices" must be an array.`)
+		}
+
+		listed = make(map[gogl.Vertex]int, len(vertices))
+		for i, raw := range vertices {
+			v, ok := decodeVertex(raw)
+			if !ok {
+				return nil, fmt.Errorf("Vertex %d must be a string or a number.", i)
+			}
+			if j, dupe := listed[v]; dupe {
+				return nil, fmt.Errorf("Vertex %d duplicates vertex %d.", i, j)
+			}
+			listed[v] = i
+			doc.Vertices = append(doc.Vertices, v)
+		}
+	}
+
+	var loops bool
+	if raw, exists := fields["edges"]; exists {
+		var edges []json.RawMessage
+		if err := json.Unmarshal(raw, &edges); err != nil || isNull(raw) {
+			return nil, errors.New(`"edges" must be an array.`)
+		}
+
+		type pair struct{ u, v gogl.Vertex }
+		seen := make(map[pair]int, len(edges))
+		for i, raw := range edges {
+			de, err := decodeEdge(i, raw, doc.EdgeType)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, v := range []gogl.Vertex{de.U, de.V} {
+				if _, ok := listed[v]; listed != nil && !ok {
+					return nil, fmt.Errorf(`Edge %d joins vertex %v, which is not listed in "vertices".`, i, v)
+				}
+			}
+
+			if j, dupe := seen[pair{de.U, de.V}]; dupe {
+				return nil, fmt.Errorf("Edge %d duplicates edge %d.", i, j)
+			}
+			if j, reversed := seen[pair{de.V, de.U}]; reversed && !doc.Directed {
+				return nil, fmt.Errorf("Edges %d and %d join the same vertices in opposite directions, but the graph is undirected.", j, i)
+			}
+			seen[pair{de.U, de.V}] = i
+
+			loops = loops || de.U == de.V
+			doc.Edges = append(doc.Edges, de)
+		}
+	}
+
+	// The document is valid; only now is the graph built.
+	spec := gogl.Spec().Mutable()
+	if doc.Directed {
+		spec = spec.Directed()
+	}
+	if loops {
+		spec = spec.Loop()
+	}
+	switch doc.EdgeType {
+	case WeightedEdges:
+		spec = spec.Weighted()
+	case LabeledEdges:
+		spec = spec.Labeled()
+	}
+
+	g := spec.Create(al.G)
+	b := &batcher{g: g, directed: doc.Directed, edgeType: doc.EdgeType}
+	for _, v := range doc.Vertices {
+		b.vertex(v)
+	}
+	for _, de := range doc.Edges {
+		rec := record{Kind: recEdge, U: de.U, V: de.V}
+		if de.Weight != nil {
+			rec.Weight = *de.Weight
+		}
+		if de.Label != nil {
+			rec.Label = *de.Label
+		}
+		b.edge(rec)
+	}
+	b.flush()
+
+	return g, nil
+}
+
+// Decodes and validates the i'th edge of a document of the given edge type.
+func decodeEdge(i int, raw json.RawMessage, edgeType string) (de docEdge, err error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return de, fmt.Errorf("Edge %d is not a JSON object.", i)
+	}
+	if err := checkFields(fields, fmt.Sprintf("edge %d", i), "u", "v", "weight", "label"); err != nil {
+		return de, err
+	}
+
+	for _, end := range []struct {
+		name string
+		v    *gogl.Vertex
+	}{{"u", &de.U}, {"v", &de.V}} {
+		raw, exists := fields[end.name]
+		if !exists {
+			return de, fmt.Errorf("Edge %d is missing %q.", i, end.name)
+		}
+		var ok bool
+		if *end.v, ok = decodeVertex(raw); !ok {
+			return de, fmt.Errorf("%q of edge %d must be a string or a number.", end.name, i)
+		}
+	}
+
+	raw, hasWeight := fields["weight"]
+	switch {
+	case hasWeight && edgeType != WeightedEdges:
+		return de, fmt.Errorf("Edge %d has a weight, but the graph is not weighted.", i)
+	case !hasWeight && edgeType == WeightedEdges:
+		return de, fmt.Errorf(`Edge %d is missing "weight".`, i)
+	case hasWeight:
+		de.Weight = new(float64)
+		if err := json.Unmarshal(raw, de.Weight); err != nil || isNull(raw) {
+			return de, fmt.Errorf(`"weight" of edge %d must be a number.`, i)
+		}
+	}
+
+	raw, hasLabel := fields["label"]
+	switch {
+	case hasLabel && edgeType != LabeledEdges:
+		return de, fmt.Errorf("Edge %d has a label, but the graph is not labeled.", i)
+	case !hasLabel && edgeType == LabeledEdges:
+		return de, fmt.Errorf(`Edge %d is missing "label".`, i)
+	case hasLabel:
+		de.Label = new(string)
+		if err := json.Unmarshal(raw, de.Label); err != nil || isNull(raw) {
+			return de, fmt.Errorf(`"label" of edge %d must be a string.`, i)
+		}
+	}
+
+	return de, nil
+}
+
+// Returns an error naming the first of the fields, in sorted order, that is not
+// among those allowed.
+func checkFields(fields map[string]json.RawMessage, what string, allowed ...string) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var ok bool
+		for _, a := range allowed {
+			ok = ok || name == a
+		}
+		if !ok {
+			return fmt.Errorf("Unrecognized field %q in %s.", name, what)
+		}
+	}
+	return nil
+}
+
+// Decodes a vertex, which must be a JSON string or number. Numbers are converted
+// as by NewJSONDecoder.
+func decodeVertex(raw json.RawMessage) (gogl.Vertex, bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+
+	switch v.(type) {
+	case string, json.Number:
+		return fromJSON(v), true
+	}
+	return nil, false
+}
+
+func isNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
@@ -0,0 +1,111 @@
+package io
+
+import (
+	"regexp"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type DocumentSuite struct{}
+
+var _ = Suite(&DocumentSuite{})
+
+func (s *DocumentSuite) TestRoundTrip(c *C) {
+	graphs := []gogl.Graph{
+		gogl.Spec().Using(gogl.EdgeList{
+			gogl.NewEdge("foo", "bar"),
+			gogl.NewEdge("bar", 42),
+		}).Create(al.G),
+		gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+			gogl.NewWeightedArc(1, 2, 5.23),
+			gogl.NewWeightedArc(2, 1, -1),
+			gogl.NewWeightedArc(3, 1, 0),
+		}).Create(al.G),
+		gogl.Spec().Labeled().Loop().Using(gogl.LabeledEdgeList{
+			gogl.NewLabeledEdge("a", "b", "foo"),
+			gogl.NewLabeledEdge("b", "b", ""),
+		}).Create(al.G),
+	}
+	graphs[0].(gogl.MutableGraph).EnsureVertex("isolate")
+
+	for i, g := range graphs {
+		data, err := MarshalGraph(g)
+		c.Assert(err, IsNil)
+
+		g2, err := UnmarshalGraph(data)
+		c.Assert(err, IsNil, Commentf("graph %d: %s", i, data))
+
+		_, d1 := g.(gogl.Digraph)
+		_, d2 := g2.(gogl.Digraph)
+		c.Assert(d2, Equals, d1)
+		c.Assert(gogl.Order(g2), Equals, gogl.Order(g), Commentf("graph %d", i))
+		c.Assert(gogl.Size(g2), Equals, gogl.Size(g), Commentf("graph %d", i))
+
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			switch e := e.(type) {
+			case gogl.WeightedEdge:
+				u, v := e.Both()
+				c.Assert(g2.(gogl.WeightedDigraph).HasWeightedArc(gogl.NewWeightedArc(u, v, e.Weight())), Equals, true, Commentf("edge %v", e))
+			case gogl.LabeledEdge:
+				c.Assert(g2.(gogl.LabeledGraph).HasLabeledEdge(e), Equals, true, Commentf("edge %v", e))
+			default:
+				c.Assert(g2.HasEdge(e), Equals, true, Commentf("edge %v", e))
+			}
+			return
+		})
+	}
+}
+
+func (s *DocumentSuite) TestMalformed(c *C) {
+	for doc, msg := range map[string]string{
+		`[1, 2]`:                                                                   "Document is not a JSON object.",
+		`{"directed": true`:                                                        "Document is not a JSON object.",
+		`{"edges": []}`:                                                            `Document is missing "directed".`,
+		`{"directed": "yes"}`:                                                      `"directed" must be a boolean.`,
+		`{"directed": false, "extra": 1}`:                                          `Unrecognized field "extra" in document.`,
+		`{"directed": false, "edgeType": "fuzzy"}`:                                 `Unrecognized edge type "fuzzy".`,
+		`{"directed": false, "vertices": [1, {"x": 2}]}`:                           "Vertex 1 must be a string or a number.",
+		`{"directed": false, "vertices": ["a", "b", "a"]}`:                         "Vertex 2 duplicates vertex 0.",
+		`{"directed": false, "edges": {"u": 1, "v": 2}}`:                           `"edges" must be an array.`,
+		`{"directed": false, "edges": [[1, 2]]}`:                                   "Edge 0 is not a JSON object.",
+		`{"directed": false, "edges": [{"u": 1, "v": 2}, {"v": 3}]}`:               `Edge 1 is missing "u".`,
+		`{"directed": false, "edges": [{"u": 1}]}`:                                 `Edge 0 is missing "v".`,
+		`{"directed": false, "edges": [{"u": 1, "v": null}]}`:                      `"v" of edge 0 must be a string or a number.`,
+		`{"directed": false, "edges": [{"u": 1, "v": 2, "w": 3}]}`:                 `Unrecognized field "w" in edge 0.`,
+		`{"directed": false, "edges": [{"u": 1, "v": 2, "weight": 3}]}`:            "Edge 0 has a weight, but the graph is not weighted.",
+		`{"directed": false, "edgeType": "weighted", "edges": [{"u": 1, "v": 2}]}`: `Edge 0 is missing "weight".`,
+		`{"directed": false, "edgeType": "weighted", "edges": [{"u": 1, "v": 2, "weight": "heavy"}]}`:         `"weight" of edge 0 must be a number.`,
+		`{"directed": false, "edgeType": "weighted", "edges": [{"u": 1, "v": 2, "weight": 1, "label": "x"}]}`: "Edge 0 has a label, but the graph is not labeled.",
+		`{"directed": true, "edgeType": "labeled", "edges": [{"u": 1, "v": 2}]}`:                              `Edge 0 is missing "label".`,
+		`{"directed": false, "vertices": [1, 2], "edges": [{"u": 1, "v": 3}]}`:                                `Edge 0 joins vertex 3, which is not listed in "vertices".`,
+		`{"directed": true, "edges": [{"u": 1, "v": 2}, {"u": 1, "v": 2}]}`:                                   "Edge 1 duplicates edge 0.",
+		`{"directed": false, "edges": [{"u": 1, "v": 2}, {"u": 2, "v": 3}, {"u": 2, "v": 1}]}`:                "Edges 0 and 2 join the same vertices in opposite directions, but the graph is undirected.",
+	} {
+		g, err := UnmarshalGraph([]byte(doc))
+		c.Assert(g, IsNil, Commentf("document: %s", doc))
+		c.Assert(err, ErrorMatches, regexp.QuoteMeta(msg), Commentf("document: %s", doc))
+	}
+}
+
+func (s *DocumentSuite) TestValid(c *C) {
+	// Opposite arcs are fine in a directed graph, and vertices may be left implicit.
+	g, err := UnmarshalGraph([]byte(`{"directed": true, "edges": [{"u": "a", "v": "b"}, {"u": "b", "v": "a"}]}`))
+	c.Assert(err, IsNil)
+	c.Assert(gogl.Order(g), Equals, 2)
+	c.Assert(g.(gogl.Digraph).HasArc(gogl.NewArc("b", "a")), Equals, true)
+
+	g, err = UnmarshalGraph([]byte(`{"directed": false, "vertices": [1, 2.5, "x"]}`))
+	c.Assert(err, IsNil)
+	c.Assert(g.HasVertex(1), Equals, true)
+	c.Assert(g.HasVertex(2.5), Equals, true)
+	c.Assert(g.HasVertex("x"), Equals, true)
+}
+
+func (s *DocumentSuite) TestDataGraphRejected(c *C) {
+	_, err := MarshalGraph(gogl.Spec().DataEdges().Using(gogl.DataEdgeList{
+		gogl.NewDataEdge(1, 2, "foo"),
+	}).Create(al.G))
+	c.Assert(err, ErrorMatches, "Graphs with data edges cannot be marshaled.")
+}
@@ -0,0 +1,561 @@
+// Contains logic for reading and writing graphs in Graphviz's DOT language.
+package dot
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Parses a DOT document and builds an adjacency list graph from it.
+//
+// Only a common subset of DOT is understood:
+//
+//   - graph and digraph (optionally strict) documents; directedness of the
+//     returned graph is determined by the keyword
+//   - node statements, which ensure the vertex exists (making isolates possible)
+//   - edge statements using -> or -- as appropriate, including chains (a -> b -> c)
+//   - weight and label attributes on edges
+//   - comments, and graph/node/edge attribute statements (which are ignored)
+//
+// In quoted strings, \" and \\ stand for a quotation mark and a backslash; any other
+// backslash is kept as is.
+//
+// Subgraphs (including anonymous {a b} edge targets), ports, and HTML strings are not
+// supported, and produce an error.
+//
+// Vertices are the DOT node IDs, as strings (so node 1 becomes vertex "1"). If any
+// edge carries a weight attribute, a weighted graph is returned (missing weights are
+// 0); otherwise, if any edge carries a label, a labeled graph is returned; otherwise,
+// a basic graph is returned. If any edge joins a node to itself, the graph permits
+// self-loops.
+func Unmarshal(data []byte) (gogl.Graph, error) {
+	dg, err := parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	spec := gogl.Spec().Using(dg.source())
+	if dg.directed {
+		spec = spec.Directed()
+	}
+	if dg.loops {
+		spec = spec.Loop()
+	}
+	if dg.weighted {
+		spec = spec.Weighted()
+	} else if dg.labeled {
+		spec = spec.Labeled()
+	}
+
+	return spec.Create(al.G), nil
+}
+
+// Reads a DOT document from r, returning it as a GraphSource from which any
+// graph implementation can be built, as with gogl.Spec().Using(src).
+//
+// The subset of DOT understood, and the vertices and edges produced, are as for
+// Unmarshal. If the document is a digraph, the source is also a DigraphSource,
+// its edges are Arcs, and the spec should be Directed; if any edge carries a
+// weight, they are WeightedEdges, or else LabeledEdges if any carries a label.
+// Syntax errors are returned as *ParseErrors, giving the line and column.
+func ReadDOT(r io.Reader) (gogl.GraphSource, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dg, err := parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+	return dg.source(), nil
+}
+
+// A parsed DOT document.
+type dotGraph struct {
+	directed bool
+	weighted bool
+	labeled  bool
+	loops    bool // whether any edge joins a node to itself
+	vertices []gogl.Vertex
+	seen     map[gogl.Vertex]bool
+	edges    []dotEdge
+}
+
+type dotEdge struct {
+	u, v   string
+	label  string
+	weight float64
+}
+
+func (dg *dotGraph) ensureVertex(id string) {
+	if !dg.seen[id] {
+		dg.seen[id] = true
+		dg.vertices = append(dg.vertices, id)
+	}
+}
+
+// Returns the document's vertices and edges as a GraphSource, which is also a
+// DigraphSource if the document is a digraph.
+func (dg *dotGraph) source() gogl.GraphSource {
+	edges := make([]gogl.Edge, len(dg.edges))
+	for i, e := range dg.edges {
+		edges[i] = dg.edge(e)
+	}
+
+	if dg.directed {
+		return gogl.VertexArcList{V: dg.vertices, E: edges}
+	}
+	return gogl.VertexEdgeList{V: dg.vertices, E: edges}
+}
+
+// Produces the most specific edge type the document calls for.
+func (dg *dotGraph) edge(e dotEdge) gogl.Edge {
+	switch {
+	case dg.directed && dg.weighted:
+		return gogl.NewWeightedArc(e.u, e.v, e.weight)
+	case dg.directed && dg.labeled:
+		return gogl.NewLabeledArc(e.u, e.v, e.label)
+	case dg.directed:
+		return gogl.NewArc(e.u, e.v)
+	case dg.weighted:
+		return gogl.NewWeightedEdge(e.u, e.v, e.weight)
+	case dg.labeled:
+		return gogl.NewLabeledEdge(e.u, e.v, e.label)
+	default:
+		return gogl.NewEdge(e.u, e.v)
+	}
+}
+
+/* Lexing */
+
+type tokenKind int
+
+const (
+	tEOF tokenKind = iota
+	tID
+	tQuoted // a double-quoted ID; never treated as a keyword
+	tEdgeOp
+	tPunct
+)
+
+type token struct {
+	kind      tokenKind
+	val       string
+	line, col int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tEOF:
+		return "end of input"
+	case tQuoted:
+		return strconv.Quote(t.val)
+	default:
+		return "'" + t.val + "'"
+	}
+}
+
+// Reports whether the token is the given (case-insensitive) keyword.
+func (t token) is(keyword string) bool {
+	return t.kind == tID && strings.EqualFold(t.val, keyword)
+}
+
+func (t token) punct(p string) bool {
+	return t.kind == tPunct && t.val == p
+}
+
+// ParseError describes a syntax error in a DOT document, and where it occurred.
+type ParseError struct {
+	Line, Col int
+	Msg       string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("dot: line %d, column %d: %s", e.Line, e.Col, e.Msg)
+}
+
+type lexer struct {
+	src       []rune
+	pos       int
+	line, col int
+}
+
+func (l *lexer) errorf(line, col int, format string, args ...interface{}) error {
+	return &ParseError{Line: line, Col: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.pos]
+	l.pos++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+// Skips whitespace and all three comment styles.
+func (l *lexer) skip() error {
+	for l.pos < len(l.src) {
+		r := l.peek(0)
+		switch {
+		case r == ' ' || r == '\t' || r == '\r' || r == '\n':
+			l.advance()
+		case r == '#' && l.col == 1:
+			// preprocessor-style line, per the DOT spec
+			for l.pos < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case r == '/' && l.peek(1) == '/':
+			for l.pos < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case r == '/' && l.peek(1) == '*':
+			line, col := l.line, l.col
+			l.advance()
+			l.advance()
+			for {
+				if l.pos >= len(l.src) {
+					return l.errorf(line, col, "unterminated comment")
+				}
+				if l.peek(0) == '*' && l.peek(1) == '/' {
+					l.advance()
+					l.advance()
+					break
+				}
+				l.advance()
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func isIDStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r >= 0x80
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skip(); err != nil {
+		return token{}, err
+	}
+
+	t := token{line: l.line, col: l.col}
+	if l.pos >= len(l.src) {
+		t.kind = tEOF
+		return t, nil
+	}
+
+	r := l.peek(0)
+	switch {
+	case r == '-' && (l.peek(1) == '>' || l.peek(1) == '-'):
+		l.advance()
+		t.kind, t.val = tEdgeOp, "-"+string(l.advance())
+	case strings.ContainsRune("{}[]=;,:", r):
+		l.advance()
+		t.kind, t.val = tPunct, string(r)
+	case r == '"':
+		l.advance()
+		var sb strings.Builder
+		for {
+			if l.pos >= len(l.src) {
+				return t, l.errorf(t.line, t.col, "unterminated string")
+			}
+			c := l.advance()
+			if c == '"' {
+				break
+			}
+			if c == '\\' && (l.peek(0) == '"' || l.peek(0) == '\\') {
+				c = l.advance()
+			} else if c == '\\' && l.peek(0) == '\n' {
+				// line continuation
+				l.advance()
+				continue
+			}
+			sb.WriteRune(c)
+		}
+		t.kind, t.val = tQuoted, sb.String()
+	case r == '<':
+		return t, l.errorf(t.line, t.col, "HTML strings are not supported")
+	case isIDStart(r):
+		start := l.pos
+		for l.pos < len(l.src) && (isIDStart(l.peek(0)) || isDigit(l.peek(0))) {
+			l.advance()
+		}
+		t.kind, t.val = tID, string(l.src[start:l.pos])
+	case isDigit(r) || r == '.' || r == '-':
+		start := l.pos
+		l.advance()
+		for l.pos < len(l.src) && (isDigit(l.peek(0)) || l.peek(0) == '.') {
+			l.advance()
+		}
+		t.kind, t.val = tID, string(l.src[start:l.pos])
+		if _, err := strconv.ParseFloat(t.val, 64); err != nil {
+			return t, l.errorf(t.line, t.col, "malformed numeral %q", t.val)
+		}
+	default:
+		return t, l.errorf(t.line, t.col, "unexpected character %q", r)
+	}
+
+	return t, nil
+}
+
+/* Parsing */
+
+type parser struct {
+	lex *lexer
+	tok token
+	g   *dotGraph
+}
+
+func parse(src string) (*dotGraph, error) {
+	p := &parser{
+		lex: &lexer{src: []rune(src), line: 1, col: 1},
+		g:   &dotGraph{seen: make(map[gogl.Vertex]bool)},
+	}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.parseGraph(); err != nil {
+		return nil, err
+	}
+	return p.g, nil
+}
+
+func (p *parser) advance() (err error) {
+	p.tok, err = p.lex.next()
+	return
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return p.lex.errorf(p.tok.line, p.tok.col, format, args...)
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.tok.punct(punct) {
+		return p.errorf("expected '%s', found %s", punct, p.tok)
+	}
+	return p.advance()
+}
+
+func (p *parser) parseGraph() error {
+	if p.tok.is("strict") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case p.tok.is("graph"):
+	case p.tok.is("digraph"):
+		p.g.directed = true
+	default:
+		return p.errorf("expected 'graph' or 'digraph', found %s", p.tok)
+	}
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	// optional graph name
+	if p.tok.kind == tID || p.tok.kind == tQuoted {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for !p.tok.punct("}") {
+		if p.tok.kind == tEOF {
+			return p.errorf("expected '}', found %s", p.tok)
+		}
+		if err := p.parseStmt(); err != nil {
+			return err
+		}
+		if p.tok.punct(";") {
+			if err := p.advance(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.tok.kind != tEOF {
+		return p.errorf("unexpected %s after end of graph", p.tok)
+	}
+	return nil
+}
+
+func (p *parser) parseStmt() error {
+	switch {
+	case p.tok.is("subgraph") || p.tok.punct("{"):
+		return p.errorf("subgraphs are not supported")
+	case p.tok.is("graph") || p.tok.is("node") || p.tok.is("edge"):
+		// attribute statement; defaults are not applied, so just consume it
+		if err := p.advance(); err != nil {
+			return err
+		}
+		_, err := p.parseAttrLists()
+		return err
+	case p.tok.kind != tID && p.tok.kind != tQuoted:
+		return p.errorf("expected statement, found %s", p.tok)
+	}
+
+	first, err := p.parseNodeID()
+	if err != nil {
+		return err
+	}
+
+	if p.tok.punct("=") {
+		// graph attribute assignment (ID = ID); ignored
+		if err = p.advance(); err != nil {
+			return err
+		}
+		_, err = p.parseID()
+		return err
+	}
+
+	ids := []string{first}
+	for p.tok.kind == tEdgeOp {
+		if p.g.directed && p.tok.val != "->" {
+			return p.errorf("undirected edge operator '--' used in digraph")
+		} else if !p.g.directed && p.tok.val != "--" {
+			return p.errorf("directed edge operator '->' used in undirected graph")
+		}
+		if err = p.advance(); err != nil {
+			return err
+		}
+		if p.tok.punct("{") || p.tok.is("subgraph") {
+			return p.errorf("subgraphs are not supported")
+		}
+
+		id, err := p.parseNodeID()
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	attrTok := p.tok
+	attrs, err := p.parseAttrLists()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		p.g.ensureVertex(id)
+	}
+
+	if len(ids) == 1 {
+		// node statement; its attributes are of no interest
+		return nil
+	}
+
+	e := dotEdge{}
+	if w, exists := attrs["weight"]; exists {
+		if e.weight, err = strconv.ParseFloat(w, 64); err != nil {
+			return p.lex.errorf(attrTok.line, attrTok.col, "weight %q is not a number", w)
+		}
+		p.g.weighted = true
+	}
+	if l, exists := attrs["label"]; exists {
+		e.label = l
+		p.g.labeled = true
+	}
+
+	for i := 1; i < len(ids); i++ {
+		e.u, e.v = ids[i-1], ids[i]
+		p.g.loops = p.g.loops || e.u == e.v
+		p.g.edges = append(p.g.edges, e)
+	}
+
+	return nil
+}
+
+func (p *parser) parseID() (string, error) {
+	if p.tok.kind != tID && p.tok.kind != tQuoted {
+		return "", p.errorf("expected identifier, found %s", p.tok)
+	}
+
+	id := p.tok.val
+	return id, p.advance()
+}
+
+func (p *parser) parseNodeID() (string, error) {
+	if p.tok.is("node") || p.tok.is("edge") || p.tok.is("graph") || p.tok.is("digraph") || p.tok.is("subgraph") || p.tok.is("strict") {
+		return "", p.errorf("unexpected keyword %s", p.tok)
+	}
+
+	id, err := p.parseID()
+	if err != nil {
+		return "", err
+	}
+	if p.tok.punct(":") {
+		return "", p.errorf("ports are not supported")
+	}
+	return id, nil
+}
+
+// Parses zero or more consecutive bracketed attribute lists, merging them.
+func (p *parser) parseAttrLists() (map[string]string, error) {
+	attrs := make(map[string]string)
+
+	for p.tok.punct("[") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		for !p.tok.punct("]") {
+			key, err := p.parseID()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect("="); err != nil {
+				return nil, err
+			}
+			val, err := p.parseID()
+			if err != nil {
+				return nil, err
+			}
+			attrs[key] = val
+
+			if p.tok.punct(",") || p.tok.punct(";") {
+				if err = p.advance(); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	return attrs, nil
+}
@@ -0,0 +1,129 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type ReadSuite struct{}
+
+var _ = Suite(&ReadSuite{})
+
+func (s *ReadSuite) TestBasicUndirected(c *C) {
+	g, err := Unmarshal([]byte(`
+		// a comment
+		graph G {
+			a -- b -- c;
+			d /* an isolate */
+			c -- a
+		}`))
+	c.Assert(err, IsNil)
+
+	_, directed := g.(gogl.Digraph)
+	c.Assert(directed, Equals, false)
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(gogl.Size(g), Equals, 3)
+	c.Assert(g.HasEdge(gogl.NewEdge("b", "a")), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge("b", "c")), Equals, true)
+	c.Assert(g.HasEdge(gogl.NewEdge("a", "c")), Equals, true)
+	c.Assert(g.HasVertex("d"), Equals, true)
+}
+
+func (s *ReadSuite) TestWeightedDigraph(c *C) {
+	g, err := Unmarshal([]byte(`strict digraph {
+		node [shape=circle]
+		rankdir = LR
+		1 -> 2 [weight=2.5]
+		2 -> "three" [label="ignored", weight=-4];
+		"three" -> 1 [weight="1e2"]
+	}`))
+	c.Assert(err, IsNil)
+
+	wg, ok := g.(gogl.WeightedDigraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc("1", "2", 2.5)), Equals, true)
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc("2", "three", -4)), Equals, true)
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc("three", "1", 100)), Equals, true)
+	c.Assert(wg.HasArc(gogl.NewArc("2", "1")), Equals, false)
+}
+
+func (s *ReadSuite) TestLabeledQuoting(c *C) {
+	g, err := Unmarshal([]byte(`graph {
+		"say \"hi\"" -- b [label="x y"][color=red]
+		b -- c [label=z]
+	}`))
+	c.Assert(err, IsNil)
+
+	lg, ok := g.(gogl.LabeledGraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(lg.HasLabeledEdge(gogl.NewLabeledEdge(`say "hi"`, "b", "x y")), Equals, true)
+	c.Assert(lg.HasLabeledEdge(gogl.NewLabeledEdge("b", "c", "z")), Equals, true)
+}
+
+func (s *ReadSuite) TestEmpty(c *C) {
+	g, err := Unmarshal([]byte(`digraph empty {}`))
+	c.Assert(err, IsNil)
+	c.Assert(gogl.Order(g), Equals, 0)
+	_, directed := g.(gogl.Digraph)
+	c.Assert(directed, Equals, true)
+}
+
+func (s *ReadSuite) TestErrors(c *C) {
+	cases := []struct{ src, msg string }{
+		{`digraph { a -- b }`, "dot: line 1, column 13: undirected edge operator '--' used in digraph"},
+		{`graph { a -> b }`, "dot: line 1, column 11: directed edge operator '->' used in undirected graph"},
+		{"graph {\n  subgraph cluster { a }\n}", "dot: line 2, column 3: subgraphs are not supported"},
+		{`graph { a -- {b c} }`, "dot: line 1, column 14: subgraphs are not supported"},
+		{`graph { a:n -- b }`, "dot: line 1, column 10: ports are not supported"},
+		{`graph { a -- b [weight=heavy] }`, `dot: line 1, column 16: weight "heavy" is not a number`},
+		{`graph { a -- b `, "dot: line 1, column 16: expected '}', found end of input"},
+		{`tree { }`, "dot: line 1, column 1: expected 'graph' or 'digraph', found 'tree'"},
+		{`graph { a -- <b> }`, "dot: line 1, column 14: HTML strings are not supported"},
+		{`graph { "a }`, "dot: line 1, column 9: unterminated string"},
+		{`graph { } graph { }`, "dot: line 1, column 11: unexpected 'graph' after end of graph"},
+	}
+
+	for _, tc := range cases {
+		_, err := Unmarshal([]byte(tc.src))
+		c.Assert(err, NotNil, Commentf("source: %s", tc.src))
+		c.Assert(err.Error(), Equals, tc.msg, Commentf("source: %s", tc.src))
+	}
+}
+
+func (s *ReadSuite) TestReadDOT(c *C) {
+	src, err := ReadDOT(strings.NewReader(`digraph {
+		a -> b [weight=2]
+		b -> c
+		d
+	}`))
+	c.Assert(err, IsNil)
+	_, ok := src.(gogl.DigraphSource)
+	c.Assert(ok, Equals, true)
+
+	g := gogl.Spec().Directed().Weighted().Using(src).Create(al.G).(gogl.WeightedDigraph)
+	c.Assert(gogl.Order(g), Equals, 4)
+	c.Assert(g.HasVertex("d"), Equals, true)
+	c.Assert(g.HasWeightedArc(gogl.NewWeightedArc("a", "b", 2)), Equals, true)
+	c.Assert(g.HasWeightedArc(gogl.NewWeightedArc("b", "c", 0)), Equals, true)
+
+	src, err = ReadDOT(strings.NewReader(`graph { a -- b [label=x] }`))
+	c.Assert(err, IsNil)
+	_, ok = src.(gogl.DigraphSource)
+	c.Assert(ok, Equals, false)
+	lg := gogl.Spec().Labeled().Using(src).Create(al.G).(gogl.LabeledGraph)
+	c.Assert(lg.HasLabeledEdge(gogl.NewLabeledEdge("b", "a", "x")), Equals, true)
+
+	_, err = ReadDOT(strings.NewReader("graph {\n  a -> b\n}"))
+	perr, ok := err.(*ParseError)
+	c.Assert(ok, Equals, true)
+	c.Assert(perr.Line, Equals, 2)
+	c.Assert(perr.Col, Equals, 5)
+}
@@ -0,0 +1,123 @@
+package dot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Writes the graph to w as a DOT document, such as Graphviz can render.
+//
+// A Digraph is written as a digraph, its arcs joined by ->; any other graph is
+// written as a graph, its edges joined by --. Every vertex gets a node statement
+// before the edges, so that isolates are kept. The label of a LabeledEdge and
+// the weight of a WeightedEdge become its label and weight attributes.
+//
+// Vertices are identified by their fmt.Sprint representation, and are quoted
+// unless that is a plain DOT identifier or numeral; labels are always quoted.
+// Unmarshal reads the result back, though its vertices are then strings.
+func WriteDOT(w io.Writer, g gogl.Graph) error {
+	bw := bufio.NewWriter(w)
+
+	dg, directed := g.(gogl.Digraph)
+	op := "--"
+	if directed {
+		bw.WriteString("digraph {\n")
+		op = "->"
+	} else {
+		bw.WriteString("graph {\n")
+	}
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		fmt.Fprintf(bw, "\t%s;\n", quoteID(fmt.Sprint(v)))
+		return
+	})
+
+	writeEdge := func(e gogl.Edge, u, v gogl.Vertex) {
+		fmt.Fprintf(bw, "\t%s %s %s", quoteID(fmt.Sprint(u)), op, quoteID(fmt.Sprint(v)))
+
+		var attrs []string
+		if le, ok := e.(gogl.LabeledEdge); ok {
+			attrs = append(attrs, "label="+quote(le.Label()))
+		}
+		if we, ok := e.(gogl.WeightedEdge); ok {
+			attrs = append(attrs, "weight="+quoteID(strconv.FormatFloat(we.Weight(), 'g', -1, 64)))
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(bw, " [%s]", strings.Join(attrs, ", "))
+		}
+		bw.WriteString(";\n")
+	}
+
+	if directed {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			writeEdge(a, a.Source(), a.Target())
+			return
+		})
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			writeEdge(e, u, v)
+			return
+		})
+	}
+
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// Returns id as it may appear in a DOT document: bare if it is an identifier or
+// numeral, and quoted otherwise.
+func quoteID(id string) string {
+	if isPlainID(id) || isNumeral(id) {
+		return id
+	}
+	return quote(id)
+}
+
+// Quotes s as a DOT string, escaping its backslashes and quotation marks.
+func quote(s string) string {
+	return `"` + quoteEscaper.Replace(s) + `"`
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// Reports whether s is an unquoted DOT identifier, and not a keyword.
+func isPlainID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if !isIDStart(r) && (i == 0 || !isDigit(r)) {
+			return false
+		}
+	}
+	for _, kw := range []string{"node", "edge", "graph", "digraph", "subgraph", "strict"} {
+		if strings.EqualFold(s, kw) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether s is a DOT numeral: an optional minus sign, then digits with
+// at most one decimal point among them.
+func isNumeral(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	var digits, points int
+	for _, r := range s {
+		switch {
+		case isDigit(r):
+			digits++
+		case r == '.':
+			points++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && points <= 1
+}
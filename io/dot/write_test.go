@@ -0,0 +1,125 @@
+package dot
+
+import (
+	"bytes"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type WriteSuite struct{}
+
+var _ = Suite(&WriteSuite{})
+
+func (s *WriteSuite) TestUndirected(c *C) {
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+	}).Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex("c")
+
+	var buf bytes.Buffer
+	c.Assert(WriteDOT(&buf, g), IsNil)
+
+	out := buf.String()
+	c.Assert(out, Matches, "(?s)graph \\{\n.*\\}\n")
+	c.Assert(out, Matches, "(?s).*\t(a -- b|b -- a);\n.*")
+	c.Assert(out, Matches, "(?s).*\tc;\n.*")
+
+	back, err := Unmarshal(buf.Bytes())
+	c.Assert(err, IsNil)
+	c.Assert(gogl.Order(back), Equals, 3)
+	c.Assert(gogl.Size(back), Equals, 1)
+	c.Assert(back.HasEdge(gogl.NewEdge("a", "b")), Equals, true)
+}
+
+func (s *WriteSuite) TestLoop(c *C) {
+	g := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "a"),
+		gogl.NewEdge("a", "b"),
+	}).Create(al.G)
+
+	var buf bytes.Buffer
+	c.Assert(WriteDOT(&buf, g), IsNil)
+
+	back, err := Unmarshal(buf.Bytes())
+	c.Assert(err, IsNil)
+	c.Assert(gogl.Size(back), Equals, 2)
+	c.Assert(back.HasEdge(gogl.NewEdge("a", "a")), Equals, true)
+	c.Assert(back.HasEdge(gogl.NewEdge("a", "b")), Equals, true)
+}
+
+func (s *WriteSuite) TestWeightedDigraph(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc(1, 2, 2.5),
+		gogl.NewWeightedArc(2, 3, -4),
+		gogl.NewWeightedArc(3, 1, 1e21),
+	}).Create(al.G)
+
+	var buf bytes.Buffer
+	c.Assert(WriteDOT(&buf, g), IsNil)
+	c.Assert(buf.String(), Matches, "(?s)digraph \\{\n.*\t1 -> 2 \\[weight=2.5\\];\n.*")
+	c.Assert(buf.String(), Matches, "(?s).*\t3 -> 1 \\[weight=\"1e\\+21\"\\];\n.*")
+
+	back, err := Unmarshal(buf.Bytes())
+	c.Assert(err, IsNil)
+	wg, ok := back.(gogl.WeightedDigraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc("1", "2", 2.5)), Equals, true)
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc("2", "3", -4)), Equals, true)
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc("3", "1", 1e21)), Equals, true)
+	c.Assert(wg.HasArc(gogl.NewArc("2", "1")), Equals, false)
+}
+
+func (s *WriteSuite) TestQuoting(c *C) {
+	g := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge("two words", `say "hi"`, "x y"),
+		gogl.NewLabeledEdge("node", "a-b", ""),
+	}).Create(al.G)
+
+	var buf bytes.Buffer
+	c.Assert(WriteDOT(&buf, g), IsNil)
+	c.Assert(buf.String(), Matches, `(?s).*"two words";.*`)
+	c.Assert(buf.String(), Matches, `(?s).*"node";.*`)
+	c.Assert(buf.String(), Matches, `(?s).*"a-b";.*`)
+
+	back, err := Unmarshal(buf.Bytes())
+	c.Assert(err, IsNil)
+	lg, ok := back.(gogl.LabeledGraph)
+	c.Assert(ok, Equals, true)
+	c.Assert(lg.HasLabeledEdge(gogl.NewLabeledEdge("two words", `say "hi"`, "x y")), Equals, true)
+	c.Assert(lg.HasLabeledEdge(gogl.NewLabeledEdge("node", "a-b", "")), Equals, true)
+}
+
+func (s *WriteSuite) TestBackslashes(c *C) {
+	g := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge(`C:\dir\`, "b", `x\`),
+		gogl.NewLabeledEdge(`a\"b`, `"\\"`, `\"q\"`),
+	}).Create(al.G)
+
+	var buf bytes.Buffer
+	c.Assert(WriteDOT(&buf, g), IsNil)
+
+	src, err := ReadDOT(&buf)
+	c.Assert(err, IsNil)
+	back := gogl.Spec().Labeled().Using(src).Create(al.G).(gogl.LabeledGraph)
+	c.Assert(gogl.Order(back), Equals, 4)
+	c.Assert(back.HasLabeledEdge(gogl.NewLabeledEdge(`C:\dir\`, "b", `x\`)), Equals, true)
+	c.Assert(back.HasLabeledEdge(gogl.NewLabeledEdge(`a\"b`, `"\\"`, `\"q\"`)), Equals, true)
+}
+
+func (s *WriteSuite) TestIDs(c *C) {
+	for id, plain := range map[string]bool{
+		"a_1":   true,
+		"-2.5":  true,
+		".5":    true,
+		"1a":    false,
+		"":      false,
+		"1.2.3": false,
+		"Graph": false,
+		"é":     true,
+		"a b":   false,
+	} {
+		c.Assert(quoteID(id) == id, Equals, plain, Commentf("id %q", id))
+	}
+}
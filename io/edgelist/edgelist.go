@@ -0,0 +1,134 @@
+// Reads and writes graphs as plain text edge lists, one edge per line.
+package edgelist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Writes the graph's edges to w, one per line, as the two endpoints separated by
+// a space, followed by the weight if the edge is a WeightedEdge:
+//
+//	a b 1.5
+//
+// A Digraph's arcs are written from source to target. Vertices are written as
+// their fmt.Sprint representation, which must be non-empty, must not begin with
+// #, and must not contain whitespace, else an error is returned; what has been
+// written up to that point is left in w. Isolated vertices, having no edges,
+// are not written, and labels and data are dropped.
+func Write(w io.Writer, g gogl.Graph) error {
+	bw := bufio.NewWriter(w)
+
+	var err error
+	line := func(e gogl.Edge, u, v gogl.Vertex) (terminate bool) {
+		us, vs := fmt.Sprint(u), fmt.Sprint(v)
+		for _, s := range []string{us, vs} {
+			if s == "" || strings.HasPrefix(s, "#") || strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+				err = fmt.Errorf("Vertex %q cannot be written in an edge list.", s)
+				return true
+			}
+		}
+
+		bw.WriteString(us + " " + vs)
+		if we, ok := e.(gogl.WeightedEdge); ok {
+			bw.WriteString(" " + strconv.FormatFloat(we.Weight(), 'g', -1, 64))
+		}
+		bw.WriteString("\n")
+		return
+	}
+
+	if dg, ok := g.(gogl.Digraph); ok {
+		dg.Arcs(func(a gogl.Arc) (terminate bool) {
+			return line(a, a.Source(), a.Target())
+		})
+	} else {
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			u, v := e.Both()
+			return line(e, u, v)
+		})
+	}
+
+	if ferr := bw.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// Reads an edge list, as written by Write, returning it as a GraphSource from
+// which any graph implementation can be built, as with gogl.Spec().Using(src).
+//
+// Each line holds two vertices, separated by whitespace, and optionally a third
+// column, which is parsed as a float weight. Lines with a weight give
+// WeightedEdges, and those without give basic edges; every line must have the
+// same number of columns as the first. If directed is true, the source is also a
+// DigraphSource, each line giving an arc from the first vertex to the second.
+// Blank lines, and lines beginning with #, are skipped. Vertices are strings.
+func Read(r io.Reader, directed bool) (gogl.GraphSource, error) {
+	var (
+		edges         gogl.EdgeList
+		arcs          gogl.ArcList
+		weightedEdges gogl.WeightedEdgeList
+		weightedArcs  gogl.WeightedArcList
+		columns, line int
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if columns == 0 {
+			columns = len(fields)
+			if columns != 2 && columns != 3 {
+				return nil, fmt.Errorf("Line %d: expected 2 or 3 fields, found %d.", line, columns)
+			}
+		} else if len(fields) != columns {
+			return nil, fmt.Errorf("Line %d: expected %d fields, found %d.", line, columns, len(fields))
+		}
+
+		u, v := fields[0], fields[1]
+		if columns == 2 {
+			if directed {
+				arcs = append(arcs, gogl.NewArc(u, v))
+			} else {
+				edges = append(edges, gogl.NewEdge(u, v))
+			}
+			continue
+		}
+
+		w, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: invalid weight %q.", line, fields[2])
+		}
+		if directed {
+			weightedArcs = append(weightedArcs, gogl.NewWeightedArc(u, v, w))
+		} else {
+			weightedEdges = append(weightedEdges, gogl.NewWeightedEdge(u, v, w))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// An edge list has no isolates, so the vertices are exactly those of the edges.
+	switch {
+	case columns == 3 && directed:
+		return weightedArcs, nil
+	case columns == 3:
+		return weightedEdges, nil
+	case directed:
+		return arcs, nil
+	default:
+		return edges, nil
+	}
+}
@@ -0,0 +1,92 @@
+package edgelist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type EdgeListSuite struct{}
+
+var _ = Suite(&EdgeListSuite{})
+
+func (s *EdgeListSuite) TestRead(c *C) {
+	src, err := Read(strings.NewReader(`
+# a comment
+a b
+b	c
+
+  c   a
+`), false)
+	c.Assert(err, IsNil)
+	_, directed := src.(gogl.DigraphSource)
+	c.Assert(directed, Equals, false)
+
+	g := gogl.Spec().Using(src).Create(al.G)
+	c.Assert(gogl.Order(g), Equals, 3)
+	c.Assert(gogl.Size(g), Equals, 3)
+	c.Assert(g.HasEdge(gogl.NewEdge("a", "c")), Equals, true)
+
+	src, err = Read(strings.NewReader("1 2 0.5\n2 1 -3\n"), true)
+	c.Assert(err, IsNil)
+	_, directed = src.(gogl.DigraphSource)
+	c.Assert(directed, Equals, true)
+
+	wg := gogl.Spec().Directed().Weighted().Using(src).Create(al.G).(gogl.WeightedDigraph)
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc("1", "2", 0.5)), Equals, true)
+	c.Assert(wg.HasWeightedArc(gogl.NewWeightedArc("2", "1", -3)), Equals, true)
+}
+
+func (s *EdgeListSuite) TestReadErrors(c *C) {
+	cases := []struct{ src, msg string }{
+		{"a\n", "Line 1: expected 2 or 3 fields, found 1."},
+		{"a b c d\n", "Line 1: expected 2 or 3 fields, found 4."},
+		{"a b 1\n\nc d\n", "Line 3: expected 3 fields, found 2."},
+		{"a b heavy\n", `Line 1: invalid weight "heavy".`},
+	}
+	for _, tc := range cases {
+		_, err := Read(strings.NewReader(tc.src), false)
+		c.Assert(err, ErrorMatches, tc.msg, Commentf("source: %q", tc.src))
+	}
+}
+
+func (s *EdgeListSuite) TestRoundTrip(c *C) {
+	g := gogl.Spec().Weighted().Using(gogl.WeightedEdgeList{
+		gogl.NewWeightedEdge("a", "b", 1.5),
+		gogl.NewWeightedEdge("b", "c", 1e-9),
+	}).Create(al.G)
+
+	var buf bytes.Buffer
+	c.Assert(Write(&buf, g), IsNil)
+	c.Assert(strings.Count(buf.String(), "\n"), Equals, 2)
+
+	src, err := Read(&buf, false)
+	c.Assert(err, IsNil)
+	back := gogl.Spec().Weighted().Using(src).Create(al.G).(gogl.WeightedGraph)
+	c.Assert(back.HasWeightedEdge(gogl.NewWeightedEdge("a", "b", 1.5)), Equals, true)
+	c.Assert(back.HasWeightedEdge(gogl.NewWeightedEdge("c", "b", 1e-9)), Equals, true)
+
+	dg := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc(1, 2),
+	}).Create(al.G)
+	buf.Reset()
+	c.Assert(Write(&buf, dg), IsNil)
+	c.Assert(buf.String(), Equals, "1 2\n")
+}
+
+func (s *EdgeListSuite) TestWriteErrors(c *C) {
+	for _, bad := range []string{"two words", "", "#hash"} {
+		g := gogl.Spec().Using(gogl.EdgeList{
+			gogl.NewEdge("ok", bad),
+		}).Create(al.G)
+		var buf bytes.Buffer
+		c.Assert(Write(&buf, g), ErrorMatches, "Vertex .* cannot be written in an edge list.")
+	}
+}
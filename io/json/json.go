@@ -0,0 +1,43 @@
+// Reads and writes graphs as JSON documents, for exchange with web frontends
+// and other tools that speak JSON rather than gogl's streams.
+package json
+
+import (
+	"github.com/sdboyer/gogl"
+	gio "github.com/sdboyer/gogl/io"
+)
+
+// Writes the graph as a single JSON object, in the document format of
+// io.MarshalGraph:
+//
+//	{
+//		"directed": false,
+//		"edgeType": "labeled",
+//		"vertices": ["a", "b", 3],
+//		"edges": [{"u": "a", "v": "b", "label": "road"}]
+//	}
+//
+// Every vertex is listed, so isolates survive. Edges carry a "weight" if the
+// graph is weighted, and a "label" if it is labeled. A Digraph is written as
+// directed, each edge running from u to v. Graphs with data edges cannot be
+// marshaled.
+func Marshal(g gogl.Graph) ([]byte, error) {
+	return gio.MarshalGraph(g)
+}
+
+// Reads a graph from a JSON document, as written by Marshal, returning it as a
+// GraphSource that can be fed into gogl.Spec().Using(). Round-tripping a graph
+// preserves its vertices, edges, directedness and edge weights or labels.
+//
+// The source is a mutable adjacency list of the document's directedness and
+// edge subtype; a directed document gives a DigraphSource. Vertices come back
+// as strings, or as ints or float64s for JSON numbers. The document is fully
+// validated first, as described for io.UnmarshalGraph, and malformed input
+// yields an error rather than a partial graph.
+func Unmarshal(data []byte) (gogl.GraphSource, error) {
+	g, err := gio.UnmarshalGraph(data)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
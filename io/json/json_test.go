@@ -0,0 +1,61 @@
+package json
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type JSONSuite struct{}
+
+var _ = Suite(&JSONSuite{})
+
+func (s *JSONSuite) TestRoundTrip(c *C) {
+	wg := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("a", "b", 1.5),
+		gogl.NewWeightedArc("b", "a", -2),
+		gogl.NewWeightedArc(3, "a", 0),
+	}).Create(al.G)
+	wg.(gogl.VertexSetMutator).EnsureVertex("lonely")
+
+	data, err := Marshal(wg)
+	c.Assert(err, IsNil)
+	src, err := Unmarshal(data)
+	c.Assert(err, IsNil)
+
+	back := gogl.Spec().Directed().Weighted().Using(src).Create(al.G).(gogl.WeightedDigraph)
+	c.Assert(gogl.Order(back), Equals, 4)
+	c.Assert(gogl.Size(back), Equals, 3)
+	c.Assert(back.HasVertex("lonely"), Equals, true)
+	c.Assert(back.HasWeightedArc(gogl.NewWeightedArc("a", "b", 1.5)), Equals, true)
+	c.Assert(back.HasWeightedArc(gogl.NewWeightedArc("b", "a", -2)), Equals, true)
+	c.Assert(back.HasWeightedArc(gogl.NewWeightedArc(3, "a", 0)), Equals, true)
+
+	lg := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge("x", "y", "road"),
+	}).Create(al.G)
+
+	data, err = Marshal(lg)
+	c.Assert(err, IsNil)
+	src, err = Unmarshal(data)
+	c.Assert(err, IsNil)
+	_, directed := src.(gogl.DigraphSource)
+	c.Assert(directed, Equals, false)
+
+	lback := gogl.Spec().Labeled().Using(src).Create(al.G).(gogl.LabeledGraph)
+	c.Assert(gogl.Order(lback), Equals, 2)
+	c.Assert(lback.HasLabeledEdge(gogl.NewLabeledEdge("y", "x", "road")), Equals, true)
+}
+
+func (s *JSONSuite) TestErrors(c *C) {
+	_, err := Unmarshal([]byte(`[1, 2]`))
+	c.Assert(err, ErrorMatches, "Document is not a JSON object.")
+
+	_, err = Unmarshal([]byte(`{"directed": false, "edges": [{"u": "a", "v": "b", "weight": 1}]}`))
+	c.Assert(err, ErrorMatches, "Edge 0 has a weight, but the graph is not weighted.")
+}
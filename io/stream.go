@@ -0,0 +1,353 @@
+// Contains logic for persisting graphs to, and restoring them from, byte streams.
+package io
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdio "io"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// The stream format version written by Encoders. Decoders accept any version up to this one.
+//
+// Version 2 added the Loops field to the header.
+const StreamVersion = 2
+
+// Edge subtypes, as recorded in a stream's header.
+const (
+	BasicEdges    = "basic"
+	WeightedEdges = "weighted"
+	LabeledEdges  = "labeled"
+)
+
+// The first value in every stream, describing the graph that follows.
+type header struct {
+	Version  int
+	Directed bool
+	Loops    bool // whether any edge joins a vertex to itself
+	EdgeType string
+}
+
+const (
+	recVertex byte = iota + 1
+	recEdge
+	recEnd
+)
+
+// Every value after the header is a record. Vertices are all sent before edges, so
+// that isolates survive; an end record terminates the stream, so that truncation
+// can be detected.
+type record struct {
+	Kind   byte
+	U, V   gogl.Vertex
+	Weight float64 `json:",omitempty"`
+	Label  string  `json:",omitempty"`
+}
+
+// Both encoding/gob and encoding/json encoders (and decoders) satisfy these.
+type valueEncoder interface {
+	Encode(interface{}) error
+}
+
+type valueDecoder interface {
+	Decode(interface{}) error
+}
+
+// An Encoder writes graphs to a stream incrementally, one vertex or edge at a time,
+// so that the whole graph never has to be buffered in memory.
+type Encoder struct {
+	enc valueEncoder
+}
+
+// Creates an Encoder that writes to w using encoding/gob.
+//
+// Vertices are sent as interface values, so any vertex type other than Go's
+// basic types must be registered with gob.Register().
+func NewEncoder(w stdio.Writer) *Encoder {
+	return &Encoder{enc: gob.NewEncoder(w)}
+}
+
+// Creates an Encoder that writes to w as a sequence of newline-delimited JSON values.
+func NewJSONEncoder(w stdio.Writer) *Encoder {
+	return &Encoder{enc: json.NewEncoder(w)}
+}
+
+// Writes the provided graph to the stream.
+//
+// If g is a DigraphSource, its arcs are written and the stream is marked as
+// directed. The edge subtype is determined by whether g is a WeightedGraph or a
+// LabeledGraph; failing that, from the type of the first edge g produces. Graphs
+// with data edges cannot be encoded.
+func (e *Encoder) Encode(g gogl.GraphSource) error {
+	edgeType, ok := edgeTypeOf(g)
+	if !ok {
+		return errors.New("Graphs with data edges cannot be streamed.")
+	}
+
+	dg, directed := g.(gogl.DigraphSource)
+	h := header{Version: StreamVersion, Directed: directed, Loops: hasLoop(g), EdgeType: edgeType}
+
+	err := e.enc.Encode(h)
+	if err != nil {
+		return err
+	}
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		err = e.enc.Encode(record{Kind: recVertex, U: v})
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+
+	write := func(edge gogl.Edge) (terminate bool) {
+		rec := record{Kind: recEdge}
+		if a, ok := edge.(gogl.Arc); ok && directed {
+			rec.U, rec.V = a.Source(), a.Target()
+		} else {
+			rec.U, rec.V = edge.Both()
+		}
+
+		switch h.EdgeType {
+		case WeightedEdges:
+			if we, ok := edge.(gogl.WeightedEdge); ok {
+				rec.Weight = we.Weight()
+			}
+		case LabeledEdges:
+			if le, ok := edge.(gogl.LabeledEdge); ok {
+				rec.Label = le.Label()
+			}
+		}
+
+		err = e.enc.Encode(rec)
+		return err != nil
+	}
+
+	if directed {
+		dg.Arcs(func(a gogl.Arc) bool { return write(a) })
+	} else {
+		g.Edges(write)
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.enc.Encode(record{Kind: recEnd})
+}
+
+// Determines the edge subtype of the given graph: from whether it is a
+// WeightedGraph or a LabeledGraph, or failing that, from the type of the first
+// edge it produces. Graphs with data edges have no serializable subtype, so false
+// is returned for them.
+func edgeTypeOf(g gogl.GraphSource) (string, bool) {
+	switch g.(type) {
+	case gogl.WeightedGraph:
+		return WeightedEdges, true
+	case gogl.LabeledGraph:
+		return LabeledEdges, true
+	case gogl.DataGraph:
+		return "", false
+	}
+
+	edgeType := BasicEdges
+	g.Edges(func(edge gogl.Edge) bool {
+		switch edge.(type) {
+		case gogl.WeightedEdge:
+			edgeType = WeightedEdges
+		case gogl.LabeledEdge:
+			edgeType = LabeledEdges
+		}
+		return true
+	})
+	return edgeType, true
+}
+
+// Indicates whether any of the given graph's edges joins a vertex to itself.
+func hasLoop(g gogl.GraphSource) (loops bool) {
+	g.Edges(func(edge gogl.Edge) bool {
+		u, v := edge.Both()
+		loops = gogl.VertexKey(u) == gogl.VertexKey(v)
+		return loops
+	})
+	return
+}
+
+// A Decoder reads graphs written by an Encoder.
+type Decoder struct {
+	dec  valueDecoder
+	json bool
+}
+
+// Creates a Decoder that reads a gob stream, as written by NewEncoder, from r.
+func NewDecoder(r stdio.Reader) *Decoder {
+	return &Decoder{dec: gob.NewDecoder(r)}
+}
+
+// Creates a Decoder that reads a JSON stream, as written by NewJSONEncoder, from r.
+//
+// JSON does not distinguish between numeric types; numeric vertices are decoded
+// as int if they are integral, and float64 otherwise.
+func NewJSONDecoder(r stdio.Reader) *Decoder {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return &Decoder{dec: dec, json: true}
+}
+
+// The number of vertices or edges accumulated before they are added to the graph.
+// Adding them in batches lets the graph presize its storage.
+const decodeBatchSize = 4096
+
+// Reads a graph from the stream, returning a mutable adjacency list graph of the
+// directedness and edge subtype recorded in the stream's header. If the header
+// records self-loops, the graph permits them; version 1 streams do not record
+// this, so graphs decoded from them always do.
+//
+// The graph is built incrementally as the stream is read, in batches; at no point
+// is the stream itself buffered in full.
+func (d *Decoder) Decode() (gogl.Graph, error) {
+	var h header
+	if err := d.dec.Decode(&h); err != nil {
+		return nil, err
+	}
+	if h.Version < 1 || h.Version > StreamVersion {
+		return nil, fmt.Errorf("Unsupported stream format version %d.", h.Version)
+	}
+
+	spec := gogl.Spec().Mutable()
+	if h.Directed {
+		spec = spec.Directed()
+	}
+	if h.Loops || h.Version < 2 {
+		spec = spec.Loop()
+	}
+
+	switch h.EdgeType {
+	case BasicEdges:
+	case WeightedEdges:
+		spec = spec.Weighted()
+	case LabeledEdges:
+		spec = spec.Labeled()
+	default:
+		return nil, fmt.Errorf("Unrecognized edge type %q in stream header.", h.EdgeType)
+	}
+
+	g := spec.Create(al.G)
+	b := &batcher{g: g, directed: h.Directed, edgeType: h.EdgeType}
+
+	for {
+		var rec record
+		if err := d.dec.Decode(&rec); err != nil {
+			if err == stdio.EOF {
+				return nil, errors.New("Stream ended before the graph was complete.")
+			}
+			return nil, err
+		}
+
+		if d.json {
+			rec.U, rec.V = fromJSON(rec.U), fromJSON(rec.V)
+		}
+
+		switch rec.Kind {
+		case recVertex:
+			b.vertex(rec.U)
+		case recEdge:
+			b.edge(rec)
+		case recEnd:
+			b.flush()
+			return g, nil
+		default:
+			return nil, fmt.Errorf("Unrecognized record kind %d in stream.", rec.Kind)
+		}
+	}
+}
+
+// Converts numbers, which the JSON decoder leaves as json.Number, to int or float64.
+func fromJSON(v gogl.Vertex) gogl.Vertex {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := n.Float64()
+		return f
+	}
+	return v
+}
+
+// Accumulates decoded vertices and edges, adding them to the graph in batches.
+type batcher struct {
+	g        gogl.Graph
+	directed bool
+	edgeType string
+	vertices []gogl.Vertex
+	edges    []record
+}
+
+func (b *batcher) vertex(v gogl.Vertex) {
+	b.vertices = append(b.vertices, v)
+	if len(b.vertices) == decodeBatchSize {
+		b.flush()
+	}
+}
+
+func (b *batcher) edge(rec record) {
+	b.edges = append(b.edges, rec)
+	if len(b.edges) == decodeBatchSize {
+		b.flush()
+	}
+}
+
+func (b *batcher) flush() {
+	if len(b.vertices) > 0 {
+		b.g.(gogl.VertexSetMutator).EnsureVertex(b.vertices...)
+		b.vertices = b.vertices[:0]
+	}
+
+	if len(b.edges) == 0 {
+		return
+	}
+
+	switch {
+	case b.edgeType == WeightedEdges && b.directed:
+		arcs := make([]gogl.WeightedArc, len(b.edges))
+		for i, r := range b.edges {
+			arcs[i] = gogl.NewWeightedArc(r.U, r.V, r.Weight)
+		}
+		b.g.(gogl.WeightedArcSetMutator).AddArcs(arcs...)
+	case b.edgeType == WeightedEdges:
+		edges := make([]gogl.WeightedEdge, len(b.edges))
+		for i, r := range b.edges {
+			edges[i] = gogl.NewWeightedEdge(r.U, r.V, r.Weight)
+		}
+		b.g.(gogl.WeightedEdgeSetMutator).AddEdges(edges...)
+	case b.edgeType == LabeledEdges && b.directed:
+		arcs := make([]gogl.LabeledArc, len(b.edges))
+		for i, r := range b.edges {
+			arcs[i] = gogl.NewLabeledArc(r.U, r.V, r.Label)
+		}
+		b.g.(gogl.LabeledArcSetMutator).AddArcs(arcs...)
+	case b.edgeType == LabeledEdges:
+		edges := make([]gogl.LabeledEdge, len(b.edges))
+		for i, r := range b.edges {
+			edges[i] = gogl.NewLabeledEdge(r.U, r.V, r.Label)
+		}
+		b.g.(gogl.LabeledEdgeSetMutator).AddEdges(edges...)
+	case b.directed:
+		arcs := make([]gogl.Arc, len(b.edges))
+		for i, r := range b.edges {
+			arcs[i] = gogl.NewArc(r.U, r.V)
+		}
+		b.g.(gogl.ArcSetMutator).AddArcs(arcs...)
+	default:
+		edges := make([]gogl.Edge, len(b.edges))
+		for i, r := range b.edges {
+			edges[i] = gogl.NewEdge(r.U, r.V)
+		}
+		b.g.(gogl.EdgeSetMutator).AddEdges(edges...)
+	}
+
+	b.edges = b.edges[:0]
+}
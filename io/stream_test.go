@@ -0,0 +1,223 @@
+package io
+
+import (
+	"bytes"
+	"encoding/gob"
+	stdio "io"
+	"io/ioutil"
+	stdrand "math/rand"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type StreamSuite struct{}
+
+var _ = Suite(&StreamSuite{})
+
+type streamCodec struct {
+	enc func(stdio.Writer) *Encoder
+	dec func(stdio.Reader) *Decoder
+}
+
+var codecs = map[string]streamCodec{
+	"gob":  {NewEncoder, NewDecoder},
+	"json": {NewJSONEncoder, NewJSONDecoder},
+}
+
+// Round-trips the graph through each codec, checking that the result has the
+// same vertices and edges.
+func assertRoundTrip(c *C, g gogl.Graph, edgeEq func(gogl.Graph, gogl.Edge) bool) {
+	for name, codec := range codecs {
+		buf := &bytes.Buffer{}
+		c.Assert(codec.enc(buf).Encode(g), IsNil, Commentf("codec: %s", name))
+
+		g2, err := codec.dec(buf).Decode()
+		c.Assert(err, IsNil, Commentf("codec: %s", name))
+
+		_, d1 := g.(gogl.Digraph)
+		_, d2 := g2.(gogl.Digraph)
+		c.Assert(d2, Equals, d1, Commentf("codec: %s", name))
+		c.Assert(gogl.Order(g2), Equals, gogl.Order(g), Commentf("codec: %s", name))
+		c.Assert(gogl.Size(g2), Equals, gogl.Size(g), Commentf("codec: %s", name))
+
+		g.Vertices(func(v gogl.Vertex) (terminate bool) {
+			c.Assert(g2.HasVertex(v), Equals, true, Commentf("codec: %s, vertex %v", name, v))
+			return
+		})
+		g.Edges(func(e gogl.Edge) (terminate bool) {
+			c.Assert(edgeEq(g2, e), Equals, true, Commentf("codec: %s, edge %v", name, e))
+			return
+		})
+	}
+}
+
+func (s *StreamSuite) TestRoundTripBasic(c *C) {
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("foo", "bar"),
+		gogl.NewEdge("bar", 42),
+	}).Create(al.G).(gogl.MutableGraph)
+	g.EnsureVertex("isolate")
+
+	assertRoundTrip(c, g, func(g2 gogl.Graph, e gogl.Edge) bool {
+		return g2.HasEdge(e)
+	})
+}
+
+func (s *StreamSuite) TestRoundTripWeightedDigraph(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc(1, 2, 5.23),
+		gogl.NewWeightedArc(2, 3, -1),
+		gogl.NewWeightedArc(3, 1, 0),
+	}).Create(al.G)
+
+	assertRoundTrip(c, g, func(g2 gogl.Graph, e gogl.Edge) bool {
+		u, v := e.Both()
+		wd, ok := g2.(gogl.WeightedDigraph)
+		return ok && wd.HasWeightedArc(gogl.NewWeightedArc(u, v, e.(gogl.WeightedEdge).Weight()))
+	})
+}
+
+func (s *StreamSuite) TestRoundTripLabeled(c *C) {
+	g := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge("a", "b", "foo"),
+		gogl.NewLabeledEdge("b", "c", ""),
+	}).Create(al.G)
+
+	assertRoundTrip(c, g, func(g2 gogl.Graph, e gogl.Edge) bool {
+		lg, ok := g2.(gogl.LabeledGraph)
+		return ok && lg.HasLabeledEdge(e.(gogl.LabeledEdge))
+	})
+}
+
+func (s *StreamSuite) TestRoundTripLoop(c *C) {
+	g := gogl.Spec().Loop().Using(gogl.EdgeList{
+		gogl.NewEdge("foo", "foo"),
+		gogl.NewEdge("foo", "bar"),
+	}).Create(al.G)
+
+	assertRoundTrip(c, g, func(g2 gogl.Graph, e gogl.Edge) bool {
+		return g2.HasEdge(e)
+	})
+}
+
+func (s *StreamSuite) TestVersion1Loops(c *C) {
+	// Version 1 headers carry no Loops field, so loops must be kept regardless.
+	stream := `{"Version":1,"EdgeType":"basic"}
+{"Kind":1,"U":"foo"}
+{"Kind":2,"U":"foo","V":"foo"}
+{"Kind":3}
+`
+	g, err := NewJSONDecoder(strings.NewReader(stream)).Decode()
+	c.Assert(err, IsNil)
+	c.Assert(g.HasEdge(gogl.NewEdge("foo", "foo")), Equals, true)
+	c.Assert(gogl.Size(g), Equals, 1)
+}
+
+func (s *StreamSuite) TestDataGraphRejected(c *C) {
+	g := gogl.Spec().DataEdges().Using(gogl.DataEdgeList{
+		gogl.NewDataEdge(1, 2, "foo"),
+	}).Create(al.G)
+
+	err := NewEncoder(ioutil.Discard).Encode(g)
+	c.Assert(err, ErrorMatches, "Graphs with data edges cannot be streamed.")
+}
+
+func (s *StreamSuite) TestHeaderValidation(c *C) {
+	buf := &bytes.Buffer{}
+	gob.NewEncoder(buf).Encode(header{Version: StreamVersion + 1, EdgeType: BasicEdges})
+	_, err := NewDecoder(buf).Decode()
+	c.Assert(err, ErrorMatches, "Unsupported stream format version 3.")
+
+	_, err = NewJSONDecoder(strings.NewReader(`{"Version":1,"EdgeType":"fuzzy"}`)).Decode()
+	c.Assert(err, ErrorMatches, `Unrecognized edge type "fuzzy" in stream header.`)
+}
+
+func (s *StreamSuite) TestTruncatedStream(c *C) {
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("foo", "bar"),
+		gogl.NewEdge("bar", "baz"),
+	}).Create(al.G)
+
+	buf := &bytes.Buffer{}
+	c.Assert(NewJSONEncoder(buf).Encode(g), IsNil)
+
+	// Drop the end record, which is the final line.
+	lines := strings.SplitAfter(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	truncated := strings.Join(lines[:len(lines)-1], "")
+
+	_, err := NewJSONDecoder(strings.NewReader(truncated)).Decode()
+	c.Assert(err, ErrorMatches, "Stream ended before the graph was complete.")
+}
+
+func (s *StreamSuite) TestLargeGraphThroughPipe(c *C) {
+	src := rand.BernoulliDistribution(1000, 0.2, true, true, stdrand.NewSource(1))
+	g := gogl.Spec().Directed().Using(src).Create(al.G).(gogl.Digraph)
+
+	pr, pw := stdio.Pipe()
+	go func() {
+		pw.CloseWithError(NewEncoder(pw).Encode(g))
+	}()
+
+	g2, err := NewDecoder(pr).Decode()
+	c.Assert(err, IsNil)
+	c.Assert(gogl.Order(g2), Equals, 1000)
+	c.Assert(gogl.Size(g2), Equals, gogl.Size(g))
+
+	g.Arcs(func(a gogl.Arc) (terminate bool) {
+		if !g2.(gogl.Digraph).HasArc(a) {
+			c.Errorf("Decoded graph is missing arc %v.", a)
+			return true
+		}
+		return
+	})
+}
+
+// Samples heap usage periodically while draining a stream.
+type heapSampler struct {
+	writes int
+	peak   uint64
+}
+
+func (h *heapSampler) Write(p []byte) (int, error) {
+	if h.writes++; h.writes%5000 == 0 {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		if ms.HeapAlloc > h.peak {
+			h.peak = ms.HeapAlloc
+		}
+	}
+	return len(p), nil
+}
+
+func (s *StreamSuite) TestEncodingMemoryBounded(c *C) {
+	// An unstable Bernoulli graph generates its ~900k arcs on the fly, so
+	// nothing but the encoder itself could hold them in memory.
+	src := rand.BernoulliDistribution(1000, 0.9, true, false, stdrand.NewSource(1))
+
+	runtime.GC()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	base := ms.HeapAlloc
+
+	pr, pw := stdio.Pipe()
+	go func() {
+		pw.CloseWithError(NewEncoder(pw).Encode(src))
+	}()
+
+	sampler := &heapSampler{peak: base}
+	n, err := stdio.Copy(sampler, pr)
+	c.Assert(err, IsNil)
+
+	// Buffering the arcs would take tens of MB; the stream should take far less.
+	c.Assert(n > 8<<20, Equals, true, Commentf("stream was only %d bytes", n))
+	c.Assert(sampler.peak-base < 8<<20, Equals, true, Commentf("heap grew by %d bytes", sampler.peak-base))
+}
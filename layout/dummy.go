@@ -0,0 +1,79 @@
+package layout
+
+import (
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// A Dummy is a vertex inserted by InsertDummyVertices along an arc that spans
+// several layers. It stands in for the arc where it passes through Layer, and
+// records the arc's original ends so the chain can be drawn as one bent line.
+type Dummy struct {
+	Source, Target gogl.Vertex
+	Layer          int
+}
+
+// Makes every arc of a layered digraph connect adjacent layers, as the crossing
+// minimization step of a Sugiyama-style layered drawing requires.
+//
+// layers assigns each vertex of g to a layer, by index; typically, every arc
+// leads from an earlier layer to a later one, as when layers come from
+// algo.Generations. Each arc between adjacent layers is kept as is. Each arc
+// spanning more than one is replaced by a chain of arcs through a new Dummy
+// vertex in every layer between its ends, in the arc's direction, whichever way
+// that runs. Each dummy carries the layer it belongs in.
+//
+// Returned are the resulting digraph, whose arcs are basic arcs, and the set of
+// dummy vertices within it. Self-loops are dropped. The layers must include
+// every vertex of g exactly once, and no arc may join two vertices of the same
+// layer, else panic.
+func InsertDummyVertices(g gogl.Digraph, layers [][]gogl.Vertex) (gogl.Digraph, map[gogl.Vertex]bool) {
+	layerOf := make(map[gogl.Vertex]int)
+	for i, layer := range layers {
+		for _, v := range layer {
+			if _, exists := layerOf[v]; exists {
+				panic("Vertex appears in more than one layer.")
+			}
+			layerOf[v] = i
+		}
+	}
+
+	dg := gogl.Spec().Directed().Create(al.G).(gogl.MutableDigraph)
+	dummies := make(map[gogl.Vertex]bool)
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		if _, exists := layerOf[v]; !exists {
+			panic("Vertex is not assigned a layer.")
+		}
+		dg.EnsureVertex(v)
+		return
+	})
+
+	g.Arcs(func(a gogl.Arc) (terminate bool) {
+		u, v := a.Both()
+		if u == v {
+			return
+		}
+
+		from, to := layerOf[u], layerOf[v]
+		step := 1
+		switch {
+		case from == to:
+			panic("Arc joins two vertices in the same layer.")
+		case from > to:
+			step = -1
+		}
+
+		prev := u
+		for l := from + step; l != to; l += step {
+			d := Dummy{Source: u, Target: v, Layer: l}
+			dummies[d] = true
+			dg.AddArcs(gogl.NewArc(prev, d))
+			prev = d
+		}
+		dg.AddArcs(gogl.NewArc(prev, v))
+		return
+	})
+
+	return dg.(gogl.Digraph), dummies
+}
@@ -0,0 +1,128 @@
+package layout
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/algo"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type DummySuite struct{}
+
+var _ = Suite(&DummySuite{})
+
+// Checks that every arc of the result joins adjacent layers, that the dummies
+// number as many as the layers spanned by g's arcs between their ends, and that
+// each of g's arcs survives as a chain of its own dummies.
+func checkDummies(c *C, g gogl.Digraph, layers [][]gogl.Vertex, dg gogl.Digraph, dummies map[gogl.Vertex]bool) {
+	layerOf := make(map[gogl.Vertex]int)
+	for i, layer := range layers {
+		for _, v := range layer {
+			layerOf[v] = i
+		}
+	}
+	for d := range dummies {
+		layerOf[d] = d.(Dummy).Layer
+	}
+
+	dg.Arcs(func(a gogl.Arc) (terminate bool) {
+		span := layerOf[a.Target()] - layerOf[a.Source()]
+		c.Assert(span == 1 || span == -1, Equals, true, Commentf("arc %v spans %d layers", a, span))
+		return
+	})
+
+	var spanned int
+	g.Arcs(func(a gogl.Arc) (terminate bool) {
+		u, v := a.Both()
+		span := layerOf[v] - layerOf[u]
+		if span < 0 {
+			span = -span
+		}
+		spanned += span - 1
+
+		// Follow the chain from u, which must end at v.
+		at := u
+		for i := 1; i < span; i++ {
+			var next gogl.Vertex
+			dg.SuccessorsOf(at, func(w gogl.Vertex) (terminate bool) {
+				if d, ok := w.(Dummy); ok && d.Source == u && d.Target == v {
+					next = w
+					return true
+				}
+				return
+			})
+			c.Assert(next, NotNil, Commentf("chain for %v broken at %v", a, at))
+			at = next
+		}
+		c.Assert(dg.HasArc(gogl.NewArc(at, v)), Equals, true, Commentf("chain for %v", a))
+		return
+	})
+
+	c.Assert(dummies, HasLen, spanned)
+	c.Assert(gogl.Order(dg), Equals, gogl.Order(g)+spanned)
+	c.Assert(gogl.Size(dg), Equals, gogl.Size(g)+spanned)
+}
+
+func (s *DummySuite) TestLongArcs(c *C) {
+	// a reaches d directly, across two layers, as well as through b and c.
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "d"),
+		gogl.NewArc("a", "d"),
+		gogl.NewArc("b", "d"),
+	}).Create(al.G).(gogl.Digraph)
+	layers := [][]gogl.Vertex{{"a"}, {"b"}, {"c"}, {"d"}}
+
+	dg, dummies := InsertDummyVertices(g, layers)
+	checkDummies(c, g, layers, dg, dummies)
+	c.Assert(dummies, DeepEquals, map[gogl.Vertex]bool{
+		Dummy{"a", "d", 1}: true,
+		Dummy{"a", "d", 2}: true,
+		Dummy{"b", "d", 2}: true,
+	})
+	c.Assert(dg.HasArc(gogl.NewArc("a", "d")), Equals, false)
+	c.Assert(dg.HasArc(gogl.NewArc(Dummy{"a", "d", 1}, Dummy{"a", "d", 2})), Equals, true)
+
+	// An arc running back up the layers is chained the other way.
+	g.(gogl.MutableDigraph).AddArcs(gogl.NewArc("d", "a"))
+	dg, dummies = InsertDummyVertices(g, layers)
+	checkDummies(c, g, layers, dg, dummies)
+	c.Assert(dg.HasArc(gogl.NewArc("d", Dummy{"d", "a", 2})), Equals, true)
+	c.Assert(dg.HasArc(gogl.NewArc(Dummy{"d", "a", 1}, "a")), Equals, true)
+}
+
+func (s *DummySuite) TestGenerations(c *C) {
+	for seed := int64(1); seed <= 5; seed++ {
+		// Keep only the arcs from lower to higher numbers, making a DAG.
+		src := rand.BernoulliDistribution(30, 0.1, true, true, stdrand.NewSource(seed))
+		var arcs gogl.ArcList
+		gogl.Spec().Directed().Using(src).Create(al.G).(gogl.Digraph).Arcs(func(a gogl.Arc) (terminate bool) {
+			if u, v := a.Both(); u.(int) < v.(int) {
+				arcs = append(arcs, a)
+			}
+			return
+		})
+		g := gogl.Spec().Directed().Using(arcs).Create(al.G).(gogl.Digraph)
+
+		layers, err := algo.Generations(g)
+		c.Assert(err, IsNil)
+
+		dg, dummies := InsertDummyVertices(g, layers)
+		checkDummies(c, g, layers, dg, dummies)
+		c.Assert(algo.IsDAG(dg), Equals, true)
+	}
+}
+
+func (s *DummySuite) TestInvalidLayers(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+	}).Create(al.G).(gogl.Digraph)
+
+	c.Assert(func() { InsertDummyVertices(g, [][]gogl.Vertex{{"a", "b"}}) }, PanicMatches, "Arc joins two vertices in the same layer.")
+	c.Assert(func() { InsertDummyVertices(g, [][]gogl.Vertex{{"a"}}) }, PanicMatches, "Vertex is not assigned a layer.")
+	c.Assert(func() { InsertDummyVertices(g, [][]gogl.Vertex{{"a"}, {"b", "a"}}) }, PanicMatches, "Vertex appears in more than one layer.")
+}
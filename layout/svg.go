@@ -0,0 +1,148 @@
+// Contains logic for laying out graphs in a plane, and for rendering those layouts.
+package layout
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Options control the output produced by RenderSVG.
+//
+// Any zero-valued field is replaced by its default value.
+type Options struct {
+	Width  float64 // Width of the SVG canvas. Defaults to 640.
+	Height float64 // Height of the SVG canvas. Defaults to 480.
+	Margin float64 // Padding kept clear around the edges of the canvas. Defaults to 20.
+	Radius float64 // Radius of the circle drawn for each vertex. Defaults to 10.
+}
+
+func (o Options) withDefaults() Options {
+	if o.Width == 0 {
+		o.Width = 640
+	}
+	if o.Height == 0 {
+		o.Height = 480
+	}
+	if o.Margin == 0 {
+		o.Margin = 20
+	}
+	if o.Radius == 0 {
+		o.Radius = 10
+	}
+	return o
+}
+
+// Renders the provided graph into an SVG document, written to the given writer.
+//
+// pos must contain a coordinate pair for every vertex in the graph; the coordinates
+// may be in any units, as they are scaled (preserving aspect ratio) to fit the canvas
+// described by the options.
+//
+// Vertices are drawn as circles labeled with their fmt.Sprint() value. Edges are drawn
+// as lines; if the graph is a Digraph, lines terminate in an arrowhead at the target
+// vertex. LabeledEdges are annotated with their label, and WeightedEdges with their weight.
+func RenderSVG(w io.Writer, g gogl.Graph, pos map[gogl.Vertex][2]float64, opts Options) error {
+	opts = opts.withDefaults()
+
+	if opts.Width <= 2*opts.Margin || opts.Height <= 2*opts.Margin {
+		return errors.New("Canvas is too small for the requested margin.")
+	}
+
+	var err error
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		p, exists := pos[v]
+		if !exists {
+			err = fmt.Errorf("No position provided for vertex %v.", v)
+			return true
+		}
+
+		minX, maxX = math.Min(minX, p[0]), math.Max(maxX, p[0])
+		minY, maxY = math.Min(minY, p[1]), math.Max(maxY, p[1])
+		return
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// Scale uniformly, so the drawing isn't distorted, and center the result.
+	innerW, innerH := opts.Width-2*opts.Margin, opts.Height-2*opts.Margin
+	scale := 1.0
+	if maxX > minX || maxY > minY {
+		scale = math.Min(innerW/math.Max(maxX-minX, 1e-12), innerH/math.Max(maxY-minY, 1e-12))
+	}
+	offX := opts.Margin + (innerW-(maxX-minX)*scale)/2
+	offY := opts.Margin + (innerH-(maxY-minY)*scale)/2
+
+	project := func(v gogl.Vertex) (x, y float64) {
+		p := pos[v]
+		return offX + (p[0]-minX)*scale, offY + (p[1]-minY)*scale
+	}
+
+	_, directed := g.(gogl.Digraph)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n",
+		opts.Width, opts.Height, opts.Width, opts.Height)
+
+	if directed {
+		buf.WriteString(`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto">`)
+		buf.WriteString(`<path d="M 0 0 L 10 5 L 0 10 z"/></marker></defs>` + "\n")
+	}
+
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		u, v := e.Both()
+		x1, y1 := project(u)
+		x2, y2 := project(v)
+
+		if directed {
+			// Pull the endpoint back to the target circle's boundary so the arrowhead is visible.
+			dx, dy := x2-x1, y2-y1
+			if d := math.Hypot(dx, dy); d > opts.Radius {
+				x2, y2 = x2-dx/d*opts.Radius, y2-dy/d*opts.Radius
+			}
+			fmt.Fprintf(buf, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="black" marker-end="url(#arrow)"/>`+"\n", x1, y1, x2, y2)
+		} else {
+			fmt.Fprintf(buf, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="black"/>`+"\n", x1, y1, x2, y2)
+		}
+
+		var label string
+		if le, ok := e.(gogl.LabeledEdge); ok {
+			label = le.Label()
+		} else if we, ok := e.(gogl.WeightedEdge); ok {
+			label = fmt.Sprint(we.Weight())
+		}
+
+		if label != "" {
+			writeText(buf, (x1+x2)/2, (y1+y2)/2, label)
+		}
+		return
+	})
+
+	g.Vertices(func(v gogl.Vertex) (terminate bool) {
+		x, y := project(v)
+		fmt.Fprintf(buf, `<circle cx="%g" cy="%g" r="%g" fill="white" stroke="black"/>`+"\n", x, y, opts.Radius)
+		writeText(buf, x, y, fmt.Sprint(v))
+		return
+	})
+
+	buf.WriteString("</svg>\n")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// Writes a centered, XML-escaped text element at the given coordinates.
+func writeText(buf *bytes.Buffer, x, y float64, s string) {
+	fmt.Fprintf(buf, `<text x="%g" y="%g" text-anchor="middle" dominant-baseline="middle">`, x, y)
+	xml.EscapeText(buf, []byte(s))
+	buf.WriteString("</text>\n")
+}
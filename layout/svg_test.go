@@ -0,0 +1,113 @@
+package layout
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type SVGSuite struct{}
+
+var _ = Suite(&SVGSuite{})
+
+var svgPos = map[gogl.Vertex][2]float64{
+	"foo": {0, 0},
+	"bar": {1, 0},
+	"baz": {1, 1},
+	"qux": {0, 1},
+}
+
+// Decodes the document, failing on malformed XML, and counts elements by name.
+func countElements(c *C, doc []byte) map[string]int {
+	counts := make(map[string]int)
+	dec := xml.NewDecoder(bytes.NewReader(doc))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, IsNil)
+
+		if se, ok := tok.(xml.StartElement); ok {
+			counts[se.Name.Local]++
+		}
+	}
+	return counts
+}
+
+func (s *SVGSuite) TestRenderUndirected(c *C) {
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("foo", "bar"),
+		gogl.NewEdge("bar", "baz"),
+		gogl.NewEdge("baz", "qux"),
+	}).Create(al.G)
+
+	buf := &bytes.Buffer{}
+	c.Assert(RenderSVG(buf, g, svgPos, Options{}), IsNil)
+
+	counts := countElements(c, buf.Bytes())
+	c.Assert(counts["svg"], Equals, 1)
+	c.Assert(counts["circle"], Equals, 4)
+	c.Assert(counts["line"], Equals, 3)
+	c.Assert(counts["marker"], Equals, 0)
+}
+
+func (s *SVGSuite) TestRenderDirectedWeighted(c *C) {
+	g := gogl.Spec().Directed().Weighted().Using(gogl.WeightedArcList{
+		gogl.NewWeightedArc("foo", "bar", 2.5),
+		gogl.NewWeightedArc("bar", "baz", 4),
+	}).Create(al.G)
+
+	pos := map[gogl.Vertex][2]float64{
+		"foo": {0, 0},
+		"bar": {1, 0},
+		"baz": {1, 1},
+	}
+
+	buf := &bytes.Buffer{}
+	c.Assert(RenderSVG(buf, g, pos, Options{Width: 200, Height: 200}), IsNil)
+
+	counts := countElements(c, buf.Bytes())
+	c.Assert(counts["circle"], Equals, 3)
+	c.Assert(counts["line"], Equals, 2)
+	c.Assert(counts["marker"], Equals, 1)
+	// one text per vertex, one per weighted edge
+	c.Assert(counts["text"], Equals, 5)
+	c.Assert(strings.Contains(buf.String(), ">2.5<"), Equals, true)
+}
+
+func (s *SVGSuite) TestRenderEscapesLabels(c *C) {
+	g := gogl.Spec().Labeled().Using(gogl.LabeledEdgeList{
+		gogl.NewLabeledEdge("<a>", "b&c", "x<y"),
+	}).Create(al.G)
+
+	pos := map[gogl.Vertex][2]float64{
+		"<a>": {0, 0},
+		"b&c": {3, 4},
+	}
+
+	buf := &bytes.Buffer{}
+	c.Assert(RenderSVG(buf, g, pos, Options{}), IsNil)
+	counts := countElements(c, buf.Bytes())
+	c.Assert(counts["text"], Equals, 3)
+}
+
+func (s *SVGSuite) TestRenderMissingPosition(c *C) {
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("foo", "quark"),
+	}).Create(al.G)
+
+	buf := &bytes.Buffer{}
+	err := RenderSVG(buf, g, svgPos, Options{})
+	c.Assert(err, ErrorMatches, "No position provided for vertex quark.")
+	c.Assert(buf.Len(), Equals, 0)
+}
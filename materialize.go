@@ -0,0 +1,42 @@
+package gogl
+
+// The number of edges Materialize adds to the destination graph at a time.
+const materializeBatch = 4096
+
+// Loads all of the source's vertices and edges into the destination graph, which
+// need not be empty.
+//
+// Edges are added in batches of a few thousand, so that the destination can
+// take advantage of any bulk-loading optimizations in its AddEdges. After each
+// batch, progress is called with the total number of edges loaded so far; it is
+// always called at least once, after the last batch. If progress returns an error,
+// loading stops, and that error is returned. Edges already added are not removed.
+// progress may be nil.
+func Materialize(src GraphSource, dst MutableGraph, progress func(edgesLoaded int) error) error {
+	dst.EnsureVertex(CollectVertices(src)...)
+
+	var loaded int
+	var err error
+	batch := make([]Edge, 0, materializeBatch)
+	flush := func() {
+		dst.AddEdges(batch...)
+		loaded += len(batch)
+		batch = batch[:0]
+		if progress != nil {
+			err = progress(loaded)
+		}
+	}
+
+	src.Edges(func(e Edge) (terminate bool) {
+		if batch = append(batch, e); len(batch) == materializeBatch {
+			flush()
+		}
+		return err != nil
+	})
+
+	if err == nil && (len(batch) > 0 || loaded == 0) {
+		flush()
+	}
+
+	return err
+}
@@ -0,0 +1,78 @@
+package gogl_test
+
+import (
+	"errors"
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type MaterializeSuite struct{}
+
+var _ = Suite(&MaterializeSuite{})
+
+func (s *MaterializeSuite) TestAllEdgesTransferred(c *C) {
+	src := rand.BernoulliDistribution(300, 0.2, false, true, stdrand.NewSource(1))
+	// Bernoulli graphs may contain loops.
+	dst := Spec().Loop().Create(al.G).(MutableGraph)
+
+	var calls []int
+	err := Materialize(src, dst, func(n int) error {
+		calls = append(calls, n)
+		return nil
+	})
+	c.Assert(err, IsNil)
+
+	c.Assert(Order(dst), Equals, 300)
+	c.Assert(Size(dst), Equals, Size(src))
+	src.Edges(func(e Edge) (terminate bool) {
+		c.Assert(dst.HasEdge(e), Equals, true, Commentf("edge %v missing", e))
+		return
+	})
+
+	// Progress is reported periodically, ending at the full count.
+	c.Assert(len(calls) > 1, Equals, true)
+	for i := 1; i < len(calls); i++ {
+		c.Assert(calls[i] > calls[i-1], Equals, true)
+	}
+	c.Assert(calls[len(calls)-1], Equals, Size(src))
+}
+
+func (s *MaterializeSuite) TestIsolatesAndNoProgress(c *C) {
+	src := Spec().Create(al.G).(MutableGraph)
+	src.EnsureVertex("a", "b")
+	dst := Spec().Create(al.G).(MutableGraph)
+
+	var calls []int
+	c.Assert(Materialize(src, dst, func(n int) error {
+		calls = append(calls, n)
+		return nil
+	}), IsNil)
+	c.Assert(Order(dst), Equals, 2)
+	c.Assert(calls, DeepEquals, []int{0})
+
+	c.Assert(Materialize(EdgeList{NewEdge(1, 2)}, dst, nil), IsNil)
+	c.Assert(dst.HasEdge(NewEdge(1, 2)), Equals, true)
+}
+
+func (s *MaterializeSuite) TestAbort(c *C) {
+	src := rand.BernoulliDistribution(300, 0.5, false, true, stdrand.NewSource(1))
+	dst := Spec().Create(al.G).(MutableGraph)
+
+	stop := errors.New("Stop.")
+	var calls int
+	err := Materialize(src, dst, func(n int) error {
+		if calls++; calls == 2 {
+			return stop
+		}
+		return nil
+	})
+	c.Assert(err, Equals, stop)
+	c.Assert(calls, Equals, 2)
+
+	// Whatever was loaded before the abort stays.
+	c.Assert(Size(dst) > 0 && Size(dst) < Size(src), Equals, true)
+}
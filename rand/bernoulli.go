@@ -112,6 +112,11 @@ func (g *stableBernoulliGraph) Order() int {
 	return int(g.order)
 }
 
+// Returns the number of vertices in the graph, which is always exact.
+func (g *stableBernoulliGraph) VertexCount() (count int, exact bool) {
+	return int(g.order), true
+}
+
 func (g *stableBernoulliGraph) Size() int {
 	return g.size
 }
@@ -203,6 +208,12 @@ func (g unstableBernoulliGraph) Order() int {
 	return int(g.order)
 }
 
+// Returns the number of vertices the graph is configured with. Unstable graphs make
+// no guarantees about the graph they produce, so the count is reported as inexact.
+func (g unstableBernoulliGraph) VertexCount() (count int, exact bool) {
+	return int(g.order), false
+}
+
 type unstableBernoulliDigraph struct {
 	unstableBernoulliGraph
 }
@@ -0,0 +1,47 @@
+package rand
+
+import (
+	stdrand "math/rand"
+
+	"github.com/sdboyer/gogl"
+)
+
+// Selects k of the source's edges uniformly at random, without replacement.
+//
+// Sampling is done by reservoir in a single pass over Edges(), so the number of
+// edges need not be known in advance; this makes it suitable for cheaply
+// estimating properties of large or streaming graphs, such as an unstable
+// BernoulliDistribution. If the source has k or fewer edges, all of them are
+// returned. The order of the returned edges is not meaningful.
+//
+// k must be non-negative, else panic. If no rand source is provided, the stdlib
+// math's global rand source is used.
+func SampleEdges(g gogl.EdgeEnumerator, k int, src stdrand.Source) []gogl.Edge {
+	if k < 0 {
+		panic("k must be non-negative.")
+	}
+
+	intn := stdrand.Intn
+	if src != nil {
+		intn = stdrand.New(src).Intn
+	}
+
+	reservoir := make([]gogl.Edge, 0, k)
+	if k == 0 {
+		return reservoir
+	}
+
+	var seen int
+	g.Edges(func(e gogl.Edge) (terminate bool) {
+		seen++
+		if len(reservoir) < k {
+			reservoir = append(reservoir, e)
+		} else if i := intn(seen); i < k {
+			// Each edge seen so far has an equal k/seen chance of being held.
+			reservoir[i] = e
+		}
+		return
+	})
+
+	return reservoir
+}
@@ -0,0 +1,76 @@
+package rand
+
+import (
+	"math"
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+)
+
+type SampleTest struct{}
+
+var _ = Suite(&SampleTest{})
+
+func (s *SampleTest) TestSmallGraph(c *C) {
+	el := gogl.EdgeList{
+		gogl.NewEdge(1, 2),
+		gogl.NewEdge(2, 3),
+		gogl.NewEdge(3, 4),
+	}
+
+	c.Assert(SampleEdges(el, 0, nil), HasLen, 0)
+	c.Assert(SampleEdges(el, 3, nil), HasLen, 3)
+
+	all := SampleEdges(el, 10, stdrand.NewSource(1))
+	c.Assert(all, HasLen, 3)
+	for _, e := range el {
+		var found bool
+		for _, e2 := range all {
+			found = found || e2 == e
+		}
+		c.Assert(found, Equals, true, Commentf("edge %v missing from sample", e))
+	}
+
+	c.Assert(func() { SampleEdges(el, -1, nil) }, PanicMatches, "k must be non-negative.")
+}
+
+func (s *SampleTest) TestNoDuplicates(c *C) {
+	src := BernoulliDistribution(30, 0.5, false, false, stdrand.NewSource(1))
+	sample := SampleEdges(src, 50, stdrand.NewSource(2))
+	c.Assert(sample, HasLen, 50)
+
+	seen := make(map[[2]gogl.Vertex]bool)
+	for _, e := range sample {
+		u, v := e.Both()
+		c.Assert(seen[[2]gogl.Vertex{u, v}], Equals, false, Commentf("edge %v sampled twice", e))
+		seen[[2]gogl.Vertex{u, v}] = true
+	}
+}
+
+func (s *SampleTest) TestUniform(c *C) {
+	var el gogl.EdgeList
+	for i := 0; i < 10; i++ {
+		el = append(el, gogl.NewEdge(i, i+1))
+	}
+
+	const runs, k = 20000, 3
+	counts := make(map[gogl.Edge]int)
+	r := stdrand.NewSource(1)
+	for i := 0; i < runs; i++ {
+		for _, e := range SampleEdges(el, k, r) {
+			counts[e]++
+		}
+	}
+
+	// Each edge should be selected in k/10 of the runs. A chi-squared statistic
+	// with 9 degrees of freedom exceeds 27.9 with probability 0.001.
+	expected := float64(runs*k) / float64(len(el))
+	var chi2 float64
+	for _, e := range el {
+		d := float64(counts[e]) - expected
+		chi2 += d * d / expected
+	}
+	c.Assert(chi2 < 27.9, Equals, true, Commentf("chi-squared statistic %v; counts %v", chi2, counts))
+	c.Assert(math.Abs(float64(counts[el[0]])-expected) < 0.05*expected, Equals, true)
+}
@@ -0,0 +1,117 @@
+package gogl
+
+// Returns a read-only view of the given digraph with the direction of every arc
+// reversed: its successors are g's predecessors, its out-arcs g's in-arcs, and so
+// on. Each arc and edge the view enumerates is freshly reversed, keeping its
+// weight, label or data.
+//
+// Unlike Transpose, which typically copies the whole graph, nothing is copied;
+// every call passes straight through to g, so the view costs no memory, and
+// reflects any later changes to g. This suits algorithms, like Kosaraju's, that
+// only need to traverse the reverse graph. The view's own Transpose returns g.
+//
+// The view is a plain Digraph, whatever g's kind; its arcs keep their weights,
+// but it offers no weighted (or labeled, or data) membership checks.
+func ReversedView(g Digraph) Digraph {
+	return reversedView{g}
+}
+
+type reversedView struct {
+	g Digraph
+}
+
+func reverse(e Edge) Edge {
+	u, v := e.Both()
+	return withEndpoints(e, v, u)
+}
+
+func (r reversedView) Vertices(f VertexStep) {
+	r.g.Vertices(f)
+}
+
+func (r reversedView) Edges(f EdgeStep) {
+	r.g.Edges(func(e Edge) bool {
+		return f(reverse(e))
+	})
+}
+
+func (r reversedView) Arcs(f ArcStep) {
+	r.g.Arcs(func(a Arc) bool {
+		return f(reverse(a).(Arc))
+	})
+}
+
+func (r reversedView) IncidentTo(v Vertex, f EdgeStep) {
+	r.g.IncidentTo(v, func(e Edge) bool {
+		return f(reverse(e))
+	})
+}
+
+func (r reversedView) AdjacentTo(v Vertex, f VertexStep) {
+	r.g.AdjacentTo(v, f)
+}
+
+func (r reversedView) ArcsFrom(v Vertex, f ArcStep) {
+	r.g.ArcsTo(v, func(a Arc) bool {
+		return f(reverse(a).(Arc))
+	})
+}
+
+func (r reversedView) ArcsTo(v Vertex, f ArcStep) {
+	r.g.ArcsFrom(v, func(a Arc) bool {
+		return f(reverse(a).(Arc))
+	})
+}
+
+func (r reversedView) SuccessorsOf(v Vertex, f VertexStep) {
+	r.g.PredecessorsOf(v, f)
+}
+
+func (r reversedView) PredecessorsOf(v Vertex, f VertexStep) {
+	r.g.SuccessorsOf(v, f)
+}
+
+func (r reversedView) HasVertex(v Vertex) bool {
+	return r.g.HasVertex(v)
+}
+
+func (r reversedView) HasEdge(e Edge) bool {
+	u, v := e.Both()
+	return r.g.HasEdge(NewEdge(v, u))
+}
+
+func (r reversedView) HasArc(a Arc) bool {
+	return r.g.HasArc(NewArc(a.Target(), a.Source()))
+}
+
+func (r reversedView) DegreeOf(v Vertex) (degree int, exists bool) {
+	return r.g.DegreeOf(v)
+}
+
+func (r reversedView) InDegreeOf(v Vertex) (degree int, exists bool) {
+	return r.g.OutDegreeOf(v)
+}
+
+func (r reversedView) OutDegreeOf(v Vertex) (degree int, exists bool) {
+	return r.g.InDegreeOf(v)
+}
+
+// Returns the underlying graph, of which this is the transpose.
+func (r reversedView) Transpose() Digraph {
+	return r.g
+}
+
+// Returns the order of the underlying graph.
+func (r reversedView) Order() int {
+	return Order(r.g)
+}
+
+// Returns the size of the underlying graph.
+func (r reversedView) Size() int {
+	return Size(r.g)
+}
+
+// Passes through the underlying graph's vertex count hint.
+func (r reversedView) VertexCount() (count int, exact bool) {
+	return VertexCountHint(r.g)
+}
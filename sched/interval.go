@@ -0,0 +1,67 @@
+// Contains scheduling helpers built on gogl's graph algorithms.
+package sched
+
+import (
+	"sort"
+
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/algo"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// An Interval is a span of time, [Start, End), during which some task needs a
+// resource. Intervals are half-open, so one that ends as another starts does not
+// overlap it.
+type Interval struct {
+	Start, End float64
+}
+
+// Assigns a resource - a meeting room, a machine, a register - to each of the
+// given intervals, such that no two overlapping intervals share one. Returned is
+// a map from each interval's index to its resource; resources are numbered from 0.
+//
+// This is graph coloring of the interval graph, which has an edge between each
+// pair of overlapping intervals. Interval graphs are perfect, and coloring one
+// greedily in order of interval start is optimal: the number of resources used is
+// the greatest number of intervals that overlap at any one time.
+//
+// Every interval's End must not precede its Start, else panic.
+func IntervalColoring(intervals []Interval) map[int]int {
+	order := make([]gogl.Vertex, len(intervals))
+	for i, iv := range intervals {
+		if iv.End < iv.Start {
+			panic("Interval ends before it starts.")
+		}
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return intervals[order[i].(int)].Start < intervals[order[j].(int)].Start
+	})
+
+	// Sweep in order of start, keeping the intervals that are still open; each
+	// interval overlaps exactly those open when it starts.
+	g := gogl.Spec().Create(al.G).(gogl.MutableGraph)
+	var open []int
+	for _, v := range order {
+		i := v.(int)
+		g.EnsureVertex(i)
+
+		still := open[:0]
+		for _, j := range open {
+			if intervals[j].End > intervals[i].Start {
+				still = append(still, j)
+				g.AddEdges(gogl.NewEdge(j, i))
+			}
+		}
+		open = append(still, i)
+	}
+
+	colors, _ := algo.GreedyColoringInOrder(g, order)
+
+	assignment := make(map[int]int, len(colors))
+	for v, c := range colors {
+		assignment[v.(int)] = c
+	}
+	return assignment
+}
@@ -0,0 +1,67 @@
+package sched
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type IntervalSuite struct{}
+
+var _ = Suite(&IntervalSuite{})
+
+// Checks that every interval has a resource, that overlapping intervals never
+// share one, and returns the number of resources used.
+func checkAssignment(c *C, intervals []Interval, assignment map[int]int) int {
+	c.Assert(assignment, HasLen, len(intervals))
+
+	used := make(map[int]bool)
+	for i, r := range assignment {
+		used[r] = true
+		for j := i + 1; j < len(intervals); j++ {
+			if intervals[i].Start < intervals[j].End && intervals[j].Start < intervals[i].End {
+				c.Assert(assignment[j] != r, Equals, true, Commentf("intervals %d and %d overlap, but share resource %d", i, j, r))
+			}
+		}
+	}
+	return len(used)
+}
+
+func (s *IntervalSuite) TestMeetingRooms(c *C) {
+	// A day of meetings, in hours. Between 10 and 11, four are running at once.
+	meetings := []Interval{
+		{9, 10.5},
+		{9.5, 11},
+		{10, 12},
+		{10, 11},
+		{11, 13},
+		{12, 13},
+		{13, 14},
+		{8, 9},
+	}
+
+	assignment := IntervalColoring(meetings)
+	c.Assert(checkAssignment(c, meetings, assignment), Equals, 4)
+
+	// Back-to-back meetings can share a room.
+	chain := []Interval{{1, 2}, {2, 3}, {3, 4}}
+	assignment = IntervalColoring(chain)
+	c.Assert(checkAssignment(c, chain, assignment), Equals, 1)
+}
+
+func (s *IntervalSuite) TestNested(c *C) {
+	// Listed out of start order; the middle interval bridges the two short ones,
+	// and all three sit within the long one.
+	intervals := []Interval{{1, 2}, {3, 4}, {0, 5}, {1.5, 3.5}}
+	assignment := IntervalColoring(intervals)
+	c.Assert(checkAssignment(c, intervals, assignment), Equals, 3)
+}
+
+func (s *IntervalSuite) TestEdgeCases(c *C) {
+	c.Assert(IntervalColoring(nil), HasLen, 0)
+	c.Assert(IntervalColoring([]Interval{{1, 1}}), DeepEquals, map[int]int{0: 0})
+	c.Assert(func() { IntervalColoring([]Interval{{2, 1}}) }, PanicMatches, "Interval ends before it starts.")
+}
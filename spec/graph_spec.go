@@ -95,6 +95,10 @@ var GraphFixtures = map[string]GraphSource{
 		NewArc("foo", "qux"),
 		loopArc{"isolate"},
 	},
+	"loop": ArcList{
+		NewArc("foo", "foo"),
+		NewArc("foo", "bar"),
+	},
 	"w-2e3v": WeightedArcList{
 		NewWeightedArc(1, 2, 5.23),
 		NewWeightedArc(2, 3, 5.821),
@@ -148,6 +152,16 @@ func SetUpTestsFromSpec(gp GraphProperties, fn graphFactory) bool {
 	if _, ok := g.(Digraph); ok {
 		directed = true
 		Suite(&DigraphSuite{Factory: fact})
+		Suite(&ReversedViewSuite{Factory: fact})
+
+		// The reverse of a transpose must pass as the graph itself.
+		Suite(&DigraphSuite{Factory: func(gs GraphSource) Graph {
+			return ReversedView(fact(gs).(Digraph).Transpose())
+		}})
+	}
+
+	if _, ok := g.(MixedGraph); ok {
+		Suite(&MixedGraphSuite{Factory: fact})
 	}
 
 	// Set up the basic Graph suite unconditionally
@@ -157,6 +171,8 @@ func SetUpTestsFromSpec(gp GraphProperties, fn graphFactory) bool {
 		Suite(&SimpleGraphSuite{fact, directed})
 	}
 
+	Suite(&LoopSuite{fact, directed, gp&G_LOOPS != 0})
+
 	if _, ok := g.(VertexSetMutator); ok {
 		Suite(&VertexSetMutatorSuite{fact})
 	}
@@ -30,6 +30,13 @@ func (s *DigraphSuite) TestTranspose(c *C) {
 	c.Assert(g2.HasArc(GraphFixtures["2e3v"].(ArcList)[1]), Equals, false)
 }
 
+func (s *DigraphSuite) TestTransposeEmpty(c *C) {
+	g2 := s.Factory(NullGraph).(Digraph).Transpose()
+
+	c.Assert(Order(g2), Equals, 0)
+	c.Assert(Size(g2), Equals, 0)
+}
+
 func (s *DigraphSuite) TestOutDegreeOf(c *C) {
 	g := s.Factory(GraphFixtures["3e5v1i"]).(Digraph)
 
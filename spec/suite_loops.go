@@ -0,0 +1,137 @@
+package spec
+
+import (
+	"fmt"
+
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+)
+
+/* LoopSuite - tests for self-loop handling, in graphs that permit loops and those that don't */
+
+type LoopSuite struct {
+	Factory  func(GraphSource) Graph
+	Directed bool
+	Loops    bool // whether the graph under test permits loops
+}
+
+func (s *LoopSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T (loops: %v)", s.Factory(NullGraph), s.Loops)
+}
+
+func (s *LoopSuite) TestLoopFromSource(c *C) {
+	g := s.Factory(GraphFixtures["loop"])
+
+	c.Assert(g.HasEdge(NewEdge("foo", "foo")), Equals, s.Loops)
+	c.Assert(g.HasEdge(NewEdge("foo", "bar")), Equals, true)
+
+	if s.Loops {
+		c.Assert(Size(g), Equals, 2)
+	} else {
+		c.Assert(Size(g), Equals, 1)
+	}
+
+	var loops int
+	g.Edges(func(e Edge) (terminate bool) {
+		if u, v := e.Both(); u == v {
+			loops++
+		}
+		return
+	})
+
+	if s.Loops {
+		c.Assert(loops, Equals, 1)
+	} else {
+		c.Assert(loops, Equals, 0)
+	}
+
+	if s.Directed {
+		c.Assert(g.(Digraph).HasArc(NewArc("foo", "foo")), Equals, s.Loops)
+	}
+}
+
+func (s *LoopSuite) TestAddLoop(c *C) {
+	g := s.Factory(NullGraph)
+
+	switch m := g.(type) {
+	case EdgeSetMutator:
+		m.AddEdges(NewEdge("foo", "foo"))
+	case ArcSetMutator:
+		m.AddArcs(NewArc("foo", "foo"))
+	default:
+		return
+	}
+
+	// A rejected loop must leave no trace, not even its vertex.
+	c.Assert(g.HasEdge(NewEdge("foo", "foo")), Equals, s.Loops)
+	c.Assert(g.HasVertex("foo"), Equals, s.Loops)
+	if s.Loops {
+		c.Assert(Size(g), Equals, 1)
+	} else {
+		c.Assert(Size(g), Equals, 0)
+	}
+}
+
+// An Identifiable vertex, which graphs must hand back as given.
+type loopVertex struct {
+	name string
+}
+
+func (v loopVertex) ID() string { return v.name }
+
+func (s *LoopSuite) TestAddLoopInBatch(c *C) {
+	// Implementations may load large batches differently from small ones, so the
+	// same loop-bearing batch is tried at several sizes.
+	for _, n := range []int{10, 100} {
+		g := s.Factory(NullGraph)
+		loop := loopVertex{"loop"}
+
+		switch m := g.(type) {
+		case EdgeSetMutator:
+			edges := make([]Edge, 0, n+1)
+			for i := 0; i < n; i++ {
+				edges = append(edges, NewEdge(i, i+1))
+			}
+			m.AddEdges(append(edges, NewEdge(loop, loop))...)
+		case ArcSetMutator:
+			arcs := make([]Arc, 0, n+1)
+			for i := 0; i < n; i++ {
+				arcs = append(arcs, NewArc(i, i+1))
+			}
+			m.AddArcs(append(arcs, NewArc(loop, loop))...)
+		default:
+			return
+		}
+
+		c.Assert(g.HasVertex(loop), Equals, s.Loops, Commentf("batch of %v", n))
+		if s.Loops {
+			c.Assert(Order(g), Equals, n+2, Commentf("batch of %v", n))
+			c.Assert(Size(g), Equals, n+1, Commentf("batch of %v", n))
+		} else {
+			c.Assert(Order(g), Equals, n+1, Commentf("batch of %v", n))
+			c.Assert(Size(g), Equals, n, Commentf("batch of %v", n))
+		}
+
+		g.Vertices(func(v Vertex) (terminate bool) {
+			switch v.(type) {
+			case int, loopVertex:
+			default:
+				c.Errorf("batch of %v: unexpected vertex %#v", n, v)
+			}
+			return
+		})
+	}
+}
+
+func (s *LoopSuite) TestRemoveVertexWithLoop(c *C) {
+	g := s.Factory(GraphFixtures["loop"])
+
+	m, ok := g.(VertexSetMutator)
+	if !ok {
+		return
+	}
+
+	m.RemoveVertex("foo")
+	c.Assert(Size(g), Equals, 0)
+	c.Assert(Order(g), Equals, 1)
+}
@@ -0,0 +1,214 @@
+package spec
+
+import (
+	"fmt"
+
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+)
+
+/* MixedGraphSuite - tests for graphs holding both undirected edges and arcs */
+
+type MixedGraphSuite struct {
+	Factory func(GraphSource) Graph
+}
+
+func (s *MixedGraphSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T", s.Factory(NullGraph))
+}
+
+// Two-way streets a-b and d-e, and one-way streets around b, c and a, with c and
+// d joined by a one-way street in each direction.
+var mixedFixture = EdgeList{
+	NewEdge("a", "b"),
+	NewArc("b", "c"),
+	NewArc("c", "a"),
+	NewArc("c", "d"),
+	NewArc("d", "c"),
+	NewEdge("d", "e"),
+}
+
+func (s *MixedGraphSuite) TestEdgesSymmetricArcsNot(c *C) {
+	g := s.Factory(mixedFixture).(MixedGraph)
+
+	c.Assert(g.HasUndirectedEdge(NewEdge("a", "b")), Equals, true)
+	c.Assert(g.HasUndirectedEdge(NewEdge("b", "a")), Equals, true)
+	c.Assert(g.HasArc(NewArc("a", "b")), Equals, false)
+	c.Assert(g.HasArc(NewArc("b", "a")), Equals, false)
+	c.Assert(collectVertices(func(f VertexStep) { g.SuccessorsOf("a", f) }).Has("b"), Equals, true)
+	c.Assert(collectVertices(func(f VertexStep) { g.SuccessorsOf("b", f) }).Has("a"), Equals, true)
+	c.Assert(collectVertices(func(f VertexStep) { g.PredecessorsOf("a", f) }).Has("b"), Equals, true)
+	c.Assert(collectVertices(func(f VertexStep) { g.PredecessorsOf("b", f) }).Has("a"), Equals, true)
+
+	c.Assert(g.HasArc(NewArc("b", "c")), Equals, true)
+	c.Assert(g.HasArc(NewArc("c", "b")), Equals, false)
+	c.Assert(g.HasUndirectedEdge(NewEdge("b", "c")), Equals, false)
+	c.Assert(g.HasUndirectedEdge(NewEdge("c", "b")), Equals, false)
+	c.Assert(collectVertices(func(f VertexStep) { g.SuccessorsOf("b", f) }).Has("c"), Equals, true)
+	c.Assert(collectVertices(func(f VertexStep) { g.SuccessorsOf("c", f) }).Has("b"), Equals, false)
+	c.Assert(collectVertices(func(f VertexStep) { g.PredecessorsOf("c", f) }).Has("b"), Equals, true)
+	c.Assert(collectVertices(func(f VertexStep) { g.PredecessorsOf("b", f) }).Has("c"), Equals, false)
+
+	// HasEdge is indifferent to the kind of connection, and to its direction.
+	c.Assert(g.HasEdge(NewEdge("b", "a")), Equals, true)
+	c.Assert(g.HasEdge(NewEdge("c", "b")), Equals, true)
+	c.Assert(g.HasEdge(NewEdge("a", "d")), Equals, false)
+
+	// Arcs both ways are two arcs, not an edge.
+	c.Assert(g.HasArc(NewArc("c", "d")), Equals, true)
+	c.Assert(g.HasArc(NewArc("d", "c")), Equals, true)
+	c.Assert(g.HasUndirectedEdge(NewEdge("c", "d")), Equals, false)
+}
+
+func (s *MixedGraphSuite) TestEnumerators(c *C) {
+	g := s.Factory(mixedFixture).(MixedGraph)
+
+	c.Assert(Order(g), Equals, 5)
+	c.Assert(Size(g), Equals, 6)
+
+	var edges, arcs int
+	g.Edges(func(e Edge) (terminate bool) {
+		if _, ok := e.(Arc); ok {
+			arcs++
+		} else {
+			edges++
+		}
+		return
+	})
+	c.Assert(edges, Equals, 2)
+	c.Assert(arcs, Equals, 4)
+
+	undirected := collectEdges(g.UndirectedEdges)
+	c.Assert(undirected.Size(), Equals, 2)
+	c.Assert(undirected.Has(NewArc("a", "b")) || undirected.Has(NewArc("b", "a")), Equals, true)
+	c.Assert(undirected.Has(NewArc("d", "e")) || undirected.Has(NewArc("e", "d")), Equals, true)
+
+	c.Assert(sameSet(collectArcs(g.Arcs), collectArcs(ArcList{
+		NewArc("b", "c"),
+		NewArc("c", "a"),
+		NewArc("c", "d"),
+		NewArc("d", "c"),
+	}.Arcs)), Equals, true)
+
+	c.Assert(sameSet(collectArcs(func(f ArcStep) { g.ArcsFrom("c", f) }), collectArcs(ArcList{
+		NewArc("c", "a"),
+		NewArc("c", "d"),
+	}.Arcs)), Equals, true)
+	c.Assert(sameSet(collectArcs(func(f ArcStep) { g.ArcsTo("c", f) }), collectArcs(ArcList{
+		NewArc("b", "c"),
+		NewArc("d", "c"),
+	}.Arcs)), Equals, true)
+	c.Assert(collectArcs(func(f ArcStep) { g.ArcsFrom("a", f) }).Size(), Equals, 0)
+
+	c.Assert(collectEdges(func(f EdgeStep) { g.IncidentTo("c", f) }).Size(), Equals, 4)
+	c.Assert(collectEdges(func(f EdgeStep) { g.IncidentTo("a", f) }).Size(), Equals, 2)
+	c.Assert(sameSet(collectVertices(func(f VertexStep) { g.AdjacentTo("d", f) }), collectVertices(EdgeList{
+		NewEdge("c", "e"),
+	}.Vertices)), Equals, true)
+}
+
+func (s *MixedGraphSuite) TestEnumeratorTermination(c *C) {
+	g := s.Factory(mixedFixture).(MixedGraph)
+
+	var hit int
+	g.Edges(func(e Edge) (terminate bool) {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+
+	hit = 0
+	g.UndirectedEdges(func(e Edge) (terminate bool) {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+
+	hit = 0
+	g.Arcs(func(a Arc) (terminate bool) {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+
+	hit = 0
+	g.IncidentTo("c", func(e Edge) (terminate bool) {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+}
+
+func (s *MixedGraphSuite) TestDegrees(c *C) {
+	g := s.Factory(mixedFixture).(MixedGraph)
+
+	for v, want := range map[string][3]int{
+		"a": {1, 0, 2},
+		"c": {2, 2, 4},
+		"d": {1, 1, 3},
+		"e": {0, 0, 1},
+	} {
+		in, exists := g.InDegreeOf(v)
+		c.Assert(exists, Equals, true)
+		out, _ := g.OutDegreeOf(v)
+		degree, _ := g.DegreeOf(v)
+		c.Assert([3]int{in, out, degree}, Equals, want, Commentf("vertex %s", v))
+	}
+
+	_, exists := g.InDegreeOf("missing")
+	c.Assert(exists, Equals, false)
+	_, exists = g.DegreeOf("missing")
+	c.Assert(exists, Equals, false)
+}
+
+func (s *MixedGraphSuite) TestCopyKeepsKinds(c *C) {
+	g := s.Factory(s.Factory(mixedFixture)).(MixedGraph)
+
+	c.Assert(Size(g), Equals, 6)
+	c.Assert(g.HasUndirectedEdge(NewEdge("e", "d")), Equals, true)
+	c.Assert(g.HasArc(NewArc("c", "a")), Equals, true)
+	c.Assert(g.HasArc(NewArc("a", "c")), Equals, false)
+
+	// A plain digraph source contributes only arcs.
+	g = s.Factory(GraphFixtures["2e3v"]).(MixedGraph)
+	c.Assert(collectEdges(g.UndirectedEdges).Size(), Equals, 0)
+	c.Assert(g.HasArc(NewArc("foo", "bar")), Equals, true)
+}
+
+func (s *MixedGraphSuite) TestMutation(c *C) {
+	g, ok := s.Factory(mixedFixture).(MutableMixedGraph)
+	if !ok {
+		return
+	}
+
+	// Vertices already joined by one kind of connection can't take the other.
+	g.AddArcs(NewArc("a", "b"))
+	g.AddEdges(NewEdge("c", "b"))
+	c.Assert(Size(g), Equals, 6)
+	c.Assert(g.HasArc(NewArc("a", "b")), Equals, false)
+	c.Assert(g.HasUndirectedEdge(NewEdge("b", "c")), Equals, false)
+
+	// Removal is specific to the kind, too.
+	g.RemoveEdges(NewEdge("c", "b"))
+	g.RemoveArcs(NewArc("b", "a"))
+	c.Assert(Size(g), Equals, 6)
+
+	g.RemoveArcs(NewArc("c", "d"))
+	c.Assert(Size(g), Equals, 5)
+	c.Assert(g.HasArc(NewArc("d", "c")), Equals, true)
+	c.Assert(g.HasEdge(NewEdge("c", "d")), Equals, true)
+
+	g.RemoveEdges(NewEdge("b", "a"))
+	c.Assert(Size(g), Equals, 4)
+	c.Assert(g.HasEdge(NewEdge("a", "b")), Equals, false)
+
+	g.AddArcs(NewArc("a", "b"))
+	c.Assert(g.HasArc(NewArc("a", "b")), Equals, true)
+	c.Assert(Size(g), Equals, 5)
+
+	g.RemoveVertex("c")
+	c.Assert(Size(g), Equals, 2)
+	c.Assert(g.HasEdge(NewEdge("d", "e")), Equals, true)
+	c.Assert(g.HasArc(NewArc("a", "b")), Equals, true)
+	c.Assert(collectEdges(func(f EdgeStep) { g.IncidentTo("d", f) }).Size(), Equals, 1)
+}
@@ -0,0 +1,143 @@
+package spec
+
+import (
+	"fmt"
+
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+	"gopkg.in/fatih/set.v0"
+)
+
+/* ReversedViewSuite - tests for ReversedView over a digraph implementation */
+
+type ReversedViewSuite struct {
+	Factory func(GraphSource) Graph
+}
+
+func (s *ReversedViewSuite) SuiteLabel() string {
+	return fmt.Sprintf("%T", s.Factory(NullGraph))
+}
+
+// The fixtures on which the view is compared against an explicit transpose.
+var reversedViewFixtures = []string{"arctest", "2e3v", "3e4v", "3e5v1i", "loop"}
+
+// Indicates whether the two sets hold the same items.
+func sameSet(a, b *set.SetNonTS) bool {
+	return a.Size() == b.Size() && (a.Size() == 0 || b.Has(a.List()...))
+}
+
+// Collects the arcs passed to a step function, as basic arcs.
+func collectArcs(enum func(ArcStep)) *set.SetNonTS {
+	arcs := set.NewNonTS()
+	enum(func(a Arc) (terminate bool) {
+		arcs.Add(NewArc(a.Both()))
+		return
+	})
+	return arcs
+}
+
+// Collects the edges passed to a step function, as basic arcs, so that their
+// orientation is kept.
+func collectEdges(enum func(EdgeStep)) *set.SetNonTS {
+	edges := set.NewNonTS()
+	enum(func(e Edge) (terminate bool) {
+		edges.Add(NewArc(e.Both()))
+		return
+	})
+	return edges
+}
+
+// Collects the vertices passed to a step function.
+func collectVertices(enum func(VertexStep)) *set.SetNonTS {
+	vertices := set.NewNonTS()
+	enum(func(v Vertex) (terminate bool) {
+		vertices.Add(v)
+		return
+	})
+	return vertices
+}
+
+func (s *ReversedViewSuite) TestMatchesTranspose(c *C) {
+	for _, name := range reversedViewFixtures {
+		g := s.Factory(GraphFixtures[name]).(Digraph)
+		view, tg := ReversedView(g), g.Transpose()
+		comment := Commentf("fixture %s", name)
+
+		c.Assert(Order(view), Equals, Order(tg), comment)
+		c.Assert(Size(view), Equals, Size(tg), comment)
+		c.Assert(sameSet(collectVertices(view.Vertices), collectVertices(tg.Vertices)), Equals, true, comment)
+		c.Assert(sameSet(collectArcs(view.Arcs), collectArcs(tg.Arcs)), Equals, true, comment)
+		c.Assert(sameSet(collectEdges(view.Edges), collectEdges(tg.Edges)), Equals, true, comment)
+
+		g.Vertices(func(v Vertex) (terminate bool) {
+			vc := Commentf("fixture %s, vertex %v", name, v)
+
+			from := func(t Digraph) func(ArcStep) { return func(f ArcStep) { t.ArcsFrom(v, f) } }
+			to := func(t Digraph) func(ArcStep) { return func(f ArcStep) { t.ArcsTo(v, f) } }
+			succ := func(t Digraph) func(VertexStep) { return func(f VertexStep) { t.SuccessorsOf(v, f) } }
+			pred := func(t Digraph) func(VertexStep) { return func(f VertexStep) { t.PredecessorsOf(v, f) } }
+			adj := func(t Digraph) func(VertexStep) { return func(f VertexStep) { t.AdjacentTo(v, f) } }
+			inc := func(t Digraph) func(EdgeStep) { return func(f EdgeStep) { t.IncidentTo(v, f) } }
+
+			c.Assert(sameSet(collectArcs(from(view)), collectArcs(from(tg))), Equals, true, vc)
+			c.Assert(sameSet(collectArcs(to(view)), collectArcs(to(tg))), Equals, true, vc)
+			c.Assert(sameSet(collectVertices(succ(view)), collectVertices(succ(tg))), Equals, true, vc)
+			c.Assert(sameSet(collectVertices(pred(view)), collectVertices(pred(tg))), Equals, true, vc)
+			c.Assert(sameSet(collectVertices(adj(view)), collectVertices(adj(tg))), Equals, true, vc)
+			c.Assert(sameSet(collectEdges(inc(view)), collectEdges(inc(tg))), Equals, true, vc)
+
+			vd, vok := view.InDegreeOf(v)
+			td, tok := tg.InDegreeOf(v)
+			c.Assert([]interface{}{vd, vok}, DeepEquals, []interface{}{td, tok}, vc)
+			vd, vok = view.OutDegreeOf(v)
+			td, tok = tg.OutDegreeOf(v)
+			c.Assert([]interface{}{vd, vok}, DeepEquals, []interface{}{td, tok}, vc)
+			vd, vok = view.DegreeOf(v)
+			td, tok = tg.DegreeOf(v)
+			c.Assert([]interface{}{vd, vok}, DeepEquals, []interface{}{td, tok}, vc)
+
+			g.Vertices(func(w Vertex) (terminate bool) {
+				c.Assert(view.HasArc(NewArc(v, w)), Equals, tg.HasArc(NewArc(v, w)), Commentf("fixture %s, arc %v->%v", name, v, w))
+				c.Assert(view.HasEdge(NewEdge(v, w)), Equals, tg.HasEdge(NewEdge(v, w)), Commentf("fixture %s, edge %v-%v", name, v, w))
+				return
+			})
+			return
+		})
+
+		c.Assert(view.HasVertex("missing"), Equals, false, comment)
+		_, exists := view.InDegreeOf("missing")
+		c.Assert(exists, Equals, false, comment)
+	}
+}
+
+func (s *ReversedViewSuite) TestTransposeIsOriginal(c *C) {
+	g := s.Factory(GraphFixtures["arctest"]).(Digraph)
+	c.Assert(ReversedView(g).Transpose(), Equals, g)
+	c.Assert(ReversedView(g).HasArc(NewArc("bar", "foo")), Equals, true)
+	c.Assert(ReversedView(g).HasArc(NewArc("foo", "bar")), Equals, false)
+}
+
+func (s *ReversedViewSuite) TestTermination(c *C) {
+	view := ReversedView(s.Factory(GraphFixtures["arctest"]).(Digraph))
+
+	var hit int
+	view.Arcs(func(a Arc) (terminate bool) {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+
+	hit = 0
+	view.ArcsFrom("bar", func(a Arc) (terminate bool) {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+
+	hit = 0
+	view.PredecessorsOf("foo", func(v Vertex) (terminate bool) {
+		hit++
+		return true
+	})
+	c.Assert(hit, Equals, 1)
+}
@@ -0,0 +1,46 @@
+// Contains generic graph traversals, written against gogl's iterator interfaces.
+package traverse
+
+import "github.com/sdboyer/gogl"
+
+// Walks the graph breadth-first from the start vertex, level by level, calling
+// visit with each vertex reached and its depth: the number of edges between it
+// and the start, which is visited first, at depth 0. As with the *Step
+// functions, visit returns true to terminate the walk early.
+//
+// Neighbors are found with AdjacentTo, or, if g is a Digraph, with SuccessorsOf,
+// so that arcs are only followed in their direction. Each vertex is visited at
+// most once, however many cycles lead back to it, and vertices are compared by
+// gogl.VertexKey. The walk ends when no unvisited vertex remains reachable, so
+// other components are never visited. If start is not present in the graph,
+// nothing is visited.
+func BreadthFirstSearch(g gogl.Graph, start gogl.Vertex, visit func(v gogl.Vertex, depth int) (terminate bool)) {
+	if !g.HasVertex(start) {
+		return
+	}
+
+	neighbors := g.AdjacentTo
+	if dg, ok := g.(gogl.Digraph); ok {
+		neighbors = dg.SuccessorsOf
+	}
+
+	seen := map[interface{}]struct{}{gogl.VertexKey(start): {}}
+	frontier := []gogl.Vertex{start}
+	for depth := 0; len(frontier) > 0; depth++ {
+		var next []gogl.Vertex
+		for _, v := range frontier {
+			if visit(v, depth) {
+				return
+			}
+
+			neighbors(v, func(w gogl.Vertex) (terminate bool) {
+				if _, visited := seen[gogl.VertexKey(w)]; !visited {
+					seen[gogl.VertexKey(w)] = struct{}{}
+					next = append(next, w)
+				}
+				return
+			})
+		}
+		frontier = next
+	}
+}
@@ -0,0 +1,84 @@
+package traverse
+
+import (
+	"testing"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+// Hook gocheck into the go test runner
+func TestHookup(t *testing.T) { TestingT(t) }
+
+type BFSSuite struct{}
+
+var _ = Suite(&BFSSuite{})
+
+// Runs a full search, returning each vertex's depth and the order of visits.
+func depths(g gogl.Graph, start gogl.Vertex) (map[gogl.Vertex]int, []gogl.Vertex) {
+	depth := make(map[gogl.Vertex]int)
+	var order []gogl.Vertex
+	BreadthFirstSearch(g, start, func(v gogl.Vertex, d int) (terminate bool) {
+		depth[v] = d
+		order = append(order, v)
+		return
+	})
+	return depth, order
+}
+
+func (s *BFSSuite) TestUndirected(c *C) {
+	// A square a-b-c-d with a tail d-e, and a separate edge x-y.
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("b", "c"),
+		gogl.NewEdge("c", "d"),
+		gogl.NewEdge("d", "a"),
+		gogl.NewEdge("d", "e"),
+		gogl.NewEdge("x", "y"),
+	}).Create(al.G)
+
+	depth, order := depths(g, "a")
+	c.Assert(depth, DeepEquals, map[gogl.Vertex]int{"a": 0, "b": 1, "d": 1, "c": 2, "e": 2})
+	c.Assert(order, HasLen, 5)
+	for i := 1; i < len(order); i++ {
+		c.Assert(depth[order[i-1]] <= depth[order[i]], Equals, true, Commentf("order %v", order))
+	}
+
+	depth, _ = depths(g, "y")
+	c.Assert(depth, DeepEquals, map[gogl.Vertex]int{"y": 0, "x": 1})
+
+	depth, _ = depths(g, "nope")
+	c.Assert(depth, HasLen, 0)
+}
+
+func (s *BFSSuite) TestDirected(c *C) {
+	// A cycle a->b->c->a, with c->d; nothing leads back to d's predecessors.
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "a"),
+		gogl.NewArc("c", "d"),
+	}).Create(al.G)
+
+	depth, _ := depths(g, "a")
+	c.Assert(depth, DeepEquals, map[gogl.Vertex]int{"a": 0, "b": 1, "c": 2, "d": 3})
+
+	depth, _ = depths(g, "d")
+	c.Assert(depth, DeepEquals, map[gogl.Vertex]int{"d": 0})
+}
+
+func (s *BFSSuite) TestTerminate(c *C) {
+	var pairs gogl.EdgeList
+	for i := 0; i < 10; i++ {
+		pairs = append(pairs, gogl.NewEdge(i, i+1))
+	}
+	g := gogl.Spec().Using(pairs).Create(al.G)
+
+	var visited []gogl.Vertex
+	BreadthFirstSearch(g, 0, func(v gogl.Vertex, d int) (terminate bool) {
+		visited = append(visited, v)
+		return d == 3
+	})
+	c.Assert(visited, DeepEquals, []gogl.Vertex{0, 1, 2, 3})
+}
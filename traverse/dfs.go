@@ -0,0 +1,149 @@
+package traverse
+
+import "github.com/sdboyer/gogl"
+
+// Receives the events of a depth-first search. Each callback is optional; nil
+// ones are skipped.
+//
+// Every edge examined by the search is either a tree edge, by which an
+// undiscovered vertex is first reached, or leads to a vertex already
+// discovered. Of the latter, OnBackEdge receives those leading to a vertex
+// still on the search stack - an ancestor, or the vertex itself - which close a
+// cycle. The rest lead to finished vertices, and occur only in Digraphs:
+// OnForwardEdge receives those leading to a descendant, discovered after the
+// vertex the arc leaves, and OnCrossEdge those leading anywhere else.
+type DFSVisitor struct {
+	OnDiscover    func(v gogl.Vertex)
+	OnFinish      func(v gogl.Vertex)
+	OnTreeEdge    func(e gogl.Edge)
+	OnBackEdge    func(e gogl.Edge)
+	OnForwardEdge func(e gogl.Edge)
+	OnCrossEdge   func(e gogl.Edge)
+}
+
+const (
+	discovered = iota + 1
+	finished
+)
+
+// A vertex on the search stack, with the edges out of it and how many of them
+// have been examined.
+type dfsFrame struct {
+	v     gogl.Vertex
+	edges []gogl.Edge
+	next  int
+	// In undirected graphs, the vertex v was reached from. The first edge
+	// leading back to it is the tree edge by which v was reached, and is not a
+	// back edge.
+	parent    gogl.Vertex
+	hasParent bool
+}
+
+// Walks the graph depth-first from the start vertex, reporting each event to
+// the hooks. OnDiscover is called as a vertex is first reached, and OnFinish
+// once every vertex reachable from it has been finished. An edge is passed to
+// OnTreeEdge before the vertex it leads to is discovered.
+//
+// Edges are found with IncidentTo, or, if g is a Digraph, with ArcsFrom, so that
+// arcs are only followed in their direction; the edges passed to the hooks are
+// those of the graph, with any weights, labels or data. Adjacency in undirected
+// graphs is symmetric, so the tree edge leading back to a vertex's parent is
+// not reported again as a back edge. A Digraph has a cycle reachable from start
+// iff OnBackEdge is called; a self-loop is a back edge.
+//
+// The search keeps an explicit stack, rather than recursing, so it is safe on
+// arbitrarily deep graphs. Vertices are compared by gogl.VertexKey. Vertices not
+// reachable from start are never discovered, and if start is not present in
+// the graph, nothing is.
+func DepthFirstSearch(g gogl.Graph, start gogl.Vertex, hooks DFSVisitor) {
+	if !g.HasVertex(start) {
+		return
+	}
+
+	dg, directed := g.(gogl.Digraph)
+	edgesFrom := func(v gogl.Vertex) (edges []gogl.Edge) {
+		if directed {
+			dg.ArcsFrom(v, func(a gogl.Arc) (terminate bool) {
+				edges = append(edges, a)
+				return
+			})
+		} else {
+			g.IncidentTo(v, func(e gogl.Edge) (terminate bool) {
+				edges = append(edges, e)
+				return
+			})
+		}
+		return
+	}
+
+	state := make(map[interface{}]int)
+	at := make(map[interface{}]int) // discovery order, for telling forward edges from cross edges
+	discover := func(v gogl.Vertex) dfsFrame {
+		state[gogl.VertexKey(v)] = discovered
+		at[gogl.VertexKey(v)] = len(at)
+		if hooks.OnDiscover != nil {
+			hooks.OnDiscover(v)
+		}
+		return dfsFrame{v: v, edges: edgesFrom(v)}
+	}
+
+	stack := []dfsFrame{discover(start)}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next == len(top.edges) {
+			state[gogl.VertexKey(top.v)] = finished
+			if hooks.OnFinish != nil {
+				hooks.OnFinish(top.v)
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		e := top.edges[top.next]
+		top.next++
+
+		w := otherEnd(e, top.v)
+		switch state[gogl.VertexKey(w)] {
+		case 0:
+			if hooks.OnTreeEdge != nil {
+				hooks.OnTreeEdge(e)
+			}
+			from := top.v
+			stack = append(stack, discover(w))
+			if !directed {
+				stack[len(stack)-1].parent, stack[len(stack)-1].hasParent = from, true
+			}
+		case discovered:
+			if top.hasParent && gogl.VertexKey(w) == gogl.VertexKey(top.parent) {
+				// Only the first sighting is the tree edge; a parallel edge to the
+				// parent is a genuine back edge.
+				top.hasParent = false
+				continue
+			}
+			if hooks.OnBackEdge != nil {
+				hooks.OnBackEdge(e)
+			}
+		case finished:
+			// In undirected graphs, this is a back edge seen from its other end.
+			if !directed {
+				continue
+			}
+			if at[gogl.VertexKey(top.v)] < at[gogl.VertexKey(w)] {
+				if hooks.OnForwardEdge != nil {
+					hooks.OnForwardEdge(e)
+				}
+			} else if hooks.OnCrossEdge != nil {
+				hooks.OnCrossEdge(e)
+			}
+		}
+	}
+}
+
+// Returns the end of e that is not v, or v itself for a self-loop.
+func otherEnd(e gogl.Edge, v gogl.Vertex) gogl.Vertex {
+	u, w := e.Both()
+	if gogl.VertexKey(u) == gogl.VertexKey(v) {
+		return w
+	}
+	return u
+}
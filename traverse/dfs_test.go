@@ -0,0 +1,119 @@
+package traverse
+
+import (
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type DFSSuite struct{}
+
+var _ = Suite(&DFSSuite{})
+
+// Records every event of a search, as strings, in the order they occur.
+func record(g gogl.Graph, start gogl.Vertex) (events []string, tree, back []gogl.Edge) {
+	DepthFirstSearch(g, start, DFSVisitor{
+		OnDiscover: func(v gogl.Vertex) { events = append(events, "discover "+v.(string)) },
+		OnFinish:   func(v gogl.Vertex) { events = append(events, "finish "+v.(string)) },
+		OnTreeEdge: func(e gogl.Edge) { tree = append(tree, e) },
+		OnBackEdge: func(e gogl.Edge) { back = append(back, e) },
+	})
+	return
+}
+
+func (s *DFSSuite) TestDirected(c *C) {
+	// A path a->b->c with a shortcut a->c, which is a forward or tree edge
+	// depending on the order arcs are examined, but never a back edge.
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("a", "c"),
+	}).Create(al.G)
+
+	events, tree, back := record(g, "a")
+	c.Assert(events, HasLen, 6)
+	c.Assert(events[0], Equals, "discover a")
+	c.Assert(events[5], Equals, "finish a")
+	c.Assert(tree, HasLen, 2)
+	c.Assert(back, HasLen, 0)
+
+	// If b is discovered before c, c is beneath it and so finishes first.
+	pos := make(map[string]int)
+	for i, e := range events {
+		pos[e] = i
+	}
+	c.Assert(pos["finish c"] < pos["finish b"] || pos["discover b"] > pos["finish c"], Equals, true)
+
+	// Closing the cycle adds a back edge.
+	g.(gogl.MutableDigraph).AddArcs(gogl.NewArc("c", "a"))
+	_, tree, back = record(g, "a")
+	c.Assert(tree, HasLen, 2)
+	c.Assert(back, HasLen, 1)
+	c.Assert(back[0].(gogl.Arc).Target(), Equals, "a")
+
+	// Searching from the end only follows arcs forward.
+	events, _, _ = record(g, "c")
+	c.Assert(events, HasLen, 6)
+	g.(gogl.MutableDigraph).RemoveArcs(gogl.NewArc("c", "a"))
+	events, _, _ = record(g, "c")
+	c.Assert(events, DeepEquals, []string{"discover c", "finish c"})
+}
+
+func (s *DFSSuite) TestUndirected(c *C) {
+	// A tree has no back edges, despite each edge being seen from both ends.
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("a", "c"),
+		gogl.NewEdge("c", "d"),
+		gogl.NewEdge("x", "y"),
+	}).Create(al.G)
+
+	events, tree, back := record(g, "a")
+	c.Assert(events, HasLen, 8)
+	c.Assert(tree, HasLen, 3)
+	c.Assert(back, HasLen, 0)
+
+	// A triangle has exactly one.
+	g.(gogl.MutableGraph).AddEdges(gogl.NewEdge("b", "c"))
+	_, tree, back = record(g, "a")
+	c.Assert(tree, HasLen, 3)
+	c.Assert(back, HasLen, 1)
+}
+
+func (s *DFSSuite) TestSelfLoop(c *C) {
+	g := gogl.Spec().Directed().Loop().Using(gogl.ArcList{
+		gogl.NewArc("a", "a"),
+	}).Create(al.G)
+
+	_, tree, back := record(g, "a")
+	c.Assert(tree, HasLen, 0)
+	c.Assert(back, HasLen, 1)
+}
+
+func (s *DFSSuite) TestDeep(c *C) {
+	// Deep enough that a recursive search would strain the stack.
+	const n = 100000
+	var arcs gogl.ArcList
+	for i := 0; i < n-1; i++ {
+		arcs = append(arcs, gogl.NewArc(i, i+1))
+	}
+	g := gogl.Spec().Directed().Using(arcs).Create(al.G)
+
+	var discovered, finished int
+	var last gogl.Vertex
+	DepthFirstSearch(g, 0, DFSVisitor{
+		OnDiscover: func(v gogl.Vertex) { discovered++ },
+		OnFinish: func(v gogl.Vertex) {
+			if finished == 0 {
+				last = v
+			}
+			finished++
+		},
+	})
+	c.Assert(discovered, Equals, n)
+	c.Assert(finished, Equals, n)
+	c.Assert(last, Equals, n-1)
+
+	// Missing start vertices, and nil hooks, are fine.
+	DepthFirstSearch(g, -1, DFSVisitor{})
+}
@@ -0,0 +1,94 @@
+package traverse
+
+import (
+	"github.com/sdboyer/gogl"
+)
+
+// The kinds of event that occur during a depth-first traversal.
+type EventKind int
+
+const (
+	// A vertex is reached for the first time.
+	Discover EventKind = iota
+	// All of a vertex's edges have been explored.
+	Finish
+	// An edge leading to an undiscovered vertex, which becomes its child in the DFS tree.
+	TreeEdge
+	// An edge leading to an ancestor in the DFS tree (including the vertex itself).
+	BackEdge
+	// An edge leading to a descendant already finished (digraphs only).
+	ForwardEdge
+	// An edge leading to a finished vertex that is not a descendant (digraphs only).
+	CrossEdge
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case Discover:
+		return "Discover"
+	case Finish:
+		return "Finish"
+	case TreeEdge:
+		return "TreeEdge"
+	case BackEdge:
+		return "BackEdge"
+	case ForwardEdge:
+		return "ForwardEdge"
+	case CrossEdge:
+		return "CrossEdge"
+	}
+	return "Unknown"
+}
+
+// A single step in a depth-first traversal. Discover and Finish events carry a
+// Vertex; edge events carry the Edge, oriented in the direction it was traversed.
+type TraversalEvent struct {
+	Kind   EventKind
+	Vertex gogl.Vertex
+	Edge   gogl.Edge
+}
+
+// Performs a depth-first traversal of g from the given start vertex, returning
+// every step it takes, in order.
+//
+// This is DepthFirstSearch, with each of its events recorded. Each vertex
+// reachable from start is discovered and finished exactly once, and each edge
+// examined is classified relative to the DFS tree, as DFSVisitor describes. For
+// Digraphs, arcs are followed in their direction, and all four edge classes can
+// occur. In undirected graphs, only tree and back edges exist; each edge is
+// reported once, from the end that reaches it first, as a basic edge from that
+// end to the other.
+//
+// If start is not in the graph, nil is returned.
+func Trace(g gogl.Graph, start gogl.Vertex) []TraversalEvent {
+	_, directed := g.(gogl.Digraph)
+
+	var events []TraversalEvent
+	var path []gogl.Vertex // the vertices on the search stack
+	edge := func(kind EventKind) func(gogl.Edge) {
+		return func(e gogl.Edge) {
+			if !directed {
+				v := path[len(path)-1]
+				e = gogl.NewEdge(v, otherEnd(e, v))
+			}
+			events = append(events, TraversalEvent{Kind: kind, Edge: e})
+		}
+	}
+
+	DepthFirstSearch(g, start, DFSVisitor{
+		OnDiscover: func(v gogl.Vertex) {
+			path = append(path, v)
+			events = append(events, TraversalEvent{Kind: Discover, Vertex: v})
+		},
+		OnFinish: func(v gogl.Vertex) {
+			path = path[:len(path)-1]
+			events = append(events, TraversalEvent{Kind: Finish, Vertex: v})
+		},
+		OnTreeEdge:    edge(TreeEdge),
+		OnBackEdge:    edge(BackEdge),
+		OnForwardEdge: edge(ForwardEdge),
+		OnCrossEdge:   edge(CrossEdge),
+	})
+
+	return events
+}
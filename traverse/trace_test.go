@@ -0,0 +1,147 @@
+package traverse
+
+import (
+	stdrand "math/rand"
+
+	. "github.com/sdboyer/gocheck"
+	"github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/rand"
+)
+
+type TraceSuite struct{}
+
+var _ = Suite(&TraceSuite{})
+
+// Reduces a trace's edge events to a map from "u->v" to the edge's kind.
+func edgeKinds(events []TraversalEvent) map[[2]gogl.Vertex]EventKind {
+	kinds := make(map[[2]gogl.Vertex]EventKind)
+	for _, ev := range events {
+		if ev.Edge != nil {
+			u, v := ev.Edge.Both()
+			kinds[[2]gogl.Vertex{u, v}] = ev.Kind
+		}
+	}
+	return kinds
+}
+
+func (s *TraceSuite) TestBackEdge(c *C) {
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("c", "a"),
+	}).Create(al.G)
+
+	c.Assert(Trace(g, "a"), DeepEquals, []TraversalEvent{
+		{Kind: Discover, Vertex: "a"},
+		{Kind: TreeEdge, Edge: gogl.NewArc("a", "b")},
+		{Kind: Discover, Vertex: "b"},
+		{Kind: TreeEdge, Edge: gogl.NewArc("b", "c")},
+		{Kind: Discover, Vertex: "c"},
+		{Kind: BackEdge, Edge: gogl.NewArc("c", "a")},
+		{Kind: Finish, Vertex: "c"},
+		{Kind: Finish, Vertex: "b"},
+		{Kind: Finish, Vertex: "a"},
+	})
+}
+
+func (s *TraceSuite) TestForwardAndCrossEdges(c *C) {
+	// Whether a->c is a forward edge or b->c a cross edge depends on which of a's
+	// arcs is explored first; both outcomes are correct DFS classifications.
+	g := gogl.Spec().Directed().Using(gogl.ArcList{
+		gogl.NewArc("a", "b"),
+		gogl.NewArc("b", "c"),
+		gogl.NewArc("a", "c"),
+	}).Create(al.G)
+
+	forward := map[[2]gogl.Vertex]EventKind{
+		{"a", "b"}: TreeEdge,
+		{"b", "c"}: TreeEdge,
+		{"a", "c"}: ForwardEdge,
+	}
+	cross := map[[2]gogl.Vertex]EventKind{
+		{"a", "c"}: TreeEdge,
+		{"a", "b"}: TreeEdge,
+		{"b", "c"}: CrossEdge,
+	}
+
+	for i := 0; i < 20; i++ {
+		c.Assert([]map[[2]gogl.Vertex]EventKind{forward, cross}, Contains, edgeKinds(Trace(g, "a")))
+	}
+}
+
+func (s *TraceSuite) TestClassificationInvariants(c *C) {
+	g := gogl.Spec().Directed().Using(rand.BernoulliDistribution(40, 0.1, true, true, stdrand.NewSource(1))).Create(al.G).(gogl.Digraph)
+	events := Trace(g, 0)
+
+	// Rebuild timestamps and the DFS tree from the trace.
+	disc, fin := make(map[gogl.Vertex]int), make(map[gogl.Vertex]int)
+	parent := make(map[gogl.Vertex]gogl.Vertex)
+	for t, ev := range events {
+		switch ev.Kind {
+		case Discover:
+			disc[ev.Vertex] = t
+		case Finish:
+			fin[ev.Vertex] = t
+		case TreeEdge:
+			u, v := ev.Edge.Both()
+			parent[v] = u
+		}
+	}
+	isAncestor := func(u, v gogl.Vertex) bool {
+		return disc[u] <= disc[v] && fin[v] <= fin[u]
+	}
+
+	var arcs int
+	for _, ev := range events {
+		if ev.Edge == nil {
+			continue
+		}
+		arcs++
+
+		u, v := ev.Edge.Both()
+		switch ev.Kind {
+		case TreeEdge:
+			c.Assert(parent[v], Equals, u)
+		case BackEdge:
+			c.Assert(isAncestor(v, u), Equals, true, Commentf("back edge %v->%v", u, v))
+		case ForwardEdge:
+			c.Assert(isAncestor(u, v) && parent[v] != u, Equals, true, Commentf("forward edge %v->%v", u, v))
+		case CrossEdge:
+			c.Assert(isAncestor(u, v) || isAncestor(v, u), Equals, false, Commentf("cross edge %v->%v", u, v))
+		}
+	}
+
+	// Every arc out of a reached vertex is classified exactly once.
+	var expected int
+	for v := range disc {
+		d, _ := g.OutDegreeOf(v)
+		expected += d
+	}
+	c.Assert(arcs, Equals, expected)
+}
+
+func (s *TraceSuite) TestUndirected(c *C) {
+	// A triangle with a tail: the triangle closes with one back edge.
+	g := gogl.Spec().Using(gogl.EdgeList{
+		gogl.NewEdge("a", "b"),
+		gogl.NewEdge("b", "c"),
+		gogl.NewEdge("c", "a"),
+		gogl.NewEdge("c", "d"),
+	}).Create(al.G)
+
+	counts := make(map[EventKind]int)
+	for _, ev := range Trace(g, "a") {
+		counts[ev.Kind]++
+	}
+	c.Assert(counts, DeepEquals, map[EventKind]int{
+		Discover: 4,
+		Finish:   4,
+		TreeEdge: 3,
+		BackEdge: 1,
+	})
+}
+
+func (s *TraceSuite) TestMissingStart(c *C) {
+	c.Assert(Trace(gogl.NullGraph, "a"), IsNil)
+}
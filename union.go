@@ -0,0 +1,111 @@
+package gogl
+
+// Presents the given graph sources together as a single GraphSource: their
+// disjoint union. Nothing is copied; enumeration passes through to each source in
+// turn.
+//
+// To keep the sources apart even where their vertices coincide, every vertex is
+// relabeled as a [2]interface{} pairing the index of its source with the original
+// vertex, so vertex v of the second source appears as [2]interface{}{1, v}. Edges
+// are relabeled to match, and keep their weights, labels or data; no edge ever
+// joins vertices from different sources. This makes it possible to push a batch
+// of small graphs through a single pass of some algorithm.
+//
+// If every source is a DigraphSource, so is the result. The result also reports
+// its Order() and Size(), which are the sums of the sources'.
+func DisjointUnion(sources ...GraphSource) GraphSource {
+	u := disjointUnion(sources)
+	if len(sources) == 0 {
+		return u
+	}
+
+	for _, src := range sources {
+		if _, ok := src.(DigraphSource); !ok {
+			return u
+		}
+	}
+	return disjointDiUnion{u}
+}
+
+type disjointUnion []GraphSource
+
+func (u disjointUnion) Vertices(f VertexStep) {
+	for i, src := range u {
+		var terminated bool
+		src.Vertices(func(v Vertex) bool {
+			terminated = f([2]interface{}{i, v})
+			return terminated
+		})
+		if terminated {
+			return
+		}
+	}
+}
+
+func (u disjointUnion) Edges(f EdgeStep) {
+	for i, src := range u {
+		var terminated bool
+		src.Edges(func(e Edge) bool {
+			terminated = f(tagEdge(i, e))
+			return terminated
+		})
+		if terminated {
+			return
+		}
+	}
+}
+
+// Returns the order of the union, which is the sum of the orders of its sources.
+func (u disjointUnion) Order() int {
+	var order int
+	for _, src := range u {
+		order += Order(src)
+	}
+	return order
+}
+
+// Returns the size of the union, which is the sum of the sizes of its sources.
+func (u disjointUnion) Size() int {
+	var size int
+	for _, src := range u {
+		size += Size(src)
+	}
+	return size
+}
+
+// Reports the number of vertices in the union, which is exact only if every
+// source's own hint is.
+func (u disjointUnion) VertexCount() (count int, exact bool) {
+	for _, src := range u {
+		n, exact := VertexCountHint(src)
+		if !exact {
+			return 0, false
+		}
+		count += n
+	}
+	return count, true
+}
+
+type disjointDiUnion struct {
+	disjointUnion
+}
+
+func (u disjointDiUnion) Arcs(f ArcStep) {
+	for i, src := range u.disjointUnion {
+		var terminated bool
+		src.(DigraphSource).Arcs(func(a Arc) bool {
+			terminated = f(tagEdge(i, a).(Arc))
+			return terminated
+		})
+		if terminated {
+			return
+		}
+	}
+}
+
+// Relabels the endpoints of the given edge as belonging to the i'th source of a
+// disjoint union.
+func tagEdge(i int, e Edge) Edge {
+	u, v := e.Both()
+	return withEndpoints(e, [2]interface{}{i, u}, [2]interface{}{i, v})
+}
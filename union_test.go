@@ -0,0 +1,104 @@
+package gogl_test
+
+import (
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+)
+
+type DisjointUnionSuite struct{}
+
+var _ = Suite(&DisjointUnionSuite{})
+
+func (s *DisjointUnionSuite) TestOrderAndSize(c *C) {
+	// The same vertices in each source, which must nonetheless stay apart.
+	triangle := Spec().Using(EdgeList{
+		NewEdge(1, 2),
+		NewEdge(2, 3),
+		NewEdge(3, 1),
+	}).Create(al.G)
+	path := Spec().Using(EdgeList{
+		NewEdge(1, 2),
+		NewEdge(2, 4),
+	}).Create(al.G).(MutableGraph)
+	path.EnsureVertex(5)
+
+	u := DisjointUnion(triangle, path, triangle)
+	c.Assert(Order(u), Equals, 10)
+	c.Assert(Size(u), Equals, 8)
+	n, exact := VertexCountHint(u)
+	c.Assert(n, Equals, 10)
+	c.Assert(exact, Equals, true)
+
+	// Counting by enumeration agrees with the reported sums.
+	var vertices, edges int
+	u.Vertices(func(v Vertex) (terminate bool) {
+		vertices++
+		return
+	})
+	u.Edges(func(e Edge) (terminate bool) {
+		edges++
+		a, b := e.Both()
+		c.Assert(a.([2]interface{})[0], Equals, b.([2]interface{})[0], Commentf("edge %v crosses sources", e))
+		return
+	})
+	c.Assert(vertices, Equals, 10)
+	c.Assert(edges, Equals, 8)
+
+	g := Spec().Using(u).Create(al.G)
+	c.Assert(Order(g), Equals, 10)
+	c.Assert(Size(g), Equals, 8)
+	c.Assert(g.HasVertex([2]interface{}{1, 5}), Equals, true)
+	c.Assert(g.HasEdge(NewEdge([2]interface{}{2, 3}, [2]interface{}{2, 1})), Equals, true)
+	c.Assert(g.HasEdge(NewEdge([2]interface{}{0, 2}, [2]interface{}{0, 4})), Equals, false)
+}
+
+func (s *DisjointUnionSuite) TestDirectedAndWeighted(c *C) {
+	a := Spec().Directed().Weighted().Using(WeightedArcList{
+		NewWeightedArc("x", "y", 1.5),
+	}).Create(al.G)
+	b := Spec().Directed().Weighted().Using(WeightedArcList{
+		NewWeightedArc("y", "x", -2),
+		NewWeightedArc("x", "z", 0),
+	}).Create(al.G)
+
+	u := DisjointUnion(a, b)
+	dgs, ok := u.(DigraphSource)
+	c.Assert(ok, Equals, true)
+
+	var arcs []Arc
+	dgs.Arcs(func(a Arc) (terminate bool) {
+		arcs = append(arcs, a)
+		return
+	})
+	c.Assert(arcs, HasLen, 3)
+
+	g := Spec().Directed().Weighted().Using(u).Create(al.G).(WeightedDigraph)
+	c.Assert(g.HasWeightedArc(NewWeightedArc([2]interface{}{0, "x"}, [2]interface{}{0, "y"}, 1.5)), Equals, true)
+	c.Assert(g.HasWeightedArc(NewWeightedArc([2]interface{}{1, "y"}, [2]interface{}{1, "x"}, -2)), Equals, true)
+	c.Assert(g.HasArc(NewArc([2]interface{}{1, "x"}, [2]interface{}{1, "y"})), Equals, false)
+
+	// Mixing in an undirected source gives an undirected union.
+	_, ok = DisjointUnion(a, EdgeList{NewEdge(1, 2)}).(DigraphSource)
+	c.Assert(ok, Equals, false)
+}
+
+func (s *DisjointUnionSuite) TestTermination(c *C) {
+	u := DisjointUnion(EdgeList{NewEdge(1, 2)}, EdgeList{NewEdge(1, 2), NewEdge(2, 3)})
+
+	var n int
+	u.Vertices(func(v Vertex) (terminate bool) {
+		n++
+		return n == 3
+	})
+	c.Assert(n, Equals, 3)
+
+	n = 0
+	u.Edges(func(e Edge) (terminate bool) {
+		n++
+		return n == 2
+	})
+	c.Assert(n, Equals, 2)
+
+	c.Assert(Order(DisjointUnion()), Equals, 0)
+}
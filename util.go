@@ -1,5 +1,9 @@
 package gogl
 
+import (
+	"context"
+)
+
 // Returns the number of vertices in a graph.
 //
 // If available, this function will take advantage of the optional optimization Order() method.
@@ -20,6 +24,21 @@ func Order(g VertexEnumerator) int {
 	}
 }
 
+// Returns a hint for the number of vertices in a graph, suitable for presizing data
+// structures, and whether that hint is exact.
+//
+// Only the optional VertexCountHinter interface is consulted; if the graph does not
+// implement it, or reports an inexact count, (0, false) is returned. Unlike Order(),
+// this never iterates the graph's vertices.
+func VertexCountHint(g VertexEnumerator) (count int, exact bool) {
+	if h, ok := g.(VertexCountHinter); ok {
+		if count, exact = h.VertexCount(); exact {
+			return
+		}
+	}
+	return 0, false
+}
+
 // Returns the number of edges in a graph.
 //
 // If available, this function will take advantage of the optional optimization Size() method.
@@ -45,8 +64,10 @@ func Size(g EdgeEnumerator) int {
 //
 // This is a convenience function. Avoid it on very large graphs or in performance critical sections.
 func CollectVertices(g VertexEnumerator) (vertices []Vertex) {
-	if c, ok := g.(VertexCounter); ok {
+	if n, exact := VertexCountHint(g); exact {
 		// If possible, size the slice based on the number of vertices the graph reports it has
+		vertices = make([]Vertex, 0, n)
+	} else if c, ok := g.(VertexCounter); ok {
 		vertices = make([]Vertex, 0, c.Order())
 	} else {
 		// Otherwise just pick something...reasonable?
@@ -164,3 +185,56 @@ func CollectArcsTo(v Vertex, g IncidentArcEnumerator) (arcs []Arc) {
 
 	return arcs
 }
+
+// The buffer size of the channels returned by VerticesChan and EdgesChan.
+const chanBuffer = 16
+
+// Returns a channel on which all of a graph's vertices are sent, for easy range-ing.
+// The channel is closed once every vertex has been sent, or once the context is done.
+//
+// The vertices are enumerated by a separate goroutine, which stops promptly when the
+// context is cancelled. If the channel is neither fully drained nor the context
+// cancelled, that goroutine blocks forever, and it and the graph are leaked; so if you
+// might stop ranging early, always cancel the context.
+func VerticesChan(g VertexEnumerator, ctx context.Context) <-chan Vertex {
+	ch := make(chan Vertex, chanBuffer)
+
+	go func() {
+		defer close(ch)
+		g.Vertices(func(v Vertex) (terminate bool) {
+			select {
+			case ch <- v:
+				return false
+			case <-ctx.Done():
+				return true
+			}
+		})
+	}()
+
+	return ch
+}
+
+// Returns a channel on which all of a graph's edges are sent, for easy range-ing.
+// The channel is closed once every edge has been sent, or once the context is done.
+//
+// The edges are enumerated by a separate goroutine, which stops promptly when the
+// context is cancelled. If the channel is neither fully drained nor the context
+// cancelled, that goroutine blocks forever, and it and the graph are leaked; so if you
+// might stop ranging early, always cancel the context.
+func EdgesChan(g EdgeEnumerator, ctx context.Context) <-chan Edge {
+	ch := make(chan Edge, chanBuffer)
+
+	go func() {
+		defer close(ch)
+		g.Edges(func(e Edge) (terminate bool) {
+			select {
+			case ch <- e:
+				return false
+			case <-ctx.Done():
+				return true
+			}
+		})
+	}()
+
+	return ch
+}
@@ -1,10 +1,12 @@
 package gogl_test
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/sdboyer/gocheck"
 	. "github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
 	"github.com/sdboyer/gogl/spec"
 	"gopkg.in/fatih/set.v0"
 )
@@ -46,6 +48,20 @@ func (s *CollectionFunctorsSuite) TestCollectVertices(c *C) {
 	c.Assert(set2.Has("baz"), Equals, true)
 }
 
+func (s *CollectionFunctorsSuite) TestVertexCountHint(c *C) {
+	g := Spec().Using(spec.GraphFixtures["2e3v"]).Create(al.G)
+	n, exact := VertexCountHint(g)
+	c.Assert(exact, Equals, true)
+	c.Assert(n, Equals, 3)
+
+	// Edge lists can't report a count without enumerating, so offer no hint.
+	n, exact = VertexCountHint(spec.GraphFixtures["2e3v"])
+	c.Assert(exact, Equals, false)
+	c.Assert(n, Equals, 0)
+
+	c.Assert(len(CollectVertices(g)), Equals, 3)
+}
+
 func (s *CollectionFunctorsSuite) TestCollectAdjacentVertices(c *C) {
 	slice := CollectVerticesAdjacentTo("bar", spec.GraphLiteralFixture(true))
 
@@ -125,6 +141,65 @@ func (s *CollectionFunctorsSuite) TestCollectArcsTo(c *C) {
 	c.Assert(set.Has(NewArc("foo", "bar")), Equals, true)
 }
 
+// Tests for channel adapters
+type ChanAdaptersSuite struct{}
+
+var _ = Suite(&ChanAdaptersSuite{})
+
+// Enumerates the integers in [0, n) as vertices.
+type vertexRange int
+
+func (n vertexRange) Vertices(f VertexStep) {
+	for i := 0; i < int(n); i++ {
+		if f(i) {
+			return
+		}
+	}
+}
+
+func (s *ChanAdaptersSuite) TestVerticesChan(c *C) {
+	set := set.NewNonTS()
+	for v := range VerticesChan(spec.GraphLiteralFixture(true), context.Background()) {
+		set.Add(v)
+	}
+
+	c.Assert(set.Size(), Equals, 4)
+	c.Assert(set.Has("foo"), Equals, true)
+	c.Assert(set.Has("bar"), Equals, true)
+	c.Assert(set.Has("baz"), Equals, true)
+	c.Assert(set.Has("isolate"), Equals, true)
+}
+
+func (s *ChanAdaptersSuite) TestEdgesChan(c *C) {
+	set := set.NewNonTS()
+	for e := range EdgesChan(spec.GraphLiteralFixture(true), context.Background()) {
+		set.Add(NewEdge(e.Both()))
+	}
+
+	c.Assert(set.Size(), Equals, 2)
+	c.Assert(set.Has(NewEdge("foo", "bar")), Equals, true)
+	c.Assert(set.Has(NewEdge("bar", "baz")), Equals, true)
+}
+
+func (s *ChanAdaptersSuite) TestCancel(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := VerticesChan(vertexRange(10000), ctx)
+
+	c.Assert(<-ch, Equals, 0)
+	cancel()
+
+	// The producer stops, closing the channel, well before it runs out of vertices.
+	count := 1
+	for range ch {
+		count++
+	}
+	c.Assert(count < 10000, Equals, true)
+
+	// A context that is already done still sees the channel closed.
+	for range VerticesChan(vertexRange(10000), ctx) {
+	}
+}
+
 type CountingFunctorsSuite struct{}
 
 var _ = Suite(&CountingFunctorsSuite{})
@@ -9,3 +9,36 @@ package gogl
 // that makes them unique has no bearing on the graph's behavior. In Go-speak, that
 // translates pretty nicely to interface{}.
 type Vertex interface{}
+
+// An Identifiable vertex supplies its own identity, by which graphs and
+// algorithms key it in place of the vertex value itself.
+//
+// Vertices are ordinarily used directly as map keys, which requires them to be
+// comparable: a vertex of slice, map or func type, or a struct containing one,
+// causes a runtime panic. Implementing Identifiable lifts that requirement, so
+// that rich struct vertices can be used safely. Two vertices with the same ID are
+// then the same vertex, whatever their contents; a graph keeps the first it is
+// given. IDs share no namespace with other vertices, so an Identifiable vertex
+// with ID "foo" is distinct from the string vertex "foo".
+//
+// The adjacency lists, the multigraphs in graph/multi, the edge lists,
+// VertexIndex, the traversals in packages traverse and dfs, and the algorithms in
+// package algo all respect Identifiable. Only those algorithms that return or
+// take maps keyed by vertex, such as ShortestPaths or IsBipartite, still require
+// comparable vertices.
+type Identifiable interface {
+	ID() string
+}
+
+// The key under which an Identifiable vertex is stored.
+type vertexID string
+
+// Returns the key by which the given vertex should be stored and compared: its
+// ID, if it is Identifiable, or the vertex itself otherwise. Two vertices are the
+// same vertex exactly when their keys are equal.
+func VertexKey(v Vertex) interface{} {
+	if id, ok := v.(Identifiable); ok {
+		return vertexID(id.ID())
+	}
+	return v
+}
@@ -0,0 +1,47 @@
+package gogl
+
+// A VertexIndex assigns each of a graph's vertices a dense integer id in the range
+// [0, n), so that per-vertex state can be kept in slices, matrices and bitsets
+// rather than maps.
+//
+// The index is a snapshot: it does not track later changes to the graph.
+type VertexIndex struct {
+	vertices []Vertex
+	index    map[interface{}]int // keyed by VertexKey
+}
+
+// Builds a VertexIndex over the vertices of the given graph, in a single pass over
+// its Vertices. Ids are assigned in enumeration order.
+func NewVertexIndex(g VertexEnumerator) *VertexIndex {
+	vi := &VertexIndex{vertices: CollectVertices(g)}
+	vi.index = make(map[interface{}]int, len(vi.vertices))
+	for i, v := range vi.vertices {
+		vi.index[VertexKey(v)] = i
+	}
+
+	return vi
+}
+
+// Returns the id of the given vertex, or -1 if it is not in the index.
+func (vi *VertexIndex) IndexOf(v Vertex) int {
+	if i, exists := vi.index[VertexKey(v)]; exists {
+		return i
+	}
+	return -1
+}
+
+// Returns the vertex with the given id. Panics if the id is out of range.
+func (vi *VertexIndex) VertexAt(i int) Vertex {
+	return vi.vertices[i]
+}
+
+// Returns the number of vertices in the index.
+func (vi *VertexIndex) Len() int {
+	return len(vi.vertices)
+}
+
+// Returns the indexed vertices, ordered by id. The slice is shared with the index,
+// and must not be modified.
+func (vi *VertexIndex) List() []Vertex {
+	return vi.vertices
+}
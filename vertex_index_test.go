@@ -0,0 +1,63 @@
+package gogl_test
+
+import (
+	. "github.com/sdboyer/gocheck"
+	. "github.com/sdboyer/gogl"
+	"github.com/sdboyer/gogl/graph/al"
+	"github.com/sdboyer/gogl/spec"
+)
+
+type VertexIndexSuite struct{}
+
+var _ = Suite(&VertexIndexSuite{})
+
+func (s *VertexIndexSuite) TestRoundTrip(c *C) {
+	for name, src := range spec.GraphFixtures {
+		g := Spec().Using(src).Create(al.G)
+		vi := NewVertexIndex(g)
+
+		c.Assert(vi.Len(), Equals, Order(g), Commentf("fixture %s", name))
+		c.Assert(vi.List(), HasLen, vi.Len())
+
+		for i := 0; i < vi.Len(); i++ {
+			c.Assert(vi.IndexOf(vi.VertexAt(i)), Equals, i, Commentf("fixture %s", name))
+		}
+		g.Vertices(func(v Vertex) (terminate bool) {
+			i := vi.IndexOf(v)
+			c.Assert(i >= 0 && i < vi.Len(), Equals, true, Commentf("fixture %s, vertex %v", name, v))
+			c.Assert(vi.VertexAt(i), Equals, v)
+			return
+		})
+	}
+}
+
+func (s *VertexIndexSuite) TestMissingVertex(c *C) {
+	vi := NewVertexIndex(spec.GraphLiteralFixture(true))
+	c.Assert(vi.IndexOf("qux"), Equals, -1)
+	c.Assert(func() { vi.VertexAt(vi.Len()) }, PanicMatches, ".*index out of range.*")
+
+	c.Assert(NewVertexIndex(NullGraph).Len(), Equals, 0)
+}
+
+type namedVertex struct {
+	name  string
+	attrs map[string]string
+}
+
+func (v namedVertex) ID() string { return v.name }
+
+func (s *VertexIndexSuite) TestIdentifiable(c *C) {
+	g := Spec().Using(EdgeList{
+		NewEdge(namedVertex{"a", nil}, namedVertex{"b", nil}),
+		NewEdge(namedVertex{"b", nil}, namedVertex{"c", nil}),
+	}).Create(al.G)
+	vi := NewVertexIndex(g)
+
+	c.Assert(vi.Len(), Equals, 3)
+	for _, name := range []string{"a", "b", "c"} {
+		i := vi.IndexOf(namedVertex{name, map[string]string{"x": "y"}})
+		c.Assert(i >= 0, Equals, true)
+		c.Assert(vi.VertexAt(i).(namedVertex).name, Equals, name)
+	}
+	c.Assert(vi.IndexOf(namedVertex{name: "d"}), Equals, -1)
+}